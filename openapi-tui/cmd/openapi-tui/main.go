@@ -0,0 +1,2294 @@
+// Package main implements a professional Terminal User Interface (TUI) for OpenAPI specification
+// validation and API testing. It uses the Charm Bracelet Bubble Tea framework for reactive
+// terminal applications and Lip Gloss for beautiful styling.
+//
+// Architecture:
+// - Modular design with separate packages for models, views, testing, validation, etc.
+// - Single Bubble Tea program with multiple screen states
+// - Screen-based navigation (menu → help/validate/test)
+// - Embedded models for each feature (validation, testing)
+// - Dynamic borders that adapt to terminal size
+// - Async operations with spinners and progress indicators
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/atotto/clipboard"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	
+	"github.com/Traves-Theberge/OpenAPI-Toolkit/openapi-tui/internal/config"
+	"github.com/Traves-Theberge/OpenAPI-Toolkit/openapi-tui/internal/errors"
+	"github.com/Traves-Theberge/OpenAPI-Toolkit/openapi-tui/internal/export"
+	"github.com/Traves-Theberge/OpenAPI-Toolkit/openapi-tui/internal/models"
+	"github.com/Traves-Theberge/OpenAPI-Toolkit/openapi-tui/internal/testing"
+	"github.com/Traves-Theberge/OpenAPI-Toolkit/openapi-tui/internal/ui"
+	"github.com/Traves-Theberge/OpenAPI-Toolkit/openapi-tui/internal/validation"
+)
+
+// printResolvedRequests prints one "METHOD URL" line per request for headless
+// use, falling back to the saved spec path and base URL, and returns the exit
+// code: 0 once printed, 1 when the spec can't be loaded, and 2 when there is
+// no spec or base URL
+func printResolvedRequests(specPath, baseURL string) int {
+	m := initialModel()
+	if specPath == "" {
+		specPath = m.Config.SpecPath
+	}
+	if baseURL == "" {
+		baseURL = m.Config.BaseURL
+	}
+	if specPath == "" || baseURL == "" {
+		fmt.Fprintln(os.Stderr, "-list-requests needs -spec and -base-url (or a saved spec and base URL)")
+		return 2
+	}
+	if validation.IsSpecURL(specPath) {
+		path, err := downloadSpec(m.Config, specPath)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		defer os.Remove(path)
+		specPath = path
+	}
+
+	// The same options as a run, so overrides and the global query show
+	opts, err := m.runOptions()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	lines, err := testing.ListResolvedRequests(specPath, baseURL, opts)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	for _, line := range lines {
+		fmt.Println(line)
+	}
+	return 0
+}
+
+// runHeadless tests a spec without the TUI, using the saved config and
+// falling back to the saved spec path and base URL. It prints a line per
+// result and a summary, and returns the exit code: 0 when the run stays
+// within the configured failure threshold, 1 when it doesn't or the spec
+// can't be tested, and 2 when there is no spec or base URL
+func runHeadless(specPath, baseURL string) int {
+	m := initialModel()
+	if specPath == "" {
+		specPath = m.Config.SpecPath
+	}
+	if baseURL == "" {
+		baseURL = m.Config.BaseURL
+	}
+	if specPath == "" || baseURL == "" {
+		fmt.Fprintln(os.Stderr, "-run needs -spec and -base-url (or a saved spec and base URL)")
+		return 2
+	}
+	for _, warning := range m.Config.Warnings {
+		fmt.Fprintln(os.Stderr, "warning:", warning)
+	}
+	if validation.IsSpecURL(specPath) {
+		path, err := downloadSpec(m.Config, specPath)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		defer os.Remove(path)
+		specPath = path
+	}
+
+	opts, err := m.runOptions()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	results, err := testing.RunTestsWithOptions(specPath, baseURL, opts, nil)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	stats := ui.CalculateStats(results)
+	for _, r := range results {
+		fmt.Printf("%-4s %-7s %s  %s\n", r.Status, r.Method, r.Endpoint, r.Message)
+	}
+	fmt.Printf("\n%d/%d passed, %d failed", stats.Passed, stats.Total, stats.Failed)
+	if stats.Skipped > 0 {
+		fmt.Printf(", %d skipped", stats.Skipped)
+	}
+	fmt.Println()
+	threshold := models.RunThreshold(m.Config)
+	if stats.Failed > 0 && threshold.IsSet() {
+		if threshold.Allows(stats.Passed, stats.Failed) {
+			fmt.Println("Failures are within the configured threshold")
+		} else {
+			fmt.Println("Failures exceed the configured threshold")
+		}
+	}
+	return threshold.ExitCode(stats.Passed, stats.Failed)
+}
+
+// flakyRunWindow is how many recent runs of a spec are checked for flaky endpoints
+const flakyRunWindow = 5
+
+// endpointFilterDebounce is how long the endpoint selector waits after the last
+// search keystroke before filtering, so fast typing filters once
+const endpointFilterDebounce = 150 * time.Millisecond
+
+// inFlightRefresh is how often the progress view reloads the in-flight requests
+const inFlightRefresh = 250 * time.Millisecond
+
+// progressBuffer is how many progress messages a run can queue; the view
+// only needs the latest, so the runner drops messages when it is full
+const progressBuffer = 64
+
+// inFlightTickMsg asks the progress view to reload the in-flight requests
+type inFlightTickMsg struct{}
+
+// inFlightTick schedules the next in-flight refresh
+func inFlightTick() tea.Cmd {
+	return tea.Tick(inFlightRefresh, func(time.Time) tea.Msg {
+		return inFlightTickMsg{}
+	})
+}
+
+// endpointFilterMsg asks the endpoint selector to filter for the search edit
+// numbered Seq; edits made since then supersede it
+type endpointFilterMsg struct {
+	Seq int
+}
+
+// specFetchedMsg carries the outcome of downloading the spec at URL to the
+// temporary file Path
+type specFetchedMsg struct {
+	URL  string
+	Path string
+	Err  error
+}
+
+// model is a local wrapper around models.Model to implement tea.Model interface
+type model struct {
+	models.Model
+	runControl *testing.RunControl // In-flight requests of the current run, for cancelling one at a time
+	progress   chan tea.Msg        // Progress messages of the current run
+	specCopies map[string]string   // Temporary spec files made this session, by the spec (URL or pasted file) they copy; removed on exit
+}
+
+// initialModel creates and initializes the main application model
+// Loads configuration and prepares all sub-models
+func initialModel() model {
+	// Load configuration from file
+	cfg := config.LoadConfig()
+	registerResponseValidators(cfg)
+
+	// Load test run history
+	history, err := models.LoadHistory()
+	if err != nil {
+		// If history can't be loaded, start with empty history
+		// Error is logged but doesn't prevent app from starting
+		history = &models.TestHistory{}
+	}
+
+	// Initialize the main application model with default values and loaded config
+	m := model{
+		Model: models.Model{
+			Cursor:                0,
+			Screen:                models.MenuScreen,
+			Width:                 80,
+			Height:                24,
+			VerboseMode:           cfg.VerboseMode,
+			Config:                cfg,
+			ValidateModel:         ui.InitialValidateModel(),
+			TestModel:             ui.InitialTestModel(),
+			CustomRequestModel:    ui.InitialCustomRequestModel(),
+			EndpointSelectorModel: ui.InitialEndpointSelectorModel(),
+			History:               history,
+			ResponseCache:         models.LoadResponseCache(models.DefaultCacheEntries, models.DefaultCacheBodySize),
+			HistoryIndex:          0,
+		},
+		specCopies: make(map[string]string),
+	}
+
+	// Pre-fill spec path and base URL if saved in config
+	if cfg.SpecPath != "" {
+		m.TestModel.SpecInput.SetValue(cfg.SpecPath)
+	}
+	if cfg.BaseURL != "" {
+		m.TestModel.UrlInput.SetValue(cfg.BaseURL)
+	}
+
+	return m
+}
+
+// Init returns the initial command to run when the program starts
+func (m model) Init() tea.Cmd {
+	return m.TestModel.Spinner.Tick
+}
+
+// Update handles all incoming messages and updates the model accordingly
+func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.Width = msg.Width
+		m.Height = msg.Height
+		return m, nil
+	case tea.KeyMsg:
+		switch m.Screen {
+		case models.MenuScreen:
+			return m.updateMenu(msg)
+		case models.HelpScreen:
+			return m.updateHelp(msg)
+		case models.ValidateScreen:
+			return m.updateValidate(msg)
+		case models.TestScreen:
+			return m.updateTest(msg)
+		case models.CustomRequestScreen:
+			return m.updateCustomRequest(msg)
+		case models.EndpointSelectorScreen:
+			return m.updateEndpointSelector(msg)
+		case models.HistoryScreen:
+			return m.updateHistory(msg)
+		case models.ConfigEditorScreen:
+			return m.updateConfigEditor(msg)
+		case models.ConfigInfoScreen:
+			return m.updateConfigInfo(msg)
+		}
+	case endpointFilterMsg:
+		if m.Screen == models.EndpointSelectorScreen {
+			return m.updateEndpointSelector(msg)
+		}
+	case specFetchedMsg:
+		// Keep every download, even one no longer waited for, so it is removed on exit
+		if msg.Err == nil {
+			m.keepSpecCopy(msg.URL, msg.Path)
+		}
+		if m.Screen == models.ValidateScreen && m.ValidateModel.Fetching && msg.URL == m.ValidateModel.TextInput.Value() {
+			return m.updateValidate(msg)
+		}
+		if m.Screen == models.TestScreen && m.TestModel.FetchingSpec && msg.URL == m.TestModel.SpecInput.Value() {
+			return m.updateTest(msg)
+		}
+	case testing.TestProgressMsg:
+		// Keep listening only while a run is in progress
+		if m.Screen == models.TestScreen && m.TestModel.Step == 2 {
+			m.TestModel.Completed, m.TestModel.Total, m.TestModel.Active = msg.Completed, msg.Total, msg.Active
+			m.TestModel.LatestEndpoint, m.TestModel.LatestSummary = msg.Endpoint, msg.Summary
+			return m, tea.Batch(testing.WaitForProgress(m.progress), ui.WindowTitle(ui.ProgressTitle(msg.Completed, msg.Total)))
+		}
+	case inFlightTickMsg:
+		// Keep refreshing only while a run is in progress
+		if m.Screen == models.TestScreen && m.TestModel.Step == 2 {
+			m.refreshInFlight()
+			return m, inFlightTick()
+		}
+	case testing.TestCompleteMsg:
+		if m.Screen == models.TestScreen {
+			return m.updateTest(msg)
+		}
+		if m.Screen == models.CustomRequestScreen {
+			return m.updateCustomRequest(msg)
+		}
+	case testing.TestErrorMsg:
+		if m.Screen == models.TestScreen {
+			return m.updateTest(msg)
+		}
+		if m.Screen == models.CustomRequestScreen {
+			return m.updateCustomRequest(msg)
+		}
+	}
+	return m, nil
+}
+
+// updateMenu handles key events in the main menu screen
+func (m model) updateMenu(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	items := models.MenuItems(m.Config)
+	switch msg.String() {
+	case "ctrl+c", "q":
+		return m, tea.Quit
+	case "up", "k":
+		if m.Cursor > 0 {
+			m.Cursor--
+		}
+	case "down", "j":
+		if m.Cursor < len(items)-1 {
+			m.Cursor++
+		}
+	case "h", "?":
+		m.Screen = models.HelpScreen
+		return m, nil
+	case "v":
+		m.VerboseMode = !m.VerboseMode
+		m.Config.VerboseMode = m.VerboseMode
+		config.SaveConfig(m.Config)
+		return m, nil
+	case "i":
+		// Show where config.yaml lives and what it holds
+		path, contents, err := config.ReadConfigFile()
+		m.ConfigInfo = models.ConfigInfoModel{Path: path, Contents: contents, Err: err}
+		m.Screen = models.ConfigInfoScreen
+		return m, nil
+	case "enter":
+		if m.Cursor >= len(items) {
+			return m, nil
+		}
+		switch items[m.Cursor] {
+		case models.MenuValidate:
+			m.Screen = models.ValidateScreen
+			return m, nil
+		case models.MenuTestAll:
+			// Test All Endpoints
+			m.Screen = models.TestScreen
+			return m, nil
+		case models.MenuSelectTest:
+			// Select & Test Endpoints - need spec path and base URL first
+			m.Screen = models.TestScreen
+			m.TestModel.Step = 0
+			m.TestModel.SelectEndpoints = true  // Flag to show endpoint selector after step 1
+			return m, nil
+		case models.MenuFavorites:
+			// Run Favorites - test only pinned endpoints after spec path and base URL
+			m.Screen = models.TestScreen
+			m.TestModel.Step = 0
+			m.TestModel.RunFavorites = true
+			return m, nil
+		case models.MenuTestChanged:
+			// Test Changed Endpoints - test only operations added or modified since the previous spec
+			m.Screen = models.TestScreen
+			m.TestModel.Step = 0
+			m.TestModel.RunChanged = true
+			return m, nil
+		case models.MenuCustomRequest:
+			m.Screen = models.CustomRequestScreen
+			m.CustomRequestModel = ui.InitialCustomRequestModel()
+			return m, nil
+		case models.MenuListRequests:
+			// List Request URLs - resolve every request after spec path and base URL
+			m.Screen = models.TestScreen
+			m.TestModel.Step = 0
+			m.TestModel.ListRequests = true
+			return m, nil
+		case models.MenuHistory:
+			m.Screen = models.HistoryScreen
+			m.HistoryIndex = 0
+			m.HistoryMarked = nil
+			return m, nil
+		case models.MenuSettings:
+			// Settings
+			m.Screen = models.ConfigEditorScreen
+			m.ConfigEditorModel = ui.InitialConfigEditorModel(m.Config)
+			return m, nil
+		case models.MenuHelp:
+			m.Screen = models.HelpScreen
+			return m, nil
+		case models.MenuQuit:
+			return m, tea.Quit
+		}
+	}
+	return m, nil
+}
+
+// updateConfigInfo handles key events in the config file info screen
+func (m model) updateConfigInfo(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// A reset needs a second keypress; anything but y cancels it
+	if m.ConfigInfo.ConfirmReset {
+		m.ConfigInfo.ConfirmReset = false
+		if msg.String() != "y" {
+			m.ConfigInfo.Notice = "Reset cancelled"
+			return m, nil
+		}
+		return m.resetConfig(), nil
+	}
+
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "q", "esc", "enter":
+		m.Screen = models.MenuScreen
+		return m, nil
+	case "y":
+		// Copy the path, which may be clipped on screen
+		if m.ConfigInfo.Path != "" {
+			if err := clipboard.WriteAll(m.ConfigInfo.Path); err != nil {
+				m.ConfigInfo.Notice = fmt.Sprintf("❌ Could not copy to clipboard: %v", err)
+			} else {
+				m.ConfigInfo.Notice = fmt.Sprintf("📋 Copied %s to clipboard", m.ConfigInfo.Path)
+			}
+		}
+		return m, nil
+	case "R":
+		m.ConfigInfo.ConfirmReset = true
+		m.ConfigInfo.Notice = ""
+		return m, nil
+	}
+	return m, nil
+}
+
+// resetConfig backs up the config file, replaces it with the defaults and
+// reloads the settings, showing the outcome on the config info screen
+func (m model) resetConfig() model {
+	backupPath, err := config.ResetConfig()
+	if err != nil {
+		m.ConfigInfo.Notice = fmt.Sprintf("❌ Reset failed: %v", err)
+		return m
+	}
+	m.Config = config.LoadConfig()
+	registerResponseValidators(m.Config)
+	m.VerboseMode = m.Config.VerboseMode
+	path, contents, err := config.ReadConfigFile()
+	m.ConfigInfo = models.ConfigInfoModel{Path: path, Contents: contents, Err: err}
+	m.ConfigInfo.Notice = "✅ Config reset to defaults"
+	if backupPath != "" {
+		m.ConfigInfo.Notice += ", previous config saved to " + backupPath
+	}
+	return m
+}
+
+// updateHelp handles key events in the help screen
+func (m model) updateHelp(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q", "esc", "h", "?":
+		m.Screen = models.MenuScreen
+		return m, nil
+	}
+	return m, nil
+}
+
+// updateValidate handles events in the validation screen
+func (m model) updateValidate(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.Type {
+		case tea.KeyEnter:
+			if m.ValidateModel.Done {
+				m.Screen = models.MenuScreen
+				m.ValidateModel = ui.InitialValidateModel()
+				return m, nil
+			}
+
+			if m.ValidateModel.Fetching {
+				return m, nil
+			}
+			filePath := m.ValidateModel.TextInput.Value()
+			if filePath == "" {
+				m.ValidateModel.Err = fmt.Errorf("file path cannot be empty")
+				return m, nil
+			}
+			if validation.IsSpecURL(filePath) {
+				m.ValidateModel.Err = nil
+				m.ValidateModel.Fetching = true
+				return m, m.fetchSpec(filePath)
+			}
+			m.validateSpec(filePath)
+			return m, nil
+		case tea.KeyCtrlP:
+			// Validate a spec copied to the clipboard without saving it first
+			if m.ValidateModel.Done || m.ValidateModel.Fetching {
+				return m, nil
+			}
+			data, err := clipboard.ReadAll()
+			if err != nil {
+				m.ValidateModel.Err = fmt.Errorf("could not read the clipboard (no clipboard in headless sessions): %w", err)
+				return m, nil
+			}
+			filePath, err := validation.SpecFromData([]byte(data))
+			if err != nil {
+				m.ValidateModel.Err = fmt.Errorf("clipboard does not hold a usable spec: %w", err)
+				return m, nil
+			}
+			m.keepSpecCopy(filePath, filePath)
+			m.ValidateModel.TextInput.SetValue(filePath)
+			m.validateSpec(filePath)
+			return m, nil
+		case tea.KeyCtrlC, tea.KeyEsc:
+			m.Screen = models.MenuScreen
+			m.ValidateModel = ui.InitialValidateModel()
+			return m, nil
+		}
+
+		// Export the generated request bodies of a valid spec as fixtures
+		if m.ValidateModel.Done && m.ValidateModel.Err == nil && msg.String() == "b" {
+			filename, err := export.ExportGeneratedBodies(m.specFile(m.ValidateModel.TextInput.Value()))
+			if err != nil {
+				m.ValidateModel.ExportSuccess = fmt.Sprintf("❌ Fixtures export failed: %v", err)
+			} else {
+				m.ValidateModel.ExportSuccess = fmt.Sprintf("✅ Generated request bodies exported to %s", filename)
+			}
+			return m, nil
+		}
+		if m.ValidateModel.Fetching {
+			return m, nil
+		}
+	case specFetchedMsg:
+		m.ValidateModel.Fetching = false
+		if msg.Err != nil {
+			m.ValidateModel.Err = msg.Err
+			return m, nil
+		}
+		m.validateSpec(msg.Path)
+		return m, nil
+	}
+
+	m.ValidateModel.TextInput, cmd = m.ValidateModel.TextInput.Update(msg)
+	return m, cmd
+}
+
+// validateSpec validates the spec at filePath and shows the outcome
+func (m *model) validateSpec(filePath string) {
+	result, warnings, err := validation.ValidateSpecWithOptions(filePath, m.specOptions())
+	if err != nil {
+		m.ValidateModel.Err = err
+		return
+	}
+	m.ValidateModel.Err = nil
+	m.ValidateModel.Result = result
+	m.ValidateModel.Warnings = warnings
+	m.ValidateModel.Webhooks, _ = validation.ListWebhooks(filePath)
+	m.ValidateModel.Done = true
+}
+
+// updateTest handles events in the testing screen
+func (m model) updateTest(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch m.TestModel.Step {
+	case 0:
+		switch msg := msg.(type) {
+		case tea.KeyMsg:
+			switch msg.Type {
+			case tea.KeyEnter:
+				if m.TestModel.FetchingSpec {
+					return m, nil
+				}
+				specPath := m.TestModel.SpecInput.Value()
+				if specPath == "" {
+					m.TestModel.Err = fmt.Errorf("spec file path cannot be empty")
+					return m, nil
+				}
+				if validation.IsSpecURL(specPath) {
+					m.TestModel.Err = nil
+					m.TestModel.FetchingSpec = true
+					return m, m.fetchSpec(specPath)
+				}
+				m.acceptSpec(specPath)
+				return m, nil
+			case tea.KeyCtrlP:
+				// Test a spec copied to the clipboard without saving it first
+				if m.TestModel.FetchingSpec {
+					return m, nil
+				}
+				data, err := clipboard.ReadAll()
+				if err != nil {
+					m.TestModel.Err = fmt.Errorf("could not read the clipboard (no clipboard in headless sessions): %w", err)
+					return m, nil
+				}
+				specPath, err := validation.SpecFromData([]byte(data))
+				if err != nil {
+					m.TestModel.Err = fmt.Errorf("clipboard does not hold a usable spec: %w", err)
+					return m, nil
+				}
+				m.keepSpecCopy(specPath, specPath)
+				m.TestModel.SpecInput.SetValue(specPath)
+				m.acceptSpec(specPath)
+				return m, nil
+			case tea.KeyCtrlC, tea.KeyEsc:
+				m.Screen = models.MenuScreen
+				m.TestModel = ui.InitialTestModel()
+				return m, nil
+			}
+			if m.TestModel.FetchingSpec {
+				return m, nil
+			}
+			m.TestModel.SpecInput, cmd = m.TestModel.SpecInput.Update(msg)
+		case specFetchedMsg:
+			m.TestModel.FetchingSpec = false
+			if msg.Err != nil {
+				m.TestModel.Err = msg.Err
+				return m, nil
+			}
+			m.acceptSpec(msg.Path)
+			return m, nil
+		case testing.TestCompleteMsg:
+			m.TestModel.Results = msg.Results
+			m.TestModel.Err = nil
+			m.TestModel.Step = 3
+			m.TestModel.Testing = false
+			m.cacheResponses(msg.Results)
+			return m, nil
+		case testing.TestErrorMsg:
+			m.TestModel.Err = msg.Err
+			m.TestModel.Step = 3
+			m.TestModel.Testing = false
+			return m, nil
+		}
+	case 1:
+		switch msg := msg.(type) {
+		case tea.KeyMsg:
+			switch msg.Type {
+			case tea.KeyEnter:
+				if m.TestModel.UrlInput.Value() == "" {
+					m.TestModel.Err = fmt.Errorf("base URL cannot be empty")
+					return m, nil
+				}
+
+				m.Config.SpecPath = m.TestModel.SpecInput.Value()
+				m.Config.BaseURL = m.TestModel.UrlInput.Value()
+				config.SaveConfig(m.Config)
+
+				// List flow: show the resolved URLs instead of testing
+				if m.TestModel.ListRequests {
+					opts, err := m.runOptions()
+					if err != nil {
+						m.TestModel.Err = err
+						return m, nil
+					}
+					lines, err := testing.ListResolvedRequests(m.specFile(m.Config.SpecPath), m.Config.BaseURL, opts)
+					if err != nil {
+						m.TestModel.Err = err
+						return m, nil
+					}
+					m.TestModel.RequestList = lines
+					m.TestModel.Err = nil
+					m.TestModel.Step = 6
+					return m, nil
+				}
+
+				// Check if we should show endpoint selector
+				if m.TestModel.SelectEndpoints {
+					// Load endpoints from spec
+					endpoints, err := validation.ExtractEndpoints(m.specFile(m.Config.SpecPath))
+					if err != nil {
+						m.TestModel.Err = fmt.Errorf("failed to load endpoints: %w", err)
+						return m, nil
+					}
+					endpoints = validation.FilterByGlobs(endpoints, m.Config.IncludeGlobs, m.Config.ExcludeGlobs)
+
+					// Initialize endpoint selector
+					m.EndpointSelectorModel = ui.InitialEndpointSelectorModel()
+					m.EndpointSelectorModel.AllEndpoints = endpoints
+					m.EndpointSelectorModel.FilteredEndpoints = endpoints
+					m.EndpointSelectorModel.Ready = true
+
+					// Switch to endpoint selector screen
+					m.Screen = models.EndpointSelectorScreen
+					return m, nil
+				}
+
+				// Normal flow: test all endpoints
+				opts, err := m.runOptions()
+				if err != nil {
+					m.TestModel.Err = err
+					return m, nil
+				}
+
+				// Favorites flow: test only pinned endpoints
+				if m.TestModel.RunFavorites {
+					endpoints, err := validation.ExtractEndpoints(m.specFile(m.Config.SpecPath))
+					if err != nil {
+						m.TestModel.Err = fmt.Errorf("failed to load endpoints: %w", err)
+						return m, nil
+					}
+					opts.Selection = validation.PinnedEndpoints(endpoints, m.Config.PinnedEndpoints)
+					if len(opts.Selection) == 0 {
+						m.TestModel.Err = fmt.Errorf("no pinned endpoints in this spec (press * in the endpoint selector to pin one)")
+						return m, nil
+					}
+					m.TestModel.SpecEndpoints = endpoints
+				}
+
+				// Changed flow: test only operations added or modified since the previous spec
+				if m.TestModel.RunChanged {
+					if m.Config.PreviousSpec == "" {
+						m.TestModel.Err = fmt.Errorf("no previous spec to compare with (set previousSpec in config.yaml)")
+						return m, nil
+					}
+					diff, err := validation.DiffSpecs(m.Config.PreviousSpec, m.specFile(m.Config.SpecPath))
+					if err != nil {
+						m.TestModel.Err = err
+						return m, nil
+					}
+					opts.Selection = diff.Changed()
+					if len(opts.Selection) == 0 {
+						m.TestModel.Err = fmt.Errorf("no endpoints added or modified since %s", m.Config.PreviousSpec)
+						return m, nil
+					}
+					if endpoints, err := validation.ExtractEndpoints(m.specFile(m.Config.SpecPath)); err == nil {
+						m.TestModel.SpecEndpoints = endpoints
+					}
+				}
+				if !m.runConfirmed(opts) {
+					return m, nil
+				}
+				m.TestModel.LastSelection = opts.Selection
+				m.TestModel.Step = 2
+				m.TestModel.Testing = true
+				m.TestModel.TestStartTime = time.Now()
+				cmd = m.startRun(m.TestModel.SpecInput.Value(), m.TestModel.UrlInput.Value(), opts)
+				return m, cmd
+			case tea.KeyCtrlC, tea.KeyEsc:
+				if m.TestModel.ConfirmRequests > 0 {
+					// Back out of a large run without leaving the screen
+					m.TestModel.ConfirmRequests = 0
+					return m, nil
+				}
+				m.Screen = models.MenuScreen
+				m.TestModel = ui.InitialTestModel()
+				return m, nil
+			}
+			m.TestModel.ConfirmRequests = 0
+			m.TestModel.UrlInput, cmd = m.TestModel.UrlInput.Update(msg)
+		}
+	case 2:
+		switch msg := msg.(type) {
+		case specFetchedMsg:
+			// A history entry's spec URL is downloaded, run it
+			m.TestModel.FetchingSpec = false
+			if msg.Err != nil {
+				m.TestModel.Err = msg.Err
+				m.TestModel.Step = 3
+				m.TestModel.Testing = false
+				return m, nil
+			}
+			return m, testing.RunTestCmd(msg.Path, m.TestModel.UrlInput.Value(), nil, m.VerboseMode, m.Config.MaxRetries, m.Config.RetryDelay)
+		case tea.KeyMsg:
+			switch msg.Type {
+			case tea.KeyCtrlC, tea.KeyEsc:
+				// Abandon the whole run, so it stops sending requests
+				m.runControl.CancelRun()
+				m.Screen = models.MenuScreen
+				m.TestModel = ui.InitialTestModel()
+				return m, nil
+			}
+			switch msg.String() {
+			case "up", "k":
+				m.selectInFlight(m.TestModel.InFlightCursor - 1)
+			case "down", "j":
+				m.selectInFlight(m.TestModel.InFlightCursor + 1)
+			case "c":
+				// Cancel only the selected request; the rest of the run carries on
+				m.runControl.Cancel(m.TestModel.InFlightSelected)
+				m.refreshInFlight()
+			case "s":
+				// Cancel whichever request has been waiting the longest
+				m.runControl.CancelSlowest()
+				m.refreshInFlight()
+			}
+			return m, nil
+		case testing.TestCompleteMsg:
+			m.TestModel.Results = msg.Results
+			m.TestModel.Err = nil
+			m.TestModel.Step = 3
+			m.TestModel.Testing = false
+			m.cacheResponses(msg.Results)
+			
+			// Save to history
+			duration := time.Since(m.TestModel.TestStartTime)
+			entry := models.CreateHistoryEntry(
+				m.TestModel.SpecInput.Value(),
+				m.TestModel.UrlInput.Value(),
+				msg.Results,
+				duration,
+			)
+			m.History.AddEntry(entry)
+			m.TestModel.Flaky = models.DetectFlaky(m.History.RecentRuns(entry.SpecPath, entry.BaseURL, flakyRunWindow))
+			m.TestModel.BodyReport = m.bodyGenerationReport()
+			m.TestModel.Regression = nil
+			if m.Config.BaselineFile != "" {
+				report := export.CompareToBaseline(msg.Results, m.Config.BaselineFile, m.Config.LatencyTolerance)
+				m.TestModel.Regression = &report
+			}
+			
+			// Persist history to disk (ignore errors to not disrupt user flow)
+			_ = models.SaveHistory(m.History)
+			
+			return m, ui.WindowTitle(ui.OutcomeTitle(msg.Results))
+		case testing.TestErrorMsg:
+			m.TestModel.Err = msg.Err
+			m.TestModel.Step = 3
+			m.TestModel.Testing = false
+			return m, ui.WindowTitle("Test run failed")
+		}
+		m.TestModel.Spinner, cmd = m.TestModel.Spinner.Update(msg)
+	case 3:
+		switch msg := msg.(type) {
+		case tea.KeyMsg:
+			// If the profile prompt is open, it takes every key
+			if m.TestModel.ProfilePrompt {
+				switch msg.Type {
+				case tea.KeyEsc:
+					m.closeProfilePrompt()
+					return m, nil
+				case tea.KeyEnter:
+					m.saveProfile()
+					return m, nil
+				default:
+					m.TestModel.ProfileInput, cmd = m.TestModel.ProfileInput.Update(msg)
+					return m, cmd
+				}
+			}
+
+			// If the filter is being typed, handle filter input first
+			if m.TestModel.FilterActive && m.TestModel.FilterInput.Focused() {
+				switch msg.Type {
+				case tea.KeyEsc:
+					// Esc while filtering: exit filter mode
+					m.TestModel.FilterActive = false
+					m.TestModel.FilterInput.Blur()
+					m.TestModel.FilterInput.SetValue("")
+					return m, nil
+				case tea.KeyEnter:
+					// Enter while filtering: keep the filter applied and hand
+					// the keys back to the results, so logs can be opened
+					m.TestModel.FilterInput.Blur()
+					return m, nil
+				default:
+					// Route all other keys to filter input
+					m.TestModel.FilterInput, cmd = m.TestModel.FilterInput.Update(msg)
+					return m, cmd
+				}
+			}
+			
+			// Normal key handling when filter is not active
+			m.syncResultsTable()
+			switch msg.String() {
+			case "v":
+				// Toggle verbose mode
+				m.VerboseMode = !m.VerboseMode
+				m.Config.VerboseMode = m.VerboseMode
+				config.SaveConfig(m.Config)
+				return m, nil
+			case "f":
+				// Enter filter mode, or edit an applied filter
+				m.TestModel.FilterActive = true
+				m.TestModel.FilterInput.Focus()
+				return m, nil
+			case "esc":
+				// Esc with a filter applied clears it before leaving
+				if m.TestModel.FilterActive {
+					m.TestModel.FilterActive = false
+					m.TestModel.FilterInput.SetValue("")
+					return m, nil
+				}
+			case "x":
+				// Toggle showing only failing results
+				m.TestModel.ShowOnlyFailures = !m.TestModel.ShowOnlyFailures
+				m.syncResultsTable()
+				return m, nil
+			case "M":
+				// Show or hide the results table's Message column
+				m.TestModel.HideMessageColumn = !m.TestModel.HideMessageColumn
+				return m, nil
+			case "D":
+				// Show or hide a Duration column in the results table
+				m.TestModel.ShowDurationColumn = !m.TestModel.ShowDurationColumn
+				return m, nil
+			case "P":
+				// Offer to save the run's inputs as a named profile
+				m.TestModel.ProfilePrompt = true
+				m.TestModel.ProfileInput.Focus()
+				return m, nil
+			case "F":
+				// Jump to the first failing result
+				if idx := ui.FirstFailure(ui.VisibleResults(m.TestModel)); idx >= 0 {
+					m.TestModel.Table.SetCursor(idx)
+				} else {
+					m.TestModel.ExportSuccess = "✅ No failing results"
+				}
+				return m, nil
+			case "home":
+				// Jump to the first result ('g' exports coverage instead)
+				m.TestModel.Table.GotoTop()
+				return m, nil
+			case "end", "G":
+				// Jump to the last result
+				m.TestModel.Table.GotoBottom()
+				return m, nil
+			case "a":
+				// Expand or collapse the passing results
+				m.TestModel.CollapsePassing = !m.TestModel.CollapsePassing
+				return m, nil
+			case "t":
+				// Group the results by operation tag, with per-tag subtotals
+				m.TestModel.GroupByTag = !m.TestModel.GroupByTag
+				m.TestModel.Table.SetCursor(0)
+				return m, nil
+			case "u":
+				// Expand or collapse the untested endpoints of a selective run
+				if m.TestModel.SpecEndpoints != nil {
+					m.TestModel.ShowUntested = !m.TestModel.ShowUntested
+				}
+				return m, nil
+			case "e":
+				if len(m.TestModel.Results) > 0 {
+					specPath := m.TestModel.SpecInput.Value()
+					filename, err := export.ExportResultsWithMetadata(m.TestModel.Results, specPath, m.exportMetadata())
+					if err != nil {
+						m.TestModel.Err = errors.EnhanceFileError(err, "export file")
+					} else {
+						m.TestModel.ExportSuccess = fmt.Sprintf("✅ Exported JSON to %s", filename)
+						if path, err := export.AbsolutePath(filename); err == nil {
+							m.TestModel.LastExportPath = path
+						}
+					}
+				}
+				return m, nil
+			case "h":
+				if len(m.TestModel.Results) > 0 {
+					specPath := m.TestModel.SpecInput.Value()
+					baseURL := m.TestModel.UrlInput.Value()
+					filename, err := export.ExportResultsToHTMLWithMetadata(m.TestModel.Results, specPath, baseURL, m.Config.DurationUnit, m.exportMetadata())
+					if err != nil {
+						m.TestModel.Err = errors.EnhanceFileError(err, "HTML export file")
+					} else {
+						m.TestModel.ExportSuccess = fmt.Sprintf("✅ Exported HTML to %s", filename)
+						if path, err := export.AbsolutePath(filename); err == nil {
+							m.TestModel.LastExportPath = path
+						}
+					}
+				}
+				return m, nil
+			case "j":
+				if len(m.TestModel.Results) > 0 {
+					specPath := m.TestModel.SpecInput.Value()
+					baseURL := m.TestModel.UrlInput.Value()
+					filename, err := export.ExportResultsToJUnitWithMetadata(m.TestModel.Results, specPath, baseURL, m.exportMetadata())
+					if err != nil {
+						m.TestModel.Err = errors.EnhanceFileError(err, "JUnit XML export file")
+					} else {
+						m.TestModel.ExportSuccess = fmt.Sprintf("✅ Exported JUnit XML to %s", filename)
+						if path, err := export.AbsolutePath(filename); err == nil {
+							m.TestModel.LastExportPath = path
+						}
+					}
+				}
+				return m, nil
+			case "J":
+				// Export one JSON object per line, for piping into jq or analytics
+				if len(m.TestModel.Results) > 0 {
+					filename, err := export.ExportResultsToJSONL(m.TestModel.Results)
+					if err != nil {
+						m.TestModel.Err = errors.EnhanceFileError(err, "JSON lines export file")
+					} else {
+						m.TestModel.ExportSuccess = fmt.Sprintf("✅ Exported JSON lines to %s", filename)
+						if path, err := export.AbsolutePath(filename); err == nil {
+							m.TestModel.LastExportPath = path
+						}
+					}
+				}
+				return m, nil
+			case "m":
+				// Copy the whole run as Markdown for sharing, or save it when
+				// there is no clipboard
+				if len(m.TestModel.Results) > 0 {
+					specPath := m.TestModel.SpecInput.Value()
+					baseURL := m.TestModel.UrlInput.Value()
+					metadata := m.exportMetadata()
+					if err := clipboard.WriteAll(export.FormatResultsMarkdownWithMetadata(m.TestModel.Results, specPath, baseURL, metadata)); err == nil {
+						m.TestModel.ExportSuccess = "📋 Copied the run as Markdown to clipboard"
+						return m, nil
+					}
+					filename, err := export.ExportResultsToMarkdownWithMetadata(m.TestModel.Results, specPath, baseURL, metadata)
+					if err != nil {
+						m.TestModel.Err = errors.EnhanceFileError(err, "Markdown export file")
+					} else {
+						m.TestModel.ExportSuccess = fmt.Sprintf("✅ No clipboard available, exported Markdown to %s", filename)
+						if path, err := export.AbsolutePath(filename); err == nil {
+							m.TestModel.LastExportPath = path
+						}
+					}
+				}
+				return m, nil
+			case "g":
+				// Export how many of the spec's operations this run tested
+				if len(m.TestModel.Results) > 0 {
+					filename, err := export.ExportCoverageWithMetadata(m.specFile(m.TestModel.SpecInput.Value()), m.TestModel.Results, m.exportMetadata())
+					if err != nil {
+						m.TestModel.Err = errors.EnhanceFileError(err, "coverage export file")
+					} else {
+						m.TestModel.ExportSuccess = fmt.Sprintf("✅ Exported coverage to %s", filename)
+						if path, err := export.AbsolutePath(filename); err == nil {
+							m.TestModel.LastExportPath = path
+						}
+					}
+				}
+				return m, nil
+			case "p":
+				// Export a bug-report reproduction of the selected result
+				visible := ui.VisibleResults(m.TestModel)
+				selectedIdx := m.TestModel.Table.Cursor()
+				if selectedIdx >= 0 && selectedIdx < len(visible) {
+					filename, err := export.ExportReproduction(visible[selectedIdx])
+					if err != nil {
+						m.TestModel.ExportSuccess = fmt.Sprintf("❌ %v", err)
+					} else {
+						m.TestModel.ExportSuccess = fmt.Sprintf("✅ Exported reproduction to %s", filename)
+						if path, err := export.AbsolutePath(filename); err == nil {
+							m.TestModel.LastExportPath = path
+						}
+					}
+				}
+				return m, nil
+			case "y":
+				// Copy the last exported report's full path, which may be clipped on screen
+				if m.TestModel.LastExportPath != "" {
+					if err := clipboard.WriteAll(m.TestModel.LastExportPath); err != nil {
+						m.TestModel.ExportSuccess = fmt.Sprintf("❌ Could not copy to clipboard: %v", err)
+					} else {
+						m.TestModel.ExportSuccess = fmt.Sprintf("📋 Copied %s to clipboard", m.TestModel.LastExportPath)
+					}
+				}
+				return m, nil
+			case "V":
+				// Re-run the same spec, URL and selection with verbose logs
+				opts, err := m.verboseRerunOptions()
+				if err != nil {
+					m.TestModel.Err = err
+					return m, nil
+				}
+				m.VerboseMode = true
+				m.Config.VerboseMode = true
+				config.SaveConfig(m.Config)
+				m.TestModel.Results = nil
+				m.TestModel.ExportSuccess = ""
+				m.TestModel.Step = 2
+				m.TestModel.Testing = true
+				m.TestModel.TestStartTime = time.Now()
+				cmd = m.startRun(m.TestModel.SpecInput.Value(), m.TestModel.UrlInput.Value(), opts)
+				return m, cmd
+			case "r":
+				// View test run history
+				m.Screen = models.HistoryScreen
+				m.HistoryIndex = 0
+				m.HistoryMarked = nil
+				return m, nil
+			case "c":
+				// Show the last cached response for the selected endpoint
+				if m.Config.CacheResponses {
+					selectedIdx := m.TestModel.Table.Cursor()
+					if selectedIdx >= 0 && selectedIdx < len(ui.VisibleResults(m.TestModel)) {
+						m.TestModel.SelectedLog = selectedIdx
+						m.TestModel.Step = 5
+					}
+				}
+				return m, nil
+			case "o":
+				// Show the spec's definition of the selected result's operation
+				visible := ui.VisibleResults(m.TestModel)
+				selectedIdx := m.TestModel.Table.Cursor()
+				if selectedIdx >= 0 && selectedIdx < len(visible) {
+					result := visible[selectedIdx]
+					definition, err := validation.OperationDefinition(m.specFile(m.TestModel.SpecInput.Value()), result.Method, result.Endpoint)
+					if err != nil {
+						m.TestModel.ExportSuccess = fmt.Sprintf("❌ %v", err)
+						return m, nil
+					}
+					m.TestModel.OperationDetail = definition
+					m.TestModel.SelectedLog = selectedIdx
+					m.TestModel.Step = 7
+				}
+				return m, nil
+			case "l":
+				visible := ui.VisibleResults(m.TestModel)
+				if m.VerboseMode && len(visible) > 0 {
+					selectedIdx := m.TestModel.Table.Cursor()
+					if selectedIdx >= 0 && selectedIdx < len(visible) {
+						result := visible[selectedIdx]
+						if result.LogEntry != nil {
+							m.TestModel.ShowingLog = true
+							m.TestModel.SelectedLog = selectedIdx
+							m.TestModel.Step = 4
+							return m, nil
+						}
+					}
+				}
+				return m, nil
+			}
+			if key.Matches(msg, ui.ResultsBackKeys) {
+				m.Screen = models.MenuScreen
+				m.TestModel = ui.InitialTestModel()
+				return m, nil
+			}
+		}
+		m.TestModel.Table, cmd = m.TestModel.Table.Update(msg)
+	case 6:
+		if msg, ok := msg.(tea.KeyMsg); ok {
+			switch msg.String() {
+			case "y":
+				if err := clipboard.WriteAll(strings.Join(m.TestModel.RequestList, "\n")); err != nil {
+					m.TestModel.ExportSuccess = fmt.Sprintf("❌ Could not copy to clipboard: %v", err)
+				} else {
+					m.TestModel.ExportSuccess = fmt.Sprintf("📋 Copied %d request URLs to clipboard", len(m.TestModel.RequestList))
+				}
+				return m, nil
+			case "esc", "enter", "ctrl+c":
+				m.Screen = models.MenuScreen
+				m.TestModel = ui.InitialTestModel()
+				return m, nil
+			}
+		}
+	case 4, 5, 7:
+		switch msg := msg.(type) {
+		case tea.KeyMsg:
+			if m.TestModel.Step == 4 && msg.String() == "y" {
+				m.TestModel.LogNotice = copyResponseBody(ui.VisibleResults(m.TestModel), m.TestModel.SelectedLog)
+				return m, nil
+			}
+			switch msg.Type {
+			case tea.KeyEsc, tea.KeyEnter:
+				m.TestModel.ShowingLog = false
+				m.TestModel.OperationDetail = ""
+				m.TestModel.LogNotice = ""
+				m.TestModel.Step = 3
+				return m, nil
+			case tea.KeyCtrlC:
+				m.Screen = models.MenuScreen
+				m.TestModel = ui.InitialTestModel()
+				return m, nil
+			}
+		}
+	}
+
+	return m, cmd
+}
+
+// updateHistory handles key events in the history screen
+func (m model) updateHistory(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// Comparison view only needs a way back to the list
+	if m.HistoryComparing {
+		switch msg.String() {
+		case "esc", "enter":
+			m.HistoryComparing = false
+		case "ctrl+c", "q":
+			return m, tea.Quit
+		}
+		return m, nil
+	}
+
+	switch msg.String() {
+	case "esc":
+		// Return to results screen
+		m.Screen = models.TestScreen
+		m.HistoryMarked = nil
+		return m, nil
+	case " ":
+		// Mark or unmark the selected run for comparison
+		if m.HistoryIndex >= 0 && m.HistoryIndex < len(m.History.Entries) {
+			m.HistoryMarked = models.ToggleCompareMark(m.HistoryMarked, m.HistoryIndex)
+		}
+		return m, nil
+	case "c":
+		// Compare the two marked runs side by side
+		if len(m.HistoryMarked) == 2 {
+			m.HistoryComparing = true
+		}
+		return m, nil
+	case "up", "k":
+		if m.HistoryIndex > 0 {
+			m.HistoryIndex--
+		}
+		return m, nil
+	case "down", "j":
+		if m.HistoryIndex < len(m.History.Entries)-1 {
+			m.HistoryIndex++
+		}
+		return m, nil
+	case "enter":
+		// Replay selected test
+		if m.HistoryIndex >= 0 && m.HistoryIndex < len(m.History.Entries) {
+			entry := m.History.Entries[m.HistoryIndex]
+			
+			// Set spec and URL from history
+			m.TestModel.SpecInput.SetValue(entry.SpecPath)
+			m.TestModel.UrlInput.SetValue(entry.BaseURL)
+			
+			// Save to config
+			m.Config.SpecPath = entry.SpecPath
+			m.Config.BaseURL = entry.BaseURL
+			config.SaveConfig(m.Config)
+			
+			// Marks are history indices, which shift once this run is recorded
+			m.HistoryMarked = nil
+
+			// Start testing
+			m.Screen = models.TestScreen
+			m.TestModel.Step = 2
+			m.TestModel.Testing = true
+			m.TestModel.Results = nil
+			m.TestModel.Err = nil
+			m.TestModel.ExportSuccess = ""
+			m.TestModel.LastSelection = nil
+			m.TestModel.TestStartTime = time.Now()
+			if validation.IsSpecURL(entry.SpecPath) {
+				m.TestModel.FetchingSpec = true
+				return m, m.fetchSpec(entry.SpecPath)
+			}
+			
+			return m, testing.RunTestCmd(entry.SpecPath, entry.BaseURL, nil, m.VerboseMode, m.Config.MaxRetries, m.Config.RetryDelay)
+		}
+		return m, nil
+	case "ctrl+c", "q":
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
+// updateConfigEditor handles key events in the configuration editor screen
+func (m model) updateConfigEditor(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	ce := &m.ConfigEditorModel
+	var cmd tea.Cmd
+
+	switch msg.String() {
+	case "ctrl+c", "q":
+		return m, tea.Quit
+	case "esc":
+		// Cancel and return to menu
+		m.Screen = models.MenuScreen
+		ce.ValidationError = ""
+		return m, nil
+	case "tab", "down":
+		// Move to next field (13 fields total now)
+		ce.FocusedField = (ce.FocusedField + 1) % 13
+		m.updateConfigEditorFocus()
+		return m, nil
+	case "shift+tab", "up":
+		// Move to previous field (13 fields total now)
+		ce.FocusedField = (ce.FocusedField - 1 + 13) % 13
+		m.updateConfigEditorFocus()
+		return m, nil
+	case "enter":
+		// Save configuration
+		return m.saveConfig()
+	}
+
+	// Update the focused field's text input
+	switch ce.FocusedField {
+	case 0:
+		ce.SpecPathInput, cmd = ce.SpecPathInput.Update(msg)
+	case 1:
+		ce.BaseURLInput, cmd = ce.BaseURLInput.Update(msg)
+	case 2:
+		ce.VerboseInput, cmd = ce.VerboseInput.Update(msg)
+	case 3:
+		ce.AuthTypeInput, cmd = ce.AuthTypeInput.Update(msg)
+	case 4:
+		ce.TokenInput, cmd = ce.TokenInput.Update(msg)
+	case 5:
+		ce.APIKeyNameInput, cmd = ce.APIKeyNameInput.Update(msg)
+	case 6:
+		ce.APIKeyInInput, cmd = ce.APIKeyInInput.Update(msg)
+	case 7:
+		ce.UsernameInput, cmd = ce.UsernameInput.Update(msg)
+	case 8:
+		ce.PasswordInput, cmd = ce.PasswordInput.Update(msg)
+	case 9:
+		ce.MaxConcurrInput, cmd = ce.MaxConcurrInput.Update(msg)
+	case 10:
+		ce.MaxRetriesInput, cmd = ce.MaxRetriesInput.Update(msg)
+	case 11:
+		ce.RetryDelayInput, cmd = ce.RetryDelayInput.Update(msg)
+	case 12:
+		ce.VersionHeaderInput, cmd = ce.VersionHeaderInput.Update(msg)
+	}
+
+	return m, cmd
+}
+
+// updateConfigEditorFocus updates which field has focus
+func (m *model) updateConfigEditorFocus() {
+	ce := &m.ConfigEditorModel
+	
+	// Blur all fields
+	ce.SpecPathInput.Blur()
+	ce.BaseURLInput.Blur()
+	ce.VerboseInput.Blur()
+	ce.AuthTypeInput.Blur()
+	ce.TokenInput.Blur()
+	ce.APIKeyNameInput.Blur()
+	ce.APIKeyInInput.Blur()
+	ce.UsernameInput.Blur()
+	ce.PasswordInput.Blur()
+	ce.MaxConcurrInput.Blur()
+	ce.MaxRetriesInput.Blur()
+	ce.RetryDelayInput.Blur()
+	ce.VersionHeaderInput.Blur()
+	
+	// Focus the current field
+	switch ce.FocusedField {
+	case 0:
+		ce.SpecPathInput.Focus()
+	case 1:
+		ce.BaseURLInput.Focus()
+	case 2:
+		ce.VerboseInput.Focus()
+	case 3:
+		ce.AuthTypeInput.Focus()
+	case 4:
+		ce.TokenInput.Focus()
+	case 5:
+		ce.APIKeyNameInput.Focus()
+	case 6:
+		ce.APIKeyInInput.Focus()
+	case 7:
+		ce.UsernameInput.Focus()
+	case 8:
+		ce.PasswordInput.Focus()
+	case 9:
+		ce.MaxConcurrInput.Focus()
+	case 10:
+		ce.MaxRetriesInput.Focus()
+	case 11:
+		ce.RetryDelayInput.Focus()
+	case 12:
+		ce.VersionHeaderInput.Focus()
+	}
+}
+
+// keepSpecCopy records a temporary file holding the spec named name (a
+// pasted spec's own path), removing the copy it replaces, so the files can
+// be cleaned up on exit
+func (m *model) keepSpecCopy(name, path string) {
+	if m.specCopies == nil {
+		m.specCopies = make(map[string]string)
+	}
+	if previous, ok := m.specCopies[name]; ok && previous != path {
+		os.Remove(previous)
+	}
+	m.specCopies[name] = path
+}
+
+// removeSpecCopies removes the temporary spec files made this session
+func (m model) removeSpecCopies() {
+	for _, path := range m.specCopies {
+		os.Remove(path)
+	}
+}
+
+// specFile returns the file to load the spec named name from: the local
+// copy of a downloaded or pasted spec, or name itself
+func (m model) specFile(name string) string {
+	if path, ok := m.specCopies[name]; ok {
+		return path
+	}
+	return name
+}
+
+// fetchSpec downloads a spec given as an http(s) URL in the background,
+// within the configured time and size limits, so the UI keeps responding
+// The URL stays the spec's name in the inputs, config and history; flows
+// load the downloaded copy through specFile.
+func (m model) fetchSpec(specURL string) tea.Cmd {
+	cfg := m.Config
+	return func() tea.Msg {
+		path, err := downloadSpec(cfg, specURL)
+		return specFetchedMsg{URL: specURL, Path: path, Err: err}
+	}
+}
+
+// downloadSpec downloads the spec at specURL to a temporary file within the
+// configured time and size limits, returning the file's path
+func downloadSpec(cfg models.Config, specURL string) (string, error) {
+	return validation.FetchSpec(specURL,
+		time.Duration(cfg.SpecFetchTimeout)*time.Second,
+		int64(cfg.SpecMaxSizeMB)<<20)
+}
+
+// requiredFieldsValidator names the validator checking the configured
+// requiredResponseFields
+const requiredFieldsValidator = "required-fields"
+
+// registerResponseValidators registers the response validators cfg turns on,
+// and removes those it turns off
+func registerResponseValidators(cfg models.Config) {
+	if len(cfg.RequiredResponseFields) == 0 {
+		validation.UnregisterResponseValidator(requiredFieldsValidator)
+		return
+	}
+	validation.RegisterResponseValidator(requiredFieldsValidator,
+		validation.RequiredFieldsValidator{Fields: cfg.RequiredResponseFields})
+}
+
+// runOptions builds the test run options from the current configuration
+// Loads the endpoint overrides file when one is configured
+func (m model) runOptions() (testing.RunOptions, error) {
+	opts := testing.RunOptions{
+		Auth:                  m.Config.Auth,
+		TokenCommand:          m.Config.TokenCommand,
+		TokenJSONPath:         m.Config.TokenCommandJSONPath,
+		Verbose:               m.VerboseMode,
+		MaxConcurrency:        m.Config.MaxConcurrency,
+		MaxRetries:            m.Config.MaxRetries,
+		RetryDelay:            m.Config.RetryDelay,
+		RetryOn:               m.Config.RetryOn,
+		RetryStatuses:         m.Config.RetryStatuses,
+		CacheResponses:        m.Config.CacheResponses,
+		ForceScheme:           m.Config.ForceScheme,
+		LogFile:               m.Config.LogFile,
+		ReplayFile:            m.Config.ReplayFile,
+		RecordFile:            m.Config.RecordFile,
+		CaptureHeaders:        m.Config.CaptureHeaders,
+		SkipDeprecatedParams:  m.Config.SkipDeprecatedParams,
+		ExclusiveExtension:    m.Config.ExclusiveParamsExtension,
+		IncludeGlobs:          m.Config.IncludeGlobs,
+		ExcludeGlobs:          m.Config.ExcludeGlobs,
+		AcceptEncoding:        m.Config.AcceptEncoding,
+		AssumeContentType:     m.Config.AssumeContentType,
+		ResponseMode:          m.Config.ResponseValidationMode,
+		APIVersionHeader:      m.Config.APIVersionHeader,
+		RequestHeaders:        m.Config.RequestHeaders,
+		RealisticData:         m.Config.RealisticData,
+		RandomData:            m.Config.RandomData,
+		DataSeed:              m.Config.DataSeed,
+		GlobalQuery:           m.Config.GlobalQuery,
+		DateTimeFormat:        m.Config.DateTimeFormat,
+		TestOrder:             m.Config.TestOrder,
+		TestAllExamples:       m.Config.TestAllExamples,
+		ExpandEnumParams:      m.Config.ExpandEnumParams,
+		OmitOptionalBodies:    m.Config.OmitOptionalBodies,
+		ContractTests:         m.Config.ContractTests,
+		AllowBodyOnAllMethods: m.Config.AllowBodyOnAllMethods,
+		ExpectedStatuses:      m.Config.ExpectedStatuses,
+		PreflightPath:         m.Config.PreflightPath,
+		MaxRunDuration:        m.Config.MaxRunDuration,
+		DialTimeout:           m.Config.DialTimeout,
+	}
+	if m.ResponseCache != nil {
+		opts.CacheBodySize = m.ResponseCache.MaxBodySize
+	}
+	if m.Config.OverridesFile != "" {
+		overrides, err := config.LoadOverrides(m.Config.OverridesFile)
+		if err != nil {
+			return opts, err
+		}
+		opts.Overrides = overrides
+	}
+	return opts, nil
+}
+
+// bodyGenerationReport reports the request bodies the last run could not
+// generate. Best-effort: the run itself reports spec and config errors
+func (m model) bodyGenerationReport() models.BodyGenReport {
+	opts, err := m.runOptions()
+	if err != nil {
+		return models.BodyGenReport{}
+	}
+	opts.Selection = m.TestModel.LastSelection
+	report, _ := testing.BodyGenerationReport(m.specFile(m.TestModel.SpecInput.Value()), opts)
+	return report
+}
+
+// verboseRerunOptions returns the options for re-running the last run with
+// verbose logging, limited to the same endpoints
+func (m model) verboseRerunOptions() (testing.RunOptions, error) {
+	opts, err := m.runOptions()
+	if err != nil {
+		return opts, err
+	}
+	opts.Verbose = true
+	opts.Selection = m.TestModel.LastSelection
+	return opts, nil
+}
+
+// startRun starts a parallel run tracked by a fresh RunControl, so single
+// in-flight requests can be cancelled from the progress view
+func (m *model) startRun(specPath, baseURL string, opts testing.RunOptions) tea.Cmd {
+	m.runControl = testing.NewRunControl()
+	opts.Control = m.runControl
+	m.progress = make(chan tea.Msg, progressBuffer)
+	m.TestModel.InFlight = nil
+	m.TestModel.InFlightCursor, m.TestModel.InFlightSelected = 0, 0
+	m.TestModel.Completed, m.TestModel.Total, m.TestModel.Active = 0, 0, 0
+	// Passing results start folded so failures stand out
+	m.TestModel.CollapsePassing = true
+	m.TestModel.LatestEndpoint, m.TestModel.LatestSummary = "", ""
+	return tea.Batch(
+		testing.RunTestParallelCmdWithProgress(m.specFile(specPath), baseURL, opts, m.progress),
+		testing.WaitForProgress(m.progress),
+		inFlightTick(),
+	)
+}
+
+// refreshInFlight reloads the in-flight requests shown in the progress view
+func (m *model) refreshInFlight() {
+	m.showInFlight(m.runControl.InFlight())
+}
+
+// showInFlight shows the given in-flight requests, keeping the selection on
+// the same request while it is in flight, and otherwise on the row it was on
+func (m *model) showInFlight(requests []models.InFlightRequest) {
+	m.TestModel.InFlight = requests
+	for i, r := range m.TestModel.InFlight {
+		if r.ID == m.TestModel.InFlightSelected && i < ui.MaxInFlightShown {
+			m.TestModel.InFlightCursor = i
+			return
+		}
+	}
+	m.selectInFlight(m.TestModel.InFlightCursor)
+}
+
+// selectInFlight selects the in-flight request on row i, kept within the
+// rows the progress view shows
+func (m *model) selectInFlight(i int) {
+	shown := min(len(m.TestModel.InFlight), ui.MaxInFlightShown)
+	if shown == 0 {
+		m.TestModel.InFlightCursor, m.TestModel.InFlightSelected = 0, 0
+		return
+	}
+	i = max(0, min(i, shown-1))
+	m.TestModel.InFlightCursor = i
+	m.TestModel.InFlightSelected = m.TestModel.InFlight[i].ID
+}
+
+// acceptSpec moves on to the base URL once the spec at specPath is ready to test
+func (m *model) acceptSpec(specPath string) {
+	if !m.specReadyToTest(specPath) {
+		return
+	}
+	m.TestModel.Err = nil
+	m.TestModel.Step = 1
+	m.TestModel.UrlInput.Focus()
+}
+
+// specReadyToTest validates the spec first when validateBeforeTest is on
+// An invalid spec is reported and blocks testing. Lint warnings are shown
+// once; pressing Enter again with the same warnings continues anyway.
+// With quickValidate on, the spec is only parsed, so there are no warnings.
+func (m *model) specReadyToTest(specPath string) bool {
+	if !m.Config.ValidateBeforeTest {
+		return true
+	}
+	if m.Config.QuickValidate {
+		m.TestModel.SpecWarnings = nil
+		if err := validation.ParseOnly(specPath); err != nil {
+			m.TestModel.Err = err
+			return false
+		}
+		return true
+	}
+	_, warnings, err := validation.ValidateSpecWithOptions(specPath, m.specOptions())
+	if err != nil {
+		m.TestModel.Err = err
+		m.TestModel.SpecWarnings = nil
+		return false
+	}
+	if len(warnings) > 0 && !slices.Equal(warnings, m.TestModel.SpecWarnings) {
+		m.TestModel.Err = nil
+		m.TestModel.SpecWarnings = warnings
+		return false
+	}
+	m.TestModel.SpecWarnings = nil
+	return true
+}
+
+// runConfirmed asks for confirmation before a run sending more requests
+// than confirmLargeRuns, by showing the count once; pressing Enter again
+// with the same count starts the run. A spec whose requests can't be
+// counted is left for the run to report
+func (m *model) runConfirmed(opts testing.RunOptions) bool {
+	if m.Config.ConfirmLargeRuns <= 0 {
+		return true
+	}
+	count, err := testing.CountRequests(m.specFile(m.Config.SpecPath), m.Config.BaseURL, opts)
+	if err != nil || count <= m.Config.ConfirmLargeRuns || count == m.TestModel.ConfirmRequests {
+		m.TestModel.ConfirmRequests = 0
+		return true
+	}
+	m.TestModel.Err = nil
+	m.TestModel.ConfirmRequests = count
+	return false
+}
+
+// exportMetadata returns the metadata and listing options for an export
+func (m model) exportMetadata() models.ExportMetadata {
+	metadata := models.NewExportMetadata(m.Config.RunMetadata)
+	metadata.FailuresOnly = m.Config.ExportFailuresOnly
+	return metadata
+}
+
+// specOptions returns the configured spec validation limits
+func (m model) specOptions() validation.SpecOptions {
+	return validation.SpecOptions{
+		ExampleMaxDepth:     m.Config.ExampleMaxDepth,
+		ResponseConsistency: m.Config.CheckResponseConsistency,
+	}
+}
+
+// syncResultsTable gives the model's results table the visible rows. The view
+// renders a copy of the table, so without this the cursor would move (and
+// Home, End, G and F jump) over an empty table. A cursor past the last row,
+// after hiding results, is moved onto it
+func (m *model) syncResultsTable() {
+	rows := ui.ResultRows(ui.VisibleResults(m.TestModel))
+	m.TestModel.Table.SetRows(rows)
+	if len(rows) > 0 && m.TestModel.Table.Cursor() >= len(rows) {
+		m.TestModel.Table.SetCursor(len(rows) - 1)
+	}
+}
+
+// saveProfile saves the run's spec, base URL and auth under the name typed
+// in the profile prompt, reporting the outcome in the results notice
+// The prompt stays open when the name is empty
+func (m *model) saveProfile() {
+	name := strings.TrimSpace(m.TestModel.ProfileInput.Value())
+	if name == "" {
+		return
+	}
+	profile := models.Profile{
+		SpecPath: m.TestModel.SpecInput.Value(),
+		BaseURL:  m.TestModel.UrlInput.Value(),
+		Auth:     m.Config.Auth,
+	}
+	if err := config.SaveProfile(&m.Config, name, profile); err != nil {
+		m.TestModel.ExportSuccess = fmt.Sprintf("❌ Failed to save profile: %v", err)
+	} else {
+		m.TestModel.ExportSuccess = fmt.Sprintf("✅ Saved profile %q", name)
+	}
+	m.closeProfilePrompt()
+}
+
+// closeProfilePrompt hides the profile prompt and clears its input
+func (m *model) closeProfilePrompt() {
+	m.TestModel.ProfilePrompt = false
+	m.TestModel.ProfileInput.Blur()
+	m.TestModel.ProfileInput.SetValue("")
+}
+
+// applyProfile pre-fills the test screen with a saved profile's inputs
+func (m *model) applyProfile(name string) error {
+	if err := config.ApplyProfile(&m.Config, name); err != nil {
+		return err
+	}
+	m.TestModel.SpecInput.SetValue(m.Config.SpecPath)
+	m.TestModel.UrlInput.SetValue(m.Config.BaseURL)
+	return nil
+}
+
+// cacheResponses stores captured responses from a run in the response cache
+// and persists the cache so it is available in later sessions
+func (m model) cacheResponses(results []models.TestResult) {
+	if m.ResponseCache == nil {
+		return
+	}
+	stored := false
+	for _, r := range results {
+		if r.Response != nil {
+			m.ResponseCache.Put(r.Method, r.Endpoint, *r.Response)
+			stored = true
+		}
+	}
+	if stored {
+		// Ignore errors to not disrupt user flow, as with history
+		_ = models.SaveResponseCache(m.ResponseCache)
+	}
+}
+
+// saveConfig validates and saves the configuration from the editor
+func (m model) saveConfig() (tea.Model, tea.Cmd) {
+	ce := &m.ConfigEditorModel
+	
+	// Parse and validate inputs
+	authType := strings.ToLower(strings.TrimSpace(ce.AuthTypeInput.Value()))
+	verbose := strings.ToLower(strings.TrimSpace(ce.VerboseInput.Value()))
+	maxConcurrStr := strings.TrimSpace(ce.MaxConcurrInput.Value())
+	maxRetriesStr := strings.TrimSpace(ce.MaxRetriesInput.Value())
+	retryDelayStr := strings.TrimSpace(ce.RetryDelayInput.Value())
+	
+	// Validate auth type
+	if authType != "" && authType != "none" && authType != "bearer" && authType != "apikey" && authType != "basic" {
+		ce.ValidationError = "Invalid auth type. Must be: none, bearer, apikey, or basic"
+		return m, nil
+	}
+	
+	// Validate verbose mode
+	if verbose != "" && verbose != "true" && verbose != "false" {
+		ce.ValidationError = "Invalid verbose mode. Must be: true or false"
+		return m, nil
+	}
+	
+	// Validate max concurrency
+	var maxConcurrency int
+	if maxConcurrStr != "" {
+		if maxConcurrStr == "0" || maxConcurrStr == "auto" {
+			maxConcurrency = 0
+		} else if len(maxConcurrStr) == 1 && maxConcurrStr[0] >= '1' && maxConcurrStr[0] <= '9' {
+			maxConcurrency = int(maxConcurrStr[0] - '0')
+		} else {
+			ce.ValidationError = "Invalid max concurrency. Must be 0-9 or 'auto'"
+			return m, nil
+		}
+	}
+	
+	// Validate and parse max retries
+	maxRetries := 3 // default
+	if maxRetriesStr != "" {
+		parsed, err := fmt.Sscanf(maxRetriesStr, "%d", &maxRetries)
+		if err != nil || parsed != 1 || maxRetries < 0 || maxRetries > 10 {
+			ce.ValidationError = "Invalid max retries. Must be a number between 0 and 10"
+			return m, nil
+		}
+	}
+	
+	// Validate and parse retry delay
+	retryDelay := 1000 // default (ms)
+	if retryDelayStr != "" {
+		parsed, err := fmt.Sscanf(retryDelayStr, "%d", &retryDelay)
+		if err != nil || parsed != 1 || retryDelay < 100 || retryDelay > 30000 {
+			ce.ValidationError = "Invalid retry delay. Must be between 100 and 30000 milliseconds"
+			return m, nil
+		}
+	}
+	
+	// Validate the API version header
+	versionHeader, err := config.ParseAPIVersionHeader(ce.VersionHeaderInput.Value())
+	if err != nil {
+		ce.ValidationError = err.Error()
+		return m, nil
+	}
+	
+	// Validate API key settings
+	if authType == "apikey" {
+		apiKeyIn := strings.ToLower(strings.TrimSpace(ce.APIKeyInInput.Value()))
+		if apiKeyIn != "" && apiKeyIn != "header" && apiKeyIn != "query" {
+			ce.ValidationError = "API Key location must be: header or query"
+			return m, nil
+		}
+	}
+	
+	// Validate basic auth credentials; servers split them at the first colon
+	if authType == "basic" && strings.Contains(ce.UsernameInput.Value(), ":") {
+		ce.ValidationError = "Basic auth username cannot contain ':'"
+		return m, nil
+	}
+	
+	// Build new config, keeping settings the editor doesn't expose
+	newConfig := m.Config
+	newConfig.SpecPath = strings.TrimSpace(ce.SpecPathInput.Value())
+	newConfig.BaseURL = strings.TrimSpace(ce.BaseURLInput.Value())
+	newConfig.VerboseMode = verbose == "true"
+	newConfig.MaxConcurrency = maxConcurrency
+	newConfig.MaxRetries = maxRetries
+	newConfig.RetryDelay = retryDelay
+	newConfig.APIVersionHeader = versionHeader
+	newConfig.Auth = nil
+	
+	// Build auth config if auth type is set
+	if authType != "" && authType != "none" {
+		newConfig.Auth = &models.AuthConfig{
+			AuthType:   authType,
+			Token:      strings.TrimSpace(ce.TokenInput.Value()),
+			APIKeyName: strings.TrimSpace(ce.APIKeyNameInput.Value()),
+			APIKeyIn:   strings.ToLower(strings.TrimSpace(ce.APIKeyInInput.Value())),
+			Username:   strings.TrimSpace(ce.UsernameInput.Value()),
+			Password:   ce.PasswordInput.Value(), // Kept as typed: spaces can be part of a password
+		}
+		// The editor has no field for the bearer prefix, so keep the configured one
+		if m.Config.Auth != nil {
+			newConfig.Auth.BearerPrefix = m.Config.Auth.BearerPrefix
+		}
+	}
+	
+	// Save to file
+	if err := config.SaveConfig(newConfig); err != nil {
+		ce.ValidationError = fmt.Sprintf("Failed to save config: %v", err)
+		return m, nil
+	}
+	
+	// Update model config
+	m.Config = newConfig
+	registerResponseValidators(m.Config)
+	m.VerboseMode = newConfig.VerboseMode
+	
+	// Return to menu
+	m.Screen = models.MenuScreen
+	ce.ValidationError = ""
+	
+	return m, nil
+}
+
+// updateCustomRequest handles events in the custom request screen
+func (m model) updateCustomRequest(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch m.CustomRequestModel.Step {
+	case 0: // Method input
+		switch msg := msg.(type) {
+		case tea.KeyMsg:
+			switch msg.Type {
+			case tea.KeyEnter:
+				method := strings.ToUpper(strings.TrimSpace(m.CustomRequestModel.MethodInput.Value()))
+				if method == "" {
+					m.CustomRequestModel.Err = fmt.Errorf("HTTP method cannot be empty")
+					return m, nil
+				}
+				validMethods := map[string]bool{"GET": true, "POST": true, "PUT": true, "PATCH": true, "DELETE": true, "HEAD": true, "OPTIONS": true}
+				if !validMethods[method] {
+					m.CustomRequestModel.Err = fmt.Errorf("invalid HTTP method: %s (use GET, POST, PUT, PATCH, DELETE, HEAD, or OPTIONS)", method)
+					return m, nil
+				}
+				m.CustomRequestModel.Request.Method = method
+				m.CustomRequestModel.Step = 1
+				m.CustomRequestModel.MethodInput.Blur()
+				m.CustomRequestModel.EndpointInput.Focus()
+				m.CustomRequestModel.Err = nil
+				return m, nil
+			case tea.KeyCtrlC, tea.KeyEsc:
+				m.Screen = models.MenuScreen
+				m.CustomRequestModel = ui.InitialCustomRequestModel()
+				return m, nil
+			}
+			m.CustomRequestModel.MethodInput, cmd = m.CustomRequestModel.MethodInput.Update(msg)
+		}
+	
+	case 1: // Endpoint input
+		switch msg := msg.(type) {
+		case tea.KeyMsg:
+			switch msg.Type {
+			case tea.KeyEnter:
+				endpoint := strings.TrimSpace(m.CustomRequestModel.EndpointInput.Value())
+				if endpoint == "" {
+					m.CustomRequestModel.Err = fmt.Errorf("endpoint URL cannot be empty")
+					return m, nil
+				}
+				m.CustomRequestModel.Request.Endpoint = endpoint
+				m.CustomRequestModel.Step = 2
+				m.CustomRequestModel.EndpointInput.Blur()
+				m.CustomRequestModel.HeaderKeyInput.Focus()
+				m.CustomRequestModel.Err = nil
+				return m, nil
+			case tea.KeyCtrlC, tea.KeyEsc:
+				m.Screen = models.MenuScreen
+				m.CustomRequestModel = ui.InitialCustomRequestModel()
+				return m, nil
+			}
+			m.CustomRequestModel.EndpointInput, cmd = m.CustomRequestModel.EndpointInput.Update(msg)
+		}
+	
+	case 2: // Headers input
+		switch msg := msg.(type) {
+		case tea.KeyMsg:
+			switch msg.Type {
+			case tea.KeyEnter:
+				headerKey := strings.TrimSpace(m.CustomRequestModel.HeaderKeyInput.Value())
+				if headerKey == "" {
+					// Skip headers, go to body
+					m.CustomRequestModel.Step = 3
+					m.CustomRequestModel.HeaderKeyInput.Blur()
+					m.CustomRequestModel.BodyInput.Focus()
+					m.CustomRequestModel.Err = nil
+					return m, nil
+				}
+				// Need header value
+				if m.CustomRequestModel.HeaderValueInput.Value() == "" {
+					m.CustomRequestModel.HeaderKeyInput.Blur()
+					m.CustomRequestModel.HeaderValueInput.Focus()
+					return m, nil
+				}
+				// Save header
+				headerValue := strings.TrimSpace(m.CustomRequestModel.HeaderValueInput.Value())
+				m.CustomRequestModel.Request.Headers[headerKey] = headerValue
+				// Reset for next header
+				m.CustomRequestModel.HeaderKeyInput.SetValue("")
+				m.CustomRequestModel.HeaderValueInput.SetValue("")
+				m.CustomRequestModel.HeaderValueInput.Blur()
+				m.CustomRequestModel.HeaderKeyInput.Focus()
+				m.CustomRequestModel.Err = nil
+				return m, nil
+			case tea.KeyCtrlC, tea.KeyEsc:
+				m.Screen = models.MenuScreen
+				m.CustomRequestModel = ui.InitialCustomRequestModel()
+				return m, nil
+			}
+			if m.CustomRequestModel.HeaderKeyInput.Focused() {
+				m.CustomRequestModel.HeaderKeyInput, cmd = m.CustomRequestModel.HeaderKeyInput.Update(msg)
+			} else {
+				m.CustomRequestModel.HeaderValueInput, cmd = m.CustomRequestModel.HeaderValueInput.Update(msg)
+			}
+		}
+	
+	case 3: // Body input
+		switch msg := msg.(type) {
+		case tea.KeyMsg:
+			switch msg.Type {
+			case tea.KeyCtrlS:
+				// Enter starts a new line in the body, so Ctrl+S sends it
+				body := strings.TrimSpace(m.CustomRequestModel.BodyInput.Value())
+				// Body templates ({{.uuid}}, {{.now}}, ...) are rendered first
+				rendered := body
+				if testing.IsBodyTemplate(body) {
+					m.TemplateCounter++
+					var err error
+					rendered, err = testing.RenderBody(body, m.TemplateCounter)
+					if err != nil {
+						m.CustomRequestModel.Err = err
+						return m, nil
+					}
+				}
+				// ${NAME} tokens are expanded next, so they can stand for JSON values
+				headers, expandedBody := testing.ExpandRequestVariables(m.CustomRequestModel.Request.Headers, rendered, m.Config.Variables)
+				// The final body is validated once, after both expansions
+				if err := testing.ValidateRequestBody(headers, expandedBody); err != nil {
+					m.CustomRequestModel.Err = fmt.Errorf("invalid JSON: %v", err)
+					return m, nil
+				}
+				m.CustomRequestModel.Request.Body = body
+
+				// Warn once about spec mismatches before sending
+				warnings := validation.CheckCustomRequest(
+					m.specFile(m.Config.SpecPath),
+					m.CustomRequestModel.Request.Method,
+					m.CustomRequestModel.Request.Endpoint,
+					headers,
+					expandedBody,
+				)
+				if len(warnings) > 0 && !slices.Equal(warnings, m.CustomRequestModel.Warnings) {
+					m.CustomRequestModel.Warnings = warnings
+					m.CustomRequestModel.Err = nil
+					return m, nil
+				}
+
+				endpoint, err := testing.ApplyScheme(m.CustomRequestModel.Request.Endpoint, m.Config.ForceScheme)
+				if err != nil {
+					m.CustomRequestModel.Err = err
+					return m, nil
+				}
+				endpoint = testing.ApplyGlobalQuery(endpoint, m.Config.GlobalQuery)
+				m.CustomRequestModel.Step = 4
+				m.CustomRequestModel.BodyInput.Blur()
+				m.CustomRequestModel.Testing = true
+				// Execute the request
+				return m, testing.ExecuteCustomRequestCmd(
+					m.CustomRequestModel.Request.Method,
+					endpoint,
+					testing.RunHeaders(m.Config.APIVersionHeader, m.Config.RequestHeaders),
+					headers,
+					expandedBody,
+					nil, // TODO: Add auth support
+					m.VerboseMode,
+				)
+			case tea.KeyCtrlC, tea.KeyEsc:
+				m.Screen = models.MenuScreen
+				m.CustomRequestModel = ui.InitialCustomRequestModel()
+				return m, nil
+			}
+			m.CustomRequestModel.BodyInput, cmd = m.CustomRequestModel.BodyInput.Update(msg)
+		}
+	
+	case 4: // Executing request (showing spinner)
+		switch msg := msg.(type) {
+		case tea.KeyMsg:
+			switch msg.Type {
+			case tea.KeyCtrlC, tea.KeyEsc:
+				m.Screen = models.MenuScreen
+				m.CustomRequestModel = ui.InitialCustomRequestModel()
+				return m, nil
+			}
+		case testing.TestCompleteMsg:
+			if len(msg.Results) > 0 {
+				result := msg.Results[0]
+				m.CustomRequestModel.Result = &result
+				m.CustomRequestModel.Err = nil
+				m.CustomRequestModel.Step = 5
+				m.CustomRequestModel.Testing = false
+				
+				// Save to history
+				entry := models.CreateHistoryEntry(
+					"Custom Request",
+					m.CustomRequestModel.Request.Endpoint,
+					msg.Results,
+					result.Duration,
+				)
+				m.History.AddEntry(entry)
+				_ = models.SaveHistory(m.History)
+			}
+			return m, nil
+		case testing.TestErrorMsg:
+			m.CustomRequestModel.Err = msg.Err
+			m.CustomRequestModel.Step = 5
+			m.CustomRequestModel.Testing = false
+			return m, nil
+		}
+		m.CustomRequestModel.Spinner, cmd = m.CustomRequestModel.Spinner.Update(msg)
+	
+	case 5: // Show results
+		switch msg := msg.(type) {
+		case tea.KeyMsg:
+			switch msg.Type {
+			case tea.KeyEnter, tea.KeyCtrlC, tea.KeyEsc:
+				m.Screen = models.MenuScreen
+				m.CustomRequestModel = ui.InitialCustomRequestModel()
+				return m, nil
+			}
+		}
+	}
+	
+	return m, cmd
+}
+
+// updateEndpointSelector handles events in the endpoint selector screen
+func (m model) updateEndpointSelector(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if msg.Type != tea.KeyEnter {
+			// Any other key drops a pending large run confirmation
+			m.TestModel.ConfirmRequests = 0
+		}
+		switch msg.Type {
+		case tea.KeyCtrlC, tea.KeyEsc:
+			// Cancel and return to menu
+			m.Screen = models.MenuScreen
+			m.EndpointSelectorModel = ui.InitialEndpointSelectorModel()
+			return m, nil
+
+		case tea.KeyEnter:
+			// Confirm selection and start testing
+			if !m.EndpointSelectorModel.Ready {
+				return m, nil
+			}
+
+			selected := validation.GetSelectedEndpoints(m.EndpointSelectorModel.AllEndpoints)
+			if len(selected) == 0 {
+				m.EndpointSelectorModel.Err = fmt.Errorf("no endpoints selected")
+				return m, nil
+			}
+
+			// Update config with spec path
+			m.Config.SpecPath = m.TestModel.SpecInput.Value()
+			m.Config.BaseURL = m.TestModel.UrlInput.Value()
+			config.SaveConfig(m.Config)
+
+			opts, err := m.runOptions()
+			if err != nil {
+				m.EndpointSelectorModel.Err = err
+				return m, nil
+			}
+			opts.Selection = selected
+			if !m.runConfirmed(opts) {
+				return m, nil
+			}
+			m.TestModel.LastSelection = selected
+			m.TestModel.SpecEndpoints = m.EndpointSelectorModel.AllEndpoints
+
+			// Move to test screen with spinner
+			m.Screen = models.TestScreen
+			m.TestModel.Step = 2  // Spinner step
+			m.TestModel.Testing = true
+			m.TestModel.Err = nil
+			m.TestModel.TestStartTime = time.Now()
+
+			// Start parallel test execution with selected endpoints
+			cmd = m.startRun(m.Config.SpecPath, m.Config.BaseURL, opts)
+			return m, cmd
+
+		case tea.KeyCtrlY:
+			// Copy the generated request body of the endpoint under the cursor
+			endpoints := m.EndpointSelectorModel.ShownEndpoints()
+			if m.EndpointSelectorModel.Ready && m.EndpointSelectorModel.Cursor < len(endpoints) {
+				m.EndpointSelectorModel.Notice = copyGeneratedBody(m.specFile(m.Config.SpecPath), endpoints[m.EndpointSelectorModel.Cursor])
+			}
+			return m, nil
+
+		case tea.KeyUp, tea.KeyCtrlP:
+			// Move cursor up
+			if m.EndpointSelectorModel.Cursor > 0 {
+				m.EndpointSelectorModel.Cursor--
+				// Scroll up if needed
+				if m.EndpointSelectorModel.Cursor < m.EndpointSelectorModel.Offset {
+					m.EndpointSelectorModel.Offset = m.EndpointSelectorModel.Cursor
+				}
+			}
+			return m, nil
+
+		case tea.KeyDown, tea.KeyCtrlN:
+			// Move cursor down
+			endpoints := m.EndpointSelectorModel.ShownEndpoints()
+			if m.EndpointSelectorModel.Cursor < len(endpoints)-1 {
+				m.EndpointSelectorModel.Cursor++
+				// Scroll down if needed
+				if m.EndpointSelectorModel.Cursor >= m.EndpointSelectorModel.Offset+ui.EndpointSelectorHeight {
+					m.EndpointSelectorModel.Offset = m.EndpointSelectorModel.Cursor - ui.EndpointSelectorHeight + 1
+				}
+			}
+			return m, nil
+
+		case tea.KeyRunes:
+			switch string(msg.Runes) {
+			case " ":
+				// Toggle selection for the endpoint under the cursor, which
+				// indexes the shown list, keeping the search results in step
+				esm := &m.EndpointSelectorModel
+				if shown := esm.ShownEndpoints(); esm.Ready && esm.Cursor < len(shown) {
+					key := models.EndpointKey(shown[esm.Cursor].Method, shown[esm.Cursor].Path)
+					selected := !shown[esm.Cursor].Selected
+					for _, list := range [][]models.EndpointInfo{esm.AllEndpoints, esm.FilteredEndpoints} {
+						for i := range list {
+							if models.EndpointKey(list[i].Method, list[i].Path) == key {
+								list[i].Selected = selected
+							}
+						}
+					}
+				}
+				return m, nil
+
+			case "a", "A":
+				// Select all
+				m.EndpointSelectorModel.AllEndpoints = validation.SelectAllEndpoints(m.EndpointSelectorModel.AllEndpoints)
+				return m, nil
+
+			case "d", "D":
+				// Deselect all
+				m.EndpointSelectorModel.AllEndpoints = validation.DeselectAllEndpoints(m.EndpointSelectorModel.AllEndpoints)
+				return m, nil
+
+			case "*":
+				// Pin or unpin the endpoint under the cursor as a favorite
+				endpoints := m.EndpointSelectorModel.ShownEndpoints()
+				if m.EndpointSelectorModel.Ready && m.EndpointSelectorModel.Cursor < len(endpoints) {
+					m.Config.PinnedEndpoints = validation.TogglePinned(m.Config.PinnedEndpoints, endpoints[m.EndpointSelectorModel.Cursor])
+					config.SaveConfig(m.Config)
+				}
+				return m, nil
+			}
+		}
+
+		// Update search input
+		before := m.EndpointSelectorModel.SearchInput.Value()
+		m.EndpointSelectorModel.SearchInput, cmd = m.EndpointSelectorModel.SearchInput.Update(msg)
+		if m.EndpointSelectorModel.SearchInput.Value() == before {
+			return m, cmd
+		}
+
+		// Filter once typing pauses rather than on every keystroke
+		m.EndpointSelectorModel.FilterSeq++
+		seq := m.EndpointSelectorModel.FilterSeq
+		return m, tea.Batch(cmd, tea.Tick(endpointFilterDebounce, func(time.Time) tea.Msg {
+			return endpointFilterMsg{Seq: seq}
+		}))
+
+	case endpointFilterMsg:
+		esm := &m.EndpointSelectorModel
+		if msg.Seq != esm.FilterSeq {
+			// A later edit has its own filter pending
+			return m, nil
+		}
+
+		// Filter endpoints based on search, narrowing the previous results when possible
+		query := esm.SearchInput.Value()
+		esm.FilteredEndpoints = validation.RefineFilter(esm.AllEndpoints, esm.FilteredEndpoints, esm.FilterQuery, query)
+		esm.FilterQuery = query
+
+		// Reset cursor if out of bounds
+		if esm.Cursor >= len(esm.FilteredEndpoints) {
+			esm.Cursor = 0
+			esm.Offset = 0
+		}
+	}
+
+	return m, cmd
+}
+
+// copyGeneratedBody copies the body a run would send to ep to the clipboard
+// and returns a notice describing the outcome
+func copyGeneratedBody(specPath string, ep models.EndpointInfo) string {
+	key := models.EndpointKey(ep.Method, ep.Path)
+	body, err := testing.GenerateEndpointBody(specPath, ep.Method, ep.Path)
+	if err != nil {
+		return fmt.Sprintf("❌ Could not generate a body for %s: %v", key, err)
+	}
+	if body == nil {
+		return fmt.Sprintf("%s sends no request body", key)
+	}
+	if err := clipboard.WriteAll(string(body)); err != nil {
+		return fmt.Sprintf("❌ Could not copy to clipboard: %v", err)
+	}
+	return fmt.Sprintf("📋 Copied the request body for %s", key)
+}
+
+// copyResponseBody copies the response body of the selected result to the
+// clipboard and returns a notice describing the outcome
+func copyResponseBody(results []models.TestResult, selected int) string {
+	if selected < 0 || selected >= len(results) {
+		return ""
+	}
+	body, truncated := ui.ResponseBody(results[selected])
+	if body == "" {
+		return "The response has no body to copy"
+	}
+	if err := clipboard.WriteAll(body); err != nil {
+		return fmt.Sprintf("❌ Could not copy to clipboard (no clipboard in headless sessions): %v", err)
+	}
+	if truncated {
+		return fmt.Sprintf("📋 Copied the first %d bytes of the response body (the rest was not kept)", len(body))
+	}
+	return fmt.Sprintf("📋 Copied the response body (%d bytes)", len(body))
+}
+
+// View renders the current screen based on the application state
+func (m model) View() string {
+	switch m.Screen {
+	case models.MenuScreen:
+		return ui.ViewMenu(m.Model)
+	case models.HelpScreen:
+		return ui.ViewHelp(m.Model)
+	case models.ValidateScreen:
+		return ui.ViewValidate(m.Model)
+	case models.TestScreen:
+		return ui.ViewTest(m.Model)
+	case models.CustomRequestScreen:
+		return ui.ViewCustomRequest(m.Model)
+	case models.HistoryScreen:
+		return ui.ViewHistory(m.Model)
+	case models.EndpointSelectorScreen:
+		return ui.ViewEndpointSelector(m.Model)
+	case models.ConfigEditorScreen:
+		return ui.ViewConfigEditor(m.Model)
+	case models.ConfigInfoScreen:
+		return ui.ViewConfigInfo(m.Model)
+	default:
+		return "Unknown screen"
+	}
+}
+
+// main initializes and runs the Bubble Tea TUI program
+func main() {
+	listRequests := flag.Bool("list-requests", false, "print the resolved request URLs for -spec and -base-url, then exit")
+	run := flag.Bool("run", false, "test -spec against -base-url without the TUI, exiting 1 when failures exceed the configured threshold")
+	specPath := flag.String("spec", "", "OpenAPI spec file for -list-requests and -run (default: last used spec)")
+	baseURL := flag.String("base-url", "", "base URL for -list-requests and -run (default: last used base URL)")
+	profile := flag.String("profile", "", "saved profile whose spec, base URL and auth pre-fill the test screen")
+	flag.Parse()
+
+	if *listRequests {
+		os.Exit(printResolvedRequests(*specPath, *baseURL))
+	}
+	if *run {
+		os.Exit(runHeadless(*specPath, *baseURL))
+	}
+
+	m := initialModel()
+	if *profile != "" {
+		if err := m.applyProfile(*profile); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+
+	p := tea.NewProgram(m, tea.WithAltScreen())
+	final, err := p.Run()
+	if final, ok := final.(model); ok {
+		final.removeSpecCopies()
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,864 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/Traves-Theberge/OpenAPI-Toolkit/openapi-tui/internal/config"
+	"github.com/Traves-Theberge/OpenAPI-Toolkit/openapi-tui/internal/models"
+	apitesting "github.com/Traves-Theberge/OpenAPI-Toolkit/openapi-tui/internal/testing"
+)
+
+func TestInitialModel_Env(t *testing.T) {
+	originalHome := os.Getenv("HOME")
+	defer os.Setenv("HOME", originalHome)
+	os.Setenv("HOME", t.TempDir())
+	t.Setenv(config.EnvSpecPath, "env-spec.yaml")
+	t.Setenv(config.EnvBaseURL, "http://env.example.com")
+
+	m := initialModel()
+	if got := m.TestModel.SpecInput.Value(); got != "env-spec.yaml" {
+		t.Errorf("Expected spec input from %s, got %q", config.EnvSpecPath, got)
+	}
+	if got := m.TestModel.UrlInput.Value(); got != "http://env.example.com" {
+		t.Errorf("Expected base URL input from %s, got %q", config.EnvBaseURL, got)
+	}
+}
+
+func TestVerboseRerun(t *testing.T) {
+	originalHome := os.Getenv("HOME")
+	defer os.Setenv("HOME", originalHome)
+	os.Setenv("HOME", t.TempDir())
+
+	m := initialModel()
+	m.VerboseMode = false
+	m.Config.MaxRetries = 2
+	m.Screen = models.TestScreen
+	m.TestModel.SpecInput.SetValue("api.yaml")
+	m.TestModel.UrlInput.SetValue("http://localhost:8080")
+	m.TestModel.Step = 3
+	m.TestModel.Results = []models.TestResult{{Method: "GET", Endpoint: "/users", Status: "500"}}
+	selection := []models.EndpointInfo{{Method: "GET", Path: "/users", Selected: true}}
+	m.TestModel.LastSelection = selection
+
+	opts, err := m.verboseRerunOptions()
+	if err != nil {
+		t.Fatalf("verboseRerunOptions() error = %v", err)
+	}
+	if !opts.Verbose {
+		t.Error("Expected the re-run to be verbose")
+	}
+	if !reflect.DeepEqual(opts.Selection, selection) || opts.MaxRetries != 2 {
+		t.Errorf("Expected the same selection and config, got %+v", opts)
+	}
+
+	updated, cmd := m.updateTest(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("V")})
+	rerun := updated.(model)
+	if cmd == nil || !rerun.VerboseMode || rerun.TestModel.Step != 2 || !rerun.TestModel.Testing {
+		t.Errorf("Expected a verbose re-run to start, got step %d verbose %v", rerun.TestModel.Step, rerun.VerboseMode)
+	}
+	if rerun.TestModel.SpecInput.Value() != "api.yaml" || rerun.TestModel.UrlInput.Value() != "http://localhost:8080" {
+		t.Error("Expected the re-run to keep the spec and base URL")
+	}
+	if !reflect.DeepEqual(rerun.TestModel.LastSelection, selection) {
+		t.Error("Expected the re-run to keep the selection")
+	}
+}
+
+func TestUpdateTest_JumpToFirstFailure(t *testing.T) {
+	originalHome := os.Getenv("HOME")
+	defer os.Setenv("HOME", originalHome)
+	os.Setenv("HOME", t.TempDir())
+
+	m := initialModel()
+	m.Screen = models.TestScreen
+	m.TestModel.Step = 3
+	m.TestModel.Results = []models.TestResult{
+		{Method: "GET", Endpoint: "/users", Status: "200"},
+		{Method: "POST", Endpoint: "/users", Status: "201"},
+		{Method: "GET", Endpoint: "/orders", Status: "500"},
+		{Method: "DELETE", Endpoint: "/orders/1", Status: "404"},
+	}
+
+	updated, _ := m.updateTest(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("F")})
+	jumped := updated.(model)
+	if got := jumped.TestModel.Table.Cursor(); got != 2 {
+		t.Errorf("Expected the cursor on the first failure (2), got %d", got)
+	}
+
+	jumped.TestModel.Results = jumped.TestModel.Results[:2]
+	updated, _ = jumped.updateTest(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("F")})
+	if notice := updated.(model).TestModel.ExportSuccess; !strings.Contains(notice, "No failing results") {
+		t.Errorf("Expected a notice when nothing failed, got %q", notice)
+	}
+}
+
+func TestUpdateTest_JumpToFirstAndLast(t *testing.T) {
+	originalHome := os.Getenv("HOME")
+	defer os.Setenv("HOME", originalHome)
+	os.Setenv("HOME", t.TempDir())
+
+	m := initialModel()
+	m.Screen = models.TestScreen
+	m.TestModel.Step = 3
+	m.TestModel.Results = []models.TestResult{
+		{Method: "GET", Endpoint: "/users", Status: "200"},
+		{Method: "POST", Endpoint: "/users", Status: "201"},
+		{Method: "GET", Endpoint: "/orders", Status: "500"},
+	}
+
+	for _, msg := range []tea.KeyMsg{{Type: tea.KeyEnd}, {Type: tea.KeyRunes, Runes: []rune("G")}} {
+		updated, _ := m.updateTest(msg)
+		if got := updated.(model).TestModel.Table.Cursor(); got != 2 {
+			t.Errorf("%s: expected the cursor on the last result (2), got %d", msg, got)
+		}
+	}
+
+	updated, _ := m.updateTest(tea.KeyMsg{Type: tea.KeyEnd})
+	updated, _ = updated.(model).updateTest(tea.KeyMsg{Type: tea.KeyHome})
+	if got := updated.(model).TestModel.Table.Cursor(); got != 0 {
+		t.Errorf("Expected Home to move the cursor to the first result, got %d", got)
+	}
+}
+
+func TestUpdateTest_FailuresOnlyClampsCursor(t *testing.T) {
+	originalHome := os.Getenv("HOME")
+	defer os.Setenv("HOME", originalHome)
+	os.Setenv("HOME", t.TempDir())
+
+	m := initialModel()
+	m.Screen = models.TestScreen
+	m.TestModel.Step = 3
+	m.TestModel.Results = []models.TestResult{
+		{Method: "GET", Endpoint: "/orders", Status: "500"},
+		{Method: "GET", Endpoint: "/users", Status: "200"},
+		{Method: "POST", Endpoint: "/users", Status: "201"},
+	}
+	m.syncResultsTable()
+	m.TestModel.Table.SetCursor(2)
+
+	updated, _ := m.updateTest(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	if got := updated.(model).TestModel.Table.Cursor(); got != 0 {
+		t.Errorf("Expected the cursor on the only failing row (0), got %d", got)
+	}
+}
+
+func TestUpdateTest_FilterPersistsAcrossLogView(t *testing.T) {
+	originalHome := os.Getenv("HOME")
+	defer os.Setenv("HOME", originalHome)
+	os.Setenv("HOME", t.TempDir())
+
+	m := initialModel()
+	m.VerboseMode = true
+	m.Screen = models.TestScreen
+	m.TestModel.Step = 3
+	m.TestModel.Results = []models.TestResult{
+		{Method: "GET", Endpoint: "/users", Status: "200", LogEntry: &models.LogEntry{}},
+		{Method: "GET", Endpoint: "/orders", Status: "500", LogEntry: &models.LogEntry{}},
+	}
+
+	press := func(m model, keys ...tea.KeyMsg) model {
+		for _, k := range keys {
+			updated, _ := m.updateTest(k)
+			m = updated.(model)
+		}
+		return m
+	}
+	runes := func(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+
+	// Type a filter, then apply it with Enter
+	m = press(m, runes("f"), runes("orders"), tea.KeyMsg{Type: tea.KeyEnter})
+	if m.Screen != models.TestScreen || !m.TestModel.FilterActive || m.TestModel.FilterInput.Focused() {
+		t.Fatalf("Expected Enter to apply the filter on the results screen, got screen %v active %v", m.Screen, m.TestModel.FilterActive)
+	}
+
+	// Open the log of the only matching result and come back
+	m = press(m, runes("l"))
+	if m.TestModel.Step != 4 || m.TestModel.SelectedLog != 0 {
+		t.Fatalf("Expected the log detail to open, got step %d", m.TestModel.Step)
+	}
+	m = press(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.TestModel.Step != 3 {
+		t.Fatalf("Expected Esc to return to the results, got step %d", m.TestModel.Step)
+	}
+	if got := m.TestModel.FilterInput.Value(); got != "orders" || !m.TestModel.FilterActive {
+		t.Errorf("Expected the filter to persist, got %q active %v", got, m.TestModel.FilterActive)
+	}
+
+	// Esc clears the applied filter, then leaves the results
+	m = press(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.Screen != models.TestScreen || m.TestModel.FilterActive || m.TestModel.FilterInput.Value() != "" {
+		t.Errorf("Expected Esc to clear the filter first, got %q active %v", m.TestModel.FilterInput.Value(), m.TestModel.FilterActive)
+	}
+	m = press(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.Screen != models.MenuScreen {
+		t.Errorf("Expected a second Esc to return to the menu, got screen %v", m.Screen)
+	}
+}
+
+func TestUpdateCustomRequest_MultilineBody(t *testing.T) {
+	originalHome := os.Getenv("HOME")
+	defer os.Setenv("HOME", originalHome)
+	os.Setenv("HOME", t.TempDir())
+
+	m := initialModel()
+	m.Config.SpecPath = ""
+	m.Screen = models.CustomRequestScreen
+	m.CustomRequestModel.Step = 3
+	m.CustomRequestModel.Request.Method = "POST"
+	m.CustomRequestModel.Request.Endpoint = "http://localhost:8080/users"
+	m.CustomRequestModel.BodyInput.Focus()
+
+	press := func(m model, keys ...tea.KeyMsg) model {
+		for _, k := range keys {
+			updated, _ := m.updateCustomRequest(k)
+			m = updated.(model)
+		}
+		return m
+	}
+	runes := func(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+	send := tea.KeyMsg{Type: tea.KeyCtrlS}
+
+	// Enter starts a new line instead of sending, and invalid JSON is caught
+	m = press(m, runes(`{`), tea.KeyMsg{Type: tea.KeyEnter}, runes(`  "name": "Ada",`), send)
+	if m.CustomRequestModel.Step != 3 || m.CustomRequestModel.Err == nil || !strings.Contains(m.CustomRequestModel.Err.Error(), "invalid JSON") {
+		t.Fatalf("Expected invalid JSON to be rejected, got step %d err %v", m.CustomRequestModel.Step, m.CustomRequestModel.Err)
+	}
+
+	// Fix the body, pasting the rest over several lines
+	m.CustomRequestModel.BodyInput.SetValue("")
+	m = press(m, runes("{\n  \"name\": \"Ada\",\n  \"admin\": true\n}"), send)
+	if m.CustomRequestModel.Step != 4 {
+		t.Fatalf("Expected the valid body to be sent, got step %d err %v", m.CustomRequestModel.Step, m.CustomRequestModel.Err)
+	}
+	if want := "{\n  \"name\": \"Ada\",\n  \"admin\": true\n}"; m.CustomRequestModel.Request.Body != want {
+		t.Errorf("Expected the multiline body to be kept, got %q", m.CustomRequestModel.Request.Body)
+	}
+}
+
+func TestUpdateTest_SaveProfile(t *testing.T) {
+	originalHome := os.Getenv("HOME")
+	defer os.Setenv("HOME", originalHome)
+	os.Setenv("HOME", t.TempDir())
+
+	m := initialModel()
+	m.Screen = models.TestScreen
+	m.TestModel.Step = 3
+	m.TestModel.SpecInput.SetValue("petstore.yaml")
+	m.TestModel.UrlInput.SetValue("https://staging.example.com")
+	m.Config.Auth = &models.AuthConfig{AuthType: "bearer", Token: "abc"}
+	m.TestModel.Results = []models.TestResult{{Method: "GET", Endpoint: "/pets", Status: "200"}}
+
+	var updated tea.Model = m
+	for _, key := range []tea.KeyMsg{
+		{Type: tea.KeyRunes, Runes: []rune("P")},
+		{Type: tea.KeyRunes, Runes: []rune("staging")},
+		{Type: tea.KeyEnter},
+	} {
+		updated, _ = updated.(model).updateTest(key)
+	}
+	saved := updated.(model)
+	if saved.TestModel.ProfilePrompt || saved.Screen != models.TestScreen {
+		t.Errorf("Expected the prompt to close on the results screen, got prompt=%v screen=%v", saved.TestModel.ProfilePrompt, saved.Screen)
+	}
+	if !strings.Contains(saved.TestModel.ExportSuccess, "staging") {
+		t.Errorf("Expected a saved notice, got %q", saved.TestModel.ExportSuccess)
+	}
+
+	want := models.Profile{SpecPath: "petstore.yaml", BaseURL: "https://staging.example.com", Auth: m.Config.Auth}
+	if got := config.LoadConfig().Profiles["staging"]; got.SpecPath != want.SpecPath || got.BaseURL != want.BaseURL || got.Auth == nil || *got.Auth != *want.Auth {
+		t.Errorf("Expected profile %+v to be saved, got %+v", want, got)
+	}
+
+	// A fresh session starts from the profile
+	fresh := initialModel()
+	if err := fresh.applyProfile("staging"); err != nil {
+		t.Fatalf("applyProfile() failed: %v", err)
+	}
+	if fresh.TestModel.UrlInput.Value() != want.BaseURL || fresh.TestModel.SpecInput.Value() != want.SpecPath {
+		t.Errorf("Expected the profile's inputs to be pre-filled, got %q %q", fresh.TestModel.SpecInput.Value(), fresh.TestModel.UrlInput.Value())
+	}
+}
+
+func TestUpdateTest_ValidateBeforeTest(t *testing.T) {
+	originalHome := os.Getenv("HOME")
+	defer os.Setenv("HOME", originalHome)
+	os.Setenv("HOME", t.TempDir())
+
+	dir := t.TempDir()
+	writeSpec := func(name, paths string) string {
+		path := filepath.Join(dir, name)
+		spec := "openapi: 3.0.0\ninfo:\n  title: T\n  version: \"1.0\"\npaths:\n" + paths
+		if err := os.WriteFile(path, []byte(spec), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	invalid := writeSpec("invalid.yaml", "  /users:\n    get:\n      responses:\n        \"200\":\n          $ref: '#/components/responses/Missing'\n")
+	linted := writeSpec("linted.yaml", "  /users:\n    get:\n      responses:\n        \"404\":\n          description: Not found\n")
+	valid := writeSpec("valid.yaml", "  /users:\n    get:\n      responses:\n        \"200\":\n          description: OK\n          content:\n            application/json: {}\n")
+
+	enter := func(m model, specPath string) model {
+		m.TestModel.SpecInput.SetValue(specPath)
+		updated, _ := m.updateTest(tea.KeyMsg{Type: tea.KeyEnter})
+		return updated.(model)
+	}
+	start := func(validate bool) model {
+		m := initialModel()
+		m.Screen = models.TestScreen
+		m.Config.ValidateBeforeTest = validate
+		return m
+	}
+
+	// An invalid spec blocks testing
+	blocked := enter(start(true), invalid)
+	if blocked.TestModel.Step != 0 || blocked.TestModel.Err == nil {
+		t.Errorf("Expected an invalid spec to stay on the spec step with an error, got step %d, err %v", blocked.TestModel.Step, blocked.TestModel.Err)
+	}
+
+	// Warnings are shown once, then Enter again continues
+	warned := enter(start(true), linted)
+	if warned.TestModel.Step != 0 || len(warned.TestModel.SpecWarnings) == 0 {
+		t.Fatalf("Expected the lint warnings before testing, got step %d, warnings %v", warned.TestModel.Step, warned.TestModel.SpecWarnings)
+	}
+	if continued := enter(warned, linted); continued.TestModel.Step != 1 || continued.TestModel.SpecWarnings != nil {
+		t.Errorf("Expected Enter again to continue, got step %d", continued.TestModel.Step)
+	}
+
+	if m := enter(start(true), valid); m.TestModel.Step != 1 {
+		t.Errorf("Expected a valid spec to continue to the base URL, got step %d (%v)", m.TestModel.Step, m.TestModel.Err)
+	}
+
+	// A quick check only parses the spec, so lint warnings don't stop the run
+	quick := start(true)
+	quick.Config.QuickValidate = true
+	if m := enter(quick, linted); m.TestModel.Step != 1 || m.TestModel.SpecWarnings != nil {
+		t.Errorf("Expected a quick check to skip lint warnings, got step %d, warnings %v", m.TestModel.Step, m.TestModel.SpecWarnings)
+	}
+	if m := enter(quick, invalid); m.TestModel.Step != 0 || m.TestModel.Err == nil {
+		t.Errorf("Expected a quick check to catch an unresolvable ref, got step %d", m.TestModel.Step)
+	}
+
+	// With the flag off the spec is not validated first
+	if m := enter(start(false), invalid); m.TestModel.Step != 1 {
+		t.Errorf("Expected no validation with the flag off, got step %d (%v)", m.TestModel.Step, m.TestModel.Err)
+	}
+}
+
+func TestUpdateTest_RunChanged(t *testing.T) {
+	originalHome := os.Getenv("HOME")
+	defer os.Setenv("HOME", originalHome)
+	os.Setenv("HOME", t.TempDir())
+
+	dir := t.TempDir()
+	writeSpec := func(name, paths string) string {
+		path := filepath.Join(dir, name)
+		spec := "openapi: 3.0.0\ninfo:\n  title: T\n  version: \"1.0\"\npaths:\n" + paths
+		if err := os.WriteFile(path, []byte(spec), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	users := "  /users:\n    get:\n      responses:\n        \"200\":\n          description: OK\n          content:\n            application/json: {}\n"
+	posts := "  /posts:\n    get:\n      responses:\n        \"200\":\n          description: OK\n          content:\n            application/json: {}\n"
+	oldSpec := writeSpec("old.yaml", users)
+	newSpec := writeSpec("new.yaml", users+posts)
+
+	run := func(previous, spec string) model {
+		m := initialModel()
+		m.Cursor = slices.Index(models.MenuItems(m.Config), models.MenuTestChanged)
+		updated, _ := m.updateMenu(tea.KeyMsg{Type: tea.KeyEnter})
+		m = updated.(model)
+		m.Config.PreviousSpec = previous
+		m.TestModel.Step = 1
+		m.TestModel.SpecInput.SetValue(spec)
+		m.TestModel.UrlInput.SetValue("http://localhost:1")
+		updated, _ = m.updateTest(tea.KeyMsg{Type: tea.KeyEnter})
+		return updated.(model)
+	}
+
+	m := run(oldSpec, newSpec)
+	if !m.TestModel.RunChanged || m.TestModel.Step != 2 {
+		t.Fatalf("Expected the changed run to start, got step %d (%v)", m.TestModel.Step, m.TestModel.Err)
+	}
+	if len(m.TestModel.LastSelection) != 1 || m.TestModel.LastSelection[0].Path != "/posts" {
+		t.Errorf("Expected only the added endpoint to be selected, got %+v", m.TestModel.LastSelection)
+	}
+
+	if m := run(newSpec, newSpec); m.TestModel.Step != 1 || m.TestModel.Err == nil {
+		t.Errorf("Expected an error when nothing changed, got step %d", m.TestModel.Step)
+	}
+	if m := run("", newSpec); m.TestModel.Step != 1 || m.TestModel.Err == nil {
+		t.Errorf("Expected an error without a previous spec, got step %d", m.TestModel.Step)
+	}
+}
+
+func TestUpdateTest_ConfirmLargeRuns(t *testing.T) {
+	originalHome := os.Getenv("HOME")
+	defer os.Setenv("HOME", originalHome)
+	os.Setenv("HOME", t.TempDir())
+
+	specPath := filepath.Join(t.TempDir(), "spec.yaml")
+	spec := "openapi: 3.0.0\ninfo:\n  title: T\n  version: \"1.0\"\npaths:\n" +
+		"  /users:\n    get:\n      responses:\n        \"200\":\n          description: OK\n" +
+		"    post:\n      responses:\n        \"201\":\n          description: Created\n" +
+		"  /posts:\n    get:\n      responses:\n        \"200\":\n          description: OK\n          content:\n            application/json: {}\n"
+	if err := os.WriteFile(specPath, []byte(spec), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	start := func(threshold int) model {
+		m := initialModel()
+		m.Config.ConfirmLargeRuns = threshold
+		m.Screen = models.TestScreen
+		m.TestModel.Step = 1
+		m.TestModel.SpecInput.SetValue(specPath)
+		m.TestModel.UrlInput.SetValue("http://localhost:1")
+		updated, _ := m.updateTest(tea.KeyMsg{Type: tea.KeyEnter})
+		return updated.(model)
+	}
+
+	// Above the threshold the run waits for a second Enter
+	m := start(2)
+	if m.TestModel.Step != 1 || m.TestModel.ConfirmRequests != 3 {
+		t.Fatalf("Expected confirmation for 3 requests, got step %d, %d requests (%v)", m.TestModel.Step, m.TestModel.ConfirmRequests, m.TestModel.Err)
+	}
+	updated, _ := m.updateTest(tea.KeyMsg{Type: tea.KeyEnter})
+	if m := updated.(model); m.TestModel.Step != 2 || m.TestModel.ConfirmRequests != 0 {
+		t.Errorf("Expected Enter again to start the run, got step %d", m.TestModel.Step)
+	}
+
+	// Esc backs out of the confirmation
+	updated, _ = m.updateTest(tea.KeyMsg{Type: tea.KeyEsc})
+	if m := updated.(model); m.Screen != models.TestScreen || m.TestModel.ConfirmRequests != 0 {
+		t.Errorf("Expected Esc to cancel the confirmation only, got screen %v", m.Screen)
+	}
+
+	// At or below the threshold, or without one, the run starts at once
+	for _, threshold := range []int{3, 0} {
+		if m := start(threshold); m.TestModel.Step != 2 {
+			t.Errorf("Expected threshold %d to start the run without confirmation, got step %d", threshold, m.TestModel.Step)
+		}
+	}
+}
+
+func TestUpdateEndpointSelector_ConfirmLargeRuns(t *testing.T) {
+	originalHome := os.Getenv("HOME")
+	defer os.Setenv("HOME", originalHome)
+	os.Setenv("HOME", t.TempDir())
+
+	specPath := filepath.Join(t.TempDir(), "spec.yaml")
+	spec := "openapi: 3.0.0\ninfo:\n  title: T\n  version: \"1.0\"\npaths:\n" +
+		"  /users:\n    get:\n      responses:\n        \"200\":\n          description: OK\n" +
+		"    post:\n      responses:\n        \"201\":\n          description: Created\n" +
+		"  /posts:\n    get:\n      responses:\n        \"200\":\n          description: OK\n"
+	if err := os.WriteFile(specPath, []byte(spec), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	m := initialModel()
+	m.Config.ConfirmLargeRuns = 1
+	m.Screen = models.EndpointSelectorScreen
+	m.TestModel.SpecInput.SetValue(specPath)
+	m.TestModel.UrlInput.SetValue("http://localhost:1")
+	m.EndpointSelectorModel.AllEndpoints = []models.EndpointInfo{
+		{Method: "GET", Path: "/users", Selected: true},
+		{Method: "POST", Path: "/users", Selected: true},
+		{Method: "GET", Path: "/posts"},
+	}
+	m.EndpointSelectorModel.Ready = true
+
+	// Above the threshold the selected run waits for a second Enter
+	updated, _ := m.updateEndpointSelector(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(model)
+	if m.Screen != models.EndpointSelectorScreen || m.TestModel.ConfirmRequests != 2 {
+		t.Fatalf("Expected confirmation for the 2 selected requests, got screen %v, %d requests", m.Screen, m.TestModel.ConfirmRequests)
+	}
+	if view := m.View(); !strings.Contains(view, "This will send 2 requests") {
+		t.Errorf("Expected the selector to show the warning, got:\n%s", view)
+	}
+
+	// Any other key drops the confirmation
+	updated, _ = m.updateEndpointSelector(tea.KeyMsg{Type: tea.KeyDown})
+	if m := updated.(model); m.TestModel.ConfirmRequests != 0 {
+		t.Errorf("Expected another key to drop the confirmation, got %d requests", m.TestModel.ConfirmRequests)
+	}
+
+	updated, _ = m.updateEndpointSelector(tea.KeyMsg{Type: tea.KeyEnter})
+	if m := updated.(model); m.Screen != models.TestScreen || m.TestModel.Step != 2 {
+		t.Errorf("Expected Enter again to start the run, got screen %v, step %d", m.Screen, m.TestModel.Step)
+	}
+}
+
+func TestUpdateEndpointSelector_PinShownEndpoint(t *testing.T) {
+	originalHome := os.Getenv("HOME")
+	defer os.Setenv("HOME", originalHome)
+	os.Setenv("HOME", t.TempDir())
+
+	m := initialModel()
+	m.Screen = models.EndpointSelectorScreen
+	esm := &m.EndpointSelectorModel
+	esm.AllEndpoints = []models.EndpointInfo{{Method: "GET", Path: "/users"}, {Method: "GET", Path: "/posts"}}
+	esm.Ready = true
+	pin := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("*")}
+
+	// A search matching nothing shows no endpoint, so nothing is pinned
+	esm.FilterQuery = "zzz"
+	esm.FilteredEndpoints = []models.EndpointInfo{}
+	updated, _ := m.updateEndpointSelector(pin)
+	if m := updated.(model); len(m.Config.PinnedEndpoints) != 0 {
+		t.Errorf("Expected nothing pinned, got %v", m.Config.PinnedEndpoints)
+	}
+
+	// The cursor indexes the search results, not every endpoint
+	esm.FilterQuery = "posts"
+	esm.FilteredEndpoints = []models.EndpointInfo{{Method: "GET", Path: "/posts"}}
+	updated, _ = m.updateEndpointSelector(pin)
+	if m := updated.(model); len(m.Config.PinnedEndpoints) != 1 || m.Config.PinnedEndpoints[0] != "GET /posts" {
+		t.Errorf("Expected GET /posts pinned, got %v", m.Config.PinnedEndpoints)
+	}
+}
+
+func TestRunHeadless_FailureThreshold(t *testing.T) {
+	originalHome := os.Getenv("HOME")
+	defer os.Setenv("HOME", originalHome)
+	os.Setenv("HOME", t.TempDir())
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/flaky" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	operation := "    get:\n      responses:\n        \"204\":\n          description: OK\n          content:\n            application/json: {}\n"
+	spec := "openapi: 3.0.0\ninfo:\n  title: T\n  version: \"1.0\"\npaths:\n" +
+		"  /flaky:\n" + operation + "  /users:\n" + operation + "  /orders:\n" + operation
+	specPath := filepath.Join(t.TempDir(), "spec.yaml")
+	if err := os.WriteFile(specPath, []byte(spec), 0644); err != nil {
+		t.Fatal(err)
+	}
+	configPath, err := config.GetConfigPath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeConfig := func(content string) {
+		if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// One failure out of three fails the run without a threshold
+	if code := runHeadless(specPath, server.URL); code != 1 {
+		t.Errorf("Expected exit code 1 without a threshold, got %d", code)
+	}
+	writeConfig("maxAllowedFailures: 1\n")
+	if code := runHeadless(specPath, server.URL); code != 0 {
+		t.Errorf("Expected exit code 0 within maxAllowedFailures, got %d", code)
+	}
+	writeConfig("minPassRate: 50\n")
+	if code := runHeadless(specPath, server.URL); code != 0 {
+		t.Errorf("Expected exit code 0 above minPassRate, got %d", code)
+	}
+	writeConfig("minPassRate: 90\n")
+	if code := runHeadless(specPath, server.URL); code != 1 {
+		t.Errorf("Expected exit code 1 below minPassRate, got %d", code)
+	}
+	if code := runHeadless("", ""); code != 2 {
+		t.Errorf("Expected exit code 2 without a spec or base URL, got %d", code)
+	}
+}
+
+func TestRunHeadless_SpecURL(t *testing.T) {
+	originalHome := os.Getenv("HOME")
+	defer os.Setenv("HOME", originalHome)
+	os.Setenv("HOME", t.TempDir())
+	tmp := t.TempDir()
+	t.Setenv("TMPDIR", tmp)
+
+	spec := "openapi: 3.0.0\ninfo:\n  title: T\n  version: \"1.0\"\npaths:\n  /users:\n    get:\n      responses:\n        \"204\":\n          description: OK\n"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/openapi.yaml" {
+			w.Write([]byte(spec))
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	if code := runHeadless(server.URL+"/openapi.yaml", server.URL); code != 0 {
+		t.Errorf("Expected a spec URL to be downloaded and pass, got exit code %d", code)
+	}
+	if code := runHeadless(server.URL+"/missing.yaml", server.URL); code != 1 {
+		t.Errorf("Expected exit code 1 when the spec can't be downloaded, got %d", code)
+	}
+	if entries, _ := os.ReadDir(tmp); len(entries) != 0 {
+		t.Errorf("Expected the downloaded spec to be removed after the run, found %d file(s)", len(entries))
+	}
+}
+
+func TestRegisterResponseValidators_RequiredResponseFields(t *testing.T) {
+	originalHome := os.Getenv("HOME")
+	defer os.Setenv("HOME", originalHome)
+	os.Setenv("HOME", t.TempDir())
+	defer registerResponseValidators(models.Config{})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": 1}`))
+	}))
+	defer server.Close()
+
+	spec := "openapi: 3.0.0\ninfo:\n  title: T\n  version: \"1.0\"\npaths:\n  /users:\n    get:\n      responses:\n        \"200\":\n          description: OK\n          content:\n            application/json: {}\n"
+	specPath := filepath.Join(t.TempDir(), "spec.yaml")
+	if err := os.WriteFile(specPath, []byte(spec), 0644); err != nil {
+		t.Fatal(err)
+	}
+	configPath, err := config.GetConfigPath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	run := func() string {
+		m := initialModel()
+		opts, err := m.runOptions()
+		if err != nil {
+			t.Fatal(err)
+		}
+		results, err := apitesting.RunTestsWithOptions(specPath, server.URL, opts, nil)
+		if err != nil || len(results) != 1 {
+			t.Fatalf("Expected one result, got %v, %v", results, err)
+		}
+		return results[0].Message
+	}
+
+	// A configured required field the response lacks is reported
+	if err := os.WriteFile(configPath, []byte("requiredResponseFields: [requestId]\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if message := run(); !strings.Contains(message, `required-fields: response is missing required field "requestId"`) {
+		t.Errorf("Expected the missing field to be reported, got %q", message)
+	}
+
+	// Removing it from the config unregisters the check
+	if err := os.WriteFile(configPath, []byte("verbose: false\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if message := run(); message != "OK (validated)" {
+		t.Errorf("Expected the check to be off, got %q", message)
+	}
+}
+
+func TestUpdateMenu_ConfiguredItems(t *testing.T) {
+	originalHome := os.Getenv("HOME")
+	defer os.Setenv("HOME", originalHome)
+	os.Setenv("HOME", t.TempDir())
+
+	m := initialModel()
+	m.Config.MenuOrder = []string{models.MenuHistory}
+	m.Config.HiddenMenuItems = []string{models.MenuValidate, models.MenuCustomRequest, models.MenuListRequests}
+	items := models.MenuItems(m.Config)
+	if len(items) != 8 || items[0] != models.MenuHistory {
+		t.Fatalf("Expected history first and three items hidden, got %v", items)
+	}
+	for _, item := range items {
+		if item == models.MenuValidate {
+			t.Error("Expected the hidden validate item to be left out")
+		}
+	}
+
+	// The cursor stops at the last visible item
+	down := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")}
+	for i := 0; i < 10; i++ {
+		updated, _ := m.updateMenu(down)
+		m = updated.(model)
+	}
+	if m.Cursor != len(items)-1 {
+		t.Errorf("Expected the cursor to stop at %d, got %d", len(items)-1, m.Cursor)
+	}
+
+	// Enter acts on the item shown at the cursor, not its default position
+	m.Cursor = 0
+	updated, _ := m.updateMenu(tea.KeyMsg{Type: tea.KeyEnter})
+	if screen := updated.(model).Screen; screen != models.HistoryScreen {
+		t.Errorf("Expected the first item to open history, got screen %v", screen)
+	}
+}
+
+func TestUpdateMenu_ConfigInfo(t *testing.T) {
+	originalHome := os.Getenv("HOME")
+	defer os.Setenv("HOME", originalHome)
+	os.Setenv("HOME", t.TempDir())
+
+	cfg := models.Config{BaseURL: "http://localhost:8080", Auth: &models.AuthConfig{AuthType: "Bearer", Token: "s3cret"}}
+	if err := config.SaveConfig(cfg); err != nil {
+		t.Fatalf("SaveConfig() error = %v", err)
+	}
+	configPath, err := config.GetConfigPath()
+	if err != nil {
+		t.Fatalf("GetConfigPath() error = %v", err)
+	}
+
+	m := initialModel()
+	m.Width, m.Height = 200, 60
+	updated, _ := m.updateMenu(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("i")})
+	m = updated.(model)
+
+	if m.Screen != models.ConfigInfoScreen || m.ConfigInfo.Path != configPath {
+		t.Fatalf("Expected the config info screen for %s, got screen %v path %q", configPath, m.Screen, m.ConfigInfo.Path)
+	}
+	view := m.View()
+	if !strings.Contains(view, configPath) || !strings.Contains(view, "http://localhost:8080") {
+		t.Errorf("Expected the path and contents on screen, got:\n%s", view)
+	}
+	if strings.Contains(view, "s3cret") {
+		t.Errorf("Expected the token to be masked, got:\n%s", view)
+	}
+}
+
+func TestUpdateConfigInfo_Reset(t *testing.T) {
+	originalHome := os.Getenv("HOME")
+	defer os.Setenv("HOME", originalHome)
+	os.Setenv("HOME", t.TempDir())
+
+	if err := config.SaveConfig(models.Config{BaseURL: "http://localhost:8080", MaxRetries: 9, VerboseMode: true}); err != nil {
+		t.Fatalf("SaveConfig() error = %v", err)
+	}
+	m := initialModel()
+	m.Screen = models.ConfigInfoScreen
+	key := func(s string) {
+		updated, _ := m.updateConfigInfo(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)})
+		m = updated.(model)
+	}
+
+	// Any key but y cancels
+	key("R")
+	key("n")
+	if m.ConfigInfo.ConfirmReset || m.Config.MaxRetries != 9 {
+		t.Fatalf("Expected the reset to be cancelled, got %+v", m.ConfigInfo)
+	}
+
+	key("R")
+	if !m.ConfigInfo.ConfirmReset || !strings.Contains(m.View(), "Reset the config to defaults?") {
+		t.Fatalf("Expected a confirmation prompt, got:\n%s", m.View())
+	}
+	key("y")
+	if m.Config.MaxRetries != 3 || m.Config.BaseURL != "" || m.VerboseMode {
+		t.Errorf("Expected the default settings after a reset, got %+v", m.Config)
+	}
+	if !strings.Contains(m.ConfigInfo.Notice, "previous config saved to") {
+		t.Errorf("Expected the backup path in the notice, got %q", m.ConfigInfo.Notice)
+	}
+}
+
+func TestSpecCopies_Removed(t *testing.T) {
+	dir := t.TempDir()
+	first := filepath.Join(dir, "first.yaml")
+	second := filepath.Join(dir, "second.yaml")
+	for _, path := range []string{first, second} {
+		if err := os.WriteFile(path, []byte("openapi: 3.0.0"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var m model
+	m.keepSpecCopy("spec", first)
+	m.keepSpecCopy("spec", second)
+	if _, err := os.Stat(first); !os.IsNotExist(err) {
+		t.Errorf("Expected the replaced copy to be removed, stat error = %v", err)
+	}
+	m.removeSpecCopies()
+	if _, err := os.Stat(second); !os.IsNotExist(err) {
+		t.Errorf("Expected the copy to be removed on exit, stat error = %v", err)
+	}
+}
+
+func TestUpdateTest_SpecURL(t *testing.T) {
+	originalHome := os.Getenv("HOME")
+	defer os.Setenv("HOME", originalHome)
+	os.Setenv("HOME", t.TempDir())
+
+	spec := "openapi: 3.0.0\ninfo:\n  title: T\n  version: \"1.0\"\npaths:\n  /users:\n    get:\n      responses:\n        \"200\":\n          description: OK\n          content:\n            application/json: {}\n"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(spec))
+	}))
+	defer server.Close()
+	specURL := server.URL + "/openapi.yaml"
+
+	m := initialModel()
+	defer m.removeSpecCopies()
+	m.Screen = models.TestScreen
+	m.Config.ValidateBeforeTest = true
+	m.TestModel.SpecInput.SetValue(specURL)
+
+	// The download runs in the background
+	updated, cmd := m.updateTest(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(model)
+	if !m.TestModel.FetchingSpec || cmd == nil || m.TestModel.Step != 0 {
+		t.Fatalf("Expected Enter to start downloading the spec, got fetching %v, step %d", m.TestModel.FetchingSpec, m.TestModel.Step)
+	}
+
+	updated, _ = m.Update(cmd())
+	m = updated.(model)
+	if m.TestModel.FetchingSpec || m.TestModel.Step != 1 {
+		t.Fatalf("Expected the downloaded spec to continue to the base URL, got step %d (%v)", m.TestModel.Step, m.TestModel.Err)
+	}
+	if got := m.TestModel.SpecInput.Value(); got != specURL {
+		t.Errorf("Expected the spec input to keep the URL, got %q", got)
+	}
+	copy := m.specFile(specURL)
+	if copy == specURL {
+		t.Fatal("Expected a local copy of the downloaded spec")
+	}
+	if _, err := os.Stat(copy); err != nil {
+		t.Errorf("Expected the local copy to exist, got %v", err)
+	}
+	m.removeSpecCopies()
+	if _, err := os.Stat(copy); !os.IsNotExist(err) {
+		t.Errorf("Expected the local copy to be removed on exit, stat error = %v", err)
+	}
+}
+
+func TestRefreshInFlight_FollowsSelectedRequest(t *testing.T) {
+	m := model{}
+	for id := 1; id <= 10; id++ {
+		m.TestModel.InFlight = append(m.TestModel.InFlight, models.InFlightRequest{ID: id})
+	}
+	m.selectInFlight(2)
+	if m.TestModel.InFlightSelected != 3 {
+		t.Fatalf("Expected request 3 selected, got %d", m.TestModel.InFlightSelected)
+	}
+
+	// The cursor stays on the rows the view shows
+	m.selectInFlight(20)
+	if m.TestModel.InFlightCursor != 7 || m.TestModel.InFlightSelected != 8 {
+		t.Errorf("Expected the cursor on the last shown row, got row %d (request %d)", m.TestModel.InFlightCursor, m.TestModel.InFlightSelected)
+	}
+	m.selectInFlight(-1)
+	if m.TestModel.InFlightCursor != 0 || m.TestModel.InFlightSelected != 1 {
+		t.Errorf("Expected the cursor on the first row, got row %d (request %d)", m.TestModel.InFlightCursor, m.TestModel.InFlightSelected)
+	}
+
+	// Requests finishing ahead of the selected one move it up the list, and
+	// the selection follows it
+	m.selectInFlight(2)
+	m.showInFlight(m.TestModel.InFlight[2:])
+	if m.TestModel.InFlightCursor != 0 || m.TestModel.InFlightSelected != 3 {
+		t.Errorf("Expected request 3 still selected on the first row, got row %d (request %d)", m.TestModel.InFlightCursor, m.TestModel.InFlightSelected)
+	}
+
+	// Once it finishes, the request now on its row is selected
+	m.showInFlight(m.TestModel.InFlight[1:])
+	if m.TestModel.InFlightCursor != 0 || m.TestModel.InFlightSelected != 4 {
+		t.Errorf("Expected request 4 selected on the first row, got row %d (request %d)", m.TestModel.InFlightCursor, m.TestModel.InFlightSelected)
+	}
+}
@@ -1,8 +1,14 @@
 package config
 
 import (
+"fmt"
+"mime"
 "os"
+"path"
 "path/filepath"
+"regexp"
+"strings"
+"time"
 
 "gopkg.in/yaml.v3"
 "github.com/Traves-Theberge/OpenAPI-Toolkit/openapi-tui/internal/models"
@@ -23,14 +29,42 @@ return "", err
 return filepath.Join(configDir, "config.yaml"), nil
 }
 
+// Environment variables that pre-fill the spec path and base URL when the
+// config file doesn't set them
+const (
+EnvSpecPath = "OPENAPI_SPEC"
+EnvBaseURL  = "OPENAPI_BASE_URL"
+)
+
 // LoadConfig loads configuration from the config file
+// A spec path or base URL the file leaves unset is taken from OPENAPI_SPEC
+// or OPENAPI_BASE_URL.
 func LoadConfig() models.Config {
-cfg := models.Config{
+cfg := loadConfigFile()
+if cfg.SpecPath == "" {
+cfg.SpecPath = os.Getenv(EnvSpecPath)
+}
+if cfg.BaseURL == "" {
+cfg.BaseURL = os.Getenv(EnvBaseURL)
+}
+return cfg
+}
+
+// DefaultConfig returns the configuration used when the config file
+// doesn't set a value
+func DefaultConfig() models.Config {
+return models.Config{
 VerboseMode:    false,
 MaxConcurrency: 0, // 0 = auto-detect
 MaxRetries:     3, // Default: 3 retries
 RetryDelay:     1000, // Default: 1000ms initial delay
 }
+}
+
+// loadConfigFile loads configuration from the config file, with defaults for
+// anything it doesn't set
+func loadConfigFile() models.Config {
+cfg := DefaultConfig()
 
 configPath, err := GetConfigPath()
 if err != nil {
@@ -50,6 +84,150 @@ return cfg
 cfg.BaseURL = fileConfig.BaseURL
 cfg.SpecPath = fileConfig.SpecPath
 cfg.VerboseMode = fileConfig.VerboseMode
+cfg.OverridesFile = fileConfig.OverridesFile
+cfg.PinnedEndpoints = fileConfig.PinnedEndpoints
+cfg.DurationUnit = fileConfig.DurationUnit
+if !models.ValidDurationUnit(cfg.DurationUnit) {
+cfg.Warnings = append(cfg.Warnings, fmt.Sprintf("Unknown durationUnit %q, using auto (expected auto, ms or s)", cfg.DurationUnit))
+cfg.DurationUnit = models.DurationUnitAuto
+}
+cfg.CacheResponses = fileConfig.CacheResponses
+cfg.ForceScheme = strings.ToLower(fileConfig.ForceScheme)
+if cfg.ForceScheme != "" && cfg.ForceScheme != "http" && cfg.ForceScheme != "https" {
+cfg.Warnings = append(cfg.Warnings, fmt.Sprintf("Unknown forceScheme %q, ignoring it (expected http or https)", fileConfig.ForceScheme))
+cfg.ForceScheme = ""
+}
+cfg.LogFile = fileConfig.LogFile
+cfg.ReplayFile = fileConfig.ReplayFile
+cfg.RecordFile = fileConfig.RecordFile
+cfg.CaptureHeaders = fileConfig.CaptureHeaders
+for _, field := range fileConfig.RequiredResponseFields {
+if field = strings.TrimSpace(field); field != "" {
+cfg.RequiredResponseFields = append(cfg.RequiredResponseFields, field)
+}
+}
+cfg.ValidateBeforeTest = fileConfig.ValidateBeforeTest
+cfg.QuickValidate = fileConfig.QuickValidate
+cfg.PreviousSpec = fileConfig.PreviousSpec
+cfg.SkipDeprecatedParams = fileConfig.SkipDeprecatedParams
+cfg.ExclusiveParamsExtension = strings.TrimSpace(fileConfig.ExclusiveParamsExtension)
+if cfg.ExclusiveParamsExtension != "" && !strings.HasPrefix(cfg.ExclusiveParamsExtension, "x-") {
+cfg.Warnings = append(cfg.Warnings, fmt.Sprintf("Invalid exclusiveParamsExtension %q, ignoring it (extension names start with x-)", cfg.ExclusiveParamsExtension))
+cfg.ExclusiveParamsExtension = ""
+}
+cfg.IncludeGlobs = validGlobs(fileConfig.IncludeGlobs, "includeGlobs", &cfg)
+cfg.ExcludeGlobs = validGlobs(fileConfig.ExcludeGlobs, "excludeGlobs", &cfg)
+cfg.AcceptEncoding = fileConfig.AcceptEncoding
+if coding := unsupportedEncoding(cfg.AcceptEncoding); coding != "" {
+cfg.Warnings = append(cfg.Warnings, fmt.Sprintf("Unsupported acceptEncoding %q, using the default (expected gzip, deflate, br or identity)", coding))
+cfg.AcceptEncoding = ""
+}
+cfg.AssumeContentType = fileConfig.AssumeContentType
+if _, _, err := mime.ParseMediaType(cfg.AssumeContentType); cfg.AssumeContentType != "" && err != nil {
+cfg.Warnings = append(cfg.Warnings, fmt.Sprintf("Invalid assumeContentType %q, validating responses as sent (expected a media type such as application/json)", cfg.AssumeContentType))
+cfg.AssumeContentType = ""
+}
+cfg.ResponseValidationMode = strings.ToLower(fileConfig.ResponseValidationMode)
+if !models.ValidResponseValidationMode(cfg.ResponseValidationMode) {
+cfg.Warnings = append(cfg.Warnings, fmt.Sprintf("Unknown responseValidationMode %q, using lenient (expected lenient or strict)", fileConfig.ResponseValidationMode))
+cfg.ResponseValidationMode = models.ResponseValidationLenient
+}
+if fileConfig.APIVersionHeader != nil {
+header := *fileConfig.APIVersionHeader
+if !validHeaderName(header.Name) || header.Value == "" {
+cfg.Warnings = append(cfg.Warnings, fmt.Sprintf("Invalid apiVersionHeader %q, sending no version header (expected a name without spaces or colons, and a value)", header.String()))
+} else {
+cfg.APIVersionHeader = header
+}
+}
+if fileConfig.RequestHeaders != nil {
+cfg.RequestHeaders = *fileConfig.RequestHeaders
+for name, value := range cfg.RequestHeaders.Headers() {
+if strings.ContainsAny(value, "\r\n") {
+cfg.Warnings = append(cfg.Warnings, fmt.Sprintf("Invalid requestHeaders value for %s, sending no standard headers (values can't span lines)", name))
+cfg.RequestHeaders = models.RequestHeaders{}
+break
+}
+}
+}
+cfg.RealisticData = fileConfig.RealisticData
+cfg.RandomData = fileConfig.RandomData
+cfg.DataSeed = fileConfig.DataSeed
+cfg.GlobalQuery = fileConfig.GlobalQuery
+cfg.RunMetadata = fileConfig.RunMetadata
+cfg.ExportFailuresOnly = fileConfig.ExportFailuresOnly
+cfg.BaselineFile = fileConfig.BaselineFile
+cfg.LatencyTolerance = fileConfig.LatencyTolerance
+if cfg.LatencyTolerance < 0 {
+cfg.Warnings = append(cfg.Warnings, fmt.Sprintf("Invalid latencyTolerance %v, not checking latency against the baseline (expected a fraction such as 0.2)", cfg.LatencyTolerance))
+cfg.LatencyTolerance = 0
+}
+cfg.MaxAllowedFailures = fileConfig.MaxAllowedFailures
+if cfg.MaxAllowedFailures < 0 {
+cfg.Warnings = append(cfg.Warnings, fmt.Sprintf("Invalid maxAllowedFailures %d, allowing no failures", cfg.MaxAllowedFailures))
+cfg.MaxAllowedFailures = 0
+}
+cfg.ConfirmLargeRuns = fileConfig.ConfirmLargeRuns
+if cfg.ConfirmLargeRuns < 0 {
+cfg.Warnings = append(cfg.Warnings, fmt.Sprintf("Invalid confirmLargeRuns %d, never asking for confirmation", cfg.ConfirmLargeRuns))
+cfg.ConfirmLargeRuns = 0
+}
+cfg.MinPassRate = fileConfig.MinPassRate
+if cfg.MinPassRate < 0 || cfg.MinPassRate > 100 {
+cfg.Warnings = append(cfg.Warnings, fmt.Sprintf("Invalid minPassRate %v, not checking the pass rate (expected a percentage from 0 to 100)", cfg.MinPassRate))
+cfg.MinPassRate = 0
+}
+cfg.Profiles = fileConfig.Profiles
+cfg.DateTimeFormat = strings.ToLower(fileConfig.DateTimeFormat)
+if !models.ValidDateTimeFormat(cfg.DateTimeFormat) {
+cfg.Warnings = append(cfg.Warnings, fmt.Sprintf("Unknown dateTimeFormat %q, using rfc3339 (expected rfc3339 or unix)", fileConfig.DateTimeFormat))
+cfg.DateTimeFormat = models.DateTimeFormatRFC3339
+}
+cfg.TestOrder = strings.ToLower(fileConfig.TestOrder)
+if !models.ValidTestOrder(cfg.TestOrder) {
+cfg.Warnings = append(cfg.Warnings, fmt.Sprintf("Unknown testOrder %q, using alphabetical (expected alphabetical or spec)", fileConfig.TestOrder))
+cfg.TestOrder = models.TestOrderAlphabetical
+}
+cfg.TestAllExamples = fileConfig.TestAllExamples
+cfg.ExpandEnumParams = fileConfig.ExpandEnumParams
+cfg.OmitOptionalBodies = fileConfig.OmitOptionalBodies
+cfg.ContractTests = fileConfig.ContractTests
+cfg.AllowBodyOnAllMethods = fileConfig.AllowBodyOnAllMethods
+cfg.ExpectedStatuses = validExpectedStatuses(fileConfig.ExpectedStatuses, &cfg)
+cfg.Variables = fileConfig.Variables
+cfg.PreflightPath = fileConfig.PreflightPath
+if fileConfig.MaxRunDuration != "" {
+if d, err := time.ParseDuration(fileConfig.MaxRunDuration); err != nil || d < 0 {
+cfg.Warnings = append(cfg.Warnings, fmt.Sprintf("Invalid maxRunDuration %q, running without a time limit (expected a duration such as 90s or 10m)", fileConfig.MaxRunDuration))
+} else {
+cfg.MaxRunDuration = d
+}
+}
+if fileConfig.DialTimeout != "" {
+if d, err := time.ParseDuration(fileConfig.DialTimeout); err != nil || d < 0 {
+cfg.Warnings = append(cfg.Warnings, fmt.Sprintf("Invalid dialTimeout %q, using the request timeout (expected a duration such as 2s)", fileConfig.DialTimeout))
+} else {
+cfg.DialTimeout = d
+}
+}
+cfg.MenuOrder = validMenuItems(fileConfig.MenuOrder, "menuOrder", &cfg)
+cfg.HiddenMenuItems = validMenuItems(fileConfig.HiddenMenuItems, "hiddenMenuItems", &cfg)
+cfg.SpecFetchTimeout = fileConfig.SpecFetchTimeout
+if cfg.SpecFetchTimeout < 0 {
+cfg.Warnings = append(cfg.Warnings, fmt.Sprintf("Invalid specFetchTimeout %d, using the default (expected seconds >= 0)", fileConfig.SpecFetchTimeout))
+cfg.SpecFetchTimeout = 0
+}
+cfg.SpecMaxSizeMB = fileConfig.SpecMaxSizeMB
+if cfg.SpecMaxSizeMB < 0 {
+cfg.Warnings = append(cfg.Warnings, fmt.Sprintf("Invalid specMaxSizeMB %d, using the default (expected megabytes >= 0)", fileConfig.SpecMaxSizeMB))
+cfg.SpecMaxSizeMB = 0
+}
+cfg.ExampleMaxDepth = fileConfig.ExampleMaxDepth
+if cfg.ExampleMaxDepth < 0 {
+cfg.Warnings = append(cfg.Warnings, fmt.Sprintf("Invalid exampleMaxDepth %d, using the default (expected levels >= 0)", fileConfig.ExampleMaxDepth))
+cfg.ExampleMaxDepth = 0
+}
+cfg.CheckResponseConsistency = fileConfig.CheckResponseConsistency
 cfg.MaxConcurrency = fileConfig.MaxConcurrency
 if cfg.MaxConcurrency == 0 {
 cfg.MaxConcurrency = 0 // Keep 0 for auto-detect
@@ -64,21 +242,140 @@ cfg.RetryDelay = fileConfig.RetryDelay
 if cfg.RetryDelay == 0 {
 cfg.RetryDelay = 1000 // Default to 1000ms if not specified
 }
+for _, class := range fileConfig.RetryOn {
+class = strings.ToLower(class)
+if !models.ValidRetryClass(class) {
+cfg.Warnings = append(cfg.Warnings, fmt.Sprintf("Unknown retryOn class %q, ignoring it (expected one of %s)", class, strings.Join(models.RetryClasses, ", ")))
+continue
+}
+cfg.RetryOn = append(cfg.RetryOn, class)
+}
+for _, status := range fileConfig.RetryStatuses {
+if status < 100 || status > 599 {
+cfg.Warnings = append(cfg.Warnings, fmt.Sprintf("Invalid retryStatuses code %d, ignoring it (expected 100-599)", status))
+continue
+}
+cfg.RetryStatuses = append(cfg.RetryStatuses, status)
+}
+cfg.TokenCommand = fileConfig.TokenCommand
+cfg.TokenCommandJSONPath = fileConfig.TokenCommandJSONPath
+if cfg.TokenCommandJSONPath != "" && cfg.TokenCommand == "" {
+cfg.Warnings = append(cfg.Warnings, "tokenCommandJSONPath is set without a tokenCommand, ignoring it")
+}
 
 if fileConfig.Auth != nil {
 cfg.Auth = &models.AuthConfig{
 AuthType:   fileConfig.Auth.Type,
 Token:      fileConfig.Auth.Token,
+BearerPrefix: fileConfig.Auth.BearerPrefix,
 APIKeyIn:   fileConfig.Auth.APIKeyIn,
 APIKeyName: fileConfig.Auth.APIKeyName,
 Username:   fileConfig.Auth.Username,
 Password:   fileConfig.Auth.Password,
 }
+if strings.Contains(cfg.Auth.Username, ":") {
+cfg.Warnings = append(cfg.Warnings, "Basic auth username contains ':', which servers read as the end of the username (put colons in the password only)")
+}
 }
 
 return cfg
 }
 
+// validGlobs drops malformed glob patterns, recording a warning for each
+func validGlobs(patterns []string, field string, cfg *models.Config) []string {
+var valid []string
+for _, pattern := range patterns {
+if _, err := path.Match(pattern, ""); err != nil {
+cfg.Warnings = append(cfg.Warnings, fmt.Sprintf("Invalid %s pattern %q, ignoring it", field, pattern))
+continue
+}
+valid = append(valid, pattern)
+}
+return valid
+}
+
+// validMenuItems drops unknown main menu item names, recording a warning for each
+func validMenuItems(names []string, field string, cfg *models.Config) []string {
+var valid []string
+for _, name := range names {
+if !models.ValidMenuItem(name) {
+cfg.Warnings = append(cfg.Warnings, fmt.Sprintf("Unknown %s item %q, ignoring it (expected one of %s)", field, name, strings.Join(models.DefaultMenuOrder, ", ")))
+continue
+}
+if field == "hiddenMenuItems" && name == models.MenuQuit {
+cfg.Warnings = append(cfg.Warnings, "The quit menu item can't be hidden, showing it")
+continue
+}
+valid = append(valid, name)
+}
+return valid
+}
+
+// validExpectedStatuses normalizes expectedStatuses keys to "METHOD path",
+// dropping malformed keys and status codes with a warning for each
+func validExpectedStatuses(expected map[string][]int, cfg *models.Config) map[string][]int {
+if len(expected) == 0 {
+return nil
+}
+valid := make(map[string][]int)
+for key, statuses := range expected {
+method, path, ok := strings.Cut(strings.TrimSpace(key), " ")
+path = strings.TrimSpace(path)
+if !ok || !strings.HasPrefix(path, "/") {
+cfg.Warnings = append(cfg.Warnings, fmt.Sprintf("Invalid expectedStatuses key %q, ignoring it (expected \"METHOD /path\")", key))
+continue
+}
+var codes []int
+for _, status := range statuses {
+if status < 100 || status > 599 {
+cfg.Warnings = append(cfg.Warnings, fmt.Sprintf("Invalid expected status %d for %s, ignoring it (expected 100-599)", status, key))
+continue
+}
+codes = append(codes, status)
+}
+if len(codes) > 0 {
+valid[models.EndpointKey(method, path)] = codes
+}
+}
+return valid
+}
+
+// unsupportedEncoding returns the first content coding in an Accept-Encoding
+// value that responses cannot be decoded from, or "" if all are supported
+func unsupportedEncoding(acceptEncoding string) string {
+if strings.TrimSpace(acceptEncoding) == "" {
+return ""
+}
+for _, part := range strings.Split(acceptEncoding, ",") {
+coding := strings.ToLower(strings.TrimSpace(strings.SplitN(part, ";", 2)[0]))
+switch coding {
+case "gzip", "x-gzip", "deflate", "br", "identity", "*":
+default:
+return coding
+}
+}
+return ""
+}
+
+// validHeaderName reports whether name can be sent as an HTTP header name
+func validHeaderName(name string) bool {
+return name != "" && !strings.ContainsAny(name, " \t\r\n:")
+}
+
+// ParseAPIVersionHeader parses a "Name: value" header, as typed in the config
+// editor. An empty line is no header
+func ParseAPIVersionHeader(line string) (models.APIVersionHeader, error) {
+if strings.TrimSpace(line) == "" {
+return models.APIVersionHeader{}, nil
+}
+name, value, ok := strings.Cut(line, ":")
+header := models.APIVersionHeader{Name: strings.TrimSpace(name), Value: strings.TrimSpace(value)}
+if !ok || !validHeaderName(header.Name) || header.Value == "" {
+return models.APIVersionHeader{}, fmt.Errorf("API version header must be \"Name: value\", e.g. \"Accept: application/vnd.company.v2+json\"")
+}
+return header, nil
+}
+
 // SaveConfig saves the current configuration to the config file
 func SaveConfig(cfg models.Config) error {
 configPath, err := GetConfigPath()
@@ -93,12 +390,79 @@ VerboseMode:    cfg.VerboseMode,
 MaxConcurrency: cfg.MaxConcurrency,
 MaxRetries:     cfg.MaxRetries,
 RetryDelay:     cfg.RetryDelay,
+RetryOn:        cfg.RetryOn,
+RetryStatuses:  cfg.RetryStatuses,
+OverridesFile:  cfg.OverridesFile,
+PinnedEndpoints: cfg.PinnedEndpoints,
+DurationUnit:   cfg.DurationUnit,
+CacheResponses: cfg.CacheResponses,
+ForceScheme:    cfg.ForceScheme,
+LogFile:        cfg.LogFile,
+ReplayFile:     cfg.ReplayFile,
+RecordFile:     cfg.RecordFile,
+CaptureHeaders: cfg.CaptureHeaders,
+RequiredResponseFields: cfg.RequiredResponseFields,
+ValidateBeforeTest: cfg.ValidateBeforeTest,
+QuickValidate:  cfg.QuickValidate,
+PreviousSpec:   cfg.PreviousSpec,
+SkipDeprecatedParams: cfg.SkipDeprecatedParams,
+ExclusiveParamsExtension: cfg.ExclusiveParamsExtension,
+IncludeGlobs:   cfg.IncludeGlobs,
+ExcludeGlobs:   cfg.ExcludeGlobs,
+AcceptEncoding: cfg.AcceptEncoding,
+AssumeContentType: cfg.AssumeContentType,
+ResponseValidationMode: cfg.ResponseValidationMode,
+RealisticData:  cfg.RealisticData,
+RandomData:     cfg.RandomData,
+DataSeed:       cfg.DataSeed,
+GlobalQuery:    cfg.GlobalQuery,
+DateTimeFormat: cfg.DateTimeFormat,
+TestOrder:      cfg.TestOrder,
+TestAllExamples: cfg.TestAllExamples,
+ExpandEnumParams: cfg.ExpandEnumParams,
+OmitOptionalBodies: cfg.OmitOptionalBodies,
+ContractTests:  cfg.ContractTests,
+AllowBodyOnAllMethods: cfg.AllowBodyOnAllMethods,
+ExpectedStatuses: cfg.ExpectedStatuses,
+Variables:      cfg.Variables,
+MenuOrder:      cfg.MenuOrder,
+HiddenMenuItems: cfg.HiddenMenuItems,
+RunMetadata:    cfg.RunMetadata,
+ExportFailuresOnly: cfg.ExportFailuresOnly,
+BaselineFile:   cfg.BaselineFile,
+LatencyTolerance: cfg.LatencyTolerance,
+TokenCommand:   cfg.TokenCommand,
+TokenCommandJSONPath: cfg.TokenCommandJSONPath,
+MaxAllowedFailures: cfg.MaxAllowedFailures,
+ConfirmLargeRuns: cfg.ConfirmLargeRuns,
+MinPassRate:    cfg.MinPassRate,
+Profiles:       cfg.Profiles,
+PreflightPath:  cfg.PreflightPath,
+SpecFetchTimeout: cfg.SpecFetchTimeout,
+SpecMaxSizeMB:  cfg.SpecMaxSizeMB,
+ExampleMaxDepth: cfg.ExampleMaxDepth,
+CheckResponseConsistency: cfg.CheckResponseConsistency,
+}
+if cfg.MaxRunDuration > 0 {
+fileConfig.MaxRunDuration = cfg.MaxRunDuration.String()
+}
+if cfg.DialTimeout > 0 {
+fileConfig.DialTimeout = cfg.DialTimeout.String()
+}
+if cfg.APIVersionHeader.Name != "" {
+header := cfg.APIVersionHeader
+fileConfig.APIVersionHeader = &header
+}
+if len(cfg.RequestHeaders.Headers()) > 0 {
+headers := cfg.RequestHeaders
+fileConfig.RequestHeaders = &headers
 }
 
 if cfg.Auth != nil {
 fileConfig.Auth = &struct {
 Type       string `yaml:"type"`
 Token      string `yaml:"token,omitempty"`
+BearerPrefix string `yaml:"bearerPrefix,omitempty"`
 APIKeyIn   string `yaml:"apiKeyIn,omitempty"`
 APIKeyName string `yaml:"apiKeyName,omitempty"`
 Username   string `yaml:"username,omitempty"`
@@ -106,6 +470,7 @@ Password   string `yaml:"password,omitempty"`
 }{
 Type:       cfg.Auth.AuthType,
 Token:      cfg.Auth.Token,
+BearerPrefix: cfg.Auth.BearerPrefix,
 APIKeyIn:   cfg.Auth.APIKeyIn,
 APIKeyName: cfg.Auth.APIKeyName,
 Username:   cfg.Auth.Username,
@@ -120,3 +485,130 @@ return err
 
 return os.WriteFile(configPath, data, 0644)
 }
+
+// SaveProfile stores profile in cfg under name, replacing any profile of
+// that name, and saves the config file
+func SaveProfile(cfg *models.Config, name string, profile models.Profile) error {
+name = strings.TrimSpace(name)
+if name == "" {
+return fmt.Errorf("profile name is empty")
+}
+if cfg.Profiles == nil {
+cfg.Profiles = make(map[string]models.Profile)
+}
+cfg.Profiles[name] = profile
+return SaveConfig(*cfg)
+}
+
+// ApplyProfile replaces cfg's spec path, base URL and auth with those of the
+// named profile
+func ApplyProfile(cfg *models.Config, name string) error {
+profile, ok := cfg.Profiles[name]
+if !ok {
+return fmt.Errorf("no profile named %q in the config file", name)
+}
+cfg.SpecPath = profile.SpecPath
+cfg.BaseURL = profile.BaseURL
+cfg.Auth = profile.Auth
+return nil
+}
+
+// maskedValue replaces secret values when showing the config file
+const maskedValue = "********"
+
+// configLineRe matches a "key: value" line of the config file, optionally
+// a list item, capturing the indentation and key, and the value
+var configLineRe = regexp.MustCompile(`^(\s*(?:-\s+)?["']?([\w.-]+)["']?:\s+)(\S.*)$`)
+
+// ResetConfig replaces the config file with the defaults, first copying it
+// to a timestamped backup next to it, e.g. config.yaml.20250102-150405.bak
+// Returns the backup's path, or "" when there was no config file to back up
+func ResetConfig() (string, error) {
+configPath, err := GetConfigPath()
+if err != nil {
+return "", err
+}
+
+backupPath := ""
+data, err := os.ReadFile(configPath)
+switch {
+case err == nil:
+backupPath = fmt.Sprintf("%s.%s.bak", configPath, time.Now().Format("20060102-150405"))
+if err := os.WriteFile(backupPath, data, 0600); err != nil {
+return "", fmt.Errorf("failed to back up config: %w", err)
+}
+case !os.IsNotExist(err):
+return "", err
+}
+
+return backupPath, SaveConfig(DefaultConfig())
+}
+
+// ReadConfigFile returns the config file's location and its on-disk
+// contents with secret values masked, for showing to the user
+// A config file that doesn't exist yet gives empty contents and no error.
+func ReadConfigFile() (string, string, error) {
+configPath, err := GetConfigPath()
+if err != nil {
+return "", "", err
+}
+
+data, err := os.ReadFile(configPath)
+if os.IsNotExist(err) {
+return configPath, "", nil
+}
+if err != nil {
+return configPath, "", err
+}
+return configPath, MaskSecrets(string(data)), nil
+}
+
+// MaskSecrets replaces the values of secret-looking keys in config YAML,
+// such as auth tokens, passwords and API keys in variables or globalQuery
+func MaskSecrets(contents string) string {
+lines := strings.Split(contents, "\n")
+for i, line := range lines {
+match := configLineRe.FindStringSubmatch(line)
+if match != nil && isSecretKey(match[2]) {
+lines[i] = match[1] + maskedValue
+}
+}
+return strings.Join(lines, "\n")
+}
+
+// isSecretKey reports whether a config key holds a secret value
+// Keys naming where a key goes (apiKeyName, apiKeyIn) are not secrets.
+func isSecretKey(key string) bool {
+lower := strings.ToLower(key)
+for _, fragment := range []string{"token", "password", "secret"} {
+if strings.Contains(lower, fragment) {
+return true
+}
+}
+return strings.HasSuffix(lower, "key")
+}
+
+// LoadOverrides reads a YAML file of per-endpoint request overrides
+// Keys have the form "METHOD path" (e.g. "POST /users"); the method is case-insensitive
+func LoadOverrides(path string) (models.Overrides, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw map[string]models.EndpointOverride
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse overrides file: %w", err)
+	}
+
+	overrides := make(models.Overrides, len(raw))
+	for key, override := range raw {
+		parts := strings.Fields(key)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid override key %q: expected \"METHOD path\"", key)
+		}
+		overrides[models.EndpointKey(parts[0], parts[1])] = override
+	}
+
+	return overrides, nil
+}
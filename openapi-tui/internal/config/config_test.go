@@ -3,7 +3,10 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/Traves-Theberge/OpenAPI-Toolkit/openapi-tui/internal/models"
 )
@@ -63,6 +66,36 @@ func TestLoadConfig_NoFile(t *testing.T) {
 	}
 }
 
+func TestLoadConfig_Env(t *testing.T) {
+	originalHome := os.Getenv("HOME")
+	defer os.Setenv("HOME", originalHome)
+	os.Setenv("HOME", t.TempDir())
+	t.Setenv(EnvSpecPath, "env-spec.yaml")
+	t.Setenv(EnvBaseURL, "http://env.example.com")
+
+	// No config file: the environment fills both
+	cfg := LoadConfig()
+	if cfg.SpecPath != "env-spec.yaml" || cfg.BaseURL != "http://env.example.com" {
+		t.Errorf("Expected values from the environment, got %q and %q", cfg.SpecPath, cfg.BaseURL)
+	}
+
+	// A value set in the config file wins; an unset one still comes from the environment
+	configPath, err := GetConfigPath()
+	if err != nil {
+		t.Fatalf("GetConfigPath() failed: %v", err)
+	}
+	if err := os.WriteFile(configPath, []byte("specPath: file-spec.yaml\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	cfg = LoadConfig()
+	if cfg.SpecPath != "file-spec.yaml" {
+		t.Errorf("Expected the config file's spec path, got %q", cfg.SpecPath)
+	}
+	if cfg.BaseURL != "http://env.example.com" {
+		t.Errorf("Expected the environment's base URL, got %q", cfg.BaseURL)
+	}
+}
+
 // TestSaveAndLoadConfig tests round-trip save/load
 func TestSaveAndLoadConfig(t *testing.T) {
 	// Create a test config
@@ -283,6 +316,471 @@ func TestLoadConfig_CorruptFile(t *testing.T) {
 	}
 }
 
+// TestLoadConfig_InvalidDurationUnit tests that an unknown duration unit is reported and replaced
+func TestLoadConfig_InvalidDurationUnit(t *testing.T) {
+	originalHome := os.Getenv("HOME")
+	defer os.Setenv("HOME", originalHome)
+	os.Setenv("HOME", t.TempDir())
+
+	configPath, err := GetConfigPath()
+	if err != nil {
+		t.Fatalf("GetConfigPath() failed: %v", err)
+	}
+	if err := os.WriteFile(configPath, []byte("durationUnit: minutes\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	cfg := LoadConfig()
+	if cfg.DurationUnit != models.DurationUnitAuto {
+		t.Errorf("Expected DurationUnit to fall back to auto, got %q", cfg.DurationUnit)
+	}
+	if len(cfg.Warnings) != 1 {
+		t.Fatalf("Expected one warning, got %v", cfg.Warnings)
+	}
+
+	// Known units load without warnings
+	if err := os.WriteFile(configPath, []byte("durationUnit: ms\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	cfg = LoadConfig()
+	if cfg.DurationUnit != models.DurationUnitMillis || len(cfg.Warnings) != 0 {
+		t.Errorf("Expected ms without warnings, got %q %v", cfg.DurationUnit, cfg.Warnings)
+	}
+}
+
+// TestLoadConfig_DateTimeFormat tests loading and validating the generated date-time format
+func TestLoadConfig_DateTimeFormat(t *testing.T) {
+	originalHome := os.Getenv("HOME")
+	defer os.Setenv("HOME", originalHome)
+	os.Setenv("HOME", t.TempDir())
+
+	configPath, err := GetConfigPath()
+	if err != nil {
+		t.Fatalf("GetConfigPath() failed: %v", err)
+	}
+	if err := os.WriteFile(configPath, []byte("dateTimeFormat: epoch\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	cfg := LoadConfig()
+	if cfg.DateTimeFormat != models.DateTimeFormatRFC3339 || len(cfg.Warnings) != 1 {
+		t.Errorf("Expected an unknown format to fall back to rfc3339 with a warning, got %q %v", cfg.DateTimeFormat, cfg.Warnings)
+	}
+
+	if err := os.WriteFile(configPath, []byte("dateTimeFormat: Unix\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	cfg = LoadConfig()
+	if cfg.DateTimeFormat != models.DateTimeFormatUnix || len(cfg.Warnings) != 0 {
+		t.Errorf("Expected unix without warnings, got %q %v", cfg.DateTimeFormat, cfg.Warnings)
+	}
+}
+
+// TestLoadConfig_TestOrder tests loading and validating the test order
+func TestLoadConfig_TestOrder(t *testing.T) {
+	originalHome := os.Getenv("HOME")
+	defer os.Setenv("HOME", originalHome)
+	os.Setenv("HOME", t.TempDir())
+
+	configPath, err := GetConfigPath()
+	if err != nil {
+		t.Fatalf("GetConfigPath() failed: %v", err)
+	}
+	if err := os.WriteFile(configPath, []byte("testOrder: random\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	cfg := LoadConfig()
+	if cfg.TestOrder != models.TestOrderAlphabetical || len(cfg.Warnings) != 1 {
+		t.Errorf("Expected an unknown order to fall back to alphabetical with a warning, got %q %v", cfg.TestOrder, cfg.Warnings)
+	}
+
+	if err := os.WriteFile(configPath, []byte("testOrder: Spec\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	cfg = LoadConfig()
+	if cfg.TestOrder != models.TestOrderSpec || len(cfg.Warnings) != 0 {
+		t.Errorf("Expected spec without warnings, got %q %v", cfg.TestOrder, cfg.Warnings)
+	}
+}
+
+// TestLoadConfig_ResponseValidationMode tests loading and validating the
+// response validation mode
+func TestLoadConfig_ResponseValidationMode(t *testing.T) {
+	originalHome := os.Getenv("HOME")
+	defer os.Setenv("HOME", originalHome)
+	os.Setenv("HOME", t.TempDir())
+
+	configPath, err := GetConfigPath()
+	if err != nil {
+		t.Fatalf("GetConfigPath() failed: %v", err)
+	}
+	if err := os.WriteFile(configPath, []byte("responseValidationMode: picky\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	cfg := LoadConfig()
+	if cfg.ResponseValidationMode != models.ResponseValidationLenient || len(cfg.Warnings) != 1 {
+		t.Errorf("Expected an unknown mode to fall back to lenient with a warning, got %q %v", cfg.ResponseValidationMode, cfg.Warnings)
+	}
+
+	if err := os.WriteFile(configPath, []byte("responseValidationMode: Strict\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	cfg = LoadConfig()
+	if cfg.ResponseValidationMode != models.ResponseValidationStrict || len(cfg.Warnings) != 0 {
+		t.Errorf("Expected strict without warnings, got %q %v", cfg.ResponseValidationMode, cfg.Warnings)
+	}
+}
+
+// TestLoadConfig_FailureThreshold tests loading and validating the failure threshold
+func TestLoadConfig_FailureThreshold(t *testing.T) {
+	originalHome := os.Getenv("HOME")
+	defer os.Setenv("HOME", originalHome)
+	os.Setenv("HOME", t.TempDir())
+
+	configPath, err := GetConfigPath()
+	if err != nil {
+		t.Fatalf("GetConfigPath() failed: %v", err)
+	}
+	if err := os.WriteFile(configPath, []byte("maxAllowedFailures: 2\nminPassRate: 95.5\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	cfg := LoadConfig()
+	if cfg.MaxAllowedFailures != 2 || cfg.MinPassRate != 95.5 || len(cfg.Warnings) != 0 {
+		t.Errorf("Expected the threshold without warnings, got %d %v %v", cfg.MaxAllowedFailures, cfg.MinPassRate, cfg.Warnings)
+	}
+
+	if err := os.WriteFile(configPath, []byte("maxAllowedFailures: -1\nminPassRate: 150\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	cfg = LoadConfig()
+	if cfg.MaxAllowedFailures != 0 || cfg.MinPassRate != 0 || len(cfg.Warnings) != 2 {
+		t.Errorf("Expected invalid limits to be ignored with warnings, got %d %v %v", cfg.MaxAllowedFailures, cfg.MinPassRate, cfg.Warnings)
+	}
+}
+
+func TestLoadConfig_ConfirmLargeRuns(t *testing.T) {
+	originalHome := os.Getenv("HOME")
+	defer os.Setenv("HOME", originalHome)
+	os.Setenv("HOME", t.TempDir())
+
+	configPath, err := GetConfigPath()
+	if err != nil {
+		t.Fatalf("GetConfigPath() failed: %v", err)
+	}
+	if err := os.WriteFile(configPath, []byte("confirmLargeRuns: 500\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	if cfg := LoadConfig(); cfg.ConfirmLargeRuns != 500 || len(cfg.Warnings) != 0 {
+		t.Errorf("Expected the threshold without warnings, got %d %v", cfg.ConfirmLargeRuns, cfg.Warnings)
+	}
+
+	if err := os.WriteFile(configPath, []byte("confirmLargeRuns: -1\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	if cfg := LoadConfig(); cfg.ConfirmLargeRuns != 0 || len(cfg.Warnings) != 1 {
+		t.Errorf("Expected a negative threshold to be ignored with a warning, got %d %v", cfg.ConfirmLargeRuns, cfg.Warnings)
+	}
+}
+
+func TestLoadConfig_MaxRunDuration(t *testing.T) {
+	originalHome := os.Getenv("HOME")
+	defer os.Setenv("HOME", originalHome)
+	os.Setenv("HOME", t.TempDir())
+
+	configPath, err := GetConfigPath()
+	if err != nil {
+		t.Fatalf("GetConfigPath() failed: %v", err)
+	}
+	if err := os.WriteFile(configPath, []byte("maxRunDuration: soon\ndialTimeout: -1s\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	cfg := LoadConfig()
+	if cfg.MaxRunDuration != 0 || cfg.DialTimeout != 0 || len(cfg.Warnings) != 2 {
+		t.Errorf("Expected invalid durations to be ignored with warnings, got %v %v %v", cfg.MaxRunDuration, cfg.DialTimeout, cfg.Warnings)
+	}
+
+	cfg.Warnings = nil
+	cfg.MaxRunDuration = 90 * time.Second
+	cfg.DialTimeout = 2 * time.Second
+	if err := SaveConfig(cfg); err != nil {
+		t.Fatalf("SaveConfig() failed: %v", err)
+	}
+	if loaded := LoadConfig(); loaded.MaxRunDuration != 90*time.Second || loaded.DialTimeout != 2*time.Second || len(loaded.Warnings) != 0 {
+		t.Errorf("Expected the durations to round-trip, got %v %v %v", loaded.MaxRunDuration, loaded.DialTimeout, loaded.Warnings)
+	}
+}
+
+// TestLoadConfig_InvalidForceScheme tests that an unknown forced scheme is reported and ignored
+func TestLoadConfig_InvalidForceScheme(t *testing.T) {
+	originalHome := os.Getenv("HOME")
+	defer os.Setenv("HOME", originalHome)
+	os.Setenv("HOME", t.TempDir())
+
+	configPath, err := GetConfigPath()
+	if err != nil {
+		t.Fatalf("GetConfigPath() failed: %v", err)
+	}
+	if err := os.WriteFile(configPath, []byte("forceScheme: ftp\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	cfg := LoadConfig()
+	if cfg.ForceScheme != "" {
+		t.Errorf("Expected an invalid forceScheme to be ignored, got %q", cfg.ForceScheme)
+	}
+	if len(cfg.Warnings) != 1 {
+		t.Fatalf("Expected one warning, got %v", cfg.Warnings)
+	}
+
+	if err := os.WriteFile(configPath, []byte("forceScheme: HTTPS\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	cfg = LoadConfig()
+	if cfg.ForceScheme != "https" || len(cfg.Warnings) != 0 {
+		t.Errorf("Expected https without warnings, got %q %v", cfg.ForceScheme, cfg.Warnings)
+	}
+}
+
+// TestLoadConfig_Globs tests that endpoint globs load and malformed patterns are reported and dropped
+func TestLoadConfig_Globs(t *testing.T) {
+	originalHome := os.Getenv("HOME")
+	defer os.Setenv("HOME", originalHome)
+	os.Setenv("HOME", t.TempDir())
+
+	configPath, err := GetConfigPath()
+	if err != nil {
+		t.Fatalf("GetConfigPath() failed: %v", err)
+	}
+	data := "includeGlobs:\n  - /admin/*\n  - /bad/[\nexcludeGlobs:\n  - \"*/internal\"\n"
+	if err := os.WriteFile(configPath, []byte(data), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	cfg := LoadConfig()
+	if len(cfg.IncludeGlobs) != 1 || cfg.IncludeGlobs[0] != "/admin/*" {
+		t.Errorf("Expected include globs [/admin/*], got %v", cfg.IncludeGlobs)
+	}
+	if len(cfg.ExcludeGlobs) != 1 || cfg.ExcludeGlobs[0] != "*/internal" {
+		t.Errorf("Expected exclude globs [*/internal], got %v", cfg.ExcludeGlobs)
+	}
+	if len(cfg.Warnings) != 1 {
+		t.Errorf("Expected one warning for the malformed pattern, got %v", cfg.Warnings)
+	}
+}
+
+// TestLoadConfig_AcceptEncoding tests that supported encodings load and unsupported ones are reported and ignored
+func TestLoadConfig_AcceptEncoding(t *testing.T) {
+	originalHome := os.Getenv("HOME")
+	defer os.Setenv("HOME", originalHome)
+	os.Setenv("HOME", t.TempDir())
+
+	configPath, err := GetConfigPath()
+	if err != nil {
+		t.Fatalf("GetConfigPath() failed: %v", err)
+	}
+	if err := os.WriteFile(configPath, []byte("acceptEncoding: gzip, br;q=0.8\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	cfg := LoadConfig()
+	if cfg.AcceptEncoding != "gzip, br;q=0.8" || len(cfg.Warnings) != 0 {
+		t.Errorf("Expected gzip, br;q=0.8 without warnings, got %q %v", cfg.AcceptEncoding, cfg.Warnings)
+	}
+
+	if err := os.WriteFile(configPath, []byte("acceptEncoding: gzip, zstd\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	cfg = LoadConfig()
+	if cfg.AcceptEncoding != "" || len(cfg.Warnings) != 1 {
+		t.Errorf("Expected zstd to be rejected with a warning, got %q %v", cfg.AcceptEncoding, cfg.Warnings)
+	}
+}
+
+// TestLoadConfig_APIVersionHeader tests that the version header round-trips
+// and that an unusable one is reported and ignored
+func TestLoadConfig_APIVersionHeader(t *testing.T) {
+	originalHome := os.Getenv("HOME")
+	defer os.Setenv("HOME", originalHome)
+	os.Setenv("HOME", t.TempDir())
+
+	header, err := ParseAPIVersionHeader(" Accept: application/vnd.company.v2+json ")
+	if err != nil || header.Name != "Accept" || header.Value != "application/vnd.company.v2+json" {
+		t.Fatalf("ParseAPIVersionHeader() = %+v, %v", header, err)
+	}
+	if _, err := ParseAPIVersionHeader("Accept"); err == nil {
+		t.Error("Expected a header without a value to be rejected")
+	}
+
+	cfg := LoadConfig()
+	cfg.APIVersionHeader = header
+	if err := SaveConfig(cfg); err != nil {
+		t.Fatalf("SaveConfig() failed: %v", err)
+	}
+	if loaded := LoadConfig(); loaded.APIVersionHeader != header || len(loaded.Warnings) != 0 {
+		t.Errorf("Expected the header to round-trip, got %+v %v", loaded.APIVersionHeader, loaded.Warnings)
+	}
+
+	configPath, err := GetConfigPath()
+	if err != nil {
+		t.Fatalf("GetConfigPath() failed: %v", err)
+	}
+	if err := os.WriteFile(configPath, []byte("apiVersionHeader:\n  name: API Version\n  value: \"2\"\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	if loaded := LoadConfig(); loaded.APIVersionHeader.Name != "" || len(loaded.Warnings) != 1 {
+		t.Errorf("Expected a name with a space to be rejected with a warning, got %+v %v", loaded.APIVersionHeader, loaded.Warnings)
+	}
+}
+
+// TestLoadConfig_RequestHeaders tests that standard request headers
+// round-trip and that a value spanning lines is reported and ignored
+func TestLoadConfig_RequestHeaders(t *testing.T) {
+	originalHome := os.Getenv("HOME")
+	defer os.Setenv("HOME", originalHome)
+	os.Setenv("HOME", t.TempDir())
+
+	cfg := LoadConfig()
+	cfg.RequestHeaders = models.RequestHeaders{Prefer: "respond-async", IfNoneMatch: "*"}
+	if err := SaveConfig(cfg); err != nil {
+		t.Fatalf("SaveConfig() failed: %v", err)
+	}
+	if loaded := LoadConfig(); loaded.RequestHeaders != cfg.RequestHeaders || len(loaded.Warnings) != 0 {
+		t.Errorf("Expected the headers to round-trip, got %+v %v", loaded.RequestHeaders, loaded.Warnings)
+	}
+
+	configPath, err := GetConfigPath()
+	if err != nil {
+		t.Fatalf("GetConfigPath() failed: %v", err)
+	}
+	if err := os.WriteFile(configPath, []byte("requestHeaders:\n  prefer: \"respond-async\\r\\nX-Injected: 1\"\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	if loaded := LoadConfig(); len(loaded.RequestHeaders.Headers()) != 0 || len(loaded.Warnings) != 1 {
+		t.Errorf("Expected a multi-line value to be rejected with a warning, got %+v %v", loaded.RequestHeaders, loaded.Warnings)
+	}
+}
+
+// TestSaveConfig_RealisticData tests that realistic data settings round-trip
+func TestSaveConfig_RealisticData(t *testing.T) {
+	originalHome := os.Getenv("HOME")
+	defer os.Setenv("HOME", originalHome)
+	os.Setenv("HOME", t.TempDir())
+
+	if err := SaveConfig(models.Config{RealisticData: true, DataSeed: 42}); err != nil {
+		t.Fatalf("SaveConfig() failed: %v", err)
+	}
+	cfg := LoadConfig()
+	if !cfg.RealisticData || cfg.DataSeed != 42 {
+		t.Errorf("Expected realisticData with seed 42, got %v %d", cfg.RealisticData, cfg.DataSeed)
+	}
+}
+
+func TestSaveConfig_GlobalQuery(t *testing.T) {
+	originalHome := os.Getenv("HOME")
+	defer os.Setenv("HOME", originalHome)
+	os.Setenv("HOME", t.TempDir())
+
+	if err := SaveConfig(models.Config{GlobalQuery: map[string]string{"api-version": "2024-01-01"}}); err != nil {
+		t.Fatalf("SaveConfig() failed: %v", err)
+	}
+	cfg := LoadConfig()
+	if cfg.GlobalQuery["api-version"] != "2024-01-01" {
+		t.Errorf("Expected globalQuery api-version=2024-01-01, got %v", cfg.GlobalQuery)
+	}
+}
+
+func TestSaveConfig_Variables(t *testing.T) {
+	originalHome := os.Getenv("HOME")
+	defer os.Setenv("HOME", originalHome)
+	os.Setenv("HOME", t.TempDir())
+
+	if err := SaveConfig(models.Config{Variables: map[string]string{"TENANT": "acme"}}); err != nil {
+		t.Fatalf("SaveConfig() failed: %v", err)
+	}
+	cfg := LoadConfig()
+	if cfg.Variables["TENANT"] != "acme" {
+		t.Errorf("Expected variables TENANT=acme, got %v", cfg.Variables)
+	}
+}
+
+func TestLoadConfig_MenuItems(t *testing.T) {
+	originalHome := os.Getenv("HOME")
+	defer os.Setenv("HOME", originalHome)
+	os.Setenv("HOME", t.TempDir())
+
+	configPath, _ := GetConfigPath()
+	os.WriteFile(configPath, []byte("menuOrder: [favorites, reports]\nhiddenMenuItems: [history, quit]\n"), 0644)
+
+	cfg := LoadConfig()
+	if !reflect.DeepEqual(cfg.MenuOrder, []string{"favorites"}) || !reflect.DeepEqual(cfg.HiddenMenuItems, []string{"history"}) {
+		t.Errorf("Expected unknown and unhideable items to be dropped, got %v and %v", cfg.MenuOrder, cfg.HiddenMenuItems)
+	}
+	if len(cfg.Warnings) != 2 || !strings.Contains(cfg.Warnings[0], `"reports"`) || !strings.Contains(cfg.Warnings[1], "quit") {
+		t.Errorf("Expected warnings for both dropped items, got %v", cfg.Warnings)
+	}
+}
+
+func TestLoadConfig_RetryOn(t *testing.T) {
+	originalHome := os.Getenv("HOME")
+	defer os.Setenv("HOME", originalHome)
+	os.Setenv("HOME", t.TempDir())
+
+	configPath, _ := GetConfigPath()
+	os.WriteFile(configPath, []byte("retryOn: [reset, EOF, flaky]\n"), 0644)
+
+	cfg := LoadConfig()
+	if !reflect.DeepEqual(cfg.RetryOn, []string{"reset", "eof"}) {
+		t.Errorf("Expected the known retry classes, got %v", cfg.RetryOn)
+	}
+	if len(cfg.Warnings) != 1 || !strings.Contains(cfg.Warnings[0], `"flaky"`) {
+		t.Errorf("Expected a warning for the unknown class, got %v", cfg.Warnings)
+	}
+}
+
+func TestLoadConfig_RetryStatuses(t *testing.T) {
+	originalHome := os.Getenv("HOME")
+	defer os.Setenv("HOME", originalHome)
+	os.Setenv("HOME", t.TempDir())
+
+	configPath, _ := GetConfigPath()
+	os.WriteFile(configPath, []byte("retryStatuses: [429, 503, 42]\n"), 0644)
+
+	cfg := LoadConfig()
+	if !reflect.DeepEqual(cfg.RetryStatuses, []int{429, 503}) {
+		t.Errorf("Expected the valid retry statuses, got %v", cfg.RetryStatuses)
+	}
+	if len(cfg.Warnings) != 1 || !strings.Contains(cfg.Warnings[0], "42") {
+		t.Errorf("Expected a warning for the invalid status, got %v", cfg.Warnings)
+	}
+}
+
+func TestLoadConfig_ExpectedStatuses(t *testing.T) {
+	originalHome := os.Getenv("HOME")
+	defer os.Setenv("HOME", originalHome)
+	os.Setenv("HOME", t.TempDir())
+
+	configPath, _ := GetConfigPath()
+	os.WriteFile(configPath, []byte("expectedStatuses:\n  post /login: [303, 999]\n  users: [200]\n"), 0644)
+
+	cfg := LoadConfig()
+	want := map[string][]int{"POST /login": {303}}
+	if !reflect.DeepEqual(cfg.ExpectedStatuses, want) {
+		t.Errorf("Expected normalized expected statuses %v, got %v", want, cfg.ExpectedStatuses)
+	}
+	if len(cfg.Warnings) != 2 {
+		t.Errorf("Expected warnings for the bad status and key, got %v", cfg.Warnings)
+	}
+}
+
+func TestMaskSecrets(t *testing.T) {
+	contents := "baseUrl: http://localhost\nauth:\n  type: API Key\n  token: s3cret\n  apiKeyName: X-API-Key\n  password: hunter2\nvariables:\n  API_TOKEN: abc\n  TENANT: acme\nglobalQuery:\n  api_key: xyz\n"
+	want := "baseUrl: http://localhost\nauth:\n  type: API Key\n  token: ********\n  apiKeyName: X-API-Key\n  password: ********\nvariables:\n  API_TOKEN: ********\n  TENANT: acme\nglobalQuery:\n  api_key: ********\n"
+	if got := MaskSecrets(contents); got != want {
+		t.Errorf("MaskSecrets() =\n%s\nwant\n%s", got, want)
+	}
+}
+
 // TestSaveConfig_EmptyValues tests saving config with empty string values
 func TestSaveConfig_EmptyValues(t *testing.T) {
 	testConfig := models.Config{
@@ -360,3 +858,150 @@ func TestConfigPersistence(t *testing.T) {
 		}
 	}
 }
+
+func TestLoadConfig_BasicAuthCredentials(t *testing.T) {
+	originalHome := os.Getenv("HOME")
+	defer os.Setenv("HOME", originalHome)
+	os.Setenv("HOME", t.TempDir())
+
+	configPath, _ := GetConfigPath()
+	os.WriteFile(configPath, []byte("auth:\n  type: basic\n  password: \"p:ä:ss\"\n"), 0644)
+	if cfg := LoadConfig(); cfg.Auth.Password != "p:ä:ss" || cfg.Auth.Username != "" || len(cfg.Warnings) != 0 {
+		t.Errorf("Expected the password as written without warnings, got %+v %v", cfg.Auth, cfg.Warnings)
+	}
+
+	os.WriteFile(configPath, []byte("auth:\n  type: basic\n  username: \"a:b\"\n  password: secret\n"), 0644)
+	if cfg := LoadConfig(); len(cfg.Warnings) != 1 || !strings.Contains(cfg.Warnings[0], "username contains ':'") {
+		t.Errorf("Expected a warning for a colon in the username, got %v", cfg.Warnings)
+	}
+}
+
+func TestSaveProfile(t *testing.T) {
+	originalHome := os.Getenv("HOME")
+	defer os.Setenv("HOME", originalHome)
+	os.Setenv("HOME", t.TempDir())
+
+	cfg := models.Config{BaseURL: "http://localhost:3000", MaxRetries: 2}
+	profile := models.Profile{
+		SpecPath: "/specs/petstore.yaml",
+		BaseURL:  "https://staging.example.com",
+		Auth:     &models.AuthConfig{AuthType: "apikey", Token: "secret", APIKeyIn: "header", APIKeyName: "X-API-Key"},
+	}
+	if err := SaveProfile(&cfg, " staging ", profile); err != nil {
+		t.Fatalf("SaveProfile() failed: %v", err)
+	}
+	if err := SaveProfile(&cfg, "  ", profile); err == nil {
+		t.Error("Expected an error for an empty profile name")
+	}
+
+	loaded := LoadConfig()
+	if !reflect.DeepEqual(loaded.Profiles, map[string]models.Profile{"staging": profile}) {
+		t.Errorf("Expected the saved profile, got %+v", loaded.Profiles)
+	}
+	if loaded.BaseURL != "http://localhost:3000" || loaded.MaxRetries != 2 {
+		t.Errorf("Expected the rest of the config to be kept, got %+v", loaded)
+	}
+	configPath, _ := GetConfigPath()
+	data, _ := os.ReadFile(configPath)
+	if !strings.Contains(string(data), "profiles:\n    staging:\n        specPath: /specs/petstore.yaml") {
+		t.Errorf("Expected the profile under profiles in the config file, got:\n%s", data)
+	}
+
+	if err := ApplyProfile(&loaded, "staging"); err != nil {
+		t.Fatalf("ApplyProfile() failed: %v", err)
+	}
+	if loaded.SpecPath != profile.SpecPath || loaded.BaseURL != profile.BaseURL || loaded.Auth.Token != "secret" {
+		t.Errorf("Expected the profile's inputs, got %+v", loaded)
+	}
+	if err := ApplyProfile(&loaded, "production"); err == nil {
+		t.Error("Expected an error for an unknown profile")
+	}
+}
+
+// TestLoadOverrides tests parsing and key normalization of an overrides file
+func TestLoadOverrides(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "overrides.yaml")
+	content := `
+post /users:
+  body:
+    name: alice
+  headers:
+    X-Tenant: acme
+"GET /users/{id}":
+  query:
+    expand: "true"
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write overrides file: %v", err)
+	}
+
+	overrides, err := LoadOverrides(path)
+	if err != nil {
+		t.Fatalf("LoadOverrides() failed: %v", err)
+	}
+
+	post, ok := overrides.Lookup("POST", "/users")
+	if !ok {
+		t.Fatal("Expected override for POST /users")
+	}
+	if post.Headers["X-Tenant"] != "acme" {
+		t.Errorf("Expected X-Tenant header 'acme', got '%s'", post.Headers["X-Tenant"])
+	}
+	if post.Body == nil {
+		t.Error("Expected override body to be set")
+	}
+
+	get, ok := overrides.Lookup("get", "/users/{id}")
+	if !ok {
+		t.Fatal("Expected override for GET /users/{id}")
+	}
+	if get.Query["expand"] != "true" {
+		t.Errorf("Expected expand=true, got '%s'", get.Query["expand"])
+	}
+}
+
+// TestLoadOverrides_InvalidKey tests that malformed keys are rejected
+func TestLoadOverrides_InvalidKey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "overrides.yaml")
+	if err := os.WriteFile(path, []byte("/users:\n  headers:\n    X: y\n"), 0644); err != nil {
+		t.Fatalf("Failed to write overrides file: %v", err)
+	}
+
+	if _, err := LoadOverrides(path); err == nil {
+		t.Error("Expected error for key without method")
+	}
+}
+
+func TestResetConfig(t *testing.T) {
+	originalHome := os.Getenv("HOME")
+	defer os.Setenv("HOME", originalHome)
+	os.Setenv("HOME", t.TempDir())
+
+	// Nothing to back up yet
+	if backupPath, err := ResetConfig(); err != nil || backupPath != "" {
+		t.Fatalf("Expected a reset without a backup, got %q, %v", backupPath, err)
+	}
+
+	configPath, _ := GetConfigPath()
+	original := "baseUrl: http://localhost:8080\nmaxRetries: 9\nverboseMode: true\n"
+	if err := os.WriteFile(configPath, []byte(original), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	backupPath, err := ResetConfig()
+	if err != nil {
+		t.Fatalf("ResetConfig() error = %v", err)
+	}
+	if backup, err := os.ReadFile(backupPath); err != nil || string(backup) != original {
+		t.Errorf("Expected the previous config in %s, got %q, %v", backupPath, backup, err)
+	}
+	if filepath.Dir(backupPath) != filepath.Dir(configPath) || !strings.HasSuffix(backupPath, ".bak") {
+		t.Errorf("Expected a .bak file next to the config, got %s", backupPath)
+	}
+
+	cfg := LoadConfig()
+	want := DefaultConfig()
+	if cfg.BaseURL != "" || cfg.VerboseMode || cfg.MaxRetries != want.MaxRetries || cfg.RetryDelay != want.RetryDelay {
+		t.Errorf("Expected the defaults after a reset, got %+v", cfg)
+	}
+}
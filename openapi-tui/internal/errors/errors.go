@@ -1,7 +1,10 @@
 package errors
 
 import (
+	stderrors "errors"
 	"fmt"
+	"io/fs"
+	"regexp"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
@@ -13,11 +16,15 @@ type EnhancedError struct {
 	Description string   // Detailed error description
 	Suggestions []string // Actionable suggestions for fixing
 	Original    error    // Original error for reference
+	Location    string   // Where the problem is, e.g. "openapi.yaml:42" (optional)
 }
 
 // Error implements the error interface
 func (e *EnhancedError) Error() string {
 	msg := fmt.Sprintf("%s: %s", e.Title, e.Description)
+	if e.Location != "" {
+		msg += "\nLocation: " + e.Location
+	}
 	if len(e.Suggestions) > 0 {
 		msg += "\n\nSuggestions:"
 		for _, s := range e.Suggestions {
@@ -40,6 +47,10 @@ func FormatEnhancedError(err error) string {
 		msg := errorStyle.Render("❌ " + enhanced.Title)
 		msg += "\n\n" + enhanced.Description
 
+		if enhanced.Location != "" {
+			msg += "\n\n📍 " + enhanced.Location
+		}
+
 		if len(enhanced.Suggestions) > 0 {
 			msg += "\n\n" + suggestionStyle.Render("💡 Suggestions:")
 			for _, s := range enhanced.Suggestions {
@@ -61,6 +72,11 @@ func EnhanceFileError(err error, filePath string) error {
 		return nil
 	}
 
+	// A referenced file or path failed, not the spec itself
+	if enhanced := EnhanceRefError(err, filePath); enhanced != err {
+		return enhanced
+	}
+
 	errStr := err.Error()
 
 	// File not found
@@ -92,6 +108,11 @@ func EnhanceFileError(err error, filePath string) error {
 		}
 	}
 
+	// Valid JSON or YAML whose top level isn't an object, e.g. a list
+	if strings.Contains(errStr, "cannot unmarshal") && strings.Contains(errStr, "openapi3.T") {
+		return NotOpenAPIError(filePath, "", err)
+	}
+
 	// Parse errors
 	if strings.Contains(errStr, "yaml") || strings.Contains(errStr, "unmarshal") {
 		return &EnhancedError{
@@ -110,16 +131,95 @@ func EnhanceFileError(err error, filePath string) error {
 	return err
 }
 
+// NotOpenAPIError explains that a file parsed as JSON or YAML but isn't an
+// OpenAPI document. swaggerVersion is the file's swagger field, if it has one
+func NotOpenAPIError(filePath, swaggerVersion string, original error) error {
+	if swaggerVersion != "" {
+		return &EnhancedError{
+			Title:       "Swagger Document",
+			Description: fmt.Sprintf("%s is a Swagger %s document; this tool only supports OpenAPI 3.x", filePath, swaggerVersion),
+			Suggestions: []string{
+				"Convert it to OpenAPI 3.x with https://converter.swagger.io/",
+				"Or run: npx swagger2openapi spec.yaml -o openapi.yaml",
+			},
+			Original: original,
+		}
+	}
+	return &EnhancedError{
+		Title:       "Not an OpenAPI Document",
+		Description: fmt.Sprintf("%s doesn't look like an OpenAPI document: it has no 'openapi' version field", filePath),
+		Suggestions: []string{
+			"Check that you selected the spec file and not another JSON or YAML file",
+			"An OpenAPI document starts with a version field, e.g. openapi: 3.0.3",
+			"It also needs 'info' (title and version) and 'paths' at the top level",
+		},
+		Original: original,
+	}
+}
+
+// refErrorRe captures the $ref named by the spec loader's reference errors
+var refErrorRe = regexp.MustCompile(`(?:error resolving reference|found unresolved ref:|bad data in|encountered disallowed external reference:) "([^"]+)"`)
+
+// EnhanceRefError wraps a spec loading error caused by a $ref that could not
+// be resolved, naming the offending ref and, for a missing file, the path
+// that was looked up. Other errors are returned unchanged
+func EnhanceRefError(err error, specPath string) error {
+	if err == nil {
+		return nil
+	}
+
+	match := refErrorRe.FindStringSubmatch(err.Error())
+	if match == nil {
+		return err
+	}
+	ref := match[1]
+
+	description := fmt.Sprintf("%s references %q, which could not be resolved", specPath, ref)
+	suggestions := []string{
+		"Check the $ref is spelled correctly",
+		"Relative refs are resolved from the directory of the file that contains them",
+		"Check the part after '#' points to an existing path in the referenced file",
+	}
+	var pathErr *fs.PathError
+	switch {
+	case stderrors.As(err, &pathErr):
+		description += fmt.Sprintf(": %s does not exist or can't be read", pathErr.Path)
+		suggestions = []string{
+			fmt.Sprintf("Check %s exists", pathErr.Path),
+			"Relative refs are resolved from the directory of the file that contains them",
+			"Check the $ref is spelled correctly",
+		}
+	case strings.Contains(err.Error(), "disallowed external reference"):
+		description += ": external references are not allowed"
+	default:
+		// Keep the loader's reason, e.g. a fragment path that doesn't exist
+		cause := err
+		for inner := stderrors.Unwrap(cause); inner != nil; inner = stderrors.Unwrap(cause) {
+			cause = inner
+		}
+		if cause != err {
+			description += ": " + cause.Error()
+		}
+	}
+
+	return &EnhancedError{
+		Title:       "Unresolved Reference",
+		Description: description,
+		Suggestions: suggestions,
+		Original:    err,
+	}
+}
+
 // enhanceNetworkError wraps network-related errors with helpful suggestions
 func EnhanceNetworkError(err error, url string) error {
 	if err == nil {
 		return nil
 	}
 
-	errStr := err.Error()
+	category := networkCategory(err.Error())
 
 	// Connection refused
-	if strings.Contains(errStr, "connection refused") {
+	if category == CategoryConnectionRefused {
 		return &EnhancedError{
 			Title:       "Connection Refused",
 			Description: fmt.Sprintf("Cannot connect to: %s", url),
@@ -134,7 +234,7 @@ func EnhanceNetworkError(err error, url string) error {
 	}
 
 	// Timeout
-	if strings.Contains(errStr, "timeout") || strings.Contains(errStr, "deadline exceeded") {
+	if category == CategoryTimeout {
 		return &EnhancedError{
 			Title:       "Request Timeout",
 			Description: "The server took too long to respond",
@@ -149,7 +249,7 @@ func EnhanceNetworkError(err error, url string) error {
 	}
 
 	// DNS resolution failure
-	if strings.Contains(errStr, "no such host") || strings.Contains(errStr, "dns") {
+	if category == CategoryDNS {
 		return &EnhancedError{
 			Title:       "DNS Resolution Failed",
 			Description: fmt.Sprintf("Cannot resolve hostname: %s", url),
@@ -164,7 +264,7 @@ func EnhanceNetworkError(err error, url string) error {
 	}
 
 	// TLS/SSL errors
-	if strings.Contains(errStr, "tls") || strings.Contains(errStr, "certificate") {
+	if category == CategoryTLS {
 		return &EnhancedError{
 			Title:       "TLS/SSL Error",
 			Description: "Cannot establish secure connection",
@@ -181,6 +281,38 @@ func EnhanceNetworkError(err error, url string) error {
 	return err
 }
 
+// Network failure categories, as reported by NetworkErrorCategory
+const (
+	CategoryConnectionRefused = "connection refused"
+	CategoryTimeout           = "timeout"
+	CategoryDNS               = "dns"
+	CategoryTLS               = "tls"
+	CategoryOtherError        = "other error"
+)
+
+// networkCategory classifies a raw network error message the way
+// EnhanceNetworkError does, or returns CategoryOtherError
+func networkCategory(errStr string) string {
+	switch {
+	case strings.Contains(errStr, "connection refused"):
+		return CategoryConnectionRefused
+	case strings.Contains(errStr, "timeout") || strings.Contains(errStr, "deadline exceeded"):
+		return CategoryTimeout
+	case strings.Contains(errStr, "no such host") || strings.Contains(errStr, "dns"):
+		return CategoryDNS
+	case strings.Contains(errStr, "tls") || strings.Contains(errStr, "certificate"):
+		return CategoryTLS
+	}
+	return CategoryOtherError
+}
+
+// NetworkErrorCategory returns the category of a failed request's message,
+// either a raw network error or one already enhanced by EnhanceNetworkError
+// (whose titles, lowercased, match the same checks)
+func NetworkErrorCategory(message string) string {
+	return networkCategory(strings.ToLower(message))
+}
+
 // enhanceValidationError wraps validation errors with helpful suggestions
 func EnhanceValidationError(err error) error {
 	if err == nil {
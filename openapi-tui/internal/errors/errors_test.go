@@ -2,6 +2,7 @@ package errors
 
 import (
 	"errors"
+	"fmt"
 	"strings"
 	"testing"
 )
@@ -186,6 +187,27 @@ func TestEnhanceFileError(t *testing.T) {
 	})
 }
 
+// TestEnhanceRefError tests loader errors for unresolvable refs name the ref
+func TestEnhanceRefError(t *testing.T) {
+	t.Run("Missing fragment", func(t *testing.T) {
+		err := fmt.Errorf("error resolving reference %q: %w", "./schemas.yaml#/Pet", errors.New(`map key "Pet" not found`))
+		enhanced, ok := EnhanceRefError(err, "openapi.yaml").(*EnhancedError)
+		if !ok {
+			t.Fatal("Expected EnhancedError")
+		}
+		if !strings.Contains(enhanced.Description, `"./schemas.yaml#/Pet"`) || !strings.Contains(enhanced.Description, `map key "Pet" not found`) {
+			t.Errorf("Expected the ref and the reason, got: %s", enhanced.Description)
+		}
+	})
+
+	t.Run("Other errors unchanged", func(t *testing.T) {
+		err := errors.New("yaml: line 3: mapping values are not allowed")
+		if EnhanceRefError(err, "openapi.yaml") != err {
+			t.Error("Expected a non-ref error to be returned unchanged")
+		}
+	})
+}
+
 // TestEnhanceNetworkError tests network error enhancement
 func TestEnhanceNetworkError(t *testing.T) {
 	t.Run("Nil error returns nil", func(t *testing.T) {
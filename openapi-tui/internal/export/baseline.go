@@ -0,0 +1,128 @@
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/Traves-Theberge/OpenAPI-Toolkit/openapi-tui/internal/models"
+)
+
+// endpointOutcome is an endpoint's combined result in one run: it passed
+// only if every result for it passed, and took the longest of their durations
+type endpointOutcome struct {
+	status   string
+	passed   bool
+	duration time.Duration
+}
+
+// CompareToBaseline compares a run against a JSON results export from an
+// earlier run. An endpoint regressed if it passed in the baseline and fails
+// now. An endpoint that passed in both slowed down if it now takes more than
+// (1 + latencyTolerance) times its baseline duration, so 0.2 allows 20%;
+// a tolerance of 0 or less skips the latency check
+func CompareToBaseline(current []models.TestResult, baselinePath string, latencyTolerance float64) models.RegressionReport {
+	report := models.RegressionReport{BaselinePath: baselinePath}
+	baseline, err := loadBaseline(baselinePath)
+	if err != nil {
+		report.Err = err
+		return report
+	}
+
+	now := make(map[string]endpointOutcome)
+	for _, r := range current {
+		if r.Skipped() {
+			continue
+		}
+		addOutcome(now, r.Key(), r.Status, r.Passed(), r.Duration)
+	}
+
+	keys := make([]string, 0, len(now))
+	for key := range now {
+		if _, ok := baseline[key]; ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		before, after := baseline[key], now[key]
+		report.Compared++
+		regression := models.Regression{
+			Endpoint:         key,
+			BaselineStatus:   before.status,
+			Status:           after.status,
+			BaselineDuration: before.duration,
+			Duration:         after.duration,
+		}
+		switch {
+		case before.passed && !after.passed:
+			report.Regressions = append(report.Regressions, regression)
+		case before.passed && after.passed && latencyTolerance > 0 && before.duration > 0 &&
+			float64(after.duration) > float64(before.duration)*(1+latencyTolerance):
+			report.Slowdowns = append(report.Slowdowns, regression)
+		}
+	}
+	return report
+}
+
+// loadBaseline reads a JSON results export into endpoint outcomes
+// An export of failures only can't show regressions, so it is rejected.
+// Exports without the passed field, from older versions, are judged by
+// status and case
+func loadBaseline(path string) (map[string]endpointOutcome, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read baseline: %w", err)
+	}
+	var export models.ExportData
+	if err := json.Unmarshal(data, &export); err != nil {
+		return nil, fmt.Errorf("baseline %s is not a JSON results export: %w", path, err)
+	}
+	if export.Metadata.FailuresOnly {
+		return nil, fmt.Errorf("baseline %s lists only failures; export it with exportFailuresOnly off", path)
+	}
+
+	var recorded struct {
+		Results []struct {
+			Passed *bool `json:"passed"`
+		} `json:"results"`
+	}
+	json.Unmarshal(data, &recorded)
+
+	outcomes := make(map[string]endpointOutcome)
+	for i, r := range export.Results {
+		duration, _ := time.ParseDuration(r.Duration)
+		result := models.TestResult{
+			Method:     r.Method,
+			Endpoint:   r.Endpoint,
+			Status:     r.Status,
+			Case:       r.Case,
+			Example:    r.Example,
+			EnumValues: r.EnumValues,
+		}
+		passed := r.Passed
+		if i >= len(recorded.Results) || recorded.Results[i].Passed == nil {
+			passed = result.Passed()
+		}
+		addOutcome(outcomes, result.Key(), r.Status, passed, duration)
+	}
+	return outcomes, nil
+}
+
+// addOutcome folds one result into its endpoint's outcome
+func addOutcome(outcomes map[string]endpointOutcome, key, status string, passed bool, duration time.Duration) {
+	outcome, ok := outcomes[key]
+	if !ok {
+		outcomes[key] = endpointOutcome{status: status, passed: passed, duration: duration}
+		return
+	}
+	if outcome.passed && !passed {
+		outcome.status = status
+	}
+	outcome.passed = outcome.passed && passed
+	outcome.duration = max(outcome.duration, duration)
+	outcomes[key] = outcome
+}
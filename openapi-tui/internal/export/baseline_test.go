@@ -0,0 +1,102 @@
+package export
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Traves-Theberge/OpenAPI-Toolkit/openapi-tui/internal/models"
+)
+
+func TestCompareToBaseline(t *testing.T) {
+	baseline := []models.TestResult{
+		{Method: "GET", Endpoint: "/users", Status: "200", Duration: 100 * time.Millisecond},
+		{Method: "POST", Endpoint: "/users", Status: "201", Duration: 100 * time.Millisecond},
+		{Method: "GET", Endpoint: "/orders", Status: "200", Duration: 100 * time.Millisecond},
+		{Method: "GET", Endpoint: "/broken", Status: "500", Duration: 100 * time.Millisecond},
+		{Method: "GET", Endpoint: "/removed", Status: "200", Duration: 100 * time.Millisecond},
+	}
+	baselinePath := filepath.Join(t.TempDir(), "baseline.json")
+	if err := ExportResultsToFile(baseline, "spec.yaml", baselinePath); err != nil {
+		t.Fatal(err)
+	}
+
+	current := []models.TestResult{
+		{Method: "GET", Endpoint: "/users", Status: "200", Duration: 110 * time.Millisecond},
+		{Method: "POST", Endpoint: "/users", Status: "500", Duration: 90 * time.Millisecond},
+		{Method: "GET", Endpoint: "/orders", Status: "200", Duration: 300 * time.Millisecond},
+		{Method: "GET", Endpoint: "/broken", Status: "500", Duration: 100 * time.Millisecond},
+		{Method: "GET", Endpoint: "/new", Status: "500", Duration: 100 * time.Millisecond},
+	}
+
+	report := CompareToBaseline(current, baselinePath, 0.2)
+	if report.Err != nil {
+		t.Fatalf("CompareToBaseline() error = %v", report.Err)
+	}
+	if !report.Failed() || report.Compared != 4 {
+		t.Errorf("Expected a failed comparison of 4 endpoints, got %+v", report)
+	}
+	if len(report.Regressions) != 1 || report.Regressions[0].Endpoint != "POST /users" ||
+		report.Regressions[0].BaselineStatus != "201" || report.Regressions[0].Status != "500" {
+		t.Errorf("Expected POST /users to regress from 201 to 500, got %+v", report.Regressions)
+	}
+	if len(report.Slowdowns) != 1 || report.Slowdowns[0].Endpoint != "GET /orders" || report.Slowdowns[0].BaselineDuration != 100*time.Millisecond {
+		t.Errorf("Expected only GET /orders to be flagged as slower, got %+v", report.Slowdowns)
+	}
+
+	// Without a tolerance latency is not checked
+	if report := CompareToBaseline(current, baselinePath, 0); len(report.Slowdowns) != 0 || len(report.Regressions) != 1 {
+		t.Errorf("Expected no latency check with a zero tolerance, got %+v", report)
+	}
+
+	// A run that matches the baseline passes
+	if report := CompareToBaseline(baseline, baselinePath, 0.2); report.Failed() {
+		t.Errorf("Expected the baseline to pass against itself, got %+v", report)
+	}
+
+	// A missing or failures-only baseline is an error, not a pass
+	if report := CompareToBaseline(current, filepath.Join(t.TempDir(), "missing.json"), 0.2); report.Err == nil || !report.Failed() {
+		t.Errorf("Expected an error for a missing baseline, got %+v", report)
+	}
+	data, err := marshalResults(baseline, "spec.yaml", models.ExportMetadata{FailuresOnly: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	failuresOnly := filepath.Join(t.TempDir(), "failures.json")
+	if err := os.WriteFile(failuresOnly, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if report := CompareToBaseline(current, failuresOnly, 0.2); report.Err == nil || !strings.Contains(report.Err.Error(), "only failures") {
+		t.Errorf("Expected a failures-only baseline to be rejected, got %+v", report)
+	}
+}
+
+func TestCompareToBaseline_ContractCases(t *testing.T) {
+	// The invalid case passes when rejected, and expected statuses can make
+	// a non-2xx status a pass
+	baseline := []models.TestResult{
+		{Method: "POST", Endpoint: "/users", Status: "201", Case: models.CaseValid},
+		{Method: "POST", Endpoint: "/users", Status: "400", Case: models.CaseInvalid},
+		{Method: "GET", Endpoint: "/moved", Status: "301", ExpectedStatuses: []int{301}},
+	}
+	baselinePath := filepath.Join(t.TempDir(), "baseline.json")
+	if err := ExportResultsToFile(baseline, "spec.yaml", baselinePath); err != nil {
+		t.Fatal(err)
+	}
+
+	if report := CompareToBaseline(baseline, baselinePath, 0); report.Failed() || report.Compared != 3 {
+		t.Errorf("Expected each case to be compared and pass against itself, got %+v", report)
+	}
+
+	current := []models.TestResult{
+		{Method: "POST", Endpoint: "/users", Status: "201", Case: models.CaseValid},
+		{Method: "POST", Endpoint: "/users", Status: "201", Case: models.CaseInvalid},
+		{Method: "GET", Endpoint: "/moved", Status: "301", ExpectedStatuses: []int{301}},
+	}
+	report := CompareToBaseline(current, baselinePath, 0)
+	if len(report.Regressions) != 1 || report.Regressions[0].Endpoint != "POST /users [invalid]" {
+		t.Errorf("Expected only the invalid case to regress, got %+v", report.Regressions)
+	}
+}
@@ -0,0 +1,127 @@
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/Traves-Theberge/OpenAPI-Toolkit/openapi-tui/internal/models"
+	"github.com/Traves-Theberge/OpenAPI-Toolkit/openapi-tui/internal/validation"
+)
+
+// untaggedCoverage groups operations without tags in a coverage report
+const untaggedCoverage = "(untagged)"
+
+// CoverageStats counts the operations of a spec, or of one tag, that a run tested
+type CoverageStats struct {
+	Tested  int     `json:"tested"`
+	Total   int     `json:"total"`
+	Percent float64 `json:"percent"`
+}
+
+// TagCoverage is the coverage of the operations with one tag
+type TagCoverage struct {
+	Tag string `json:"tag"`
+	CoverageStats
+}
+
+// CoverageReport is the JSON structure written by ExportCoverage
+type CoverageReport struct {
+	Timestamp string                `json:"timestamp"`
+	SpecPath  string                `json:"specPath"`
+	Metadata  models.ExportMetadata `json:"metadata"`
+	Overall   CoverageStats         `json:"overall"`
+	Tags      []TagCoverage         `json:"tags"`
+	Untested  []string              `json:"untested,omitempty"` // "METHOD path" of each operation not tested
+}
+
+// ExportCoverage writes a JSON report of how many of the spec's operations
+// the results tested, overall and per tag, for coverage gates in CI
+// An operation counts as tested if it has a result, whatever its status.
+// Operations with several tags count toward each of them.
+// Returns the filename and any error
+func ExportCoverage(specPath string, tested []models.TestResult) (string, error) {
+	return ExportCoverageWithMetadata(specPath, tested, models.NewExportMetadata(nil))
+}
+
+// ExportCoverageWithMetadata exports coverage like ExportCoverage, recording
+// the build and run metadata. Every operation is always counted, so
+// metadata.FailuresOnly is ignored
+func ExportCoverageWithMetadata(specPath string, tested []models.TestResult, metadata models.ExportMetadata) (string, error) {
+	endpoints, err := validation.ExtractEndpoints(specPath)
+	if err != nil {
+		return "", err
+	}
+
+	report := buildCoverage(specPath, endpoints, tested)
+	metadata.FailuresOnly = false
+	report.Metadata = metadata
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal coverage: %w", err)
+	}
+
+	// Generate filename with timestamp
+	timestamp := time.Now().Format("20060102_150405")
+	filename := fmt.Sprintf("openapi-coverage_%s.json", timestamp)
+
+	// Write to file
+	if err := os.WriteFile(filename, data, 0644); err != nil {
+		return "", fmt.Errorf("failed to write file: %w", err)
+	}
+
+	return filename, nil
+}
+
+// buildCoverage matches the spec's endpoints against the tested results
+func buildCoverage(specPath string, endpoints []models.EndpointInfo, tested []models.TestResult) CoverageReport {
+	testedKeys := make(map[string]bool, len(tested))
+	for _, r := range tested {
+		testedKeys[models.EndpointKey(r.Method, r.Endpoint)] = true
+	}
+
+	report := CoverageReport{
+		Timestamp: time.Now().Format(time.RFC3339),
+		SpecPath:  specPath,
+		Tags:      []TagCoverage{},
+	}
+	tags := make(map[string]*CoverageStats)
+	for _, ep := range endpoints {
+		key := models.EndpointKey(ep.Method, ep.Path)
+		hit := testedKeys[key]
+
+		names := ep.Tags
+		if len(names) == 0 {
+			names = []string{untaggedCoverage}
+		}
+		for _, name := range names {
+			if tags[name] == nil {
+				tags[name] = &CoverageStats{}
+			}
+			tags[name].add(hit)
+		}
+		report.Overall.add(hit)
+		if !hit {
+			report.Untested = append(report.Untested, key)
+		}
+	}
+
+	for name, stats := range tags {
+		report.Tags = append(report.Tags, TagCoverage{Tag: name, CoverageStats: *stats})
+	}
+	sort.Slice(report.Tags, func(i, j int) bool { return report.Tags[i].Tag < report.Tags[j].Tag })
+	sort.Strings(report.Untested)
+	return report
+}
+
+// add counts one operation, updating the percentage (to one decimal place)
+func (s *CoverageStats) add(tested bool) {
+	s.Total++
+	if tested {
+		s.Tested++
+	}
+	s.Percent = math.Round(float64(s.Tested)*1000/float64(s.Total)) / 10
+}
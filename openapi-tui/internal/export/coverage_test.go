@@ -0,0 +1,125 @@
+package export
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/Traves-Theberge/OpenAPI-Toolkit/openapi-tui/internal/models"
+)
+
+const coverageSpec = `openapi: 3.0.0
+info:
+  title: Coverage
+  version: "1.0"
+paths:
+  /users:
+    get:
+      tags: [users]
+      responses:
+        "200":
+          description: OK
+    post:
+      tags: [users, admin]
+      responses:
+        "201":
+          description: Created
+  /users/{id}:
+    delete:
+      tags: [admin]
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        "204":
+          description: Deleted
+  /health:
+    get:
+      responses:
+        "200":
+          description: OK
+`
+
+func TestExportCoverage(t *testing.T) {
+	specPath := filepath.Join(t.TempDir(), "spec.yaml")
+	if err := os.WriteFile(specPath, []byte(coverageSpec), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// A partial run: two of four operations, one of them failing
+	tested := []models.TestResult{
+		{Method: "GET", Endpoint: "/users", Status: "200"},
+		{Method: "POST", Endpoint: "/users", Status: "500"},
+	}
+	filename, err := ExportCoverage(specPath, tested)
+	if err != nil {
+		t.Fatalf("ExportCoverage() error = %v", err)
+	}
+	defer os.Remove(filename)
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatalf("Failed to read coverage: %v", err)
+	}
+	var report CoverageReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		t.Fatalf("Failed to unmarshal coverage: %v", err)
+	}
+
+	if report.Overall != (CoverageStats{Tested: 2, Total: 4, Percent: 50}) {
+		t.Errorf("Unexpected overall coverage %+v", report.Overall)
+	}
+	want := []TagCoverage{
+		{Tag: "(untagged)", CoverageStats: CoverageStats{Tested: 0, Total: 1, Percent: 0}},
+		{Tag: "admin", CoverageStats: CoverageStats{Tested: 1, Total: 2, Percent: 50}},
+		{Tag: "users", CoverageStats: CoverageStats{Tested: 2, Total: 2, Percent: 100}},
+	}
+	if !reflect.DeepEqual(report.Tags, want) {
+		t.Errorf("Unexpected tag coverage:\n got %+v\nwant %+v", report.Tags, want)
+	}
+	if !reflect.DeepEqual(report.Untested, []string{"DELETE /users/{id}", "GET /health"}) {
+		t.Errorf("Unexpected untested operations %v", report.Untested)
+	}
+}
+
+func TestExportCoverageWithMetadata(t *testing.T) {
+	specPath := filepath.Join(t.TempDir(), "spec.yaml")
+	if err := os.WriteFile(specPath, []byte(coverageSpec), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	metadata := models.NewExportMetadata(map[string]string{"build": "ci-1842"})
+	metadata.FailuresOnly = true
+	filename, err := ExportCoverageWithMetadata(specPath, nil, metadata)
+	if err != nil {
+		t.Fatalf("ExportCoverageWithMetadata() error = %v", err)
+	}
+	defer os.Remove(filename)
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatalf("Failed to read coverage: %v", err)
+	}
+	var report CoverageReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		t.Fatalf("Failed to unmarshal coverage: %v", err)
+	}
+	if report.Metadata.ToolVersion == "" || report.Metadata.Run["build"] != "ci-1842" {
+		t.Errorf("Expected the build and run metadata, got %+v", report.Metadata)
+	}
+	// Coverage always counts every operation
+	if report.Metadata.FailuresOnly || report.Overall.Total != 4 {
+		t.Errorf("Expected every operation counted and no failures-only flag, got %+v", report)
+	}
+}
+
+func TestExportCoverage_InvalidSpec(t *testing.T) {
+	if _, err := ExportCoverage(filepath.Join(t.TempDir(), "missing.yaml"), nil); err == nil {
+		t.Error("Expected an error for a missing spec")
+	}
+}
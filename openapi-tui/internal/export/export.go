@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 	"github.com/Traves-Theberge/OpenAPI-Toolkit/openapi-tui/internal/models"
@@ -12,44 +13,15 @@ import (
 // ExportResults exports test results to a JSON file
 // Returns the filename and any error
 func ExportResults(results []models.TestResult, specPath string) (string, error) {
-	// Calculate statistics
-	passed := 0
-	failed := 0
-	for _, r := range results {
-		if r.Status != "ERR" && !strings.Contains(r.Message, "failed") {
-			passed++
-		} else {
-			failed++
-		}
-	}
-
-	// Create export data structure
-	data := models.ExportData{
-		Timestamp:  time.Now().Format(time.RFC3339),
-		SpecPath:   specPath,
-		BaseURL:    "",
-		TotalTests: len(results),
-		Passed:     passed,
-		Failed:     failed,
-		Results:    make([]models.ExportResult, len(results)),
-	}
-
-	// Convert test results to export format
-	for i, r := range results {
-		data.Results[i] = models.ExportResult{
-			Method:     r.Method,
-			Endpoint:   r.Endpoint,
-			Status:     r.Status,
-			Message:    r.Message,
-			Duration:   r.Duration.String(), // Convert duration to string
-			RetryCount: r.RetryCount,        // Include retry count
-		}
-	}
+	return ExportResultsWithMetadata(results, specPath, models.NewExportMetadata(nil))
+}
 
-	// Marshal to JSON with indentation
-	jsonData, err := json.MarshalIndent(data, "", "  ")
+// ExportResultsWithMetadata exports test results to a JSON file, recording
+// metadata about the build and run that produced them
+func ExportResultsWithMetadata(results []models.TestResult, specPath string, metadata models.ExportMetadata) (string, error) {
+	jsonData, err := marshalResults(results, specPath, metadata)
 	if err != nil {
-		return "", fmt.Errorf("failed to marshal results: %w", err)
+		return "", err
 	}
 
 	// Generate filename with timestamp
@@ -66,10 +38,28 @@ func ExportResults(results []models.TestResult, specPath string) (string, error)
 
 // ExportResultsToFile exports results with a custom filename
 func ExportResultsToFile(results []models.TestResult, specPath, filename string) error {
+	jsonData, err := marshalResults(results, specPath, models.NewExportMetadata(nil))
+	if err != nil {
+		return err
+	}
+
+	// Write to file
+	if err := os.WriteFile(filename, jsonData, 0644); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+
+	return nil
+}
+
+// marshalResults builds the JSON export document for results
+func marshalResults(results []models.TestResult, specPath string, metadata models.ExportMetadata) ([]byte, error) {
 	// Calculate statistics
 	passed := 0
 	failed := 0
 	for _, r := range results {
+		if r.Skipped() {
+			continue
+		}
 		if r.Status != "ERR" && !strings.Contains(r.Message, "failed") {
 			passed++
 		} else {
@@ -85,33 +75,37 @@ func ExportResultsToFile(results []models.TestResult, specPath, filename string)
 		TotalTests: len(results),
 		Passed:     passed,
 		Failed:     failed,
-		Results:    make([]models.ExportResult, len(results)),
+		Metadata:   metadata,
+		Results:    []models.ExportResult{},
 	}
 
 	// Convert test results to export format
-	for i, r := range results {
-		data.Results[i] = models.ExportResult{
-			Method:     r.Method,
-			Endpoint:   r.Endpoint,
-			Status:     r.Status,
-			Message:    r.Message,
-			Duration:   r.Duration.String(), // Convert duration to string
-			RetryCount: r.RetryCount,        // Include retry count
-		}
+	for _, r := range listedResults(results, metadata) {
+		data.Results = append(data.Results, toExportResult(r))
 	}
 
 	// Marshal to JSON with indentation
 	jsonData, err := json.MarshalIndent(data, "", "  ")
 	if err != nil {
-		return fmt.Errorf("failed to marshal results: %w", err)
+		return nil, fmt.Errorf("failed to marshal results: %w", err)
 	}
+	return jsonData, nil
+}
 
-	// Write to file
-	if err := os.WriteFile(filename, jsonData, 0644); err != nil {
-		return fmt.Errorf("failed to write file: %w", err)
+// listedResults returns the results an export lists: only the failing ones
+// when metadata.FailuresOnly is set, otherwise all of them. Totals are
+// always computed from every result
+func listedResults(results []models.TestResult, metadata models.ExportMetadata) []models.TestResult {
+	if !metadata.FailuresOnly {
+		return results
 	}
-
-	return nil
+	var failing []models.TestResult
+	for _, r := range results {
+		if r.Failed() {
+			failing = append(failing, r)
+		}
+	}
+	return failing
 }
 
 // FormatExportSummary creates a human-readable summary of export
@@ -121,3 +115,39 @@ func FormatExportSummary(filename string, resultCount int) string {
 	summary.WriteString(fmt.Sprintf("  Timestamp: %s\n", time.Now().Format("2006-01-02 15:04:05")))
 	return summary.String()
 }
+
+// AbsolutePath resolves an exported filename against the working directory,
+// so the full location can be shown or copied
+func AbsolutePath(filename string) (string, error) {
+	if filename == "" {
+		return "", fmt.Errorf("no export file")
+	}
+	return filepath.Abs(filename)
+}
+
+// toExportResult converts a test result to its export format. The resolved
+// request URL is always included so a run can be reproduced; the request
+// body only when verbose mode captured it
+func toExportResult(r models.TestResult) models.ExportResult {
+	exported := models.ExportResult{
+		Method:     r.Method,
+		Endpoint:   r.Endpoint,
+		Status:     r.Status,
+		Message:    r.Message,
+		Duration:   r.Duration.String(), // Convert duration to string
+		RetryCount: r.RetryCount,        // Include retry count
+		Passed:     r.Passed(),
+		Case:       r.Case,
+		Example:    r.Example,
+		EnumValues: r.EnumValues,
+		RequestURL: redactURL(r.RequestURL),
+		Auth:       r.Auth,
+	}
+	if r.LogEntry != nil {
+		if exported.RequestURL == "" {
+			exported.RequestURL = redactURL(r.LogEntry.RequestURL)
+		}
+		exported.RequestBody = r.LogEntry.RequestBody
+	}
+	return exported
+}
@@ -3,6 +3,7 @@ package export
 import (
 	"encoding/json"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -267,6 +268,43 @@ func TestExportResultsToFile(t *testing.T) {
 	}
 }
 
+// TestExportResultsToFile_RequestDetails tests the resolved URL is always
+// exported and the body only with verbose data
+func TestExportResultsToFile_RequestDetails(t *testing.T) {
+	results := []models.TestResult{
+		{Method: "GET", Endpoint: "/users/{id}", Status: "200", Message: "OK",
+			RequestURL: "https://api.example.com/users/1?api_key=s3cret&limit=10"},
+		{Method: "POST", Endpoint: "/users", Status: "201", Message: "OK",
+			RequestURL: "https://api.example.com/users",
+			LogEntry:   &models.LogEntry{RequestURL: "https://api.example.com/users", RequestBody: `{"name":"sample"}`}},
+	}
+
+	filename := filepath.Join(t.TempDir(), "results.json")
+	if err := ExportResultsToFile(results, "/spec.yaml", filename); err != nil {
+		t.Fatalf("ExportResultsToFile failed: %v", err)
+	}
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatalf("Failed to read exported file: %v", err)
+	}
+
+	var exportData models.ExportData
+	if err := json.Unmarshal(data, &exportData); err != nil {
+		t.Fatalf("Failed to unmarshal exported data: %v", err)
+	}
+
+	get, post := exportData.Results[0], exportData.Results[1]
+	if get.RequestURL != "https://api.example.com/users/1?api_key=%5BREDACTED%5D&limit=10" {
+		t.Errorf("Expected the resolved URL with secrets redacted, got %q", get.RequestURL)
+	}
+	if get.RequestBody != "" {
+		t.Errorf("Expected no body without verbose data, got %q", get.RequestBody)
+	}
+	if post.RequestURL != "https://api.example.com/users" || post.RequestBody != `{"name":"sample"}` {
+		t.Errorf("Expected the URL and body with verbose data, got %q %q", post.RequestURL, post.RequestBody)
+	}
+}
+
 // TestExportResultsToFile_InvalidPath tests export to invalid path
 func TestExportResultsToFile_InvalidPath(t *testing.T) {
 	results := []models.TestResult{
@@ -476,3 +514,112 @@ func TestExportResults_JSONFormat(t *testing.T) {
 		t.Error("Expected JSON to be formatted with indentation")
 	}
 }
+
+// TestAbsolutePath tests resolving an exported filename to an absolute path
+// TestExportResultsWithMetadata verifies the build and run metadata are
+// written to the JSON export
+func TestExportResultsWithMetadata(t *testing.T) {
+	originalVersion, originalCommit := models.Version, models.Commit
+	defer func() { models.Version, models.Commit = originalVersion, originalCommit }()
+	models.Version, models.Commit = "v1.2.0", "abc1234"
+
+	results := []models.TestResult{{Method: "GET", Endpoint: "/users", Status: "200", Message: "OK"}}
+	metadata := models.NewExportMetadata(map[string]string{"build": "ci-42"})
+	filename, err := ExportResultsWithMetadata(results, "spec.yaml", metadata)
+	if err != nil {
+		t.Fatalf("ExportResultsWithMetadata failed: %v", err)
+	}
+	defer os.Remove(filename)
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatalf("Failed to read exported file: %v", err)
+	}
+	var exportData models.ExportData
+	if err := json.Unmarshal(data, &exportData); err != nil {
+		t.Fatalf("Failed to unmarshal exported data: %v", err)
+	}
+
+	got := exportData.Metadata
+	if got.ToolVersion != "v1.2.0" || got.Commit != "abc1234" || got.Run["build"] != "ci-42" {
+		t.Errorf("Expected the build and run metadata, got %+v", got)
+	}
+	if _, err := time.Parse(time.RFC3339, got.GeneratedAt); err != nil {
+		t.Errorf("Expected an RFC 3339 generation time, got %q", got.GeneratedAt)
+	}
+}
+
+func TestMarshalResults_FailuresOnly(t *testing.T) {
+	results := []models.TestResult{
+		{Method: "GET", Endpoint: "/users", Status: "200", Message: "OK"},
+		{Method: "POST", Endpoint: "/users", Status: "500", Message: "Server error - request failed"},
+		{Method: "GET", Endpoint: "/orders", Status: "ERR", Message: "Connection refused"},
+		{Method: "GET", Endpoint: "/health", Status: "204", Message: "OK"},
+	}
+	metadata := models.NewExportMetadata(nil)
+	metadata.FailuresOnly = true
+
+	data, err := marshalResults(results, "spec.yaml", metadata)
+	if err != nil {
+		t.Fatalf("marshalResults failed: %v", err)
+	}
+	var exportData models.ExportData
+	if err := json.Unmarshal(data, &exportData); err != nil {
+		t.Fatalf("Failed to unmarshal exported data: %v", err)
+	}
+
+	if exportData.TotalTests != 4 || exportData.Passed != 2 || exportData.Failed != 2 {
+		t.Errorf("Expected totals for the whole run (4/2/2), got %d/%d/%d", exportData.TotalTests, exportData.Passed, exportData.Failed)
+	}
+	if len(exportData.Results) != 2 {
+		t.Fatalf("Expected only the 2 failing results, got %d", len(exportData.Results))
+	}
+	for _, r := range exportData.Results {
+		if r.Status == "200" || r.Status == "204" {
+			t.Errorf("Expected no passing results, got %s %s %s", r.Method, r.Endpoint, r.Status)
+		}
+	}
+	if !exportData.Metadata.FailuresOnly {
+		t.Error("Expected the metadata to record that only failures are listed")
+	}
+
+	filename, err := ExportResultsToJUnitWithMetadata(results, "spec.yaml", "http://localhost", metadata)
+	if err != nil {
+		t.Fatalf("ExportResultsToJUnitWithMetadata failed: %v", err)
+	}
+	defer os.Remove(filename)
+	junit, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatalf("Failed to read exported file: %v", err)
+	}
+	if strings.Count(string(junit), "<testcase ") != 2 || !strings.Contains(string(junit), `tests="4"`) {
+		t.Errorf("Expected 2 test cases out of 4 tests in JUnit, got:\n%s", junit)
+	}
+}
+
+func TestAbsolutePath(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Getwd failed: %v", err)
+	}
+
+	path, err := AbsolutePath("openapi-test-results_20240101_120000.json")
+	if err != nil {
+		t.Fatalf("AbsolutePath failed: %v", err)
+	}
+	if !filepath.IsAbs(path) {
+		t.Errorf("Expected an absolute path, got %s", path)
+	}
+	if want := filepath.Join(wd, "openapi-test-results_20240101_120000.json"); path != want {
+		t.Errorf("Expected %s, got %s", want, path)
+	}
+
+	// Absolute paths are returned cleaned but otherwise unchanged
+	if path, _ := AbsolutePath("/tmp/reports/../report.html"); path != "/tmp/report.html" {
+		t.Errorf("Expected /tmp/report.html, got %s", path)
+	}
+
+	if _, err := AbsolutePath(""); err == nil {
+		t.Error("Expected an error for an empty filename")
+	}
+}
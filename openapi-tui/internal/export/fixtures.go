@@ -0,0 +1,74 @@
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+
+	"github.com/Traves-Theberge/OpenAPI-Toolkit/openapi-tui/internal/models"
+	apitesting "github.com/Traves-Theberge/OpenAPI-Toolkit/openapi-tui/internal/testing"
+)
+
+// ExportGeneratedBodies writes the sample request body generated for every
+// operation with a request body to a JSON fixtures file, keyed "METHOD path":
+// { "POST /users": {...} }. Nothing is sent.
+// Bodies that are not JSON (e.g. multipart) are stored as strings.
+// Returns the filename and any error
+func ExportGeneratedBodies(specPath string) (string, error) {
+	loader := &openapi3.Loader{IsExternalRefsAllowed: true}
+	doc, err := loader.LoadFromFile(specPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to load spec: %w", err)
+	}
+
+	fixtures, err := generatedBodies(doc)
+	if err != nil {
+		return "", err
+	}
+
+	// Marshal to JSON with indentation (map keys are sorted)
+	jsonData, err := json.MarshalIndent(fixtures, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal fixtures: %w", err)
+	}
+
+	// Generate filename with timestamp
+	timestamp := time.Now().Format("20060102_150405")
+	filename := fmt.Sprintf("openapi-fixtures_%s.json", timestamp)
+
+	// Write to file
+	if err := os.WriteFile(filename, jsonData, 0644); err != nil {
+		return "", fmt.Errorf("failed to write file: %w", err)
+	}
+
+	return filename, nil
+}
+
+// generatedBodies collects the generated body of each body-bearing operation
+func generatedBodies(doc *openapi3.T) (map[string]interface{}, error) {
+	fixtures := make(map[string]interface{})
+	if doc.Paths == nil {
+		return fixtures, nil
+	}
+
+	for path, pathItem := range doc.Paths.Map() {
+		for method, operation := range pathItem.Operations() {
+			body, err := apitesting.GenerateRequestBody(operation)
+			if err != nil {
+				return nil, fmt.Errorf("failed to generate body for %s: %w", models.EndpointKey(method, path), err)
+			}
+			if body == nil {
+				continue
+			}
+			if json.Valid(body) {
+				fixtures[models.EndpointKey(method, path)] = json.RawMessage(body)
+			} else {
+				fixtures[models.EndpointKey(method, path)] = string(body)
+			}
+		}
+	}
+	return fixtures, nil
+}
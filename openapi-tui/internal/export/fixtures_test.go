@@ -0,0 +1,90 @@
+package export
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const fixturesSpec = `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /users:
+    get:
+      responses:
+        '200':
+          description: OK
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                name:
+                  type: string
+                  example: Ada
+      responses:
+        '201':
+          description: Created
+  /users/{id}:
+    patch:
+      requestBody:
+        content:
+          application/merge-patch+json:
+            schema:
+              type: object
+              properties:
+                age:
+                  type: integer
+                  example: 36
+      responses:
+        '200':
+          description: OK
+    delete:
+      responses:
+        '204':
+          description: Deleted
+`
+
+func TestExportGeneratedBodies(t *testing.T) {
+	specPath := filepath.Join(t.TempDir(), "spec.yaml")
+	if err := os.WriteFile(specPath, []byte(fixturesSpec), 0644); err != nil {
+		t.Fatalf("Failed to write spec: %v", err)
+	}
+
+	filename, err := ExportGeneratedBodies(specPath)
+	if err != nil {
+		t.Fatalf("ExportGeneratedBodies() error = %v", err)
+	}
+	defer os.Remove(filename)
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatalf("Failed to read fixtures file: %v", err)
+	}
+	var fixtures map[string]map[string]interface{}
+	if err := json.Unmarshal(data, &fixtures); err != nil {
+		t.Fatalf("Fixtures file is not a JSON object of bodies: %v", err)
+	}
+
+	// One entry per body-bearing operation, none for GET or DELETE
+	if len(fixtures) != 2 {
+		t.Fatalf("Expected 2 fixtures, got %d: %v", len(fixtures), fixtures)
+	}
+	if fixtures["POST /users"]["name"] != "Ada" {
+		t.Errorf("Expected POST /users body with name Ada, got %v", fixtures["POST /users"])
+	}
+	if fixtures["PATCH /users/{id}"]["age"] != float64(36) {
+		t.Errorf("Expected PATCH /users/{id} body with age 36, got %v", fixtures["PATCH /users/{id}"])
+	}
+}
+
+func TestExportGeneratedBodies_MissingSpec(t *testing.T) {
+	if _, err := ExportGeneratedBodies(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Error("Expected an error for a missing spec")
+	}
+}
@@ -23,6 +23,12 @@ type HTMLTemplateData struct {
 	HasVerbose  bool
 	TotalTime   string
 	AverageTime string
+
+	ContentTypeMismatches []models.ContentTypeMismatch // Endpoints returning undeclared content types
+	RequestSize           models.SizeStats             // Request body sizes (Count 0 when none were captured)
+	ResponseSize          models.SizeStats             // Response body sizes (Count 0 when none were captured)
+	Metadata              models.ExportMetadata        // Tool build and run values behind the report
+	FailureCategories     []models.FailureCategory     // Failures by cause, most frequent first
 }
 
 // HTMLResult represents a test result with additional display fields
@@ -33,6 +39,7 @@ type HTMLResult struct {
 	Message      string
 	Duration     string
 	RetryCount   int    // Number of retries performed
+	Auth         string // Auth applied to the request ("none" when sent without credentials)
 	RowClass     string // CSS class for row styling (success/failure)
 	HasLog       bool
 	RequestURL   string
@@ -195,6 +202,10 @@ const htmlTemplate = `<!DOCTYPE html>
         .results-table tbody tr.failure:hover {
             background: #ffebee;
         }
+        .results-table tbody tr.skipped {
+            background: #f5f5f5;
+            color: #888;
+        }
         .method {
             display: inline-block;
             padding: 4px 10px;
@@ -256,6 +267,30 @@ const htmlTemplate = `<!DOCTYPE html>
             color: #495057;
             font-size: 0.9rem;
         }
+        .results-table tbody tr.details-row td {
+            padding: 0 15px 12px;
+            background: #fafbfc;
+        }
+        .details-row summary {
+            cursor: pointer;
+            color: #667eea;
+            font-size: 0.85rem;
+            padding: 6px 0;
+        }
+        .details-row h4 {
+            margin: 10px 0 4px;
+            font-size: 0.85rem;
+            color: #495057;
+        }
+        .details-row pre {
+            background: #f1f3f5;
+            padding: 10px;
+            border-radius: 4px;
+            font-family: 'Courier New', monospace;
+            font-size: 0.85rem;
+            white-space: pre-wrap;
+            word-break: break-all;
+        }
         .footer {
             text-align: center;
             padding: 30px;
@@ -332,10 +367,22 @@ const htmlTemplate = `<!DOCTYPE html>
                 <span class="meta-value">{{.BaseURL}}</span>
             </div>
             {{end}}
+            {{with .Metadata}}
+            <div class="meta-row">
+                <span class="meta-label">Tool Version:</span>
+                <span class="meta-value">{{.ToolVersion}}{{if .Commit}} ({{.Commit}}){{end}}</span>
+            </div>
+            {{range $key, $value := .Run}}
+            <div class="meta-row">
+                <span class="meta-label">{{$key}}:</span>
+                <span class="meta-value">{{$value}}</span>
+            </div>
+            {{end}}
+            {{end}}
         </div>
         
         <div class="results">
-            <h2>📊 Test Results</h2>
+            <h2>📊 Test Results{{if .Metadata.FailuresOnly}} (failures only){{end}}</h2>
             <table class="results-table">
                 <thead>
                     <tr>
@@ -345,6 +392,7 @@ const htmlTemplate = `<!DOCTYPE html>
                         <th>Message</th>
                         <th>Duration</th>
                         <th>Retries</th>
+                        <th>Auth</th>
                     </tr>
                 </thead>
                 <tbody>
@@ -356,11 +404,112 @@ const htmlTemplate = `<!DOCTYPE html>
                         <td><span class="message">{{.Message}}</span></td>
                         <td><span class="duration">{{.Duration}}</span></td>
                         <td><span class="retry-count">{{.RetryCount}}</span></td>
+                        <td><span class="auth">{{.Auth}}</span></td>
+                    </tr>
+                    {{if .HasLog}}
+                    <tr class="details-row">
+                        <td colspan="7">
+                            <details>
+                                <summary>Request &amp; response{{if .Timestamp}} ({{.Timestamp}}){{end}}</summary>
+                                <h4>Request URL</h4>
+                                <pre>{{.RequestURL}}</pre>
+                                {{if .RequestBody}}
+                                <h4>Request Body</h4>
+                                <pre>{{.RequestBody}}</pre>
+                                {{end}}
+                                <h4>Response Body</h4>
+                                <pre>{{if .ResponseBody}}{{.ResponseBody}}{{else}}(empty){{end}}</pre>
+                            </details>
+                        </td>
                     </tr>
                     {{end}}
+                    {{end}}
                 </tbody>
             </table>
         </div>
+        {{if or .RequestSize.Count .ResponseSize.Count}}
+        <div class="results">
+            <h2>📏 Body Sizes</h2>
+            <table class="results-table">
+                <thead>
+                    <tr>
+                        <th>Body</th>
+                        <th>Count</th>
+                        <th>Min</th>
+                        <th>Average</th>
+                        <th>Max</th>
+                        <th>Total</th>
+                    </tr>
+                </thead>
+                <tbody>
+                    {{if .RequestSize.Count}}
+                    <tr>
+                        <td>Requests</td>
+                        <td>{{.RequestSize.Count}}</td>
+                        <td>{{bytes .RequestSize.Min}}</td>
+                        <td>{{bytes .RequestSize.Average}}</td>
+                        <td>{{bytes .RequestSize.Max}}</td>
+                        <td>{{bytes .RequestSize.Total}}</td>
+                    </tr>
+                    {{end}}
+                    {{if .ResponseSize.Count}}
+                    <tr>
+                        <td>Responses</td>
+                        <td>{{.ResponseSize.Count}}</td>
+                        <td>{{bytes .ResponseSize.Min}}</td>
+                        <td>{{bytes .ResponseSize.Average}}</td>
+                        <td>{{bytes .ResponseSize.Max}}</td>
+                        <td>{{bytes .ResponseSize.Total}}</td>
+                    </tr>
+                    {{end}}
+                </tbody>
+            </table>
+        </div>
+        {{end}}
+        {{if .FailureCategories}}
+        <div class="results">
+            <h2>🧭 Failures by Category</h2>
+            <table class="results-table">
+                <thead>
+                    <tr>
+                        <th>Category</th>
+                        <th>Failures</th>
+                    </tr>
+                </thead>
+                <tbody>
+                    {{range .FailureCategories}}
+                    <tr class="failure">
+                        <td>{{.Name}}</td>
+                        <td>{{.Count}}</td>
+                    </tr>
+                    {{end}}
+                </tbody>
+            </table>
+        </div>
+        {{end}}
+        {{if .ContentTypeMismatches}}
+        <div class="results">
+            <h2>⚠️ Content-Type Mismatches</h2>
+            <table class="results-table">
+                <thead>
+                    <tr>
+                        <th>Endpoint</th>
+                        <th>Undeclared Content-Type</th>
+                        <th>Responses</th>
+                    </tr>
+                </thead>
+                <tbody>
+                    {{range .ContentTypeMismatches}}
+                    <tr class="failure">
+                        <td><span class="endpoint">{{.Endpoint}}</span></td>
+                        <td><span class="message">{{join .ContentTypes ", "}}</span></td>
+                        <td>{{.Count}}/{{.Total}}</td>
+                    </tr>
+                    {{end}}
+                </tbody>
+            </table>
+        </div>
+        {{end}}
         
         <div class="footer">
             <p>Generated by <a href="https://github.com/Traves-Theberge/OpenAPI-Toolkit" target="_blank">OpenAPI TUI</a></p>
@@ -373,6 +522,17 @@ const htmlTemplate = `<!DOCTYPE html>
 // ExportResultsToHTML exports test results to a formatted HTML file
 // Returns the filename and any error
 func ExportResultsToHTML(results []models.TestResult, specPath, baseURL string) (string, error) {
+	return ExportResultsToHTMLWithUnit(results, specPath, baseURL, models.DurationUnitAuto)
+}
+
+// ExportResultsToHTMLWithUnit exports test results to HTML, formatting durations in the given unit
+func ExportResultsToHTMLWithUnit(results []models.TestResult, specPath, baseURL, durationUnit string) (string, error) {
+	return ExportResultsToHTMLWithMetadata(results, specPath, baseURL, durationUnit, models.NewExportMetadata(nil))
+}
+
+// ExportResultsToHTMLWithMetadata exports test results to HTML, formatting
+// durations in the given unit and listing the build and run metadata
+func ExportResultsToHTMLWithMetadata(results []models.TestResult, specPath, baseURL, durationUnit string, metadata models.ExportMetadata) (string, error) {
 	// Calculate statistics
 	passed := 0
 	failed := 0
@@ -380,10 +540,10 @@ func ExportResultsToHTML(results []models.TestResult, specPath, baseURL string)
 
 	for _, r := range results {
 		totalDuration += r.Duration
-		// Consider 2xx status codes as passed
-		if strings.HasPrefix(r.Status, "2") {
+		// Consider 2xx status codes as passed (4xx for invalid contract input)
+		if r.Passed() {
 			passed++
-		} else {
+		} else if r.Failed() {
 			failed++
 		}
 	}
@@ -395,18 +555,22 @@ func ExportResultsToHTML(results []models.TestResult, specPath, baseURL string)
 	}
 
 	// Format timing statistics
-	totalTime := formatDuration(totalDuration)
+	totalTime := models.FormatDurationUnit(totalDuration, durationUnit, formatDuration)
 	averageTime := ""
 	if len(results) > 0 {
-		averageTime = formatDuration(totalDuration / time.Duration(len(results)))
+		averageTime = models.FormatDurationUnit(totalDuration/time.Duration(len(results)), durationUnit, formatDuration)
 	}
 
 	// Convert results to HTML format
-	htmlResults := make([]HTMLResult, len(results))
-	for i, r := range results {
+	listed := listedResults(results, metadata)
+	htmlResults := make([]HTMLResult, len(listed))
+	hasVerbose := false
+	for i, r := range listed {
 		rowClass := "success"
-		if !strings.HasPrefix(r.Status, "2") {
+		if r.Failed() {
 			rowClass = "failure"
+		} else if r.Skipped() {
+			rowClass = "skipped"
 		}
 
 		htmlResults[i] = HTMLResult{
@@ -414,13 +578,15 @@ func ExportResultsToHTML(results []models.TestResult, specPath, baseURL string)
 			Endpoint:   r.Endpoint,
 			Status:     r.Status,
 			Message:    r.Message,
-			Duration:   formatDuration(r.Duration),
+			Duration:   models.FormatDurationUnit(r.Duration, durationUnit, formatDuration),
 			RetryCount: r.RetryCount,
+			Auth:       r.Auth,
 			RowClass:   rowClass,
 		}
 
 		// Add verbose log data if available
 		if r.LogEntry != nil {
+			hasVerbose = true
 			htmlResults[i].HasLog = true
 			htmlResults[i].RequestURL = r.LogEntry.RequestURL
 			htmlResults[i].RequestBody = r.LogEntry.RequestBody
@@ -439,14 +605,22 @@ func ExportResultsToHTML(results []models.TestResult, specPath, baseURL string)
 		Failed:      failed,
 		PassRate:    passRate,
 		Results:     htmlResults,
-		HasVerbose:  false, // Can be enhanced later
+		HasVerbose:  hasVerbose,
 		TotalTime:   totalTime,
 		AverageTime: averageTime,
+
+		ContentTypeMismatches: models.DetectContentTypeMismatches(results),
+		Metadata:              metadata,
+		FailureCategories:     models.SortFailureCategories(models.CategorizeFailures(results)),
 	}
 
+	data.RequestSize, data.ResponseSize = models.SummarizeSizes(results)
+
 	// Parse and execute template
 	funcMap := template.FuncMap{
 		"lower": strings.ToLower,
+		"join":  strings.Join,
+		"bytes": models.FormatBytes,
 	}
 
 	tmpl, err := template.New("report").Funcs(funcMap).Parse(htmlTemplate)
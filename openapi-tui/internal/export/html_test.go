@@ -355,3 +355,141 @@ func TestHTMLTemplateData(t *testing.T) {
 		t.Error("HTML should contain failure row class")
 	}
 }
+
+func TestExportResultsToHTMLWithUnit(t *testing.T) {
+	results := []models.TestResult{
+		{Method: "GET", Endpoint: "/users", Status: "200", Message: "OK", Duration: 2500 * time.Millisecond},
+	}
+
+	tests := []struct {
+		unit string
+		want string
+	}{
+		{unit: "auto", want: "2.50s"},
+		{unit: "ms", want: "2500ms"},
+		{unit: "s", want: "2.500s"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.unit, func(t *testing.T) {
+			filename, err := ExportResultsToHTMLWithUnit(results, "spec.yaml", "http://localhost", tt.unit)
+			if err != nil {
+				t.Fatalf("ExportResultsToHTMLWithUnit() error = %v", err)
+			}
+			defer os.Remove(filename)
+
+			content, err := os.ReadFile(filename)
+			if err != nil {
+				t.Fatalf("Failed to read exported HTML file: %v", err)
+			}
+			if !strings.Contains(string(content), tt.want) {
+				t.Errorf("Expected HTML to contain duration %q", tt.want)
+			}
+		})
+	}
+}
+
+func TestExportResultsToHTML_ContentTypeMismatches(t *testing.T) {
+	results := []models.TestResult{
+		{Method: "GET", Endpoint: "/users", Status: "200", Message: "content-type 'text/plain' not defined in spec", UndeclaredContentType: "text/plain"},
+		{Method: "GET", Endpoint: "/health", Status: "200", Message: "OK (validated)"},
+	}
+
+	filename, err := ExportResultsToHTML(results, "spec.yaml", "http://localhost")
+	if err != nil {
+		t.Fatalf("ExportResultsToHTML() error = %v", err)
+	}
+	defer os.Remove(filename)
+
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatalf("Failed to read exported HTML file: %v", err)
+	}
+	html := string(content)
+	for _, want := range []string{"Content-Type Mismatches", "GET /users", "text/plain", "1/1"} {
+		if !strings.Contains(html, want) {
+			t.Errorf("Expected HTML to contain %q", want)
+		}
+	}
+
+	// Without mismatches the section is left out
+	filename, err = ExportResultsToHTML(results[1:], "spec.yaml", "http://localhost")
+	if err != nil {
+		t.Fatalf("ExportResultsToHTML() error = %v", err)
+	}
+	defer os.Remove(filename)
+	content, _ = os.ReadFile(filename)
+	if strings.Contains(string(content), "Content-Type Mismatches") {
+		t.Error("Expected no mismatch section without mismatches")
+	}
+}
+
+func TestExportResultsToHTML_Sizes(t *testing.T) {
+	results := []models.TestResult{
+		{Method: "POST", Endpoint: "/users", Status: "201", Message: "OK", RequestBytes: 100, ResponseBytes: 2048},
+		{Method: "GET", Endpoint: "/users", Status: "200", Message: "OK", ResponseBytes: 1024},
+	}
+
+	filename, err := ExportResultsToHTML(results, "spec.yaml", "http://localhost")
+	if err != nil {
+		t.Fatalf("ExportResultsToHTML() error = %v", err)
+	}
+	defer os.Remove(filename)
+
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatalf("Failed to read exported HTML file: %v", err)
+	}
+	html := string(content)
+	for _, want := range []string{"Body Sizes", "<td>100 B</td>", "<td>1.5 KB</td>", "<td>3.0 KB</td>"} {
+		if !strings.Contains(html, want) {
+			t.Errorf("Expected HTML to contain %q", want)
+		}
+	}
+}
+
+func TestExportResultsToHTML_VerboseDetails(t *testing.T) {
+	results := []models.TestResult{
+		{
+			Method:   "POST",
+			Endpoint: "/users",
+			Status:   "201",
+			Message:  "Created",
+			LogEntry: &models.LogEntry{
+				RequestURL:   "https://api.example.com/users",
+				RequestBody:  `{"name":"<b>Jo</b>"}`,
+				ResponseBody: `{"id":1}`,
+				Timestamp:    time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+			},
+		},
+		{Method: "GET", Endpoint: "/health", Status: "200", Message: "OK"},
+	}
+
+	filename, err := ExportResultsToHTML(results, "spec.yaml", "https://api.example.com")
+	if err != nil {
+		t.Fatalf("ExportResultsToHTML() error = %v", err)
+	}
+	defer os.Remove(filename)
+
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatalf("Failed to read exported HTML file: %v", err)
+	}
+	html := string(content)
+	for _, want := range []string{
+		`<tr class="details-row">`,
+		"<details>",
+		"Request &amp; response (03:04:05)",
+		"<pre>https://api.example.com/users</pre>",
+		"&lt;b&gt;Jo&lt;/b&gt;",
+		"{&#34;id&#34;:1}",
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("Expected HTML to contain %q", want)
+		}
+	}
+	// Only the result with captured data gets a details row
+	if count := strings.Count(html, `<tr class="details-row">`); count != 1 {
+		t.Errorf("Expected 1 details row, got %d", count)
+	}
+}
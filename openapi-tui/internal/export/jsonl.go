@@ -0,0 +1,46 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/Traves-Theberge/OpenAPI-Toolkit/openapi-tui/internal/models"
+)
+
+// ExportResultsToJSONL exports test results as JSON lines: one compact
+// object per result, with no surrounding document, for piping into tools
+// like jq or loading into analytics. Each line has the fields of a result
+// in the JSON export
+// Returns the filename and any error
+func ExportResultsToJSONL(results []models.TestResult) (string, error) {
+	data, err := marshalResultLines(results)
+	if err != nil {
+		return "", err
+	}
+
+	// Generate filename with timestamp
+	timestamp := time.Now().Format("20060102_150405")
+	filename := fmt.Sprintf("openapi-test-results_%s.jsonl", timestamp)
+
+	// Write to file
+	if err := os.WriteFile(filename, data, 0644); err != nil {
+		return "", fmt.Errorf("failed to write file: %w", err)
+	}
+
+	return filename, nil
+}
+
+// marshalResultLines encodes each result on its own newline-terminated line
+func marshalResultLines(results []models.TestResult) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	for _, r := range results {
+		if err := encoder.Encode(toExportResult(r)); err != nil {
+			return nil, fmt.Errorf("failed to marshal results: %w", err)
+		}
+	}
+	return buf.Bytes(), nil
+}
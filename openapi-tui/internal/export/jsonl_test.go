@@ -0,0 +1,53 @@
+package export
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Traves-Theberge/OpenAPI-Toolkit/openapi-tui/internal/models"
+)
+
+func TestExportResultsToJSONL(t *testing.T) {
+	results := []models.TestResult{
+		{Method: "GET", Endpoint: "/users", Status: "200", Message: "OK", Duration: 100 * time.Millisecond},
+		{Method: "POST", Endpoint: "/users", Status: "500", Message: "failed: line one\nline two", Duration: 2 * time.Second},
+		{Method: "DELETE", Endpoint: "/users/1", Status: "ERR", Message: "connection refused"},
+	}
+
+	filename, err := ExportResultsToJSONL(results)
+	if err != nil {
+		t.Fatalf("ExportResultsToJSONL() error = %v", err)
+	}
+	defer os.Remove(filename)
+	if !strings.HasSuffix(filename, ".jsonl") {
+		t.Errorf("Expected a .jsonl filename, got %s", filename)
+	}
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatalf("Failed to read exported file: %v", err)
+	}
+	lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	if len(lines) != len(results) {
+		t.Fatalf("Expected %d lines, got %d:\n%s", len(results), len(lines), data)
+	}
+	for i, line := range lines {
+		var got models.ExportResult
+		if err := json.Unmarshal([]byte(line), &got); err != nil {
+			t.Fatalf("Line %d is not valid JSON: %v\n%s", i+1, err, line)
+		}
+		want := results[i]
+		if got.Method != want.Method || got.Endpoint != want.Endpoint || got.Status != want.Status || got.Duration != want.Duration.String() {
+			t.Errorf("Line %d: expected %s %s %s %s, got %+v", i+1, want.Method, want.Endpoint, want.Status, want.Duration, got)
+		}
+	}
+
+	// No results give an empty file rather than an empty array
+	empty, err := marshalResultLines(nil)
+	if err != nil || len(empty) != 0 {
+		t.Errorf("Expected no lines for no results, got %q, %v", empty, err)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/xml"
 	"fmt"
 	"os"
+	"sort"
 	"strings"
 	"time"
 
@@ -44,10 +45,16 @@ type JUnitTestCase struct {
 	Time      string         `xml:"time,attr"`
 	Failure   *JUnitFailure  `xml:"failure,omitempty"`
 	Error     *JUnitError    `xml:"error,omitempty"`
+	Skipped   *JUnitSkipped  `xml:"skipped,omitempty"`
 	SystemOut string         `xml:"system-out,omitempty"`
 	SystemErr string         `xml:"system-err,omitempty"`
 }
 
+// JUnitSkipped marks a test case whose request was skipped
+type JUnitSkipped struct {
+	Message string `xml:"message,attr"`
+}
+
 // JUnitFailure represents a test failure
 type JUnitFailure struct {
 	Message string `xml:"message,attr"`
@@ -65,9 +72,16 @@ type JUnitError struct {
 // ExportResultsToJUnit exports test results to JUnit XML format
 // Returns the filename and any error
 func ExportResultsToJUnit(results []models.TestResult, specPath, baseURL string) (string, error) {
+	return ExportResultsToJUnitWithMetadata(results, specPath, baseURL, models.NewExportMetadata(nil))
+}
+
+// ExportResultsToJUnitWithMetadata exports test results to JUnit XML, adding
+// the build and run metadata as suite properties
+func ExportResultsToJUnitWithMetadata(results []models.TestResult, specPath, baseURL string, metadata models.ExportMetadata) (string, error) {
 	// Calculate statistics
 	failures := 0
 	errors := 0
+	skipped := 0
 	var totalDuration time.Duration
 
 	for _, r := range results {
@@ -76,16 +90,20 @@ func ExportResultsToJUnit(results []models.TestResult, specPath, baseURL string)
 		// ERR status is an error, non-2xx is a failure
 		if r.Status == "ERR" {
 			errors++
-		} else if !strings.HasPrefix(r.Status, "2") {
+		} else if r.Skipped() {
+			skipped++
+		} else if !r.Passed() {
 			failures++
 		}
 	}
 
-	// Build test cases
-	testCases := make([]JUnitTestCase, len(results))
-	for i, r := range results {
+	// Build test cases; Tests still counts the whole run when only
+	// failures are listed
+	listed := listedResults(results, metadata)
+	testCases := make([]JUnitTestCase, len(listed))
+	for i, r := range listed {
 		// Create test case name and classname
-		testName := fmt.Sprintf("%s %s", r.Method, r.Endpoint)
+		testName := fmt.Sprintf("%s %s", r.Method, r.LabeledEndpoint())
 		className := sanitizeClassName(baseURL)
 
 		testCase := JUnitTestCase{
@@ -95,19 +113,28 @@ func ExportResultsToJUnit(results []models.TestResult, specPath, baseURL string)
 		}
 
 		// Add failure or error if test didn't pass
-		if r.Status == "ERR" {
+		if r.Skipped() {
+			testCase.Skipped = &JUnitSkipped{Message: r.Message}
+		} else if r.Status == "ERR" {
 			testCase.Error = &JUnitError{
 				Message: r.Message,
 				Type:    "Error",
 				Content: fmt.Sprintf("Test failed with error: %s\nEndpoint: %s\nMethod: %s",
 					r.Message, r.Endpoint, r.Method),
 			}
-		} else if !strings.HasPrefix(r.Status, "2") {
+		} else if !r.Passed() {
+			expected := "2xx"
+			switch {
+			case r.Case == models.CaseInvalid:
+				expected = "4xx"
+			case len(r.ExpectedStatuses) > 0:
+				expected = strings.Trim(fmt.Sprint(r.ExpectedStatuses), "[]")
+			}
 			testCase.Failure = &JUnitFailure{
 				Message: fmt.Sprintf("HTTP %s: %s", r.Status, r.Message),
 				Type:    "AssertionFailure",
-				Content: fmt.Sprintf("Expected 2xx status code, got %s\nEndpoint: %s\nMethod: %s\nMessage: %s",
-					r.Status, r.Endpoint, r.Method, r.Message),
+				Content: fmt.Sprintf("Expected %s status code, got %s\nEndpoint: %s\nMethod: %s\nMessage: %s",
+					expected, r.Status, r.Endpoint, r.Method, r.Message),
 			}
 		}
 
@@ -154,14 +181,14 @@ func ExportResultsToJUnit(results []models.TestResult, specPath, baseURL string)
 		Tests:     len(results),
 		Failures:  failures,
 		Errors:    errors,
-		Skipped:   0,
+		Skipped:   skipped,
 		Time:      formatDurationSeconds(totalDuration),
 		Timestamp: time.Now().Format(time.RFC3339),
-		Properties: []JUnitProperty{
+		Properties: append([]JUnitProperty{
 			{Name: "spec_path", Value: specPath},
 			{Name: "base_url", Value: baseURL},
 			{Name: "test_framework", Value: "openapi-tui"},
-		},
+		}, append(summaryProperties(results), metadataProperties(metadata)...)...),
 		TestCases: testCases,
 	}
 
@@ -191,6 +218,76 @@ func ExportResultsToJUnit(results []models.TestResult, specPath, baseURL string)
 	return filename, nil
 }
 
+// statusClasses are the status class properties always written, so
+// dashboards see a stable set of keys
+var statusClasses = []string{"2xx", "3xx", "4xx", "5xx", "ERR"}
+
+// summaryProperties returns the run totals as suite properties: total, passed,
+// failed (judged like the test cases, so an expected status or a rejected
+// invalid case passes) and skipped, pass rate, and a count per status class
+// of the requests sent ("status_4xx", ...)
+func summaryProperties(results []models.TestResult) []JUnitProperty {
+	counts := make(map[string]int)
+	passed := 0
+	skipped := 0
+	for _, r := range results {
+		if r.Skipped() {
+			skipped++
+			continue
+		}
+		class := "ERR"
+		if len(r.Status) == 3 && r.Status[0] >= '2' && r.Status[0] <= '5' {
+			class = r.Status[:1] + "xx"
+		}
+		counts[class]++
+		if r.Passed() {
+			passed++
+		}
+	}
+
+	passRate := 0.0
+	if len(results) > 0 {
+		passRate = float64(passed) / float64(len(results)) * 100
+	}
+
+	properties := []JUnitProperty{
+		{Name: "total", Value: fmt.Sprintf("%d", len(results))},
+		{Name: "passed", Value: fmt.Sprintf("%d", passed)},
+		{Name: "failed", Value: fmt.Sprintf("%d", len(results)-passed-skipped)},
+		{Name: "skipped", Value: fmt.Sprintf("%d", skipped)},
+		{Name: "pass_rate", Value: fmt.Sprintf("%.1f", passRate)},
+	}
+	for _, class := range statusClasses {
+		properties = append(properties, JUnitProperty{
+			Name:  "status_" + strings.ToLower(class),
+			Value: fmt.Sprintf("%d", counts[class]),
+		})
+	}
+	return properties
+}
+
+// metadataProperties returns the build and run metadata as suite properties:
+// tool_version, commit when known, "run.<key>" for each run value, and
+// failures_only when passing test cases were left out
+func metadataProperties(metadata models.ExportMetadata) []JUnitProperty {
+	properties := []JUnitProperty{{Name: "tool_version", Value: metadata.ToolVersion}}
+	if metadata.Commit != "" {
+		properties = append(properties, JUnitProperty{Name: "commit", Value: metadata.Commit})
+	}
+	keys := make([]string, 0, len(metadata.Run))
+	for key := range metadata.Run {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		properties = append(properties, JUnitProperty{Name: "run." + key, Value: metadata.Run[key]})
+	}
+	if metadata.FailuresOnly {
+		properties = append(properties, JUnitProperty{Name: "failures_only", Value: "true"})
+	}
+	return properties
+}
+
 // sanitizeClassName converts a URL to a valid Java-style class name
 func sanitizeClassName(url string) string {
 	// Remove protocol
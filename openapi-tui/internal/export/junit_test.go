@@ -3,6 +3,7 @@ package export
 import (
 	"encoding/xml"
 	"os"
+	"slices"
 	"strings"
 	"testing"
 	"time"
@@ -455,3 +456,121 @@ func TestJUnitXMLStructure(t *testing.T) {
 		t.Errorf("Expected 0 errors, got %d", suite.Errors)
 	}
 }
+
+func TestExportResultsToJUnit_SummaryProperties(t *testing.T) {
+	results := []models.TestResult{
+		{Method: "GET", Endpoint: "/users?name=<a&b>", Status: "200", Message: "OK", Duration: 1250 * time.Millisecond},
+		{Method: "POST", Endpoint: "/users", Status: "201", Message: "OK", Duration: 40 * time.Millisecond},
+		{Method: "GET", Endpoint: "/missing", Status: "404", Message: "Not Found", Duration: 5 * time.Millisecond},
+		{Method: "DELETE", Endpoint: "/users/1", Status: "ERR", Message: "connection refused"},
+	}
+
+	filename, err := ExportResultsToJUnit(results, "test.yaml", "http://localhost")
+	if err != nil {
+		t.Fatalf("ExportResultsToJUnit() failed: %v", err)
+	}
+	defer os.Remove(filename)
+
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatalf("Failed to read file: %v", err)
+	}
+	var suites JUnitTestSuites
+	if err := xml.Unmarshal(content, &suites); err != nil {
+		t.Fatalf("Expected well-formed XML, got %v", err)
+	}
+	suite := suites.Suites[0]
+
+	properties := make(map[string]string)
+	for _, p := range suite.Properties {
+		properties[p.Name] = p.Value
+	}
+	want := map[string]string{
+		"total":      "4",
+		"passed":     "2",
+		"failed":     "2",
+		"pass_rate":  "50.0",
+		"status_2xx": "2",
+		"status_3xx": "0",
+		"status_4xx": "1",
+		"status_5xx": "0",
+		"status_err": "1",
+	}
+	for name, value := range want {
+		if properties[name] != value {
+			t.Errorf("Expected property %s=%s, got %q", name, value, properties[name])
+		}
+	}
+
+	// Endpoint names survive escaping and each case carries its own time
+	if got := suite.TestCases[0].Name; got != "GET /users?name=<a&b>" {
+		t.Errorf("Expected the endpoint name to round-trip, got %q", got)
+	}
+	if suite.TestCases[0].Time != "1.250" || suite.TestCases[2].Time != "0.005" {
+		t.Errorf("Expected per-case times 1.250 and 0.005, got %s and %s", suite.TestCases[0].Time, suite.TestCases[2].Time)
+	}
+}
+
+func TestSummaryProperties_PassedResults(t *testing.T) {
+	// A rejected invalid case and an expected redirect pass; a 2xx for the
+	// invalid case fails
+	results := []models.TestResult{
+		{Method: "POST", Endpoint: "/users", Status: "201", Case: models.CaseValid},
+		{Method: "POST", Endpoint: "/users", Status: "400", Case: models.CaseInvalid},
+		{Method: "POST", Endpoint: "/orders", Status: "200", Case: models.CaseInvalid},
+		{Method: "GET", Endpoint: "/moved", Status: "301", ExpectedStatuses: []int{301}},
+	}
+	properties := make(map[string]string)
+	for _, p := range summaryProperties(results) {
+		properties[p.Name] = p.Value
+	}
+	if properties["passed"] != "3" || properties["failed"] != "1" || properties["pass_rate"] != "75.0" {
+		t.Errorf("Expected 3 passed, 1 failed and a 75.0 pass rate, got %v", properties)
+	}
+}
+
+func TestSummaryProperties_SkippedResults(t *testing.T) {
+	results := []models.TestResult{
+		{Method: "GET", Endpoint: "/users", Status: "200"},
+		{Method: "GET", Endpoint: "/orders", Status: "ERR", Message: "connection refused"},
+		{Method: "GET", Endpoint: "/items", Status: models.StatusSkipped, Message: "skipped (time budget)"},
+	}
+	properties := make(map[string]string)
+	for _, p := range summaryProperties(results) {
+		properties[p.Name] = p.Value
+	}
+	if properties["passed"] != "1" || properties["failed"] != "1" || properties["skipped"] != "1" || properties["status_err"] != "1" {
+		t.Errorf("Expected the skipped result counted apart from passes, failures and status classes, got %v", properties)
+	}
+}
+
+func TestExportResultsToJUnit_LabeledNames(t *testing.T) {
+	results := []models.TestResult{
+		{Method: "POST", Endpoint: "/users", Status: "201", Example: "admin", Case: models.CaseValid},
+		{Method: "POST", Endpoint: "/users", Status: "201", Example: "member", Case: models.CaseValid},
+		{Method: "POST", Endpoint: "/users", Status: "400", Case: models.CaseInvalid},
+	}
+
+	filename, err := ExportResultsToJUnit(results, "test.yaml", "http://localhost")
+	if err != nil {
+		t.Fatalf("ExportResultsToJUnit() failed: %v", err)
+	}
+	defer os.Remove(filename)
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatalf("Failed to read file: %v", err)
+	}
+	var suites JUnitTestSuites
+	if err := xml.Unmarshal(content, &suites); err != nil {
+		t.Fatalf("Expected well-formed XML, got %v", err)
+	}
+
+	var names []string
+	for _, testCase := range suites.Suites[0].TestCases {
+		names = append(names, testCase.Name)
+	}
+	want := []string{"POST /users [admin, valid]", "POST /users [member, valid]", "POST /users [invalid]"}
+	if !slices.Equal(names, want) {
+		t.Errorf("Expected test cases named by example and case, got %v", names)
+	}
+}
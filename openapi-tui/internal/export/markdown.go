@@ -0,0 +1,216 @@
+package export
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Traves-Theberge/OpenAPI-Toolkit/openapi-tui/internal/models"
+)
+
+// MaxMarkdownPassingRows caps the passing rows in a Markdown report, so a
+// large run still fits in a chat message or gist. Failures are always listed
+const MaxMarkdownPassingRows = 50
+
+// maxMarkdownBody caps each response body quoted in a Markdown report
+const maxMarkdownBody = 2000
+
+// ExportResultsToMarkdown writes a run to a Markdown file, as rendered by
+// FormatResultsMarkdown
+// Returns the filename and any error
+func ExportResultsToMarkdown(results []models.TestResult, specPath, baseURL string) (string, error) {
+	return ExportResultsToMarkdownWithMetadata(results, specPath, baseURL, models.NewExportMetadata(nil))
+}
+
+// ExportResultsToMarkdownWithMetadata writes a run to a Markdown file, as
+// rendered by FormatResultsMarkdownWithMetadata
+func ExportResultsToMarkdownWithMetadata(results []models.TestResult, specPath, baseURL string, metadata models.ExportMetadata) (string, error) {
+	// Generate filename with timestamp
+	timestamp := time.Now().Format("20060102_150405")
+	filename := fmt.Sprintf("openapi-test-results_%s.md", timestamp)
+
+	// Write to file
+	if err := os.WriteFile(filename, []byte(FormatResultsMarkdownWithMetadata(results, specPath, baseURL, metadata)), 0644); err != nil {
+		return "", fmt.Errorf("failed to write file: %w", err)
+	}
+
+	return filename, nil
+}
+
+// FormatResultsMarkdown renders a whole run as one Markdown document for
+// sharing with a team: summary statistics, a table of results and the
+// details of each failure, with secret JSON fields in response bodies
+// redacted. Passing rows beyond MaxMarkdownPassingRows are left out of the
+// table and counted instead
+func FormatResultsMarkdown(results []models.TestResult, specPath, baseURL string) string {
+	return FormatResultsMarkdownWithMetadata(results, specPath, baseURL, models.NewExportMetadata(nil))
+}
+
+// FormatResultsMarkdownWithMetadata renders a run like FormatResultsMarkdown,
+// listing the build and run metadata under the spec and base URL. With
+// metadata.FailuresOnly the table lists only failing results; the summary
+// still covers the whole run
+func FormatResultsMarkdownWithMetadata(results []models.TestResult, specPath, baseURL string, metadata models.ExportMetadata) string {
+	var b strings.Builder
+	var failures []models.TestResult
+	var skipped int
+	var totalDuration time.Duration
+	for _, r := range results {
+		totalDuration += r.Duration
+		if r.Failed() {
+			failures = append(failures, r)
+		} else if r.Skipped() {
+			skipped++
+		}
+	}
+	passed := len(results) - len(failures) - skipped
+
+	b.WriteString("# OpenAPI Test Results\n\n")
+	fmt.Fprintf(&b, "- Spec: `%s`\n", specPath)
+	if baseURL != "" {
+		fmt.Fprintf(&b, "- Base URL: `%s`\n", baseURL)
+	}
+	fmt.Fprintf(&b, "- Tool version: %s", metadata.ToolVersion)
+	if metadata.Commit != "" {
+		fmt.Fprintf(&b, " (%s)", metadata.Commit)
+	}
+	b.WriteString("\n")
+	keys := make([]string, 0, len(metadata.Run))
+	for key := range metadata.Run {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		fmt.Fprintf(&b, "- %s: %s\n", markdownCell(key), markdownCell(metadata.Run[key]))
+	}
+	fmt.Fprintf(&b, "- Generated: %s\n\n", time.Now().Format("2006-01-02 15:04:05"))
+
+	// Summary statistics
+	passRate, average := 0.0, time.Duration(0)
+	if len(results) > 0 {
+		passRate = float64(passed) / float64(len(results)) * 100
+		average = totalDuration / time.Duration(len(results))
+	}
+	b.WriteString("## Summary\n\n")
+	b.WriteString("| Total | Passed | Failed | Pass Rate | Total Time | Average Time |\n")
+	b.WriteString("|---|---|---|---|---|---|\n")
+	fmt.Fprintf(&b, "| %d | %d | %d | %.1f%% | %s | %s |\n\n",
+		len(results), passed, len(failures), passRate, formatDuration(totalDuration), formatDuration(average))
+	if skipped > 0 {
+		fmt.Fprintf(&b, "_%d skipped by a cancelled or timed-out run, counted as neither passed nor failed_\n\n", skipped)
+	}
+
+	if categories := models.SortFailureCategories(models.CategorizeFailures(results)); len(categories) > 0 {
+		b.WriteString("Failures by cause: ")
+		for i, category := range categories {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			fmt.Fprintf(&b, "%s %d", category.Name, category.Count)
+		}
+		b.WriteString("\n\n")
+	}
+
+	// Results, truncating passing rows
+	if metadata.FailuresOnly {
+		b.WriteString("## Results (failures only)\n\n")
+	} else {
+		b.WriteString("## Results\n\n")
+	}
+	b.WriteString("| Result | Method | Endpoint | Status | Duration | Message |\n")
+	b.WriteString("|---|---|---|---|---|---|\n")
+	passingRows := 0
+	for _, r := range listedResults(results, metadata) {
+		outcome := "❌"
+		if r.Skipped() {
+			outcome = "⏭️"
+		} else if r.Passed() {
+			if passingRows == MaxMarkdownPassingRows {
+				continue
+			}
+			passingRows++
+			outcome = "✅"
+		}
+		fmt.Fprintf(&b, "| %s | %s | `%s` | %s | %s | %s |\n",
+			outcome, r.Method, markdownCell(r.Endpoint), r.Status, formatDuration(r.Duration), markdownCell(r.Message))
+	}
+	if omitted := passed - passingRows; omitted > 0 && !metadata.FailuresOnly {
+		fmt.Fprintf(&b, "\n_%d more passing results not shown_\n", omitted)
+	}
+
+	// Failure details
+	if len(failures) > 0 {
+		b.WriteString("\n## Failures\n")
+		for _, r := range failures {
+			fmt.Fprintf(&b, "\n### %s %s\n\n", r.Method, r.Endpoint)
+			fmt.Fprintf(&b, "- Status: %s\n", r.Status)
+			fmt.Fprintf(&b, "- Message: %s\n", r.Message)
+			if r.RequestURL != "" {
+				fmt.Fprintf(&b, "- URL: `%s`\n", redactURL(r.RequestURL))
+			}
+			if r.RetryCount > 0 {
+				fmt.Fprintf(&b, "- Retries: %d\n", r.RetryCount)
+			}
+			for _, hint := range r.Hints {
+				fmt.Fprintf(&b, "- Hint: %s\n", hint)
+			}
+			if r.LogEntry != nil && r.LogEntry.ResponseBody != "" {
+				body := redactBody(r.LogEntry.ResponseBody)
+				if len(body) > maxMarkdownBody {
+					cut := maxMarkdownBody
+					for cut > 0 && !utf8.RuneStart(body[cut]) {
+						cut--
+					}
+					body = body[:cut] + "\n... (truncated)"
+				}
+				fence := codeFence(body)
+				fmt.Fprintf(&b, "\n%s\n%s\n%s\n", fence, body, fence)
+			}
+		}
+	}
+
+	return b.String()
+}
+
+// secretField matches a JSON member with a string or scalar value,
+// `"name": value`, capturing the name, the separator and the value
+var secretField = regexp.MustCompile(`"((?:[^"\\]|\\.)*)"(\s*:\s*)("(?:[^"\\]|\\.)*"|[^\s,{}\[\]]+)`)
+
+// redactBody hides the values of JSON members whose names look like secrets,
+// e.g. an access token in a login response. Matching is textual, so bodies
+// cut short by the log still have their complete members redacted
+func redactBody(body string) string {
+	return secretField.ReplaceAllStringFunc(body, func(member string) string {
+		match := secretField.FindStringSubmatch(member)
+		if !isSensitive(match[1]) {
+			return member
+		}
+		return `"` + match[1] + `"` + match[2] + `"` + redacted + `"`
+	})
+}
+
+// codeFence returns a backtick fence longer than any backtick run in body,
+// so the body can't close its own code block
+func codeFence(body string) string {
+	longest, run := 0, 0
+	for _, c := range body {
+		if c == '`' {
+			run++
+			longest = max(longest, run)
+		} else {
+			run = 0
+		}
+	}
+	return strings.Repeat("`", max(3, longest+1))
+}
+
+// markdownCell keeps a value on one table row and stops its pipes from
+// splitting the cell
+func markdownCell(value string) string {
+	value = strings.ReplaceAll(value, "\n", " ")
+	return strings.ReplaceAll(value, "|", `\|`)
+}
@@ -0,0 +1,136 @@
+package export
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Traves-Theberge/OpenAPI-Toolkit/openapi-tui/internal/models"
+)
+
+func TestFormatResultsMarkdown(t *testing.T) {
+	results := []models.TestResult{
+		{Method: "GET", Endpoint: "/users", Status: "200", Message: "OK (validated)", Duration: 100 * time.Millisecond},
+		{
+			Method: "POST", Endpoint: "/users", Status: "500", Message: "server error | retry later", Duration: 300 * time.Millisecond,
+			RequestURL: "https://api.example.com/users?api_key=secret",
+			Hints:      []string{"unsatisfiable path parameter"},
+			LogEntry:   &models.LogEntry{ResponseBody: `{"error": "boom"}`},
+		},
+		{Method: "DELETE", Endpoint: "/users/1", Status: "ERR", Message: "connection refused"},
+	}
+
+	md := FormatResultsMarkdown(results, "spec.yaml", "https://api.example.com")
+	for _, want := range []string{
+		"# OpenAPI Test Results",
+		"- Spec: `spec.yaml`",
+		"| 3 | 1 | 2 | 33.3% | 400ms | 133ms |",
+		"Failures by cause:",
+		"| ✅ | GET | `/users` | 200 | 100ms | OK (validated) |",
+		`server error \| retry later`,
+		"### POST /users",
+		"- Status: 500",
+		"- Hint: unsatisfiable path parameter",
+		"api_key=%5BREDACTED%5D",
+		`{"error": "boom"}`,
+		"### DELETE /users/1",
+		"- Message: connection refused",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("Expected the report to contain %q, got:\n%s", want, md)
+		}
+	}
+	if strings.Contains(md, "secret") {
+		t.Errorf("Expected the API key to be redacted, got:\n%s", md)
+	}
+}
+
+func TestFormatResultsMarkdownWithMetadata(t *testing.T) {
+	results := []models.TestResult{{Method: "GET", Endpoint: "/users", Status: "200", Message: "OK"}}
+	metadata := models.ExportMetadata{ToolVersion: "v1.2.0", Commit: "abc123", Run: map[string]string{"release": "2025.11", "build": "ci-1842"}}
+
+	md := FormatResultsMarkdownWithMetadata(results, "spec.yaml", "", metadata)
+	want := "- Spec: `spec.yaml`\n- Tool version: v1.2.0 (abc123)\n- build: ci-1842\n- release: 2025.11\n"
+	if !strings.Contains(md, want) {
+		t.Errorf("Expected the metadata under the spec, sorted by key, got:\n%s", md)
+	}
+}
+
+func TestFormatResultsMarkdown_Bodies(t *testing.T) {
+	// Secret fields are redacted, and a body with a backtick fence of its own
+	// can't close the block it is quoted in
+	body := "{\"access_token\": \"s3cr3t\", \"expires\": 3600, \"apiKey\": 987654, \"note\": \"```\"}"
+	results := []models.TestResult{{Method: "POST", Endpoint: "/login", Status: "500", Message: "failed", LogEntry: &models.LogEntry{ResponseBody: body}}}
+	md := FormatResultsMarkdown(results, "spec.yaml", "")
+	if strings.Contains(md, "s3cr3t") || strings.Contains(md, "987654") {
+		t.Errorf("Expected the secret fields to be redacted, got:\n%s", md)
+	}
+	if !strings.Contains(md, `"access_token": "[REDACTED]"`) || !strings.Contains(md, `"expires": 3600`) {
+		t.Errorf("Expected only the secret fields to be redacted, got:\n%s", md)
+	}
+	if !strings.Contains(md, "\n````\n{") || !strings.Contains(md, "}\n````\n") {
+		t.Errorf("Expected a four-backtick fence, got:\n%s", md)
+	}
+
+	// A long body is cut on a character boundary
+	results[0].LogEntry.ResponseBody = "x" + strings.Repeat("é", maxMarkdownBody)
+	md = FormatResultsMarkdown(results, "spec.yaml", "")
+	if !utf8.ValidString(md) || !strings.Contains(md, "... (truncated)") {
+		t.Errorf("Expected a truncated body of whole characters")
+	}
+}
+
+func TestFormatResultsMarkdown_FailuresOnly(t *testing.T) {
+	results := []models.TestResult{
+		{Method: "GET", Endpoint: "/users", Status: "200", Message: "OK"},
+		{Method: "GET", Endpoint: "/orders", Status: "500", Message: "failed"},
+	}
+	metadata := models.NewExportMetadata(nil)
+	metadata.FailuresOnly = true
+
+	md := FormatResultsMarkdownWithMetadata(results, "spec.yaml", "", metadata)
+	if strings.Contains(md, "`/users`") || !strings.Contains(md, "`/orders`") {
+		t.Errorf("Expected only the failing result listed, got:\n%s", md)
+	}
+	// The summary still covers the whole run
+	if !strings.Contains(md, "## Results (failures only)") || !strings.Contains(md, "| 2 | 1 | 1 | 50.0% |") {
+		t.Errorf("Expected a marked table and whole-run totals, got:\n%s", md)
+	}
+	if strings.Contains(md, "more passing results") {
+		t.Errorf("Expected no omitted-rows note, got:\n%s", md)
+	}
+}
+
+func TestFormatResultsMarkdown_TruncatesPassingRows(t *testing.T) {
+	var results []models.TestResult
+	for i := 0; i < MaxMarkdownPassingRows+10; i++ {
+		results = append(results, models.TestResult{Method: "GET", Endpoint: fmt.Sprintf("/items/%d", i), Status: "200", Message: "OK"})
+	}
+	results = append(results, models.TestResult{Method: "GET", Endpoint: "/broken", Status: "500", Message: "failed"})
+
+	md := FormatResultsMarkdown(results, "spec.yaml", "")
+	if rows := strings.Count(md, "| ✅ |"); rows != MaxMarkdownPassingRows {
+		t.Errorf("Expected %d passing rows, got %d", MaxMarkdownPassingRows, rows)
+	}
+	if !strings.Contains(md, "| ❌ | GET | `/broken` |") || !strings.Contains(md, "_10 more passing results not shown_") {
+		t.Errorf("Expected the failure listed and the omitted passing rows counted, got:\n%s", md)
+	}
+}
+
+func TestExportResultsToMarkdown(t *testing.T) {
+	results := []models.TestResult{{Method: "GET", Endpoint: "/users", Status: "200", Message: "OK"}}
+	filename, err := ExportResultsToMarkdown(results, "spec.yaml", "")
+	if err != nil {
+		t.Fatalf("ExportResultsToMarkdown() error = %v", err)
+	}
+	defer os.Remove(filename)
+	if !strings.HasSuffix(filename, ".md") {
+		t.Errorf("Expected a .md filename, got %s", filename)
+	}
+	if data, err := os.ReadFile(filename); err != nil || !strings.Contains(string(data), "`/users`") {
+		t.Errorf("Expected the file to hold the report, got %q (%v)", data, err)
+	}
+}
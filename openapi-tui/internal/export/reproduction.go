@@ -0,0 +1,128 @@
+package export
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Traves-Theberge/OpenAPI-Toolkit/openapi-tui/internal/models"
+)
+
+// redacted replaces secret header and query values in reproductions
+const redacted = "[REDACTED]"
+
+// sensitiveNames are name fragments marking a header or query parameter as a
+// secret, matched case-insensitively
+var sensitiveNames = []string{"auth", "cookie", "token", "secret", "key", "password", "session"}
+
+// ExportReproduction writes a minimal reproduction of a single result to a
+// Markdown file for bug reports: the method, resolved URL, request headers
+// and body, and the actual response. Secret headers and query parameters
+// are redacted.
+// Requires the verbose log data captured when verbose mode is on.
+// Returns the filename and any error
+func ExportReproduction(result models.TestResult) (string, error) {
+	if result.LogEntry == nil {
+		return "", fmt.Errorf("no request details for %s %s: re-run with verbose mode on", result.Method, result.Endpoint)
+	}
+
+	// Generate filename with timestamp
+	timestamp := time.Now().Format("20060102_150405")
+	filename := fmt.Sprintf("openapi-repro_%s_%s.md", strings.ToLower(result.Method), timestamp)
+
+	// Write to file
+	if err := os.WriteFile(filename, []byte(formatReproduction(result)), 0644); err != nil {
+		return "", fmt.Errorf("failed to write file: %w", err)
+	}
+
+	return filename, nil
+}
+
+// formatReproduction renders a result with a log entry as Markdown
+func formatReproduction(result models.TestResult) string {
+	log := result.LogEntry
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# %s %s\n\n", result.Method, result.Endpoint)
+	fmt.Fprintf(&b, "- Status: %s\n", result.Status)
+	fmt.Fprintf(&b, "- Message: %s\n", result.Message)
+	if !log.Timestamp.IsZero() {
+		fmt.Fprintf(&b, "- Sent: %s\n", log.Timestamp.Format(time.RFC3339))
+	}
+	fmt.Fprintf(&b, "- Duration: %s\n\n", result.Duration)
+
+	b.WriteString("## Request\n\n```http\n")
+	fmt.Fprintf(&b, "%s %s\n", result.Method, redactURL(log.RequestURL))
+	writeHeaders(&b, log.RequestHeaders)
+	b.WriteString("```\n\n")
+	writeBody(&b, log.RequestBody)
+
+	b.WriteString("## Response\n\n```http\n")
+	fmt.Fprintf(&b, "HTTP %s\n", result.Status)
+	writeHeaders(&b, log.ResponseHeaders)
+	b.WriteString("```\n\n")
+	writeBody(&b, log.ResponseBody)
+
+	return b.String()
+}
+
+// writeHeaders writes headers sorted by name, redacting secret values
+func writeHeaders(b *strings.Builder, headers map[string]string) {
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		value := headers[name]
+		if isSensitive(name) {
+			value = redacted
+		}
+		fmt.Fprintf(b, "%s: %s\n", name, value)
+	}
+}
+
+// writeBody writes a body as a fenced block, or notes that there was none
+func writeBody(b *strings.Builder, body string) {
+	if body == "" {
+		b.WriteString("_No body_\n\n")
+		return
+	}
+	fmt.Fprintf(b, "```\n%s\n```\n\n", body)
+}
+
+// redactURL hides the values of secret query parameters, e.g. an API key
+// sent in the query string
+func redactURL(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.RawQuery == "" {
+		return rawURL
+	}
+	query := parsed.Query()
+	changed := false
+	for name := range query {
+		if isSensitive(name) {
+			query.Set(name, redacted)
+			changed = true
+		}
+	}
+	if changed {
+		parsed.RawQuery = query.Encode()
+	}
+	return parsed.String()
+}
+
+// isSensitive reports whether a header or query parameter name looks like it
+// carries a secret
+func isSensitive(name string) bool {
+	lower := strings.ToLower(name)
+	for _, fragment := range sensitiveNames {
+		if strings.Contains(lower, fragment) {
+			return true
+		}
+	}
+	return false
+}
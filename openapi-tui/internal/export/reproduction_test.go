@@ -0,0 +1,72 @@
+package export
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Traves-Theberge/OpenAPI-Toolkit/openapi-tui/internal/models"
+)
+
+func TestExportReproduction(t *testing.T) {
+	result := models.TestResult{
+		Method:   "POST",
+		Endpoint: "/users",
+		Status:   "500",
+		Message:  "status 500 not defined in spec",
+		Duration: 120 * time.Millisecond,
+		LogEntry: &models.LogEntry{
+			RequestURL: "https://api.example.com/users?api_key=s3cret&dryRun=true",
+			RequestHeaders: map[string]string{
+				"Content-Type":  "application/json",
+				"Authorization": "Bearer s3cret",
+			},
+			RequestBody:     `{"name":"sample"}`,
+			ResponseHeaders: map[string]string{"Content-Type": "application/json"},
+			ResponseBody:    `{"error":"boom"}`,
+			Timestamp:       time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		},
+	}
+
+	filename, err := ExportReproduction(result)
+	if err != nil {
+		t.Fatalf("ExportReproduction() error = %v", err)
+	}
+	defer os.Remove(filename)
+
+	if !strings.HasPrefix(filename, "openapi-repro_post_") || !strings.HasSuffix(filename, ".md") {
+		t.Errorf("Unexpected filename %q", filename)
+	}
+
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatalf("Failed to read reproduction: %v", err)
+	}
+	repro := string(content)
+
+	for _, want := range []string{
+		"# POST /users",
+		"- Status: 500",
+		"POST https://api.example.com/users?api_key=%5BREDACTED%5D&dryRun=true",
+		"Authorization: [REDACTED]",
+		"Content-Type: application/json",
+		`{"name":"sample"}`,
+		"HTTP 500",
+		`{"error":"boom"}`,
+	} {
+		if !strings.Contains(repro, want) {
+			t.Errorf("Expected reproduction to contain %q, got:\n%s", want, repro)
+		}
+	}
+	if strings.Contains(repro, "s3cret") {
+		t.Errorf("Expected secrets to be redacted, got:\n%s", repro)
+	}
+}
+
+func TestExportReproduction_RequiresVerbose(t *testing.T) {
+	_, err := ExportReproduction(models.TestResult{Method: "GET", Endpoint: "/users", Status: "500"})
+	if err == nil || !strings.Contains(err.Error(), "verbose") {
+		t.Errorf("Expected an error asking for verbose mode, got %v", err)
+	}
+}
@@ -0,0 +1,60 @@
+package models
+
+import (
+	"runtime/debug"
+	"time"
+)
+
+// Build details, injected at build time:
+//
+//	go build -ldflags "-X github.com/Traves-Theberge/OpenAPI-Toolkit/openapi-tui/internal/models.Version=v1.2.0 \
+//	  -X github.com/Traves-Theberge/OpenAPI-Toolkit/openapi-tui/internal/models.Commit=$(git rev-parse --short HEAD)"
+var (
+	Version = "dev"
+	Commit  = ""
+)
+
+// BuildInfo identifies the build of the tool that produced a report
+type BuildInfo struct {
+	Version string
+	Commit  string // Empty when unknown
+}
+
+// CurrentBuild returns the injected build details. Without an injected
+// commit, the VCS revision Go stamps into binaries built from a checkout is
+// used instead
+func CurrentBuild() BuildInfo {
+	info := BuildInfo{Version: Version, Commit: Commit}
+	if info.Commit == "" {
+		if build, ok := debug.ReadBuildInfo(); ok {
+			for _, setting := range build.Settings {
+				if setting.Key == "vcs.revision" {
+					info.Commit = setting.Value
+				}
+			}
+		}
+	}
+	return info
+}
+
+// ExportMetadata identifies the tool build and run behind an export, for
+// tracing a report back to where it came from
+type ExportMetadata struct {
+	ToolVersion  string            `json:"toolVersion"`
+	Commit       string            `json:"commit,omitempty"`       // Commit the tool was built from
+	GeneratedAt  string            `json:"generatedAt"`            // RFC 3339
+	Run          map[string]string `json:"run,omitempty"`          // Config runMetadata, e.g. a CI build number
+	FailuresOnly bool              `json:"failuresOnly,omitempty"` // Only failing results are listed; totals still cover the whole run
+}
+
+// NewExportMetadata returns metadata for an export generated now by the
+// current build, carrying the user-supplied run values
+func NewExportMetadata(run map[string]string) ExportMetadata {
+	build := CurrentBuild()
+	return ExportMetadata{
+		ToolVersion: build.Version,
+		Commit:      build.Commit,
+		GeneratedAt: time.Now().Format(time.RFC3339),
+		Run:         run,
+	}
+}
@@ -0,0 +1,173 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Default bounds for the response cache
+const (
+	DefaultCacheEntries  = 200       // Endpoints kept before the oldest is evicted
+	DefaultCacheBodySize = 64 * 1024 // Bytes of body kept per endpoint
+)
+
+// CachedResponse is the last response captured for an endpoint
+type CachedResponse struct {
+	Status    string    `json:"status"`
+	Body      string    `json:"body"`
+	Truncated bool      `json:"truncated"` // Body was cut to the cache size limit
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// ResponseCache keeps the most recent response per endpoint across runs
+// Bounded by entry count (oldest evicted first) and body size per entry,
+// and persisted next to the run history with SaveResponseCache
+type ResponseCache struct {
+	MaxEntries  int
+	MaxBodySize int
+	entries     map[string]CachedResponse
+	order       []string // Keys from least to most recently stored
+}
+
+// NewResponseCache creates an empty cache with the given bounds
+func NewResponseCache(maxEntries, maxBodySize int) *ResponseCache {
+	return &ResponseCache{
+		MaxEntries:  maxEntries,
+		MaxBodySize: maxBodySize,
+		entries:     make(map[string]CachedResponse),
+	}
+}
+
+// Put stores the response for an endpoint, replacing any earlier one
+func (c *ResponseCache) Put(method, path string, resp CachedResponse) {
+	c.store(EndpointKey(method, path), resp)
+}
+
+// store records a response under a key, applying the body and entry bounds
+func (c *ResponseCache) store(key string, resp CachedResponse) {
+	if c.entries == nil {
+		c.entries = make(map[string]CachedResponse)
+	}
+
+	if c.MaxBodySize > 0 && len(resp.Body) > c.MaxBodySize {
+		resp.Body = resp.Body[:c.MaxBodySize]
+		resp.Truncated = true
+	}
+
+	if _, exists := c.entries[key]; exists {
+		c.remove(key)
+	}
+	c.entries[key] = resp
+	c.order = append(c.order, key)
+
+	// Evict oldest entries over the limit
+	for c.MaxEntries > 0 && len(c.order) > c.MaxEntries {
+		delete(c.entries, c.order[0])
+		c.order = c.order[1:]
+	}
+}
+
+// Get returns the cached response for an endpoint
+func (c *ResponseCache) Get(method, path string) (CachedResponse, bool) {
+	if c == nil {
+		return CachedResponse{}, false
+	}
+	resp, ok := c.entries[EndpointKey(method, path)]
+	return resp, ok
+}
+
+// Len returns the number of cached endpoints
+func (c *ResponseCache) Len() int {
+	if c == nil {
+		return 0
+	}
+	return len(c.entries)
+}
+
+// remove drops a key from the eviction order
+func (c *ResponseCache) remove(key string) {
+	for i, k := range c.order {
+		if k == key {
+			c.order = append(c.order[:i], c.order[i+1:]...)
+			return
+		}
+	}
+}
+
+// cacheRecord is one persisted cache entry
+type cacheRecord struct {
+	Key      string         `json:"key"`
+	Response CachedResponse `json:"response"`
+}
+
+// cacheFile is the on-disk form of the cache, oldest entry first
+type cacheFile struct {
+	Entries []cacheRecord `json:"entries"`
+}
+
+// SaveResponseCache saves the response cache to a file beside the history
+func SaveResponseCache(cache *ResponseCache) error {
+	if cache == nil {
+		return nil
+	}
+
+	// Get config directory
+	configDir, err := getConfigDir()
+	if err != nil {
+		return fmt.Errorf("failed to get config directory: %w", err)
+	}
+
+	// Ensure directory exists
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	file := cacheFile{Entries: make([]cacheRecord, 0, len(cache.order))}
+	for _, key := range cache.order {
+		file.Entries = append(file.Entries, cacheRecord{Key: key, Response: cache.entries[key]})
+	}
+
+	// Marshal to JSON
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal response cache: %w", err)
+	}
+
+	// Write to file
+	if err := os.WriteFile(filepath.Join(configDir, "responses.json"), data, 0644); err != nil {
+		return fmt.Errorf("failed to write response cache file: %w", err)
+	}
+
+	return nil
+}
+
+// LoadResponseCache loads the saved response cache with the given bounds
+// Returns an empty cache if there is no saved cache or it can't be read
+func LoadResponseCache(maxEntries, maxBodySize int) *ResponseCache {
+	cache := NewResponseCache(maxEntries, maxBodySize)
+
+	configDir, err := getConfigDir()
+	if err != nil {
+		return cache
+	}
+
+	data, err := os.ReadFile(filepath.Join(configDir, "responses.json"))
+	if err != nil {
+		return cache
+	}
+
+	var file cacheFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return cache
+	}
+
+	// Replay oldest first so eviction order and bounds carry over
+	for _, record := range file.Entries {
+		cache.store(record.Key, record.Response)
+	}
+
+	return cache
+}
@@ -0,0 +1,96 @@
+package models
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+func TestResponseCache_KeepsLatest(t *testing.T) {
+	cache := NewResponseCache(10, 1024)
+
+	cache.Put("GET", "/users", CachedResponse{Status: "500", Body: "first", Timestamp: time.Now()})
+	cache.Put("get", "/users", CachedResponse{Status: "200", Body: "second", Timestamp: time.Now()})
+
+	got, ok := cache.Get("GET", "/users")
+	if !ok {
+		t.Fatal("Expected cached response for GET /users")
+	}
+	if got.Status != "200" || got.Body != "second" {
+		t.Errorf("Expected latest response (200, second), got (%s, %s)", got.Status, got.Body)
+	}
+	if cache.Len() != 1 {
+		t.Errorf("Expected 1 entry, got %d", cache.Len())
+	}
+
+	if _, ok := cache.Get("POST", "/users"); ok {
+		t.Error("Did not expect a cached response for POST /users")
+	}
+}
+
+func TestResponseCache_BodySizeCap(t *testing.T) {
+	cache := NewResponseCache(10, 8)
+
+	cache.Put("GET", "/big", CachedResponse{Status: "200", Body: "0123456789abcdef"})
+
+	got, _ := cache.Get("GET", "/big")
+	if got.Body != "01234567" {
+		t.Errorf("Expected body capped to 8 bytes, got %q", got.Body)
+	}
+	if !got.Truncated {
+		t.Error("Expected Truncated to be set")
+	}
+}
+
+func TestResponseCache_EvictsOldest(t *testing.T) {
+	cache := NewResponseCache(2, 1024)
+
+	cache.Put("GET", "/a", CachedResponse{Status: "200"})
+	cache.Put("GET", "/b", CachedResponse{Status: "200"})
+	cache.Put("GET", "/a", CachedResponse{Status: "201"}) // Refreshes /a
+	cache.Put("GET", "/c", CachedResponse{Status: "200"})
+
+	if cache.Len() != 2 {
+		t.Fatalf("Expected 2 entries, got %d", cache.Len())
+	}
+	if _, ok := cache.Get("GET", "/b"); ok {
+		t.Error("Expected /b to be evicted as the oldest entry")
+	}
+	if got, ok := cache.Get("GET", "/a"); !ok || got.Status != "201" {
+		t.Error("Expected refreshed /a to be kept")
+	}
+}
+
+func TestSaveAndLoadResponseCache(t *testing.T) {
+	originalHome := os.Getenv("HOME")
+	defer os.Setenv("HOME", originalHome)
+	os.Setenv("HOME", t.TempDir())
+
+	cache := NewResponseCache(10, 1024)
+	cache.Put("GET", "/a", CachedResponse{Status: "200", Body: "alpha"})
+	cache.Put("GET", "/b", CachedResponse{Status: "404", Body: "beta"})
+
+	if err := SaveResponseCache(cache); err != nil {
+		t.Fatalf("SaveResponseCache failed: %v", err)
+	}
+
+	// Reload with a tighter entry bound: the oldest entry is evicted
+	loaded := LoadResponseCache(1, 1024)
+	if loaded.Len() != 1 {
+		t.Fatalf("Expected 1 entry after reload, got %d", loaded.Len())
+	}
+	if got, ok := loaded.Get("GET", "/b"); !ok || got.Status != "404" || got.Body != "beta" {
+		t.Errorf("Expected GET /b to survive the reload, got %+v", got)
+	}
+}
+
+func TestLoadResponseCache_Missing(t *testing.T) {
+	originalHome := os.Getenv("HOME")
+	defer os.Setenv("HOME", originalHome)
+	os.Setenv("HOME", t.TempDir())
+
+	cache := LoadResponseCache(DefaultCacheEntries, DefaultCacheBodySize)
+	if cache == nil || cache.Len() != 0 {
+		t.Errorf("Expected an empty cache when nothing was saved, got %+v", cache)
+	}
+}
@@ -5,7 +5,11 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 	"time"
+
+	"github.com/Traves-Theberge/OpenAPI-Toolkit/openapi-tui/internal/errors"
 )
 
 // HistoryEntry represents a single test run in history
@@ -112,9 +116,9 @@ func CreateHistoryEntry(specPath, baseURL string, results []TestResult, duration
 	passed := 0
 	failed := 0
 	for _, r := range results {
-		if r.Status != "ERR" && r.Status[0] == '2' {
+		if r.Passed() {
 			passed++
-		} else {
+		} else if r.Failed() {
 			failed++
 		}
 	}
@@ -135,6 +139,333 @@ func CreateHistoryEntry(specPath, baseURL string, results []TestResult, duration
 	}
 }
 
+// ToggleCompareMark marks or unmarks a history index for comparison
+// At most two entries stay marked; marking a third drops the earliest mark
+func ToggleCompareMark(marked []int, index int) []int {
+	var result []int
+	for _, i := range marked {
+		if i != index {
+			result = append(result, i)
+		}
+	}
+	if len(result) < len(marked) {
+		return result
+	}
+	result = append(result, index)
+	if len(result) > 2 {
+		result = result[len(result)-2:]
+	}
+	return result
+}
+
+// EndpointComparison holds the status of one endpoint in two history runs
+// A status of "-" means the endpoint was not tested in that run
+type EndpointComparison struct {
+	Method    string
+	Endpoint  string
+	OldStatus string
+	NewStatus string
+}
+
+// Changed reports whether the endpoint's status differs between the runs
+func (c EndpointComparison) Changed() bool {
+	return c.OldStatus != c.NewStatus
+}
+
+// CompareRuns pairs up the results of two history entries by request (see
+// TestResult.Key), sorted by endpoint then method. Endpoints are labeled with
+// the enum values, example and contract case they were tested with
+func CompareRuns(older, newer HistoryEntry) []EndpointComparison {
+	byKey := make(map[string]*EndpointComparison)
+	var keys []string
+	lookup := func(r TestResult) *EndpointComparison {
+		key := r.Key()
+		c, ok := byKey[key]
+		if !ok {
+			c = &EndpointComparison{Method: r.Method, Endpoint: r.LabeledEndpoint(), OldStatus: "-", NewStatus: "-"}
+			byKey[key] = c
+			keys = append(keys, key)
+		}
+		return c
+	}
+
+	for _, r := range older.Results {
+		lookup(r).OldStatus = r.Status
+	}
+	for _, r := range newer.Results {
+		lookup(r).NewStatus = r.Status
+	}
+
+	comparisons := make([]EndpointComparison, 0, len(keys))
+	for _, key := range keys {
+		comparisons = append(comparisons, *byKey[key])
+	}
+	sort.Slice(comparisons, func(i, j int) bool {
+		if comparisons[i].Endpoint != comparisons[j].Endpoint {
+			return comparisons[i].Endpoint < comparisons[j].Endpoint
+		}
+		return comparisons[i].Method < comparisons[j].Method
+	})
+	return comparisons
+}
+
+// RecentRuns returns the results of the newest history entries for a spec and
+// base URL, newest first, at most limit runs
+func (h *TestHistory) RecentRuns(specPath, baseURL string, limit int) [][]TestResult {
+	var runs [][]TestResult
+	for _, entry := range h.Entries {
+		if len(runs) == limit {
+			break
+		}
+		if entry.SpecPath == specPath && entry.BaseURL == baseURL {
+			runs = append(runs, entry.Results)
+		}
+	}
+	return runs
+}
+
+// DetectFlaky returns the keys (see TestResult.Key) of requests that both
+// passed and failed across the given runs, sorted
+func DetectFlaky(runs [][]TestResult) []string {
+	passed := make(map[string]bool)
+	failed := make(map[string]bool)
+	for _, results := range runs {
+		for _, r := range results {
+			key := r.Key()
+			if r.Passed() {
+				passed[key] = true
+			} else if r.Failed() {
+				failed[key] = true
+			}
+		}
+	}
+
+	var flaky []string
+	for key := range passed {
+		if failed[key] {
+			flaky = append(flaky, key)
+		}
+	}
+	sort.Strings(flaky)
+	return flaky
+}
+
+// SizeStats aggregates body sizes in bytes over the results that had a body
+type SizeStats struct {
+	Count   int // Results with a body of this kind
+	Min     int
+	Max     int
+	Average int
+	Total   int
+}
+
+// SummarizeSizes aggregates request and response body sizes across results
+// Results without a body (or whose size was not captured) are left out, so a
+// run without size data gives zero Counts.
+func SummarizeSizes(results []TestResult) (request, response SizeStats) {
+	for _, r := range results {
+		request.add(r.RequestBytes)
+		response.add(r.ResponseBytes)
+	}
+	return request, response
+}
+
+// add counts one body size, ignoring empty bodies
+func (s *SizeStats) add(size int) {
+	if size <= 0 {
+		return
+	}
+	if s.Count == 0 || size < s.Min {
+		s.Min = size
+	}
+	if size > s.Max {
+		s.Max = size
+	}
+	s.Count++
+	s.Total += size
+	s.Average = s.Total / s.Count
+}
+
+// FormatBytes formats a byte count in B, KB or MB
+func FormatBytes(n int) string {
+	switch {
+	case n < 1024:
+		return fmt.Sprintf("%d B", n)
+	case n < 1024*1024:
+		return fmt.Sprintf("%.1f KB", float64(n)/1024)
+	default:
+		return fmt.Sprintf("%.1f MB", float64(n)/(1024*1024))
+	}
+}
+
+// BodyGenReport counts how many of a run's operations that take a request
+// body got a generated one. Operations left with an empty body point to a
+// generation gap, e.g. a media type without a schema
+type BodyGenReport struct {
+	Operations int      // Operations sent with a request body, per the spec
+	Generated  int      // Of those, operations given a non-empty generated body
+	Gaps       []string // "METHOD path" of the operations whose body came out empty
+}
+
+// ContentTypeMismatch is an endpoint whose responses carried a Content-Type
+// its spec does not declare
+type ContentTypeMismatch struct {
+	Endpoint     string   // "METHOD path"
+	ContentTypes []string // Undeclared content types seen, sorted
+	Count        int      // Responses with an undeclared content type
+	Total        int      // Responses from the endpoint in the run
+}
+
+// Regression is an endpoint that got worse since a baseline run
+type Regression struct {
+	Endpoint         string // "METHOD path"
+	BaselineStatus   string
+	Status           string
+	BaselineDuration time.Duration
+	Duration         time.Duration
+}
+
+// RegressionReport compares a run against a baseline results export, for
+// regression gating. Endpoints missing from either run are not compared
+type RegressionReport struct {
+	BaselinePath string
+	Compared     int          // Endpoints present in both runs
+	Regressions  []Regression // Passed in the baseline, fail now
+	Slowdowns    []Regression // Passed in both, but slower beyond the latency tolerance
+	Err          error        // The baseline could not be read; nothing was compared
+}
+
+// Failed reports whether the run regressed, or could not be checked
+func (r RegressionReport) Failed() bool {
+	return r.Err != nil || len(r.Regressions) > 0 || len(r.Slowdowns) > 0
+}
+
+// FailureThreshold is how far a run may fail and still count as passing,
+// so a few known-flaky endpoints don't fail a CI build. The zero value
+// allows no failures
+type FailureThreshold struct {
+	MaxAllowedFailures int     // Failures a passing run may have (0 = none, unless MinPassRate is set)
+	MinPassRate        float64 // Lowest percentage of passing results, 0-100, of a passing run (0 = not checked)
+}
+
+// IsSet reports whether the threshold allows any failures
+func (t FailureThreshold) IsSet() bool {
+	return t.MaxAllowedFailures > 0 || t.MinPassRate > 0
+}
+
+// Allows reports whether a run with passed and failed results stays within
+// the threshold. When both limits are set, the run must meet both
+func (t FailureThreshold) Allows(passed, failed int) bool {
+	if !t.IsSet() {
+		return failed == 0
+	}
+	if t.MaxAllowedFailures > 0 && failed > t.MaxAllowedFailures {
+		return false
+	}
+	if t.MinPassRate > 0 && passed+failed > 0 && float64(passed)/float64(passed+failed)*100 < t.MinPassRate {
+		return false
+	}
+	return true
+}
+
+// ExitCode is the process exit code for a headless run: 0 when the run
+// stays within the threshold, 1 otherwise
+func (t FailureThreshold) ExitCode(passed, failed int) int {
+	if t.Allows(passed, failed) {
+		return 0
+	}
+	return 1
+}
+
+// RunThreshold returns the failure threshold configured in cfg
+func RunThreshold(cfg Config) FailureThreshold {
+	return FailureThreshold{MaxAllowedFailures: cfg.MaxAllowedFailures, MinPassRate: cfg.MinPassRate}
+}
+
+// DetectContentTypeMismatches groups a run's undeclared response content
+// types by endpoint, sorted by endpoint
+func DetectContentTypeMismatches(results []TestResult) []ContentTypeMismatch {
+	byKey := make(map[string]*ContentTypeMismatch)
+	totals := make(map[string]int)
+	for _, r := range results {
+		key := EndpointKey(r.Method, r.Endpoint)
+		totals[key]++
+		if r.UndeclaredContentType == "" {
+			continue
+		}
+		mismatch, ok := byKey[key]
+		if !ok {
+			mismatch = &ContentTypeMismatch{Endpoint: key}
+			byKey[key] = mismatch
+		}
+		mismatch.Count++
+		seen := false
+		for _, ct := range mismatch.ContentTypes {
+			seen = seen || ct == r.UndeclaredContentType
+		}
+		if !seen {
+			mismatch.ContentTypes = append(mismatch.ContentTypes, r.UndeclaredContentType)
+		}
+	}
+
+	mismatches := make([]ContentTypeMismatch, 0, len(byKey))
+	for key, mismatch := range byKey {
+		mismatch.Total = totals[key]
+		sort.Strings(mismatch.ContentTypes)
+		mismatches = append(mismatches, *mismatch)
+	}
+	sort.Slice(mismatches, func(i, j int) bool {
+		return mismatches[i].Endpoint < mismatches[j].Endpoint
+	})
+	return mismatches
+}
+
+// CategoryValidation counts 2xx responses that failed schema validation
+const CategoryValidation = "validation"
+
+// FailureCategory is one row of a failure breakdown
+type FailureCategory struct {
+	Name  string
+	Count int
+}
+
+// CategorizeFailures counts a run's failures by category: requests that never
+// got a response by network cause (see errors.NetworkErrorCategory), failing
+// statuses by class ("4xx", "5xx"), and 2xx responses whose body did not
+// match the spec as CategoryValidation. Passing and skipped results are not
+// counted
+func CategorizeFailures(results []TestResult) map[string]int {
+	counts := make(map[string]int)
+	for _, r := range results {
+		switch {
+		case r.Skipped():
+		case r.Status == "" || r.Status == "ERR":
+			counts[errors.NetworkErrorCategory(r.Message)]++
+		case !r.Passed():
+			counts[r.Status[:1]+"xx"]++
+		case r.Case != CaseInvalid && r.Status[0] == '2' && !strings.HasPrefix(r.Message, "OK"):
+			counts[CategoryValidation]++
+		}
+	}
+	return counts
+}
+
+// SortFailureCategories lists failure counts for display, most frequent first
+// and then by name
+func SortFailureCategories(counts map[string]int) []FailureCategory {
+	categories := make([]FailureCategory, 0, len(counts))
+	for name, count := range counts {
+		categories = append(categories, FailureCategory{Name: name, Count: count})
+	}
+	sort.Slice(categories, func(i, j int) bool {
+		if categories[i].Count != categories[j].Count {
+			return categories[i].Count > categories[j].Count
+		}
+		return categories[i].Name < categories[j].Name
+	})
+	return categories
+}
+
 // formatHistoryDuration formats a duration for display
 func formatHistoryDuration(d time.Duration) string {
 	if d < time.Second {
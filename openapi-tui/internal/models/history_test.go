@@ -1,8 +1,11 @@
 package models
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 )
@@ -207,6 +210,20 @@ func TestLoadHistoryNonExistent(t *testing.T) {
 	}
 }
 
+func TestCreateHistoryEntry_ContractCases(t *testing.T) {
+	results := []TestResult{
+		{Method: "POST", Endpoint: "/users", Status: "200", Case: CaseValid},
+		{Method: "POST", Endpoint: "/users", Status: "400", Case: CaseInvalid},
+		{Method: "PUT", Endpoint: "/users", Status: "200", Case: CaseInvalid},
+		{Method: "PATCH", Endpoint: "/users", Status: "422", Case: CaseInvalid, UndocumentedStatus: true},
+	}
+
+	entry := CreateHistoryEntry("api.yaml", "http://localhost:8080", results, time.Second)
+	if entry.Passed != 2 || entry.Failed != 2 {
+		t.Errorf("Expected invalid input rejected with a documented 4xx to pass and the rest to fail, got %d passed, %d failed", entry.Passed, entry.Failed)
+	}
+}
+
 func TestCreateHistoryEntry(t *testing.T) {
 	results := []TestResult{
 		{Method: "GET", Endpoint: "/users", Status: "200", Message: "OK", Duration: 100 * time.Millisecond},
@@ -341,3 +358,241 @@ func TestHistoryPersistence(t *testing.T) {
 		t.Errorf("Expected oldest entry (1) last, got %d", final.Entries[2].TotalTests)
 	}
 }
+
+func TestCompareRuns(t *testing.T) {
+	older := HistoryEntry{Results: []TestResult{
+		{Method: "GET", Endpoint: "/users", Status: "200"},
+		{Method: "POST", Endpoint: "/users", Status: "201"},
+		{Method: "GET", Endpoint: "/orders", Status: "200"},
+	}}
+	newer := HistoryEntry{Results: []TestResult{
+		{Method: "GET", Endpoint: "/users", Status: "200"},
+		{Method: "POST", Endpoint: "/users", Status: "500"},
+		{Method: "GET", Endpoint: "/health", Status: "200"},
+	}}
+
+	comparisons := CompareRuns(older, newer)
+	if len(comparisons) != 4 {
+		t.Fatalf("Expected 4 compared endpoints, got %d", len(comparisons))
+	}
+
+	expected := []EndpointComparison{
+		{Method: "GET", Endpoint: "/health", OldStatus: "-", NewStatus: "200"},
+		{Method: "GET", Endpoint: "/orders", OldStatus: "200", NewStatus: "-"},
+		{Method: "GET", Endpoint: "/users", OldStatus: "200", NewStatus: "200"},
+		{Method: "POST", Endpoint: "/users", OldStatus: "201", NewStatus: "500"},
+	}
+	for i, want := range expected {
+		if comparisons[i] != want {
+			t.Errorf("comparisons[%d] = %+v, want %+v", i, comparisons[i], want)
+		}
+	}
+	if comparisons[2].Changed() || !comparisons[3].Changed() {
+		t.Error("Expected only status differences to be reported as changed")
+	}
+}
+
+func TestToggleCompareMark(t *testing.T) {
+	marked := ToggleCompareMark(nil, 0)
+	marked = ToggleCompareMark(marked, 3)
+	if len(marked) != 2 || marked[0] != 0 || marked[1] != 3 {
+		t.Fatalf("Expected [0 3], got %v", marked)
+	}
+
+	// Marking a third entry drops the earliest mark
+	marked = ToggleCompareMark(marked, 5)
+	if len(marked) != 2 || marked[0] != 3 || marked[1] != 5 {
+		t.Fatalf("Expected [3 5], got %v", marked)
+	}
+
+	marked = ToggleCompareMark(marked, 3)
+	if len(marked) != 1 || marked[0] != 5 {
+		t.Errorf("Expected [5] after unmarking, got %v", marked)
+	}
+}
+
+func TestDetectFlaky(t *testing.T) {
+	runs := [][]TestResult{
+		{
+			{Method: "GET", Endpoint: "/users", Status: "200"},
+			{Method: "GET", Endpoint: "/orders", Status: "200"},
+			{Method: "DELETE", Endpoint: "/users/{id}", Status: "500"},
+		},
+		{
+			{Method: "GET", Endpoint: "/users", Status: "200"},
+			{Method: "GET", Endpoint: "/orders", Status: "503"},
+			{Method: "DELETE", Endpoint: "/users/{id}", Status: "500"},
+		},
+		{
+			{Method: "GET", Endpoint: "/users", Status: "200"},
+			{Method: "GET", Endpoint: "/orders", Status: "200"},
+			{Method: "DELETE", Endpoint: "/users/{id}", Status: "ERR"},
+		},
+	}
+
+	// Only the endpoint that alternates between pass and fail is flaky
+	flaky := DetectFlaky(runs)
+	if len(flaky) != 1 || flaky[0] != "GET /orders" {
+		t.Errorf("Expected [GET /orders], got %v", flaky)
+	}
+
+	if flaky := DetectFlaky(runs[:1]); len(flaky) != 0 {
+		t.Errorf("Expected no flaky endpoints in a single run, got %v", flaky)
+	}
+
+	// The valid and invalid cases of a contract run are separate requests
+	cases := [][]TestResult{{
+		{Method: "POST", Endpoint: "/users", Status: "201", Case: CaseValid},
+		{Method: "POST", Endpoint: "/users", Status: "400", Case: CaseInvalid},
+	}}
+	if flaky := DetectFlaky(cases); len(flaky) != 0 {
+		t.Errorf("Expected contract cases not to be merged into one flaky endpoint, got %v", flaky)
+	}
+}
+
+func TestCompareRuns_Labels(t *testing.T) {
+	older := HistoryEntry{Results: []TestResult{
+		{Method: "POST", Endpoint: "/users", Status: "201", Example: "admin"},
+		{Method: "POST", Endpoint: "/users", Status: "201", Example: "member"},
+	}}
+	newer := HistoryEntry{Results: []TestResult{
+		{Method: "POST", Endpoint: "/users", Status: "201", Example: "admin"},
+		{Method: "POST", Endpoint: "/users", Status: "400", Example: "member"},
+	}}
+
+	comparisons := CompareRuns(older, newer)
+	expected := []EndpointComparison{
+		{Method: "POST", Endpoint: "/users [admin]", OldStatus: "201", NewStatus: "201"},
+		{Method: "POST", Endpoint: "/users [member]", OldStatus: "201", NewStatus: "400"},
+	}
+	if !reflect.DeepEqual(comparisons, expected) {
+		t.Errorf("Expected each example compared on its own, got %+v", comparisons)
+	}
+}
+
+func TestDetectContentTypeMismatches(t *testing.T) {
+	results := []TestResult{
+		{Method: "GET", Endpoint: "/users", Status: "200", UndeclaredContentType: "text/plain"},
+		{Method: "GET", Endpoint: "/users", Status: "200", UndeclaredContentType: "text/html"},
+		{Method: "GET", Endpoint: "/users", Status: "200", UndeclaredContentType: "text/plain"},
+		{Method: "GET", Endpoint: "/orders", Status: "200"},
+		{Method: "POST", Endpoint: "/orders", Status: "201", UndeclaredContentType: "(none)"},
+		{Method: "POST", Endpoint: "/orders", Status: "201"},
+	}
+
+	mismatches := DetectContentTypeMismatches(results)
+	if len(mismatches) != 2 {
+		t.Fatalf("Expected 2 mismatches, got %+v", mismatches)
+	}
+	if m := mismatches[0]; m.Endpoint != "GET /users" || m.Count != 3 || m.Total != 3 ||
+		strings.Join(m.ContentTypes, ",") != "text/html,text/plain" {
+		t.Errorf("Unexpected GET /users mismatch: %+v", m)
+	}
+	if m := mismatches[1]; m.Endpoint != "POST /orders" || m.Count != 1 || m.Total != 2 {
+		t.Errorf("Unexpected POST /orders mismatch: %+v", m)
+	}
+
+	if mismatches := DetectContentTypeMismatches(results[3:4]); len(mismatches) != 0 {
+		t.Errorf("Expected no mismatches, got %+v", mismatches)
+	}
+}
+
+func TestCategorizeFailures(t *testing.T) {
+	results := []TestResult{
+		{Status: "ERR", Message: "Connection Refused: Cannot connect to: http://localhost:1"},
+		{Status: "ERR", Message: "max retries (2) exceeded: Request Timeout: The server took too long to respond"},
+		{Status: "ERR", Message: `Get "http://api.invalid/users": dial tcp: lookup api.invalid: no such host`},
+		{Status: "ERR", Message: "TLS/SSL Error: Cannot establish secure connection"},
+		{Status: "ERR", Message: "Failed to generate request body: bad schema"},
+		{Status: "404", Message: "OK"},
+		{Status: "422", Message: "OK"},
+		{Status: "500", Message: "OK"},
+		{Status: "200", Message: "response body: property \"id\" is missing"},
+		{Status: "200", Message: "OK (validated)"},
+		{Status: "400", Message: "Rejected invalid input with documented 400", Case: CaseInvalid},
+		{Status: "404", Message: "OK (expected 404)", ExpectedStatuses: []int{404}},
+		{Status: StatusSkipped, Message: "skipped (time budget)"},
+	}
+
+	counts := CategorizeFailures(results)
+	want := map[string]int{
+		"connection refused": 1,
+		"timeout":            1,
+		"dns":                1,
+		"tls":                1,
+		"other error":        1,
+		"4xx":                2,
+		"5xx":                1,
+		"validation":         1,
+	}
+	if len(counts) != len(want) {
+		t.Errorf("Expected categories %v, got %v", want, counts)
+	}
+	for category, count := range want {
+		if counts[category] != count {
+			t.Errorf("Category %q: expected %d, got %d", category, count, counts[category])
+		}
+	}
+
+	sorted := SortFailureCategories(counts)
+	if sorted[0] != (FailureCategory{Name: "4xx", Count: 2}) || sorted[1].Name != "5xx" {
+		t.Errorf("Expected the most frequent category first, then by name, got %+v", sorted)
+	}
+	if len(CategorizeFailures(results[9:])) != 0 {
+		t.Error("Expected no categories for passing results")
+	}
+}
+
+func TestRecentRuns(t *testing.T) {
+	history := &TestHistory{}
+	for i, spec := range []string{"a.yaml", "b.yaml", "a.yaml", "a.yaml"} {
+		history.AddEntry(HistoryEntry{
+			ID:       fmt.Sprintf("run%d", i),
+			SpecPath: spec,
+			BaseURL:  "http://localhost",
+			Results:  []TestResult{{Method: "GET", Endpoint: fmt.Sprintf("/run%d", i), Status: "200"}},
+		})
+	}
+
+	runs := history.RecentRuns("a.yaml", "http://localhost", 2)
+	if len(runs) != 2 {
+		t.Fatalf("Expected 2 runs, got %d", len(runs))
+	}
+	// Newest entries come first
+	if runs[0][0].Endpoint != "/run3" || runs[1][0].Endpoint != "/run2" {
+		t.Errorf("Expected runs 3 and 2, got %s and %s", runs[0][0].Endpoint, runs[1][0].Endpoint)
+	}
+}
+
+func TestFailureThreshold_ExitCode(t *testing.T) {
+	tests := []struct {
+		name      string
+		threshold FailureThreshold
+		passed    int
+		failed    int
+		want      int
+	}{
+		{"no threshold, no failures", FailureThreshold{}, 10, 0, 0},
+		{"no threshold, one failure", FailureThreshold{}, 9, 1, 1},
+		{"below max failures", FailureThreshold{MaxAllowedFailures: 3}, 8, 2, 0},
+		{"at max failures", FailureThreshold{MaxAllowedFailures: 3}, 7, 3, 0},
+		{"above max failures", FailureThreshold{MaxAllowedFailures: 3}, 6, 4, 1},
+		{"above min pass rate", FailureThreshold{MinPassRate: 90}, 19, 1, 0},
+		{"below min pass rate", FailureThreshold{MinPassRate: 90}, 8, 2, 1},
+		{"both limits met", FailureThreshold{MaxAllowedFailures: 2, MinPassRate: 80}, 9, 1, 0},
+		{"pass rate met, too many failures", FailureThreshold{MaxAllowedFailures: 2, MinPassRate: 80}, 97, 3, 1},
+		{"empty run", FailureThreshold{MinPassRate: 90}, 0, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.threshold.ExitCode(tt.passed, tt.failed); got != tt.want {
+				t.Errorf("ExitCode(%d, %d) = %d, want %d", tt.passed, tt.failed, got, tt.want)
+			}
+		})
+	}
+
+	cfg := Config{MaxAllowedFailures: 2, MinPassRate: 75}
+	if got := RunThreshold(cfg); got != (FailureThreshold{MaxAllowedFailures: 2, MinPassRate: 75}) {
+		t.Errorf("RunThreshold() = %+v", got)
+	}
+}
@@ -1,10 +1,15 @@
 package models
 
 import (
+"fmt"
+"slices"
+"strconv"
+"strings"
 "time"
 
 "github.com/charmbracelet/bubbles/spinner"
 "github.com/charmbracelet/bubbles/table"
+"github.com/charmbracelet/bubbles/textarea"
 "github.com/charmbracelet/bubbles/textinput"
 )
 
@@ -20,6 +25,7 @@ const (
 	HistoryScreen
 	EndpointSelectorScreen
 	ConfigEditorScreen
+	ConfigInfoScreen
 )
 
 // Model is the main application state
@@ -35,8 +41,22 @@ type Model struct {
 	CustomRequestModel    CustomRequestModel
 	EndpointSelectorModel EndpointSelectorModel
 	ConfigEditorModel     ConfigEditorModel
+	ConfigInfo            ConfigInfoModel
 	History               *TestHistory
+	ResponseCache         *ResponseCache // Last response per endpoint (when Config.CacheResponses is on)
+	TemplateCounter       int            // Body templates rendered for custom requests this session, for {{.counter}}
 	HistoryIndex          int  // Selected index in history view
+	HistoryMarked         []int // History entries marked for comparison (at most two)
+	HistoryComparing      bool  // Showing the side-by-side comparison of the marked entries
+}
+
+// ConfigInfoModel holds state for the config file info screen
+type ConfigInfoModel struct {
+	Path     string // Resolved location of config.yaml
+	Contents string // On-disk contents with secret values masked ("" when there is no file yet)
+	Err      error  // Problem resolving or reading the file
+	Notice   string // Result of the last copy or reset
+	ConfirmReset bool // Asking whether to reset the config to defaults
 }
 
 // ValidateModel holds state for the validation screen
@@ -44,7 +64,11 @@ type ValidateModel struct {
 TextInput textinput.Model
 Err       error
 Result    string
+Warnings  []string // Lint findings on a valid spec, e.g. operations without a success response
+Webhooks  []string // "METHOD name" of each OpenAPI 3.1 webhook operation, which are validated but not tested
 Done      bool
+ExportSuccess string // Message shown after exporting the generated request bodies
+Fetching  bool   // The spec URL is being downloaded
 }
 
 // TestModel holds state for the testing screen
@@ -52,6 +76,7 @@ type TestModel struct {
 	Step            int
 	SpecInput       textinput.Model
 	UrlInput        textinput.Model
+	FetchingSpec    bool       // The spec URL is being downloaded, before validating it or re-running a history entry
 	Spinner         spinner.Model
 	Table           table.Model
 	Results         []TestResult
@@ -63,8 +88,38 @@ type TestModel struct {
 	FilterActive    bool
 	FilterInput     textinput.Model
 	FilteredResults []TestResult
+	ShowOnlyFailures bool      // Show only non-2xx results, independent of the filter
+	CollapsePassing bool       // Fold passing results into a single "N passed" line
+	GroupByTag      bool       // Order results by operation tag, with a Tag column and per-tag subtotals
+	HideMessageColumn  bool    // Leave the Message column out of the results table, for narrow terminals
+	ShowDurationColumn bool    // Add a Duration column to the results table
 	TestStartTime   time.Time  // Track when test run started for history
 	SelectEndpoints bool       // Flag to show endpoint selector after getting spec/URL
+	RunFavorites    bool       // Flag to test only pinned endpoints after getting spec/URL
+	RunChanged      bool       // Flag to test only endpoints changed since Config.PreviousSpec after getting spec/URL
+	SpecEndpoints   []EndpointInfo // All endpoints in the spec, set for selective runs to report coverage
+	ShowUntested    bool       // Expand the list of endpoints skipped by a selective run
+	Flaky           []string   // Endpoints that both passed and failed across recent runs
+	BodyReport      BodyGenReport // Operations of the last run whose request body could not be generated
+	Regression      *RegressionReport // Comparison of the last run against the configured baseline (nil = no baseline)
+	LastExportPath  string     // Absolute path of the last exported report
+	ListRequests    bool       // Flag to list resolved request URLs instead of testing after getting spec/URL
+	RequestList     []string   // Resolved "METHOD URL" lines for the request list view
+	LastSelection   []EndpointInfo // Endpoints the last run was limited to (nil = all), for re-runs
+	InFlight        []InFlightRequest // Requests of the current run awaiting a response, slowest first
+	InFlightCursor  int        // Selected in-flight request in the progress view
+	InFlightSelected int       // ID of the selected in-flight request, followed as the list changes
+	OperationDetail string     // Raw spec definition of the selected result's operation, for the operation view
+	LogNotice       string     // Outcome of copying from the log detail view
+	Completed       int        // Requests of the current run that have finished
+	Total           int        // Requests in the current run (0 until the first one finishes)
+	Active          int        // Requests of the current run still awaiting a response
+	LatestEndpoint  string     // "METHOD /path" of the most recently finished request
+	LatestSummary   string     // Its operation summary from the spec
+	SpecWarnings    []string   // Lint warnings of the spec about to be tested; Enter again tests anyway
+	ConfirmRequests int        // Requests of a run larger than Config.ConfirmLargeRuns awaiting confirmation; Enter again tests anyway
+	ProfilePrompt   bool       // Asking for a name to save the run's inputs as a profile
+	ProfileInput    textinput.Model // Name of the profile to save
 }// CustomRequestModel holds state for the custom request screen
 type CustomRequestModel struct {
 Step             int
@@ -72,7 +127,7 @@ MethodInput      textinput.Model
 EndpointInput    textinput.Model
 HeaderKeyInput   textinput.Model
 HeaderValueInput textinput.Model
-BodyInput        textinput.Model
+BodyInput        textarea.Model // Multiline, so pasted JSON keeps its lines
 Spinner          spinner.Model
 Table            table.Model
 Request          CustomRequest
@@ -83,6 +138,7 @@ ExportSuccess    string
 ShowingLog       bool
 FilterActive     bool
 FilterInput      textinput.Model
+Warnings         []string // Spec problems found before sending; Ctrl+S again sends anyway
 }
 
 // CustomRequest holds a manually created API request
@@ -95,6 +151,33 @@ type CustomRequest struct {
 	IsCustom    bool // Flag for history tracking
 }
 
+// EndpointOverride holds explicit request data for a single endpoint
+// Values set here take precedence over anything generated from the spec
+type EndpointOverride struct {
+	Body         interface{}       `yaml:"body,omitempty"`         // Replaces the generated body (strings are sent verbatim)
+	BodyTemplate string            `yaml:"bodyTemplate,omitempty"` // Go text/template rendered as the body; takes precedence over Body
+	Headers      map[string]string `yaml:"headers,omitempty"`      // Extra request headers
+	Query        map[string]string `yaml:"query,omitempty"`        // Query parameters merged over generated ones
+	AssumeContentType string       `yaml:"assumeContentType,omitempty"` // Response Content-Type validated against, whatever the server sends
+}
+
+// Overrides maps "METHOD path" keys (e.g. "POST /users") to endpoint overrides
+type Overrides map[string]EndpointOverride
+
+// EndpointKey builds the "METHOD path" key used by Overrides and pinned endpoints
+func EndpointKey(method, path string) string {
+	return strings.ToUpper(method) + " " + path
+}
+
+// Lookup returns the override registered for a method and path, if any
+func (o Overrides) Lookup(method, path string) (EndpointOverride, bool) {
+	if o == nil {
+		return EndpointOverride{}, false
+	}
+	override, ok := o[EndpointKey(method, path)]
+	return override, ok
+}
+
 // EndpointInfo represents an API endpoint from the OpenAPI spec
 type EndpointInfo struct {
 	Path        string
@@ -104,6 +187,8 @@ type EndpointInfo struct {
 	Summary     string
 	Description string
 	Selected    bool  // For checkbox state
+	SearchPath  string // Lowercased Path, precomputed for filtering
+	SearchText  string // Lowercased searchable fields, precomputed for filtering
 }
 
 // EndpointSelectorModel holds state for the endpoint selector screen
@@ -115,6 +200,19 @@ type EndpointSelectorModel struct {
 	Offset            int      // Scroll offset
 	Err               error
 	Ready             bool     // Endpoints loaded and ready
+	FilterQuery       string   // Search query FilteredEndpoints was built from
+	FilterSeq         int      // Bumped on every search edit so stale debounced filters are dropped
+	Notice            string   // Outcome of the last copy action
+}
+
+// ShownEndpoints returns the endpoints listed in the selector, which the
+// cursor moves over: the search results while a search is active (possibly
+// none), else every endpoint
+func (esm EndpointSelectorModel) ShownEndpoints() []EndpointInfo {
+	if esm.FilterQuery != "" {
+		return esm.FilteredEndpoints
+	}
+	return esm.AllEndpoints
 }// TestResult represents the result of testing an API endpoint
 type TestResult struct {
 Method       string
@@ -124,6 +222,106 @@ Message      string
 Duration     time.Duration
 LogEntry     *LogEntry
 RetryCount   int    // Number of times this request was retried
+Response     *CachedResponse `json:"-"` // Captured response when response caching is enabled
+Hints        []string `json:",omitempty"` // Generation problems likely to explain a rejected request
+RequestBytes      int `json:",omitempty"` // Size of the request body sent
+ResponseBytes     int `json:",omitempty"` // Size of the decoded response body
+ResponseFields    int `json:",omitempty"` // JSON object fields at any depth (0 for non-JSON bodies)
+ResponseTopFields int `json:",omitempty"` // Fields of a top-level JSON object
+UndeclaredContentType string `json:",omitempty"` // Response Content-Type the spec does not declare ("(none)" when unset)
+Example           string `json:",omitempty"` // Name of the request body example sent, when testing all examples
+EnumValues        string `json:",omitempty"` // Enum parameter values sent, e.g. "status=active", when expanding enum parameters
+RequestURL        string `json:",omitempty"` // Resolved URL the request was sent to, before auth query parameters
+Case              string `json:",omitempty"` // CaseValid or CaseInvalid in contract runs (empty otherwise)
+ExpectedStatuses  []int  `json:",omitempty"` // Statuses configured as a pass for this endpoint instead of 2xx
+Auth              string `json:",omitempty"` // Auth applied to the request, e.g. "bearer" or "none" (never the credentials)
+Tags              []string `json:",omitempty"` // Tags of the tested operation, for grouping results
+UndocumentedStatus bool  `json:",omitempty"` // The spec documents no response for the status received
+}
+
+// Input cases of a contract run, which tests each operation twice
+const (
+	CaseValid   = "valid"   // Generated input that follows the spec; passes with a 2xx
+	CaseInvalid = "invalid" // Input that breaks the spec; passes when rejected with a documented 4xx
+)
+
+// StatusSkipped is the status of a result whose request was stopped or never
+// sent because the run was cancelled or ran out of time. A skipped result is
+// neither a pass nor a failure
+const StatusSkipped = "SKIP"
+
+// Skipped reports whether the result's request was skipped (see StatusSkipped)
+func (r TestResult) Skipped() bool {
+	return r.Status == StatusSkipped
+}
+
+// Failed reports whether the result is a failure: neither a pass nor skipped
+func (r TestResult) Failed() bool {
+	return !r.Passed() && !r.Skipped()
+}
+
+// Passed reports whether the result is a pass: a 2xx status, one of the
+// endpoint's configured expected statuses, or a documented 4xx for invalid
+// input in a contract run
+func (r TestResult) Passed() bool {
+	if r.Status == "" || r.Status == "ERR" || r.Status == StatusSkipped {
+		return false
+	}
+	if len(r.ExpectedStatuses) > 0 && r.Case != CaseInvalid {
+		status, err := strconv.Atoi(r.Status)
+		return err == nil && slices.Contains(r.ExpectedStatuses, status)
+	}
+	if r.Case == CaseInvalid {
+		return r.Status[0] == '4' && !r.UndocumentedStatus
+	}
+	return r.Status[0] == '2'
+}
+
+// LabeledEndpoint returns the result's endpoint labeled with the enum
+// parameter values and request body example it sent and its contract run
+// input case, e.g. "/users [status=active, invalid]"
+func (r TestResult) LabeledEndpoint() string {
+	var labels []string
+	if r.EnumValues != "" {
+		labels = append(labels, r.EnumValues)
+	}
+	if r.Example != "" {
+		labels = append(labels, r.Example)
+	}
+	if r.Case != "" {
+		labels = append(labels, r.Case)
+	}
+	if len(labels) == 0 {
+		return r.Endpoint
+	}
+	return fmt.Sprintf("%s [%s]", r.Endpoint, strings.Join(labels, ", "))
+}
+
+// Key identifies the request a result is for across runs: its method and
+// labeled endpoint, so each enum value, example and contract case of an
+// operation is tracked on its own
+func (r TestResult) Key() string {
+	return EndpointKey(r.Method, r.LabeledEndpoint())
+}
+
+// InFlightRequest is a test request that has been sent but not yet answered
+type InFlightRequest struct {
+ID       int
+Method   string
+Endpoint string
+Started  time.Time
+}
+
+// BenchmarkResult summarizes a fixed-duration throughput benchmark of one endpoint
+type BenchmarkResult struct {
+Method            string
+Endpoint          string
+Concurrency       int
+Duration          time.Duration // Actual time spent sending requests
+TotalRequests     int           // Requests that completed within the duration
+Failures          int           // Completed requests that errored or returned a status >= 400
+StatusCounts      map[int]int   // Completed requests by status code
+RequestsPerSecond float64
 }
 
 // LogEntry captures detailed request/response information
@@ -135,6 +333,14 @@ ResponseHeaders map[string]string
 ResponseBody    string
 Duration        time.Duration
 Timestamp       time.Time
+DNSLookup       time.Duration // Time spent resolving the host (0 if skipped)
+Connect         time.Duration // Time spent establishing the TCP connection (0 if reused)
+TLSHandshake    time.Duration // Time spent on the TLS handshake (0 for plain HTTP)
+TTFB            time.Duration // Time from sending the request to the first response byte
+HasExample      bool     // The spec documents a JSON example for this response
+ExampleDiff     []string // Field-level differences between the documented example and the response
+FullResponseBody  string `json:"-"` // Response body before display truncation, cut to the capture limit (DefaultCacheBodySize at most)
+FullBodyTruncated bool   `json:"-"` // FullResponseBody was cut to the capture limit
 }
 
 // ValidationResult contains OpenAPI validation results
@@ -144,14 +350,62 @@ type ValidationResult struct {
 	ContentType    string
 	SchemaErrors   []string
 	ExpectedStatus string
+	ContentTypeMismatch bool // The response Content-Type is not declared for its status
 }// AuthConfig holds authentication configuration
 type AuthConfig struct {
-AuthType   string
-Token      string
-APIKeyIn   string
-APIKeyName string
-Username   string
-Password   string
+AuthType   string `yaml:"type"`
+Token      string `yaml:"token,omitempty"`
+BearerPrefix string `yaml:"bearerPrefix,omitempty"` // Scheme sent before a bearer token, e.g. "Token" or "JWT" ("" = "Bearer")
+APIKeyIn   string `yaml:"apiKeyIn,omitempty"`
+APIKeyName string `yaml:"apiKeyName,omitempty"`
+Username   string `yaml:"username,omitempty"`
+Password   string `yaml:"password,omitempty"`
+}
+
+// APIVersionHeader is a header sent with every test request to pick the API
+// version, e.g. Accept: application/vnd.company.v2+json
+type APIVersionHeader struct {
+Name  string `yaml:"name"`
+Value string `yaml:"value"`
+}
+
+// String returns the header as "Name: value", or "" when no name is set
+func (h APIVersionHeader) String() string {
+	if h.Name == "" {
+		return ""
+	}
+	return h.Name + ": " + h.Value
+}
+
+// RequestHeaders are standard request headers sent with every test request,
+// for async preferences and conditional requests
+type RequestHeaders struct {
+Prefer      string `yaml:"prefer,omitempty"`      // e.g. respond-async or return=minimal
+IfMatch     string `yaml:"ifMatch,omitempty"`     // ETag a write must match, e.g. "\"v1\"" or *
+IfNoneMatch string `yaml:"ifNoneMatch,omitempty"` // ETag a read must differ from, for 304 checks
+}
+
+// Headers returns the set headers keyed by their canonical names
+func (h RequestHeaders) Headers() map[string]string {
+	headers := make(map[string]string)
+	if h.Prefer != "" {
+		headers["Prefer"] = h.Prefer
+	}
+	if h.IfMatch != "" {
+		headers["If-Match"] = h.IfMatch
+	}
+	if h.IfNoneMatch != "" {
+		headers["If-None-Match"] = h.IfNoneMatch
+	}
+	return headers
+}
+
+// Profile is a named set of test inputs saved from a run, so the same
+// spec, base URL and auth can be tested again later
+type Profile struct {
+SpecPath string      `yaml:"specPath,omitempty"`
+BaseURL  string      `yaml:"baseUrl,omitempty"`
+Auth     *AuthConfig `yaml:"auth,omitempty"`
 }
 
 // Config holds application configuration
@@ -160,9 +414,239 @@ BaseURL        string
 SpecPath       string
 VerboseMode    bool
 Auth           *AuthConfig
+TokenCommand   string // Shell command printing a fresh auth token, run before each test run, e.g. "gcloud auth print-access-token" (empty = off)
+TokenCommandJSONPath string // JSONPath of the token when TokenCommand prints JSON, e.g. "$.access_token" (empty = the whole output is the token)
 MaxConcurrency int  // Maximum number of concurrent test requests (0 = auto-detect)
 MaxRetries     int  // Maximum number of retry attempts for failed requests (0 = no retries, default: 3)
 RetryDelay     int  // Initial retry delay in milliseconds (default: 1000ms, doubles each retry)
+RetryOn        []string // Failure classes that are retried, see RetryClasses (empty = DefaultRetryOn)
+RetryStatuses  []int    // Response statuses that are retried, e.g. 429 and 503, in place of the 5xx class (empty = off)
+OverridesFile  string // Optional YAML file with per-endpoint request overrides
+PinnedEndpoints []string // Favorite endpoints as "METHOD path" keys
+DurationUnit   string // Duration display unit: "auto" (default), "ms" or "s"
+CacheResponses bool   // Keep the last response per endpoint for inspection from the results screen
+ForceScheme    string // Force request URLs to "http" or "https" regardless of the base URL (empty = as given)
+LogFile        string // Append a JSONL record of each request during test runs (empty = off)
+ReplayFile     string // HAR or JSON recording whose responses are returned instead of sending requests (empty = off)
+RecordFile     string // Save each test request's response here in the replay format, overwriting it every run (empty = off)
+CaptureHeaders []string // Response headers kept in verbose logs, with secret ones always redacted (empty = all)
+RequiredResponseFields []string // Top-level fields every JSON object response must have, e.g. requestId (empty = off)
+ValidateBeforeTest bool // Validate the spec before testing it: errors stop the run, warnings ask to continue
+PreviousSpec   string // Earlier version of the spec; Test Changed Endpoints tests only operations added or modified since
+QuickValidate  bool // Only check that the spec parses before testing, skipping the slow full validation of very large specs
+SkipDeprecatedParams bool // Leave deprecated query parameters out of generated requests
+ExclusiveParamsExtension string // Operation extension listing mutually exclusive query parameters, e.g. "x-mutually-exclusive" ("" = off)
+IncludeGlobs   []string // Only test endpoint paths matching one of these globs (empty = all)
+ExcludeGlobs   []string // Never test endpoint paths matching one of these globs; wins over IncludeGlobs
+AcceptEncoding string   // Accept-Encoding sent with test requests, e.g. "gzip, br" (empty = Go's default)
+AssumeContentType string // Response Content-Type responses are validated as, for servers that mislabel them, e.g. "application/json" (empty = as sent)
+ResponseValidationMode string // "lenient" (default) ignores response body fields the schema doesn't document; "strict" fails them
+APIVersionHeader APIVersionHeader // Header picking the API version, sent with every test request (empty name = off)
+RequestHeaders RequestHeaders // Prefer, If-Match and If-None-Match headers sent with every test request (empty = not sent)
+RealisticData  bool     // Generate plausible values for well-known body fields instead of "sample"
+RandomData     bool     // Generate random values the schema allows instead of placeholders like "sample" and 1
+DataSeed       int64    // Seed for realistic and random data, so runs send the same values
+GlobalQuery    map[string]string // Query parameters added to every request, e.g. api-version
+DateTimeFormat string   // Generated date and date-time values: "rfc3339" (default) or "unix"
+TestOrder      string   // Order operations are tested and listed in: "alphabetical" (default) or "spec"
+TestAllExamples bool    // Test an operation once per named request body example instead of once
+ExpandEnumParams bool   // Test an operation once per combination of its path and query parameter enum values
+OmitOptionalBodies bool // Send no body to operations whose requestBody is not required
+ContractTests  bool     // Test each operation with valid input (expecting 2xx) and invalid input (expecting 4xx)
+AllowBodyOnAllMethods bool // Send generated bodies on GET, DELETE, ... when the operation declares a requestBody
+ExpectedStatuses map[string][]int // Statuses that pass for "METHOD path" endpoints instead of 2xx, e.g. "POST /login": [303]
+PreflightPath  string   // Path checked with a GET before a test run, e.g. "/health"; a failure aborts the run (empty = off)
+MaxRunDuration time.Duration // Longest a test run may take; endpoints not done by then are reported as skipped (0 = no limit)
+DialTimeout    time.Duration // Longest a test request may take to resolve its host and connect (0 = the request timeout)
+SpecFetchTimeout int    // Seconds allowed for downloading a spec given as a URL (0 = 30)
+SpecMaxSizeMB  int      // Largest spec downloaded from a URL, in megabytes (0 = 10)
+ExampleMaxDepth int     // Deepest example nesting checked by spec validation (0 = 64)
+CheckResponseConsistency bool // Warn during spec validation about response schemas out of line with the rest of the spec
+Variables      map[string]string // Values for ${NAME} tokens in custom request headers and bodies; checked before the environment
+MenuOrder      []string // Main menu items to list first, in this order; the rest follow in the default order
+HiddenMenuItems []string // Main menu items to leave out (quit is always shown)
+RunMetadata    map[string]string // Values recorded in every export, e.g. a CI build number or release
+ExportFailuresOnly bool // JSON, HTML and JUnit exports list only failing results, with totals for the whole run
+BaselineFile   string // JSON results export each run is compared against for regressions (empty = none)
+LatencyTolerance float64 // Fraction an endpoint may slow down from the baseline before it counts as a regression, e.g. 0.2 (0 = not checked)
+MaxAllowedFailures int // Failures a run may have and still pass, for known-flaky endpoints (0 = none)
+ConfirmLargeRuns int   // Ask for confirmation before a run that sends more requests than this (0 = never ask)
+MinPassRate    float64 // Lowest pass percentage, 0-100, at which a run still passes (0 = not checked)
+Profiles       map[string]Profile // Saved test inputs by name
+Warnings       []string // Problems found in the config file when it was loaded (not saved)
+}
+
+// Duration display units for Config.DurationUnit
+const (
+	DurationUnitAuto    = "auto" // Pick µs, ms or s based on magnitude
+	DurationUnitMillis  = "ms"
+	DurationUnitSeconds = "s"
+)
+
+// Classes of failed requests that can be retried, for Config.RetryOn
+const (
+	RetryOnReset       = "reset"       // Connection reset by peer, or a broken pipe
+	RetryOnEOF         = "eof"         // Connection closed mid-request, e.g. an idle keep-alive dropped by a proxy
+	RetryOnTimeout     = "timeout"     // Timeouts and exceeded deadlines
+	RetryOnTLS         = "tls"         // TLS handshake failures
+	RetryOnServerError = "5xx"         // Responses with a 5xx status
+	RetryOnRefused     = "refused"     // Connection refused, usually a server that isn't running
+	RetryOnDNS         = "dns"         // Host name lookup failures
+	RetryOnDNSTimeout  = "dns-timeout" // Host name lookups that timed out or failed temporarily, rather than finding no such host
+	RetryOnUnreachable = "unreachable" // Network is unreachable
+)
+
+// RetryClasses lists every retry class
+var RetryClasses = []string{
+	RetryOnReset, RetryOnEOF, RetryOnTimeout, RetryOnTLS, RetryOnServerError,
+	RetryOnRefused, RetryOnDNS, RetryOnDNSTimeout, RetryOnUnreachable,
+}
+
+// DefaultRetryOn are the retry classes used when Config.RetryOn is empty:
+// transient failures only, since refused connections, unknown hosts and
+// unreachable networks rarely fix themselves within a retry's backoff
+var DefaultRetryOn = []string{RetryOnReset, RetryOnEOF, RetryOnTimeout, RetryOnTLS, RetryOnServerError, RetryOnDNSTimeout}
+
+// ValidRetryClass reports whether name is a known retry class
+func ValidRetryClass(name string) bool {
+	for _, class := range RetryClasses {
+		if class == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Formats for generated date and date-time values, for Config.DateTimeFormat
+const (
+	DateTimeFormatRFC3339 = "rfc3339" // "2024-01-01T00:00:00Z" and "2024-01-01"
+	DateTimeFormatUnix    = "unix"    // Seconds since the epoch, as a number
+)
+
+// ValidDateTimeFormat reports whether format is a known date-time format
+// An empty format means the default, RFC 3339
+func ValidDateTimeFormat(format string) bool {
+	switch format {
+	case "", DateTimeFormatRFC3339, DateTimeFormatUnix:
+		return true
+	}
+	return false
+}
+
+// Orders of a test run's operations, for Config.TestOrder
+const (
+	TestOrderAlphabetical = "alphabetical" // By path, then method
+	TestOrderSpec         = "spec"         // As listed in the spec file
+)
+
+// ValidTestOrder reports whether order is a known test order
+// An empty order means the default, alphabetical
+func ValidTestOrder(order string) bool {
+	switch order {
+	case "", TestOrderAlphabetical, TestOrderSpec:
+		return true
+	}
+	return false
+}
+
+// Response body validation modes, for Config.ResponseValidationMode
+const (
+	ResponseValidationLenient = "lenient" // Undocumented fields pass, even where additionalProperties is false
+	ResponseValidationStrict  = "strict"  // Undocumented fields fail unless additionalProperties allows them
+)
+
+// ValidResponseValidationMode reports whether mode is a known response
+// validation mode. An empty mode means the default, lenient
+func ValidResponseValidationMode(mode string) bool {
+	switch mode {
+	case "", ResponseValidationLenient, ResponseValidationStrict:
+		return true
+	}
+	return false
+}
+
+// Main menu items, as named in Config.MenuOrder and Config.HiddenMenuItems
+const (
+	MenuValidate      = "validate"
+	MenuTestAll       = "test-all"
+	MenuSelectTest    = "select-test"
+	MenuFavorites     = "favorites"
+	MenuTestChanged   = "test-changed"
+	MenuCustomRequest = "custom-request"
+	MenuListRequests  = "list-requests"
+	MenuHistory       = "history"
+	MenuSettings      = "settings"
+	MenuHelp          = "help"
+	MenuQuit          = "quit"
+)
+
+// DefaultMenuOrder lists every main menu item in its default order
+var DefaultMenuOrder = []string{
+	MenuValidate, MenuTestAll, MenuSelectTest, MenuFavorites, MenuTestChanged,
+	MenuCustomRequest, MenuListRequests, MenuHistory, MenuSettings, MenuHelp, MenuQuit,
+}
+
+// ValidMenuItem reports whether name is a known main menu item
+func ValidMenuItem(name string) bool {
+	for _, item := range DefaultMenuOrder {
+		if item == name {
+			return true
+		}
+	}
+	return false
+}
+
+// MenuItems returns the main menu items to show, in order: those named in
+// cfg.MenuOrder first, then the rest in the default order, leaving out
+// cfg.HiddenMenuItems. Unknown and repeated names are skipped, and quit is
+// never hidden so the menu can't end up empty
+func MenuItems(cfg Config) []string {
+	skip := make(map[string]bool)
+	for _, name := range cfg.HiddenMenuItems {
+		if name != MenuQuit {
+			skip[name] = true
+		}
+	}
+
+	items := make([]string, 0, len(DefaultMenuOrder))
+	for _, name := range append(append([]string{}, cfg.MenuOrder...), DefaultMenuOrder...) {
+		if ValidMenuItem(name) && !skip[name] {
+			items = append(items, name)
+			skip[name] = true
+		}
+	}
+	return items
+}
+
+// FormatFixedDuration formats d in a fixed unit
+// Returns false for "auto" or an unknown unit, leaving formatting to the caller
+func FormatFixedDuration(d time.Duration, unit string) (string, bool) {
+	switch unit {
+	case DurationUnitMillis:
+		return fmt.Sprintf("%dms", d.Milliseconds()), true
+	case DurationUnitSeconds:
+		return fmt.Sprintf("%.3fs", d.Seconds()), true
+	}
+	return "", false
+}
+
+// FormatDurationUnit formats d in the configured unit
+// "auto" falls back to the caller's human-readable formatter
+func FormatDurationUnit(d time.Duration, unit string, auto func(time.Duration) string) string {
+	if s, ok := FormatFixedDuration(d, unit); ok {
+		return s
+	}
+	return auto(d)
+}
+
+// ValidDurationUnit reports whether unit is a known duration display unit
+// An empty unit means the default, "auto"
+func ValidDurationUnit(unit string) bool {
+	switch unit {
+	case "", DurationUnitAuto, DurationUnitMillis, DurationUnitSeconds:
+		return true
+	}
+	return false
 }
 
 // ConfigFile represents the YAML configuration file structure
@@ -173,9 +657,66 @@ VerboseMode    bool   `yaml:"verboseMode"`
 MaxConcurrency int    `yaml:"maxConcurrency,omitempty"`
 MaxRetries     int    `yaml:"maxRetries,omitempty"`
 RetryDelay     int    `yaml:"retryDelay,omitempty"`
+RetryOn        []string `yaml:"retryOn,omitempty"`
+RetryStatuses  []int    `yaml:"retryStatuses,omitempty"`
+OverridesFile  string `yaml:"overridesFile,omitempty"`
+PinnedEndpoints []string `yaml:"pinnedEndpoints,omitempty"`
+DurationUnit   string `yaml:"durationUnit,omitempty"`
+CacheResponses bool   `yaml:"cacheResponses,omitempty"`
+ForceScheme    string `yaml:"forceScheme,omitempty"`
+LogFile        string `yaml:"logFile,omitempty"`
+ReplayFile     string `yaml:"replayFile,omitempty"`
+RecordFile     string `yaml:"recordFile,omitempty"`
+CaptureHeaders []string `yaml:"captureHeaders,omitempty"`
+RequiredResponseFields []string `yaml:"requiredResponseFields,omitempty"`
+ValidateBeforeTest bool `yaml:"validateBeforeTest,omitempty"`
+QuickValidate  bool     `yaml:"quickValidate,omitempty"`
+PreviousSpec   string   `yaml:"previousSpec,omitempty"`
+SkipDeprecatedParams bool `yaml:"skipDeprecatedParams,omitempty"`
+ExclusiveParamsExtension string `yaml:"exclusiveParamsExtension,omitempty"`
+IncludeGlobs   []string `yaml:"includeGlobs,omitempty"`
+ExcludeGlobs   []string `yaml:"excludeGlobs,omitempty"`
+AcceptEncoding string   `yaml:"acceptEncoding,omitempty"`
+AssumeContentType string `yaml:"assumeContentType,omitempty"`
+ResponseValidationMode string `yaml:"responseValidationMode,omitempty"`
+APIVersionHeader *APIVersionHeader `yaml:"apiVersionHeader,omitempty"`
+RequestHeaders *RequestHeaders `yaml:"requestHeaders,omitempty"`
+RealisticData  bool     `yaml:"realisticData,omitempty"`
+RandomData     bool     `yaml:"randomData,omitempty"`
+DataSeed       int64    `yaml:"dataSeed,omitempty"`
+GlobalQuery    map[string]string `yaml:"globalQuery,omitempty"`
+DateTimeFormat string   `yaml:"dateTimeFormat,omitempty"`
+TestOrder      string   `yaml:"testOrder,omitempty"`
+TestAllExamples bool    `yaml:"testAllExamples,omitempty"`
+ExpandEnumParams bool   `yaml:"expandEnumParams,omitempty"`
+OmitOptionalBodies bool `yaml:"omitOptionalBodies,omitempty"`
+ContractTests  bool     `yaml:"contractTests,omitempty"`
+AllowBodyOnAllMethods bool `yaml:"allowBodyOnAllMethods,omitempty"`
+ExpectedStatuses map[string][]int `yaml:"expectedStatuses,omitempty"`
+PreflightPath  string   `yaml:"preflightPath,omitempty"`
+MaxRunDuration string   `yaml:"maxRunDuration,omitempty"`
+DialTimeout    string   `yaml:"dialTimeout,omitempty"`
+SpecFetchTimeout int    `yaml:"specFetchTimeout,omitempty"`
+SpecMaxSizeMB  int      `yaml:"specMaxSizeMB,omitempty"`
+ExampleMaxDepth int     `yaml:"exampleMaxDepth,omitempty"`
+CheckResponseConsistency bool `yaml:"checkResponseConsistency,omitempty"`
+Variables      map[string]string `yaml:"variables,omitempty"`
+MenuOrder      []string `yaml:"menuOrder,omitempty"`
+HiddenMenuItems []string `yaml:"hiddenMenuItems,omitempty"`
+RunMetadata    map[string]string `yaml:"runMetadata,omitempty"`
+ExportFailuresOnly bool `yaml:"exportFailuresOnly,omitempty"`
+BaselineFile   string `yaml:"baselineFile,omitempty"`
+TokenCommand   string `yaml:"tokenCommand,omitempty"`
+TokenCommandJSONPath string `yaml:"tokenCommandJSONPath,omitempty"`
+LatencyTolerance float64 `yaml:"latencyTolerance,omitempty"`
+MaxAllowedFailures int `yaml:"maxAllowedFailures,omitempty"`
+ConfirmLargeRuns int   `yaml:"confirmLargeRuns,omitempty"`
+MinPassRate    float64 `yaml:"minPassRate,omitempty"`
+Profiles       map[string]Profile `yaml:"profiles,omitempty"`
 Auth           *struct {
 Type       string `yaml:"type"`
 Token      string `yaml:"token,omitempty"`
+BearerPrefix string `yaml:"bearerPrefix,omitempty"`
 APIKeyIn   string `yaml:"apiKeyIn,omitempty"`
 APIKeyName string `yaml:"apiKeyName,omitempty"`
 Username   string `yaml:"username,omitempty"`
@@ -190,7 +731,14 @@ type ExportResult struct {
 	Status     string `json:"status"`
 	Message    string `json:"message"`
 	Duration   string `json:"duration"`
+	Passed     bool   `json:"passed"`               // Whether the result passed, judged against its case and expected statuses
+	Case       string `json:"case,omitempty"`       // CaseValid or CaseInvalid in contract runs
+	Example    string `json:"example,omitempty"`    // Request body example sent, when testing all examples
+	EnumValues string `json:"enumValues,omitempty"` // Enum parameter values sent, when expanding enum parameters
 	RetryCount int    `json:"retryCount,omitempty"` // Number of retries performed
+	RequestURL  string `json:"requestUrl,omitempty"`  // Resolved URL, with secret query values redacted
+	RequestBody string `json:"requestBody,omitempty"` // Body sent, only when captured in verbose mode
+	Auth        string `json:"auth,omitempty"`        // Auth applied to the request, e.g. "bearer" or "none"
 }
 
 // ExportData represents the complete export structure
@@ -201,6 +749,7 @@ BaseURL    string         `json:"baseUrl"`
 	TotalTests int            `json:"totalTests"`
 	Passed     int            `json:"passed"`
 	Failed     int            `json:"failed"`
+	Metadata   ExportMetadata `json:"metadata"`
 	Results    []ExportResult `json:"results"`
 }
 
@@ -219,6 +768,7 @@ type ConfigEditorModel struct {
 	VerboseInput      textinput.Model
 	MaxRetriesInput   textinput.Model   // Retry configuration
 	RetryDelayInput   textinput.Model   // Retry delay in milliseconds
+	VersionHeaderInput textinput.Model  // API version header as "Name: value"
 	OriginalConfig    Config            // Store original config for cancel
 	ValidationError   string
 }
\ No newline at end of file
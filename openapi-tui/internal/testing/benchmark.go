@@ -0,0 +1,99 @@
+package testing
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/Traves-Theberge/OpenAPI-Toolkit/openapi-tui/internal/models"
+)
+
+// BenchmarkEndpoint sends req over and over from concurrency workers until
+// duration has passed, and reports the total requests and requests per
+// second. Unlike a test run, responses are not validated: the body is read
+// and discarded so connections are reused.
+// Requests still in flight when the duration ends are cancelled and not
+// counted. Headers on req are sent as-is, so auth goes there too.
+func BenchmarkEndpoint(req models.CustomRequest, duration time.Duration, concurrency int) (models.BenchmarkResult, error) {
+	if duration <= 0 {
+		return models.BenchmarkResult{}, fmt.Errorf("benchmark duration must be positive, got %s", duration)
+	}
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	target, err := url.Parse(req.Endpoint)
+	if err != nil {
+		return models.BenchmarkResult{}, fmt.Errorf("invalid endpoint URL: %w", err)
+	}
+	if len(req.QueryParams) > 0 {
+		query := target.Query()
+		for k, v := range req.QueryParams {
+			query.Set(k, v)
+		}
+		target.RawQuery = query.Encode()
+	}
+	var body []byte
+	if req.Body != "" {
+		body = []byte(req.Body)
+	}
+
+	// The default transport keeps only 2 idle connections per host, which
+	// would make workers past the second reconnect on every request
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConnsPerHost = concurrency
+	defer transport.CloseIdleConnections()
+
+	ctx, cancel := context.WithTimeout(context.Background(), duration)
+	defer cancel()
+
+	var mu sync.Mutex
+	result := models.BenchmarkResult{
+		Method:       req.Method,
+		Endpoint:     target.String(),
+		Concurrency:  concurrency,
+		StatusCounts: make(map[int]int),
+	}
+
+	var wg sync.WaitGroup
+	start := time.Now()
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for ctx.Err() == nil {
+				status, resp, _, err := sendRequest(ctx, req.Method, target.String(), body, nil, req.Headers, nil, false, transport)
+				if resp != nil && resp.Body != nil {
+					io.Copy(io.Discard, resp.Body)
+					resp.Body.Close()
+				}
+				if ctx.Err() != nil {
+					// Cut off by the end of the benchmark
+					return
+				}
+
+				mu.Lock()
+				result.TotalRequests++
+				if err != nil || status >= 400 {
+					result.Failures++
+				}
+				if err == nil {
+					result.StatusCounts[status]++
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	result.Duration = time.Since(start)
+	if result.Duration > duration {
+		result.Duration = duration
+	}
+	result.RequestsPerSecond = float64(result.TotalRequests) / result.Duration.Seconds()
+	return result, nil
+}
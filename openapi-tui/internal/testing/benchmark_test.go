@@ -0,0 +1,64 @@
+package testing
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Traves-Theberge/OpenAPI-Toolkit/openapi-tui/internal/models"
+)
+
+func TestBenchmarkEndpoint(t *testing.T) {
+	var served int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt64(&served, 1)
+		w.Write([]byte(`{"ok":true}`))
+	}))
+	defer server.Close()
+
+	duration := 300 * time.Millisecond
+	result, err := BenchmarkEndpoint(models.CustomRequest{Method: "GET", Endpoint: server.URL + "/ping"}, duration, 4)
+	if err != nil {
+		t.Fatalf("BenchmarkEndpoint() error = %v", err)
+	}
+
+	if result.TotalRequests < 10 {
+		t.Errorf("Expected a fast server to answer many requests, got %d", result.TotalRequests)
+	}
+	if int64(result.TotalRequests) > atomic.LoadInt64(&served) {
+		t.Errorf("Counted %d requests but the server only saw %d", result.TotalRequests, served)
+	}
+	if result.Failures != 0 || result.StatusCounts[200] != result.TotalRequests {
+		t.Errorf("Expected every request to succeed, got %d failures and %v", result.Failures, result.StatusCounts)
+	}
+	if result.Duration <= 0 || result.Duration > duration {
+		t.Errorf("Expected the duration to be capped at %s, got %s", duration, result.Duration)
+	}
+	want := float64(result.TotalRequests) / result.Duration.Seconds()
+	if result.RequestsPerSecond != want || result.RequestsPerSecond < float64(result.TotalRequests)/duration.Seconds() {
+		t.Errorf("Expected RPS of %.1f, got %.1f", want, result.RequestsPerSecond)
+	}
+}
+
+func TestBenchmarkEndpoint_CountsFailures(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	result, err := BenchmarkEndpoint(models.CustomRequest{Method: "GET", Endpoint: server.URL}, 100*time.Millisecond, 2)
+	if err != nil {
+		t.Fatalf("BenchmarkEndpoint() error = %v", err)
+	}
+	if result.TotalRequests == 0 || result.Failures != result.TotalRequests {
+		t.Errorf("Expected every request to fail, got %d of %d", result.Failures, result.TotalRequests)
+	}
+}
+
+func TestBenchmarkEndpoint_InvalidDuration(t *testing.T) {
+	if _, err := BenchmarkEndpoint(models.CustomRequest{Method: "GET", Endpoint: "http://localhost"}, 0, 1); err == nil {
+		t.Error("Expected an error for a zero duration")
+	}
+}
@@ -0,0 +1,156 @@
+package testing
+
+import (
+	"encoding/json"
+	"net/url"
+	"sort"
+
+	"github.com/Traves-Theberge/OpenAPI-Toolkit/openapi-tui/internal/models"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// contractJobs marks the jobs sending valid input for job's operation, job
+// itself or one per request body example, and adds one job sending invalid
+// input derived from job, for contract runs. Operations with no body or
+// required query parameter to break only get the valid jobs.
+func contractJobs(job TestJob, valid []TestJob) []TestJob {
+	jobs := make([]TestJob, 0, len(valid)+1)
+	for _, v := range valid {
+		v.Case = models.CaseValid
+		jobs = append(jobs, v)
+	}
+	if invalid, ok := invalidJob(job); ok {
+		jobs = append(jobs, invalid)
+	}
+	return jobs
+}
+
+// invalidJob derives a job whose input breaks the spec: a JSON body with its
+// required fields left out or a field of the wrong type, or else a request
+// missing its required query parameters
+func invalidJob(job TestJob) (TestJob, bool) {
+	invalid := job
+	invalid.Case = models.CaseInvalid
+	// Hints explain why valid input was rejected; here rejection is the point
+	invalid.Hints = nil
+	// Expected statuses describe success for valid input
+	invalid.ExpectedStatuses = nil
+
+	if schema := jsonBodySchema(job.Operation); schema != nil && job.BodyErr == nil {
+		body, err := json.Marshal(invalidValue(schema))
+		invalid.RequestBody, invalid.BodyErr = body, err
+		return invalid, true
+	}
+
+	required := requiredQueryParams(job.Operation)
+	if len(required) == 0 {
+		return job, false
+	}
+	u, err := url.Parse(job.Endpoint)
+	if err != nil {
+		return job, false
+	}
+	q := u.Query()
+	for _, name := range required {
+		q.Del(name)
+	}
+	u.RawQuery = q.Encode()
+	invalid.Endpoint = u.String()
+	return invalid, true
+}
+
+// jsonBodySchema returns the schema of an operation's JSON request body, if any
+func jsonBodySchema(operation *openapi3.Operation) *openapi3.Schema {
+	if operation == nil || operation.RequestBody == nil || operation.RequestBody.Value == nil {
+		return nil
+	}
+	content := operation.RequestBody.Value.Content.Get("application/json")
+	if content == nil || content.Schema == nil {
+		return nil
+	}
+	return content.Schema.Value
+}
+
+// requiredQueryParams returns the names of an operation's required query parameters
+func requiredQueryParams(operation *openapi3.Operation) []string {
+	if operation == nil {
+		return nil
+	}
+	var names []string
+	for _, ref := range operation.Parameters {
+		if ref == nil || ref.Value == nil {
+			continue
+		}
+		if ref.Value.In == openapi3.ParameterInQuery && ref.Value.Required {
+			names = append(names, ref.Value.Name)
+		}
+	}
+	return names
+}
+
+// invalidValue generates a value that fails the schema. For objects the
+// generated sample loses its required fields, or when none are required its
+// first property gets a value of the wrong type; anything else is replaced by
+// a value of the wrong type.
+func invalidValue(schema *openapi3.Schema) interface{} {
+	if sampleType(schema) != "object" {
+		return wrongTypeValue(schema)
+	}
+
+	sample, ok := GenerateSampleFromSchema(schema).(map[string]interface{})
+	if !ok {
+		sample = make(map[string]interface{})
+	}
+	if len(schema.Required) > 0 {
+		for _, name := range schema.Required {
+			delete(sample, name)
+		}
+		return sample
+	}
+
+	names := make([]string, 0, len(schema.Properties))
+	for name := range schema.Properties {
+		names = append(names, name)
+	}
+	if len(names) == 0 {
+		return "not-an-object"
+	}
+	sort.Strings(names)
+	var prop *openapi3.Schema
+	if ref := schema.Properties[names[0]]; ref != nil {
+		prop = ref.Value
+	}
+	sample[names[0]] = wrongTypeValue(prop)
+	return sample
+}
+
+// wrongTypeValue returns a value whose JSON type the schema does not allow
+func wrongTypeValue(schema *openapi3.Schema) interface{} {
+	if schema != nil && sampleType(schema) == "string" {
+		return 12345
+	}
+	return "not-a-valid-value"
+}
+
+// documentedStatus reports whether the operation documents a response for status,
+// by exact code or its range (e.g. 4XX)
+func documentedStatus(operation *openapi3.Operation, status int) bool {
+	if operation == nil || operation.Responses == nil {
+		return false
+	}
+	return operation.Responses.Status(status) != nil
+}
+
+// invalidInputMessage classifies the response to a request with invalid input
+func invalidInputMessage(status int, documented bool) string {
+	switch {
+	case status >= 400 && status < 500 && documented:
+		return "OK (invalid input rejected)"
+	case status >= 400 && status < 500:
+		return "Invalid input rejected, but the spec documents no such 4xx response"
+	case status >= 500:
+		return "Server error on invalid input (expected a 4xx)"
+	default:
+		return "Invalid input accepted (expected a 4xx)"
+	}
+}
@@ -0,0 +1,145 @@
+package testing
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Traves-Theberge/OpenAPI-Toolkit/openapi-tui/internal/models"
+)
+
+// errRequestCancelled is the error of a request cancelled through RunControl
+// It is not retried.
+var errRequestCancelled = fmt.Errorf("request cancelled by user")
+
+// errRunCancelled is the cause of a run's context once RunControl.CancelRun
+// is called, and the error of every request it stopped or kept from being sent
+var errRunCancelled = fmt.Errorf("run cancelled by user")
+
+// errTimeBudget is the cause of a run's context once RunOptions.MaxRunDuration
+// is spent, and the error of every request it stopped or kept from being sent
+var errTimeBudget = fmt.Errorf("skipped (time budget)")
+
+// RunControl tracks a run's in-flight requests, each with its own context, so
+// a single slow request can be cancelled while the rest of the run carries on
+// Safe for use from multiple workers; a nil RunControl tracks nothing.
+type RunControl struct {
+	mu        sync.Mutex
+	nextID    int
+	inFlight  map[int]*inFlightHandle
+	cancelRun context.CancelCauseFunc // Cancels the run's context, once the run has started
+	cancelled bool                    // CancelRun was called, possibly before the run started
+}
+
+// inFlightHandle pairs an in-flight request with the cancel func of its context
+type inFlightHandle struct {
+	request models.InFlightRequest
+	cancel  context.CancelFunc
+}
+
+// NewRunControl returns an empty RunControl, to be set as RunOptions.Control
+func NewRunControl() *RunControl {
+	return &RunControl{inFlight: make(map[int]*inFlightHandle)}
+}
+
+// runContext returns the run's context, derived from parent, which CancelRun
+// cancels, and a func releasing it once the run is over
+func (c *RunControl) runContext(parent context.Context) (context.Context, func()) {
+	if c == nil {
+		return parent, func() {}
+	}
+	ctx, cancel := context.WithCancelCause(parent)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.cancelRun = cancel
+	if c.cancelled {
+		cancel(errRunCancelled)
+	}
+	return ctx, func() { cancel(nil) }
+}
+
+// CancelRun aborts the whole run: the requests in flight are cancelled and
+// the rest are reported as skipped without being sent
+func (c *RunControl) CancelRun() {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.cancelled = true
+	if c.cancelRun != nil {
+		c.cancelRun(errRunCancelled)
+	}
+}
+
+// begin registers a request and returns its context, derived from the run's
+// context parent, and a func that unregisters it once the request is done
+func (c *RunControl) begin(parent context.Context, method, endpoint string) (context.Context, func()) {
+	if c == nil {
+		return parent, func() {}
+	}
+	ctx, cancel := context.WithCancel(parent)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.nextID++
+	id := c.nextID
+	c.inFlight[id] = &inFlightHandle{
+		request: models.InFlightRequest{ID: id, Method: method, Endpoint: endpoint, Started: time.Now()},
+		cancel:  cancel,
+	}
+
+	return ctx, func() {
+		c.mu.Lock()
+		delete(c.inFlight, id)
+		c.mu.Unlock()
+		cancel()
+	}
+}
+
+// InFlight returns the requests currently in flight, slowest (oldest) first
+func (c *RunControl) InFlight() []models.InFlightRequest {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	requests := make([]models.InFlightRequest, 0, len(c.inFlight))
+	for _, handle := range c.inFlight {
+		requests = append(requests, handle.request)
+	}
+	sort.Slice(requests, func(i, j int) bool {
+		return requests[i].ID < requests[j].ID
+	})
+	return requests
+}
+
+// Cancel aborts the in-flight request with the given ID
+// Returns false when the request is no longer in flight.
+func (c *RunControl) Cancel(id int) bool {
+	if c == nil {
+		return false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	handle, ok := c.inFlight[id]
+	if ok {
+		handle.cancel()
+	}
+	return ok
+}
+
+// CancelSlowest aborts the request that has been in flight the longest
+// Returns false when nothing is in flight.
+func (c *RunControl) CancelSlowest() (models.InFlightRequest, bool) {
+	requests := c.InFlight()
+	if len(requests) == 0 {
+		return models.InFlightRequest{}, false
+	}
+	return requests[0], c.Cancel(requests[0].ID)
+}
@@ -0,0 +1,247 @@
+package testing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Traves-Theberge/OpenAPI-Toolkit/openapi-tui/internal/models"
+)
+
+func TestRunControl_CancelSingleRequest(t *testing.T) {
+	fastRelease := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/slow":
+			// Hang until the client gives up on the request
+			<-r.Context().Done()
+		case "/fast":
+			<-fastRelease
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+
+	specContent := `
+openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /slow:
+    get:
+      responses:
+        '200':
+          description: OK
+  /fast:
+    get:
+      responses:
+        '200':
+          description: OK
+`
+	specPath := createTempSpec(t, specContent)
+
+	control := NewRunControl()
+	done := make(chan struct{})
+	var results []struct{ Endpoint, Status, Message string }
+	go func() {
+		defer close(done)
+		rs, err := RunTestsWithOptions(specPath, server.URL, RunOptions{MaxConcurrency: 2, MaxRetries: 3, Control: control}, nil)
+		if err != nil {
+			t.Errorf("RunTestsWithOptions failed: %v", err)
+		}
+		for _, r := range rs {
+			results = append(results, struct{ Endpoint, Status, Message string }{r.Endpoint, r.Status, r.Message})
+		}
+	}()
+
+	// Wait for both requests to be in flight
+	deadline := time.Now().Add(5 * time.Second)
+	for len(control.InFlight()) < 2 {
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected 2 requests in flight, got %+v", control.InFlight())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// Cancel only /slow, then let /fast answer
+	for _, r := range control.InFlight() {
+		if r.Endpoint == "/slow" && !control.Cancel(r.ID) {
+			t.Fatal("Expected /slow to be cancellable")
+		}
+	}
+	close(fastRelease)
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run did not finish after cancelling /slow")
+	}
+
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 results, got %+v", results)
+	}
+	for _, r := range results {
+		switch r.Endpoint {
+		case "/slow":
+			if r.Status != "ERR" || r.Message != errRequestCancelled.Error() {
+				t.Errorf("Expected /slow to be cancelled without retries, got %+v", r)
+			}
+		case "/fast":
+			if r.Status != "200" {
+				t.Errorf("Expected /fast to complete, got %+v", r)
+			}
+		}
+	}
+	if inFlight := control.InFlight(); len(inFlight) != 0 {
+		t.Errorf("Expected nothing in flight after the run, got %+v", inFlight)
+	}
+}
+
+func TestRunControl_CancelSlowest(t *testing.T) {
+	control := NewRunControl()
+	firstCtx, firstDone := control.begin(context.Background(), "GET", "/first")
+	defer firstDone()
+	secondCtx, secondDone := control.begin(context.Background(), "GET", "/second")
+	defer secondDone()
+
+	cancelled, ok := control.CancelSlowest()
+	if !ok || cancelled.Endpoint != "/first" {
+		t.Fatalf("Expected /first to be cancelled, got %+v (%v)", cancelled, ok)
+	}
+	if firstCtx.Err() == nil || secondCtx.Err() != nil {
+		t.Error("Expected only the oldest request's context to be cancelled")
+	}
+
+	// A nil control tracks nothing
+	var none *RunControl
+	if _, ok := none.CancelSlowest(); ok {
+		t.Error("Expected nothing to cancel on a nil control")
+	}
+}
+
+func TestRunControl_CancelRun(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Hang until the client gives up on the request
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	specPath := createTempSpec(t, `
+openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /a:
+    get:
+      responses:
+        '200':
+          description: OK
+  /b:
+    get:
+      responses:
+        '200':
+          description: OK
+`)
+
+	control := NewRunControl()
+	done := make(chan []string)
+	go func() {
+		results, err := RunTestsWithOptions(specPath, server.URL, RunOptions{MaxConcurrency: 1, MaxRetries: 3, Control: control}, nil)
+		if err != nil {
+			t.Errorf("RunTestsWithOptions failed: %v", err)
+		}
+		var messages []string
+		for _, r := range results {
+			messages = append(messages, r.Status+" "+r.Message)
+		}
+		done <- messages
+	}()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for len(control.InFlight()) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("Expected a request in flight")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	control.CancelRun()
+
+	select {
+	case messages := <-done:
+		want := models.StatusSkipped + " " + errRunCancelled.Error()
+		if len(messages) != 2 || messages[0] != want || messages[1] != want {
+			t.Errorf("Expected both requests to be stopped by the cancelled run, got %q", messages)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run did not finish after being cancelled")
+	}
+
+	// Cancelling before the run starts stops it too
+	early := NewRunControl()
+	early.CancelRun()
+	results, err := RunTestsWithOptions(specPath, server.URL, RunOptions{Control: early}, nil)
+	if err != nil || len(results) != 2 || results[0].Message != errRunCancelled.Error() {
+		t.Errorf("Expected a run cancelled up front to send nothing, got %+v (%v)", results, err)
+	}
+}
+
+func TestRunTestsWithOptions_MaxRunDuration(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/a" {
+			// Slow endpoints answer long after the budget is spent
+			select {
+			case <-r.Context().Done():
+			case <-time.After(5 * time.Second):
+			}
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	specPath := createTempSpec(t, `
+openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /a:
+    get:
+      responses:
+        '200':
+          description: OK
+  /b:
+    get:
+      responses:
+        '200':
+          description: OK
+  /c:
+    get:
+      responses:
+        '200':
+          description: OK
+`)
+
+	start := time.Now()
+	results, err := RunTestsWithOptions(specPath, server.URL, RunOptions{MaxConcurrency: 1, MaxRetries: 3, MaxRunDuration: 200 * time.Millisecond}, nil)
+	if err != nil {
+		t.Fatalf("RunTestsWithOptions failed: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 3*time.Second {
+		t.Errorf("Expected the run to stop at its time budget, took %v", elapsed)
+	}
+	if len(results) != 3 {
+		t.Fatalf("Expected a result per endpoint, got %+v", results)
+	}
+	if results[0].Endpoint != "/a" || results[0].Status != "200" {
+		t.Errorf("Expected /a to finish within the budget, got %+v", results[0])
+	}
+	for _, r := range results[1:] {
+		if !r.Skipped() || r.Message != errTimeBudget.Error() || r.RetryCount != 0 {
+			t.Errorf("Expected %s to be skipped by the time budget without retries, got %+v", r.Endpoint, r)
+		}
+	}
+}
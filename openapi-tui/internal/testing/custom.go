@@ -6,15 +6,18 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"os"
+	"regexp"
 	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/Traves-Theberge/OpenAPI-Toolkit/openapi-tui/internal/models"
+	"github.com/Traves-Theberge/OpenAPI-Toolkit/openapi-tui/internal/validation"
 )
 
 // ExecuteCustomRequest executes a manually created API request
-func ExecuteCustomRequest(method, endpoint string, headers map[string]string, body string, auth *models.AuthConfig, verbose bool) (models.TestResult, error) {
+func ExecuteCustomRequest(method, endpoint string, runHeaders, headers map[string]string, body string, auth *models.AuthConfig, verbose bool) (models.TestResult, error) {
 	startTime := time.Now()
 
 	// Validate method
@@ -31,18 +34,22 @@ func ExecuteCustomRequest(method, endpoint string, headers map[string]string, bo
 	}
 
 	// Validate and parse body if present
+	// Only JSON bodies are checked; an explicit non-JSON Content-Type (XML,
+	// form data, plain text) is sent as typed
 	var bodyReader io.Reader
 	if body != "" {
-		// Try to parse as JSON to validate
-		var jsonData interface{}
-		if err := json.Unmarshal([]byte(body), &jsonData); err != nil {
-			return models.TestResult{
-				Method:   method,
-				Endpoint: endpoint,
-				Status:   "ERR",
-				Message:  fmt.Sprintf("Invalid JSON body: %v", err),
-				Duration: time.Since(startTime),
-			}, fmt.Errorf("invalid JSON body: %w", err)
+		contentType := validation.HeaderValue(headers, "Content-Type")
+		if contentType == "" || validation.IsJSONContentType(contentType) {
+			var jsonData interface{}
+			if err := json.Unmarshal([]byte(body), &jsonData); err != nil {
+				return models.TestResult{
+					Method:   method,
+					Endpoint: endpoint,
+					Status:   "ERR",
+					Message:  fmt.Sprintf("Invalid JSON body: %v", err),
+					Duration: time.Since(startTime),
+				}, fmt.Errorf("invalid JSON body: %w", err)
+			}
 		}
 		bodyReader = bytes.NewBufferString(body)
 	}
@@ -59,32 +66,15 @@ func ExecuteCustomRequest(method, endpoint string, headers map[string]string, bo
 		}, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	// Set custom headers
-	for key, value := range headers {
-		req.Header.Set(key, value)
-	}
-
-	// Set Content-Type if body is present and not already set
-	if body != "" && req.Header.Get("Content-Type") == "" {
-		req.Header.Set("Content-Type", "application/json")
-	}
-
-	// Apply authentication
-	if auth != nil {
-		switch auth.AuthType {
-		case "Bearer":
-			req.Header.Set("Authorization", "Bearer "+auth.Token)
-		case "API Key":
-			if auth.APIKeyIn == "header" {
-				req.Header.Set(auth.APIKeyName, auth.Token)
-			} else if auth.APIKeyIn == "query" {
-				q := req.URL.Query()
-				q.Add(auth.APIKeyName, auth.Token)
-				req.URL.RawQuery = q.Encode()
-			}
-		case "Basic":
-			req.SetBasicAuth(auth.Username, auth.Password)
-		}
+	// Default JSON Content-Type, run-wide and custom headers, then authentication
+	if err := ApplyMutators(req, defaultContentType(body != ""), setHeaders(runHeaders), setHeaders(headers), customAuth(auth)); err != nil {
+		return models.TestResult{
+			Method:   method,
+			Endpoint: endpoint,
+			Status:   "ERR",
+			Message:  fmt.Sprintf("Failed to prepare request: %v", err),
+			Duration: time.Since(startTime),
+		}, fmt.Errorf("failed to prepare request: %w", err)
 	}
 
 	// Execute request
@@ -127,6 +117,16 @@ func ExecuteCustomRequest(method, endpoint string, headers map[string]string, bo
 		}, err
 	}
 	defer resp.Body.Close()
+	if err := decodeResponseBody(resp); err != nil {
+		return models.TestResult{
+			Method:   method,
+			Endpoint: endpoint,
+			Status:   "ERR",
+			Message:  err.Error(),
+			Duration: duration,
+			LogEntry: logEntry,
+		}, err
+	}
 
 	// Read response body
 	responseBody, err := io.ReadAll(resp.Body)
@@ -171,10 +171,35 @@ func ExecuteCustomRequest(method, endpoint string, headers map[string]string, bo
 	}, nil
 }
 
+// customAuth applies authentication as named in the custom request form
+// ("Bearer", "API Key", "Basic")
+func customAuth(auth *models.AuthConfig) RequestMutator {
+	return func(req *http.Request) error {
+		if auth == nil {
+			return nil
+		}
+		switch auth.AuthType {
+		case "Bearer":
+			req.Header.Set("Authorization", bearerPrefix(auth)+" "+auth.Token)
+		case "API Key":
+			if auth.APIKeyIn == "header" {
+				req.Header.Set(auth.APIKeyName, auth.Token)
+			} else if auth.APIKeyIn == "query" {
+				q := req.URL.Query()
+				q.Add(auth.APIKeyName, auth.Token)
+				req.URL.RawQuery = q.Encode()
+			}
+		case "Basic":
+			req.SetBasicAuth(auth.Username, auth.Password)
+		}
+		return nil
+	}
+}
+
 // ExecuteCustomRequestCmd wraps ExecuteCustomRequest as a Bubble Tea command
-func ExecuteCustomRequestCmd(method, endpoint string, headers map[string]string, body string, auth *models.AuthConfig, verbose bool) tea.Cmd {
+func ExecuteCustomRequestCmd(method, endpoint string, runHeaders, headers map[string]string, body string, auth *models.AuthConfig, verbose bool) tea.Cmd {
 	return func() tea.Msg {
-		result, err := ExecuteCustomRequest(method, endpoint, headers, body, auth, verbose)
+		result, err := ExecuteCustomRequest(method, endpoint, runHeaders, headers, body, auth, verbose)
 		if err != nil {
 			return TestErrorMsg{Err: err}
 		}
@@ -191,6 +216,15 @@ func ValidateJSONBody(body string) error {
 	return json.Unmarshal([]byte(body), &jsonData)
 }
 
+// ValidateRequestBody validates a request body as JSON unless headers set an
+// explicit non-JSON Content-Type
+func ValidateRequestBody(headers map[string]string, body string) error {
+	if contentType := validation.HeaderValue(headers, "Content-Type"); contentType != "" && !validation.IsJSONContentType(contentType) {
+		return nil
+	}
+	return ValidateJSONBody(body)
+}
+
 // FormatJSONBody formats a JSON string with indentation
 func FormatJSONBody(body string) (string, error) {
 	if body == "" {
@@ -206,3 +240,81 @@ func FormatJSONBody(body string) (string, error) {
 	}
 	return string(formatted), nil
 }
+
+// variableRe matches ${NAME} tokens in custom request headers and bodies
+var variableRe = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// lookupVariable resolves a ${NAME} token from the configured variables,
+// then the environment
+func lookupVariable(name string, vars map[string]string) (string, bool) {
+	if value, ok := vars[name]; ok {
+		return value, true
+	}
+	return os.LookupEnv(name)
+}
+
+// ExpandVariables replaces ${NAME} tokens in s with their values from vars or
+// the environment. Unknown tokens are left as typed
+func ExpandVariables(s string, vars map[string]string) string {
+	return variableRe.ReplaceAllStringFunc(s, func(token string) string {
+		if value, ok := lookupVariable(variableRe.FindStringSubmatch(token)[1], vars); ok {
+			return value
+		}
+		return token
+	})
+}
+
+// ExpandJSONVariables replaces ${NAME} tokens in a JSON body like
+// ExpandVariables, escaping values that land inside JSON strings so quotes
+// and backslashes in them keep the body valid. Tokens outside strings are
+// replaced verbatim, so {"count": ${COUNT}} can insert a number
+func ExpandJSONVariables(body string, vars map[string]string) string {
+	var b strings.Builder
+	inString := false
+	for i := 0; i < len(body); i++ {
+		c := body[i]
+		switch {
+		case inString && c == '\\' && i+1 < len(body):
+			b.WriteByte(c)
+			i++
+			b.WriteByte(body[i])
+			continue
+		case c == '"':
+			inString = !inString
+		case strings.HasPrefix(body[i:], "${"):
+			if loc := variableRe.FindStringSubmatchIndex(body[i:]); loc != nil && loc[0] == 0 {
+				token := body[i : i+loc[1]]
+				value, ok := lookupVariable(body[i+loc[2]:i+loc[3]], vars)
+				switch {
+				case !ok:
+					b.WriteString(token)
+				case inString:
+					quoted, _ := json.Marshal(value)
+					b.Write(quoted[1 : len(quoted)-1])
+				default:
+					b.WriteString(value)
+				}
+				i += loc[1] - 1
+				continue
+			}
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
+
+// ExpandRequestVariables expands ${NAME} tokens in header values and the body
+// of a custom request. The body is treated as JSON unless an explicit
+// non-JSON Content-Type is set. headers is not modified
+func ExpandRequestVariables(headers map[string]string, body string, vars map[string]string) (map[string]string, string) {
+	expanded := make(map[string]string, len(headers))
+	for k, v := range headers {
+		expanded[k] = ExpandVariables(v, vars)
+	}
+
+	contentType := validation.HeaderValue(expanded, "Content-Type")
+	if contentType == "" || validation.IsJSONContentType(contentType) {
+		return expanded, ExpandJSONVariables(body, vars)
+	}
+	return expanded, ExpandVariables(body, vars)
+}
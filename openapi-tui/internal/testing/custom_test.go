@@ -1,6 +1,7 @@
 package testing
 
 import (
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -16,7 +17,7 @@ func TestExecuteCustomRequest(t *testing.T) {
 	}))
 	defer server.Close()
 
-	result, err := ExecuteCustomRequest("GET", server.URL+"/test", nil, "", nil, false)
+	result, err := ExecuteCustomRequest("GET", server.URL+"/test", nil, nil, "", nil, false)
 	if err != nil {
 		t.Fatalf("ExecuteCustomRequest failed: %v", err)
 	}
@@ -43,7 +44,7 @@ func TestExecuteCustomRequest_Methods(t *testing.T) {
 			}))
 			defer server.Close()
 
-			result, err := ExecuteCustomRequest(method, server.URL, nil, "", nil, false)
+			result, err := ExecuteCustomRequest(method, server.URL, nil, nil, "", nil, false)
 			if err != nil {
 				t.Fatalf("ExecuteCustomRequest failed for %s: %v", method, err)
 			}
@@ -62,7 +63,7 @@ func TestExecuteCustomRequest_InvalidMethod(t *testing.T) {
 	}))
 	defer server.Close()
 
-	result, err := ExecuteCustomRequest("INVALID", server.URL, nil, "", nil, false)
+	result, err := ExecuteCustomRequest("INVALID", server.URL, nil, nil, "", nil, false)
 	if err == nil {
 		t.Fatal("Expected error for invalid method, got nil")
 	}
@@ -89,7 +90,7 @@ func TestExecuteCustomRequest_WithHeaders(t *testing.T) {
 	}))
 	defer server.Close()
 
-	result, err := ExecuteCustomRequest("GET", server.URL, expectedHeaders, "", nil, false)
+	result, err := ExecuteCustomRequest("GET", server.URL, nil, expectedHeaders, "", nil, false)
 	if err != nil {
 		t.Fatalf("ExecuteCustomRequest failed: %v", err)
 	}
@@ -99,6 +100,26 @@ func TestExecuteCustomRequest_WithHeaders(t *testing.T) {
 	}
 }
 
+// TestExecuteCustomRequest_RunHeaders tests that run-wide headers are sent,
+// and that the request's own headers replace them
+func TestExecuteCustomRequest_RunHeaders(t *testing.T) {
+	var accept, version string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		accept, version = r.Header.Get("Accept"), r.Header.Get("Api-Version")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	runHeaders := map[string]string{"Accept": "application/vnd.company.v2+json", "Api-Version": "2"}
+	headers := map[string]string{"Api-Version": "3"}
+	if _, err := ExecuteCustomRequest("GET", server.URL, runHeaders, headers, "", nil, false); err != nil {
+		t.Fatalf("ExecuteCustomRequest failed: %v", err)
+	}
+	if accept != "application/vnd.company.v2+json" || version != "3" {
+		t.Errorf("Expected the run-wide Accept and the request's Api-Version, got %q and %q", accept, version)
+	}
+}
+
 // TestExecuteCustomRequest_WithBody tests request with JSON body
 func TestExecuteCustomRequest_WithBody(t *testing.T) {
 	expectedBody := `{"name": "test", "value": 123}`
@@ -112,7 +133,7 @@ func TestExecuteCustomRequest_WithBody(t *testing.T) {
 	}))
 	defer server.Close()
 
-	result, err := ExecuteCustomRequest("POST", server.URL, nil, expectedBody, nil, false)
+	result, err := ExecuteCustomRequest("POST", server.URL, nil, nil, expectedBody, nil, false)
 	if err != nil {
 		t.Fatalf("ExecuteCustomRequest failed: %v", err)
 	}
@@ -131,7 +152,7 @@ func TestExecuteCustomRequest_InvalidJSON(t *testing.T) {
 
 	invalidJSON := `{"invalid": json}`
 
-	result, err := ExecuteCustomRequest("POST", server.URL, nil, invalidJSON, nil, false)
+	result, err := ExecuteCustomRequest("POST", server.URL, nil, nil, invalidJSON, nil, false)
 	if err == nil {
 		t.Fatal("Expected error for invalid JSON, got nil")
 	}
@@ -141,6 +162,52 @@ func TestExecuteCustomRequest_InvalidJSON(t *testing.T) {
 	}
 }
 
+// TestExecuteCustomRequest_NonJSONContentType tests that an explicit
+// non-JSON Content-Type is sent as is and skips JSON validation
+func TestExecuteCustomRequest_NonJSONContentType(t *testing.T) {
+	tests := []struct {
+		contentType string
+		body        string
+	}{
+		{"text/xml", `<user><name>Ada</name></user>`},
+		{"application/x-www-form-urlencoded", `name=Ada&age=36`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.contentType, func(t *testing.T) {
+			var gotContentType, gotBody string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotContentType = r.Header.Get("Content-Type")
+				body, _ := io.ReadAll(r.Body)
+				gotBody = string(body)
+				w.WriteHeader(http.StatusCreated)
+			}))
+			defer server.Close()
+
+			headers := map[string]string{"content-type": tt.contentType}
+			result, err := ExecuteCustomRequest("POST", server.URL, nil, headers, tt.body, nil, false)
+			if err != nil {
+				t.Fatalf("ExecuteCustomRequest failed: %v", err)
+			}
+			if result.Status != "201" {
+				t.Errorf("Expected status 201, got %s (%s)", result.Status, result.Message)
+			}
+			if gotContentType != tt.contentType {
+				t.Errorf("Expected Content-Type %s, got %s", tt.contentType, gotContentType)
+			}
+			if gotBody != tt.body {
+				t.Errorf("Expected body %q, got %q", tt.body, gotBody)
+			}
+		})
+	}
+
+	// A JSON Content-Type still requires a JSON body
+	headers := map[string]string{"Content-Type": "application/vnd.api+json"}
+	if _, err := ExecuteCustomRequest("POST", "http://localhost", nil, headers, "<not-json/>", nil, false); err == nil {
+		t.Error("Expected invalid JSON error for a +json Content-Type")
+	}
+}
+
 // TestExecuteCustomRequest_WithAuth tests authentication
 func TestExecuteCustomRequest_WithAuth(t *testing.T) {
 	t.Run("Bearer Token", func(t *testing.T) {
@@ -158,7 +225,7 @@ func TestExecuteCustomRequest_WithAuth(t *testing.T) {
 			Token:    "test-token",
 		}
 
-		result, err := ExecuteCustomRequest("GET", server.URL, nil, "", auth, false)
+		result, err := ExecuteCustomRequest("GET", server.URL, nil, nil, "", auth, false)
 		if err != nil {
 			t.Fatalf("ExecuteCustomRequest failed: %v", err)
 		}
@@ -185,7 +252,7 @@ func TestExecuteCustomRequest_WithAuth(t *testing.T) {
 			APIKeyName: "X-API-Key",
 		}
 
-		result, err := ExecuteCustomRequest("GET", server.URL, nil, "", auth, false)
+		result, err := ExecuteCustomRequest("GET", server.URL, nil, nil, "", auth, false)
 		if err != nil {
 			t.Fatalf("ExecuteCustomRequest failed: %v", err)
 		}
@@ -211,7 +278,7 @@ func TestExecuteCustomRequest_WithAuth(t *testing.T) {
 			Password: "testpass",
 		}
 
-		result, err := ExecuteCustomRequest("GET", server.URL, nil, "", auth, false)
+		result, err := ExecuteCustomRequest("GET", server.URL, nil, nil, "", auth, false)
 		if err != nil {
 			t.Fatalf("ExecuteCustomRequest failed: %v", err)
 		}
@@ -234,7 +301,7 @@ func TestExecuteCustomRequest_VerboseLogging(t *testing.T) {
 	headers := map[string]string{"X-Request-Header": "test"}
 	body := `{"request": "data"}`
 
-	result, err := ExecuteCustomRequest("POST", server.URL, headers, body, nil, true)
+	result, err := ExecuteCustomRequest("POST", server.URL, nil, headers, body, nil, true)
 	if err != nil {
 		t.Fatalf("ExecuteCustomRequest failed: %v", err)
 	}
@@ -338,7 +405,7 @@ func TestExecuteCustomRequestCmd(t *testing.T) {
 	}))
 	defer server.Close()
 
-	cmd := ExecuteCustomRequestCmd("GET", server.URL, nil, "", nil, false)
+	cmd := ExecuteCustomRequestCmd("GET", server.URL, nil, nil, "", nil, false)
 	msg := cmd()
 
 	switch msg := msg.(type) {
@@ -355,3 +422,42 @@ func TestExecuteCustomRequestCmd(t *testing.T) {
 		t.Fatalf("Expected TestCompleteMsg, got %T", msg)
 	}
 }
+
+// TestExpandRequestVariables tests ${NAME} expansion in custom request headers and bodies
+func TestExpandRequestVariables(t *testing.T) {
+	t.Setenv("OPENAPI_TUI_TEST_TOKEN", "s3cret")
+	t.Setenv("OPENAPI_TUI_TEST_NAME", `Jo "JJ" Doe`)
+
+	var gotAuth, gotBody string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		body, _ := io.ReadAll(r.Body)
+		gotBody = string(body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	template := map[string]string{"Authorization": "Bearer ${OPENAPI_TUI_TEST_TOKEN}"}
+	headers, body := ExpandRequestVariables(template,
+		`{"name": "${OPENAPI_TUI_TEST_NAME}", "count": ${COUNT}, "raw": "${OPENAPI_TUI_TEST_UNSET}"}`,
+		map[string]string{"COUNT": "3"})
+
+	if template["Authorization"] != "Bearer ${OPENAPI_TUI_TEST_TOKEN}" {
+		t.Error("Expected the typed headers to be left untouched")
+	}
+
+	result, err := ExecuteCustomRequest("POST", server.URL, nil, headers, body, nil, false)
+	if err != nil {
+		t.Fatalf("ExecuteCustomRequest failed: %v", err)
+	}
+	if result.Status != "200" {
+		t.Errorf("Expected status 200, got %s (%s)", result.Status, result.Message)
+	}
+	if gotAuth != "Bearer s3cret" {
+		t.Errorf("Expected the header variable to be expanded, got %q", gotAuth)
+	}
+	// String values are escaped, bare tokens inserted as-is and unknown tokens kept
+	if want := `{"name": "Jo \"JJ\" Doe", "count": 3, "raw": "${OPENAPI_TUI_TEST_UNSET}"}`; gotBody != want {
+		t.Errorf("Expected body %s, got %s", want, gotBody)
+	}
+}
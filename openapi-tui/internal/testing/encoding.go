@@ -0,0 +1,81 @@
+package testing
+
+import (
+	"bufio"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/andybalholm/brotli"
+)
+
+// decodeResponseBody replaces a gzip, deflate or brotli encoded response body
+// with its decoded stream, so capture and validation see the plain body
+// Go's transport only decodes gzip, and only when it chose the Accept-Encoding
+// header itself; an explicit Accept-Encoding leaves decoding to us.
+// Unknown encodings are left untouched, and so are empty bodies, such as
+// those of HEAD requests and 204 and 304 responses, which keep the header
+func decodeResponseBody(resp *http.Response) error {
+	if resp == nil || resp.Body == nil {
+		return nil
+	}
+	if resp.Request != nil && resp.Request.Method == http.MethodHead {
+		return nil
+	}
+	encoding := strings.ToLower(strings.TrimSpace(resp.Header.Get("Content-Encoding")))
+	switch encoding {
+	case "gzip", "x-gzip", "deflate", "br":
+	default:
+		return nil
+	}
+
+	// Nothing to decode in an empty body
+	buffered := bufio.NewReader(resp.Body)
+	if _, err := buffered.Peek(1); err == io.EOF {
+		resp.Body = struct {
+			io.Reader
+			io.Closer
+		}{buffered, resp.Body}
+		return nil
+	}
+
+	var decoded io.Reader
+	switch encoding {
+	case "gzip", "x-gzip":
+		gz, err := gzip.NewReader(buffered)
+		if err != nil {
+			return fmt.Errorf("failed to decode gzip response: %w", err)
+		}
+		decoded = gz
+	case "deflate":
+		decoded = deflateReader(buffered)
+	case "br":
+		decoded = brotli.NewReader(buffered)
+	}
+
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{decoded, resp.Body}
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+	return nil
+}
+
+// deflateReader decodes an HTTP "deflate" body, which should be zlib-wrapped
+// but is sent as raw DEFLATE by some servers
+func deflateReader(r io.Reader) io.Reader {
+	buffered := bufio.NewReader(r)
+	if header, err := buffered.Peek(2); err == nil && header[0]&0x0f == 8 && (uint16(header[0])<<8|uint16(header[1]))%31 == 0 {
+		if zr, err := zlib.NewReader(buffered); err == nil {
+			return zr
+		}
+	}
+	return flate.NewReader(buffered)
+}
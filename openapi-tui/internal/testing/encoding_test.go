@@ -0,0 +1,146 @@
+package testing
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+)
+
+const encodedJSON = `{"id": 1, "name": "Ada"}`
+
+// encode compresses data with the given HTTP content coding
+func encode(t *testing.T, coding string, data []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	var w io.WriteCloser
+	switch coding {
+	case "gzip":
+		w = gzip.NewWriter(&buf)
+	case "deflate":
+		w = zlib.NewWriter(&buf)
+	case "raw-deflate":
+		w, _ = flate.NewWriter(&buf, flate.DefaultCompression)
+	case "br":
+		w = brotli.NewWriter(&buf)
+	}
+	w.Write(data)
+	w.Close()
+	return buf.Bytes()
+}
+
+func TestDecodeResponseBody(t *testing.T) {
+	tests := []struct {
+		header string
+		coding string
+	}{
+		{"gzip", "gzip"},
+		{"deflate", "deflate"},
+		{"deflate", "raw-deflate"},
+		{"br", "br"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.coding, func(t *testing.T) {
+			resp := &http.Response{
+				Header: http.Header{"Content-Encoding": {tt.header}, "Content-Length": {"10"}},
+				Body:   io.NopCloser(bytes.NewReader(encode(t, tt.coding, []byte(encodedJSON)))),
+			}
+			if err := decodeResponseBody(resp); err != nil {
+				t.Fatalf("decodeResponseBody() error = %v", err)
+			}
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatalf("Failed to read decoded body: %v", err)
+			}
+			if string(body) != encodedJSON {
+				t.Errorf("Expected decoded body %s, got %q", encodedJSON, body)
+			}
+			if resp.Header.Get("Content-Encoding") != "" || resp.Header.Get("Content-Length") != "" {
+				t.Errorf("Expected encoding headers to be removed, got %v", resp.Header)
+			}
+		})
+	}
+
+	t.Run("empty body untouched", func(t *testing.T) {
+		for _, method := range []string{http.MethodGet, http.MethodHead} {
+			resp := &http.Response{
+				StatusCode: http.StatusNoContent,
+				Header:     http.Header{"Content-Encoding": {"gzip"}},
+				Body:       io.NopCloser(bytes.NewReader(nil)),
+				Request:    &http.Request{Method: method},
+			}
+			if err := decodeResponseBody(resp); err != nil {
+				t.Fatalf("%s: decodeResponseBody() error = %v", method, err)
+			}
+			if body, err := io.ReadAll(resp.Body); err != nil || len(body) != 0 {
+				t.Errorf("%s: expected an empty body, got %q (%v)", method, body, err)
+			}
+		}
+	})
+
+	t.Run("unknown encoding untouched", func(t *testing.T) {
+		resp := &http.Response{
+			Header: http.Header{"Content-Encoding": {"zstd"}},
+			Body:   io.NopCloser(bytes.NewReader([]byte("raw"))),
+		}
+		if err := decodeResponseBody(resp); err != nil {
+			t.Fatalf("decodeResponseBody() error = %v", err)
+		}
+		if resp.Header.Get("Content-Encoding") != "zstd" {
+			t.Error("Expected an unknown encoding to be left alone")
+		}
+	})
+}
+
+func TestRunTestsWithOptions_BrotliResponse(t *testing.T) {
+	var gotAcceptEncoding string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAcceptEncoding = r.Header.Get("Accept-Encoding")
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Encoding", "br")
+		w.Write(encode(t, "br", []byte(encodedJSON)))
+	}))
+	defer server.Close()
+
+	specContent := `
+openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /users/1:
+    get:
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: object
+`
+	specPath := createTempSpec(t, specContent)
+
+	results, err := RunTestsWithOptions(specPath, server.URL, RunOptions{Verbose: true, AcceptEncoding: "gzip, br"}, nil)
+	if err != nil {
+		t.Fatalf("RunTestsWithOptions failed: %v", err)
+	}
+	if gotAcceptEncoding != "gzip, br" {
+		t.Errorf("Expected Accept-Encoding 'gzip, br', got %q", gotAcceptEncoding)
+	}
+	if len(results) != 1 || results[0].LogEntry == nil {
+		t.Fatalf("Expected 1 result with a log entry, got %+v", results)
+	}
+	if results[0].LogEntry.ResponseBody != encodedJSON {
+		t.Errorf("Expected decoded response body %s, got %q", encodedJSON, results[0].LogEntry.ResponseBody)
+	}
+	if results[0].Status != "200" {
+		t.Errorf("Expected status 200, got %s (%s)", results[0].Status, results[0].Message)
+	}
+}
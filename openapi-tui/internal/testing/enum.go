@@ -0,0 +1,122 @@
+package testing
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// maxEnumCombinations caps the jobs one operation expands into when
+// expanding enum parameters, so a few large enums can't explode a run
+const maxEnumCombinations = 25
+
+// enumParam is a path or query parameter tested once per enum value
+type enumParam struct {
+	in     string
+	name   string
+	values []string
+}
+
+// enumParams returns the parameters of an operation to test once per enum
+// value: path parameters, then the query parameters the request sends,
+// with two or more scalar enum values, in declared order. Query parameters
+// in fixed, set by the global query or an endpoint override, are left alone
+func enumParams(path string, pathItem *openapi3.PathItem, operation *openapi3.Operation, sent url.Values, fixed map[string]bool) []enumParam {
+	var params []enumParam
+	for _, match := range placeholderPattern.FindAllStringSubmatch(path, -1) {
+		if values := enumValues(pathParameter(match[1], pathItem, operation)); len(values) > 1 {
+			params = append(params, enumParam{in: openapi3.ParameterInPath, name: match[1], values: values})
+		}
+	}
+	for _, paramRef := range operation.Parameters {
+		param := paramRef.Value
+		if param == nil || param.In != openapi3.ParameterInQuery || !sent.Has(param.Name) || fixed[param.Name] {
+			continue
+		}
+		if values := enumValues(param); len(values) > 1 {
+			params = append(params, enumParam{in: openapi3.ParameterInQuery, name: param.Name, values: values})
+		}
+	}
+	return params
+}
+
+// enumValues returns the distinct values of a scalar parameter's enum, as sent
+func enumValues(param *openapi3.Parameter) []string {
+	if param == nil || param.Schema == nil || param.Schema.Value == nil {
+		return nil
+	}
+	switch sampleType(param.Schema.Value) {
+	case "string", "integer", "number", "boolean":
+	default:
+		return nil
+	}
+	var values []string
+	seen := make(map[string]bool)
+	for _, v := range param.Schema.Value.Enum {
+		value := fmt.Sprintf("%v", v)
+		if v == nil || seen[value] {
+			continue
+		}
+		seen[value] = true
+		values = append(values, value)
+	}
+	return values
+}
+
+// enumJobs expands a job into one job per combination of its enum parameter
+// values, up to maxEnumCombinations, each labeled with the values it sends.
+// The job's endpoint is base followed by filledPath, which is filled again
+// for path parameters, and its query. Jobs without enum parameters are kept
+func enumJobs(job TestJob, base, filledPath, path string, pathItem *openapi3.PathItem, params []enumParam) []TestJob {
+	if len(params) == 0 || !strings.HasPrefix(job.Endpoint, base+filledPath) {
+		return []TestJob{job}
+	}
+	query := strings.TrimPrefix(job.Endpoint, base+filledPath)
+
+	combinations := [][]string{nil}
+	total := 1
+	for _, param := range params {
+		total *= len(param.values)
+		var next [][]string
+		for _, combination := range combinations {
+			for _, value := range param.values {
+				if len(next) == maxEnumCombinations {
+					break
+				}
+				next = append(next, append(append([]string(nil), combination...), value))
+			}
+		}
+		combinations = next
+	}
+
+	jobs := make([]TestJob, 0, len(combinations))
+	for _, combination := range combinations {
+		pathValues := make(map[string]string)
+		queryValues := make(map[string]string)
+		labels := make([]string, 0, len(params))
+		for i, param := range params {
+			if param.in == openapi3.ParameterInPath {
+				pathValues[param.name] = combination[i]
+			} else {
+				queryValues[param.name] = combination[i]
+			}
+			labels = append(labels, param.name+"="+combination[i])
+		}
+
+		expanded := job
+		filled, _ := fillPathWith(path, pathItem, job.Operation, pathValues)
+		expanded.Endpoint = base + filled + query
+		if len(queryValues) > 0 {
+			expanded.Endpoint = mergeQuery(expanded.Endpoint, queryValues)
+		}
+		expanded.EnumValues = strings.Join(labels, ", ")
+		if total > maxEnumCombinations {
+			expanded.Hints = append(append([]string(nil), job.Hints...),
+				fmt.Sprintf("enum parameters have %d combinations; only the first %d are tested", total, maxEnumCombinations))
+		}
+		jobs = append(jobs, expanded)
+	}
+	return jobs
+}
@@ -0,0 +1,128 @@
+package testing
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Vendor extensions a spec can set on an operation to change how it is tested
+const (
+	ExtensionSkip    = "x-test-skip"    // true leaves the operation out of test runs
+	ExtensionBody    = "x-test-body"    // Request body to send instead of a generated one; strings are sent verbatim, anything else as JSON
+	ExtensionHeaders = "x-test-headers" // Extra request headers, as a map of names to values
+)
+
+// skippedByExtension reports whether an operation opts out of testing with x-test-skip
+func skippedByExtension(operation *openapi3.Operation) bool {
+	if operation == nil {
+		return false
+	}
+	switch skip := operation.Extensions[ExtensionSkip].(type) {
+	case bool:
+		return skip
+	case string:
+		return strings.EqualFold(skip, "true")
+	}
+	return false
+}
+
+// extensionBody returns the x-test-body of an operation, if it sets one
+func extensionBody(operation *openapi3.Operation) (interface{}, bool) {
+	if operation == nil {
+		return nil, false
+	}
+	body, ok := operation.Extensions[ExtensionBody]
+	return body, ok && body != nil
+}
+
+// extensionHeaders returns the x-test-headers of an operation with canonical
+// names; values that aren't strings are formatted as-is
+func extensionHeaders(operation *openapi3.Operation) map[string]string {
+	if operation == nil {
+		return nil
+	}
+	raw, ok := operation.Extensions[ExtensionHeaders].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	headers := make(map[string]string, len(raw))
+	for name, value := range raw {
+		headers[http.CanonicalHeaderKey(name)] = fmt.Sprint(value)
+	}
+	return headers
+}
+
+// exclusiveParamGroups reads the groups of mutually exclusive query parameter
+// names an operation declares under extension: either one list of names, or
+// a list of such lists. Returns nil when extension is empty or not set
+func exclusiveParamGroups(operation *openapi3.Operation, extension string) [][]string {
+	if operation == nil || extension == "" {
+		return nil
+	}
+	raw, ok := operation.Extensions[extension].([]interface{})
+	if !ok {
+		return nil
+	}
+	var groups [][]string
+	var single []string
+	for _, item := range raw {
+		switch v := item.(type) {
+		case string:
+			single = append(single, v)
+		case []interface{}:
+			var group []string
+			for _, name := range v {
+				if s, ok := name.(string); ok {
+					group = append(group, s)
+				}
+			}
+			groups = append(groups, group)
+		}
+	}
+	if len(single) > 0 {
+		groups = append(groups, single)
+	}
+	return groups
+}
+
+// exclusiveOmissions returns the query parameters to leave out so that at
+// most one of each mutually exclusive group declared under extension is sent:
+// a required member if the group has one, otherwise the first one listed
+// that the operation declares
+func exclusiveOmissions(operation *openapi3.Operation, extension string) map[string]bool {
+	groups := exclusiveParamGroups(operation, extension)
+	if len(groups) == 0 {
+		return nil
+	}
+	declared := make(map[string]*openapi3.Parameter)
+	for _, paramRef := range operation.Parameters {
+		if param := paramRef.Value; param != nil && param.In == "query" {
+			declared[param.Name] = param
+		}
+	}
+
+	omit := make(map[string]bool)
+	for _, group := range groups {
+		kept := ""
+		for _, name := range group {
+			if param, ok := declared[name]; ok && param.Required {
+				kept = name
+				break
+			}
+		}
+		for _, name := range group {
+			if _, ok := declared[name]; ok && kept == "" {
+				kept = name
+			}
+		}
+		for _, name := range group {
+			if name != kept {
+				omit[name] = true
+			}
+		}
+	}
+	return omit
+}
@@ -0,0 +1,178 @@
+package testing
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Word lists the faker draws from
+var (
+	fakeFirstNames = []string{"Ada", "Grace", "Alan", "Linus", "Margaret", "Dennis", "Barbara", "Ken"}
+	fakeLastNames  = []string{"Lovelace", "Hopper", "Turing", "Torvalds", "Hamilton", "Ritchie", "Liskov", "Thompson"}
+	fakeCities     = []string{"London", "Paris", "Berlin", "Toronto", "Sydney", "Tokyo", "Lisbon", "Oslo"}
+	fakeCountries  = []string{"United Kingdom", "France", "Germany", "Canada", "Australia", "Japan", "Portugal", "Norway"}
+	fakeStreets    = []string{"High Street", "Main Street", "Station Road", "Church Lane", "Park Avenue", "Mill Road"}
+	fakeCompanies  = []string{"Acme Corp", "Globex", "Initech", "Umbrella Ltd", "Stark Industries", "Wayne Enterprises"}
+	fakeWords      = []string{"alpha", "bravo", "delta", "orbit", "summit", "harbor", "meadow", "signal"}
+)
+
+// Faker produces plausible values for common field names (email, name, phone,
+// url, date, ...) and string formats, for servers that reject "sample" and 1
+// Values come from a seeded generator, so the same seed and key always give
+// the same values.
+type Faker struct {
+	rng *rand.Rand
+}
+
+// NewFaker returns a faker seeded from seed and key, e.g. an operation's
+// method and path, so each operation gets its own reproducible values
+// whatever order operations are generated in
+func NewFaker(seed int64, key string) *Faker {
+	return &Faker{rng: seededRand(seed, key)}
+}
+
+// Value returns a realistic value for the named field, or false when neither
+// the name nor the format is one the faker knows
+// Only strings and a few well-known integers (age, year) are faked.
+func (f *Faker) Value(name string, schema *openapi3.Schema) (interface{}, bool) {
+	key := strings.NewReplacer("_", "", "-", "").Replace(strings.ToLower(name))
+
+	switch sampleType(schema) {
+	case "string":
+		if value, ok := f.stringByFormat(schema.Format); ok {
+			return value, true
+		}
+		return f.stringByName(key, nameWords(name))
+	case "integer":
+		if schema.Min != nil || schema.Max != nil {
+			return nil, false
+		}
+		switch {
+		case key == "age":
+			return 18 + f.rng.Intn(63), true
+		case key == "year" || strings.HasSuffix(key, "year"):
+			return 1990 + f.rng.Intn(35), true
+		}
+	}
+	return nil, false
+}
+
+// stringByFormat fakes a value for a string format
+func (f *Faker) stringByFormat(format string) (string, bool) {
+	switch format {
+	case "email":
+		return f.email(), true
+	case "uri", "url":
+		return f.url(), true
+	case "date":
+		return f.date(), true
+	case "date-time":
+		return f.date() + fmt.Sprintf("T%02d:%02d:00Z", f.rng.Intn(24), f.rng.Intn(60)), true
+	case "uuid":
+		return f.uuid(), true
+	case "hostname":
+		return f.pick(fakeWords) + ".example.com", true
+	case "ipv4":
+		return fmt.Sprintf("192.0.2.%d", 1+f.rng.Intn(254)), true
+	}
+	return "", false
+}
+
+// stringByName fakes a value for a normalized (lowercase, no separators)
+// field name, or for a name with a telling word in words (see nameWords),
+// so "avatarUrl" is a URL but "curl" isn't; more specific names are checked
+// first
+func (f *Faker) stringByName(key string, words []string) (string, bool) {
+	switch {
+	case key == "email" || slices.Contains(words, "email"):
+		return f.email(), true
+	case key == "firstname" || key == "givenname":
+		return f.pick(fakeFirstNames), true
+	case key == "lastname" || key == "surname" || key == "familyname":
+		return f.pick(fakeLastNames), true
+	case key == "username" || key == "login" || key == "handle":
+		return strings.ToLower(f.pick(fakeFirstNames)) + fmt.Sprintf("%d", 10+f.rng.Intn(90)), true
+	case key == "company" || key == "companyname" || key == "organization":
+		return f.pick(fakeCompanies), true
+	case key == "name" || key == "fullname" || key == "displayname":
+		return f.pick(fakeFirstNames) + " " + f.pick(fakeLastNames), true
+	case slices.Contains(words, "phone") || key == "mobile" || key == "tel":
+		return fmt.Sprintf("+1-555-01%02d", f.rng.Intn(100)), true
+	case slices.Contains(words, "url") || key == "website" || key == "homepage" || key == "link":
+		return f.url(), true
+	case slices.Contains(words, "date") || key == "birthday" || key == "dob":
+		return f.date(), true
+	case key == "city":
+		return f.pick(fakeCities), true
+	case key == "country":
+		return f.pick(fakeCountries), true
+	case key == "street" || key == "address" || key == "addressline1":
+		return fmt.Sprintf("%d %s", 1+f.rng.Intn(200), f.pick(fakeStreets)), true
+	case key == "zip" || key == "zipcode" || key == "postcode" || key == "postalcode":
+		return fmt.Sprintf("%05d", f.rng.Intn(100000)), true
+	case key == "uuid" || key == "guid":
+		return f.uuid(), true
+	}
+	return "", false
+}
+
+// nameWords splits a field name into lowercase words at separators and case
+// changes, e.g. "avatarURL" and "avatar_url" into "avatar" and "url"
+func nameWords(name string) []string {
+	var words []string
+	var word []rune
+	runes := []rune(name)
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			if len(word) > 0 {
+				words = append(words, strings.ToLower(string(word)))
+				word = nil
+			}
+			continue
+		}
+		// A word starts at an upper case letter after a lower case one, or at
+		// the last upper case letter of an acronym followed by lower case
+		if unicode.IsUpper(r) && len(word) > 0 {
+			prev := word[len(word)-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if !unicode.IsUpper(prev) || nextLower {
+				words = append(words, strings.ToLower(string(word)))
+				word = nil
+			}
+		}
+		word = append(word, r)
+	}
+	if len(word) > 0 {
+		words = append(words, strings.ToLower(string(word)))
+	}
+	return words
+}
+
+func (f *Faker) pick(words []string) string {
+	return words[f.rng.Intn(len(words))]
+}
+
+func (f *Faker) email() string {
+	return strings.ToLower(f.pick(fakeFirstNames)+"."+f.pick(fakeLastNames)) + "@example.com"
+}
+
+func (f *Faker) url() string {
+	return "https://example.com/" + f.pick(fakeWords)
+}
+
+func (f *Faker) date() string {
+	return fmt.Sprintf("%d-%02d-%02d", 1990+f.rng.Intn(35), 1+f.rng.Intn(12), 1+f.rng.Intn(28))
+}
+
+func (f *Faker) uuid() string {
+	b := make([]byte, 16)
+	f.rng.Read(b)
+	b[6] = b[6]&0x0f | 0x40 // Version 4
+	b[8] = b[8]&0x3f | 0x80 // RFC 4122 variant
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
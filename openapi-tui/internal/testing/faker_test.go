@@ -0,0 +1,154 @@
+package testing
+
+import (
+	"encoding/json"
+	"regexp"
+	"slices"
+	"testing"
+
+	"github.com/Traves-Theberge/OpenAPI-Toolkit/openapi-tui/internal/models"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+var emailPattern = regexp.MustCompile(`^[a-z]+\.[a-z]+@example\.com$`)
+
+// userSchema describes a user with well-known and unknown field names
+func userSchema() *openapi3.Schema {
+	role := openapi3.NewStringSchema()
+	role.Enum = []interface{}{"admin", "member"}
+	nickname := openapi3.NewStringSchema()
+	nickname.Example = "ace"
+
+	schema := openapi3.NewObjectSchema()
+	schema.Properties = openapi3.Schemas{
+		"email":      &openapi3.SchemaRef{Value: openapi3.NewStringSchema()},
+		"name":       &openapi3.SchemaRef{Value: openapi3.NewStringSchema()},
+		"phone":      &openapi3.SchemaRef{Value: openapi3.NewStringSchema()},
+		"created_at": &openapi3.SchemaRef{Value: openapi3.NewDateTimeSchema()},
+		"role":       &openapi3.SchemaRef{Value: role},
+		"nickname":   &openapi3.SchemaRef{Value: nickname},
+		"notes":      &openapi3.SchemaRef{Value: openapi3.NewStringSchema()},
+	}
+	return schema
+}
+
+func TestGenerateSample_RealisticData(t *testing.T) {
+	sample := generateSample(userSchema(), "", sampleOptions{faker: NewFaker(42, "POST /users")}).(map[string]interface{})
+
+	if email, _ := sample["email"].(string); !emailPattern.MatchString(email) {
+		t.Errorf("Expected an email-shaped value, got %q", sample["email"])
+	}
+	if name, _ := sample["name"].(string); name == "sample" || name == "" {
+		t.Errorf("Expected a realistic name, got %q", sample["name"])
+	}
+	if phone, _ := sample["phone"].(string); !regexp.MustCompile(`^\+1-555-01\d\d$`).MatchString(phone) {
+		t.Errorf("Expected a phone-shaped value, got %q", sample["phone"])
+	}
+	if createdAt, _ := sample["created_at"].(string); !regexp.MustCompile(`^\d{4}-\d\d-\d\dT\d\d:\d\d:00Z$`).MatchString(createdAt) {
+		t.Errorf("Expected a date-time value, got %q", sample["created_at"])
+	}
+
+	// Enums and examples win; unknown names keep the plain sample
+	if sample["role"] != "admin" || sample["nickname"] != "ace" || sample["notes"] != "sample" {
+		t.Errorf("Expected enum, example and plain values to be kept, got %v", sample)
+	}
+}
+
+func TestGenerateSample_RealisticDataReproducible(t *testing.T) {
+	generate := func(seed int64) string {
+		data, _ := json.Marshal(generateSample(userSchema(), "", sampleOptions{faker: NewFaker(seed, "POST /users")}))
+		return string(data)
+	}
+
+	if first, second := generate(7), generate(7); first != second {
+		t.Errorf("Expected the same seed to give the same body:\n%s\n%s", first, second)
+	}
+	// Sanity check that the seed is actually used
+	differs := false
+	for seed := int64(8); seed < 18 && !differs; seed++ {
+		differs = generate(seed) != generate(7)
+	}
+	if !differs {
+		t.Error("Expected other seeds to give different bodies")
+	}
+}
+
+func TestBuildJobs_RealisticData(t *testing.T) {
+	doc := &openapi3.T{Paths: openapi3.NewPaths()}
+	doc.Paths.Set("/users", &openapi3.PathItem{Post: &openapi3.Operation{
+		RequestBody: &openapi3.RequestBodyRef{Value: openapi3.NewRequestBody().WithJSONSchema(userSchema())},
+	}})
+
+	var plain, realistic map[string]interface{}
+	json.Unmarshal(buildJobs(doc, "http://localhost", RunOptions{})[0].RequestBody, &plain)
+	json.Unmarshal(buildJobs(doc, "http://localhost", RunOptions{RealisticData: true, DataSeed: 1})[0].RequestBody, &realistic)
+
+	if plain["email"] != "sample" {
+		t.Errorf("Expected plain data by default, got %v", plain["email"])
+	}
+	if email, _ := realistic["email"].(string); !emailPattern.MatchString(email) {
+		t.Errorf("Expected an email-shaped value with RealisticData, got %v", realistic["email"])
+	}
+}
+
+func TestGenerateSample_DateTimeFormat(t *testing.T) {
+	schema := openapi3.NewObjectSchema()
+	schema.Properties = openapi3.Schemas{
+		"created_at": &openapi3.SchemaRef{Value: openapi3.NewDateTimeSchema()},
+		"birthday":   &openapi3.SchemaRef{Value: openapi3.NewStringSchema().WithFormat("date")},
+		"name":       &openapi3.SchemaRef{Value: openapi3.NewStringSchema()},
+	}
+
+	// RFC 3339 by default
+	sample := generateSample(schema, "", sampleOptions{}).(map[string]interface{})
+	if sample["created_at"] != "2024-01-01T00:00:00Z" || sample["birthday"] != "2024-01-01" {
+		t.Errorf("Expected RFC 3339 dates by default, got %v", sample)
+	}
+
+	// Epoch seconds in unix mode; other strings are untouched
+	sample = generateSample(schema, "", sampleOptions{dateTimeFormat: models.DateTimeFormatUnix}).(map[string]interface{})
+	if sample["created_at"] != int64(1704067200) || sample["birthday"] != int64(1704067200) || sample["name"] != "sample" {
+		t.Errorf("Expected unix timestamps, got %v", sample)
+	}
+
+	// Faked dates are converted too
+	sample = generateSample(schema, "", sampleOptions{faker: NewFaker(1, "POST /users"), dateTimeFormat: models.DateTimeFormatUnix}).(map[string]interface{})
+	if _, ok := sample["created_at"].(int64); !ok {
+		t.Errorf("Expected a faked date-time as a unix timestamp, got %v", sample["created_at"])
+	}
+}
+
+func TestFaker_WholeWordNames(t *testing.T) {
+	faker := NewFaker(7, "POST /users")
+	str := openapi3.NewStringSchema()
+
+	// Telling words count in any naming style
+	for _, name := range []string{"avatarUrl", "avatar_url", "profileURL", "startDate", "date_of_birth", "homePhone", "workEmail"} {
+		if _, ok := faker.Value(name, str); !ok {
+			t.Errorf("Expected %q to be faked", name)
+		}
+	}
+
+	// but not inside another word
+	for _, name := range []string{"candidate", "updated", "curl", "microphone"} {
+		if value, ok := faker.Value(name, str); ok {
+			t.Errorf("Expected %q not to be faked, got %v", name, value)
+		}
+	}
+}
+
+func TestNameWords(t *testing.T) {
+	tests := map[string][]string{
+		"avatarURL":    {"avatar", "url"},
+		"avatar_url":   {"avatar", "url"},
+		"URLPath":      {"url", "path"},
+		"created-at":   {"created", "at"},
+		"candidate":    {"candidate"},
+		"addressLine1": {"address", "line1"},
+	}
+	for name, want := range tests {
+		if got := nameWords(name); !slices.Equal(got, want) {
+			t.Errorf("nameWords(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
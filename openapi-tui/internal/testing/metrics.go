@@ -0,0 +1,42 @@
+package testing
+
+import "encoding/json"
+
+// analyzeJSON returns the number of object fields at any depth of a JSON
+// body, counting fields of objects nested in arrays, and the body's size
+// in bytes
+// fields is 0 when the body is not JSON.
+func analyzeJSON(body []byte) (fields, bytes int) {
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return 0, len(body)
+	}
+	return countFields(value), len(body)
+}
+
+// countFields counts the object fields in a decoded JSON value
+func countFields(value interface{}) int {
+	count := 0
+	switch v := value.(type) {
+	case map[string]interface{}:
+		count += len(v)
+		for _, child := range v {
+			count += countFields(child)
+		}
+	case []interface{}:
+		for _, child := range v {
+			count += countFields(child)
+		}
+	}
+	return count
+}
+
+// topLevelFields returns the number of fields of a top-level JSON object,
+// or 0 for any other body
+func topLevelFields(body []byte) int {
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(body, &object); err != nil {
+		return 0
+	}
+	return len(object)
+}
@@ -0,0 +1,34 @@
+package testing
+
+import "testing"
+
+func TestAnalyzeJSON(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		wantFields int
+		wantTop    int
+	}{
+		// id, name, address, tags, items + address.city, address.zip + items[0].sku, items[1].sku
+		{"nested object", `{"id":1,"name":"Ada","address":{"city":"London","zip":"N1"},"tags":["a","b"],"items":[{"sku":"x"},{"sku":"y"}]}`, 9, 5},
+		{"array of objects", `[{"id":1},{"id":2,"name":"Ada"}]`, 3, 0},
+		{"scalar", `42`, 0, 0},
+		{"not JSON", `<html></html>`, 0, 0},
+		{"empty", ``, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fields, size := analyzeJSON([]byte(tt.body))
+			if fields != tt.wantFields {
+				t.Errorf("analyzeJSON() fields = %d, want %d", fields, tt.wantFields)
+			}
+			if size != len(tt.body) {
+				t.Errorf("analyzeJSON() bytes = %d, want %d", size, len(tt.body))
+			}
+			if top := topLevelFields([]byte(tt.body)); top != tt.wantTop {
+				t.Errorf("topLevelFields() = %d, want %d", top, tt.wantTop)
+			}
+		})
+	}
+}
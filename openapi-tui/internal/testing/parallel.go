@@ -1,11 +1,21 @@
 package testing
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	stderrors "errors"
 	"fmt"
 	"io"
+	"net/http"
+	"net/url"
 	"runtime"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -17,73 +27,167 @@ import (
 
 // TestJob represents a single test to execute
 type TestJob struct {
-	Method      string
-	Path        string
-	Endpoint    string
-	RequestBody []byte
-	Operation   *openapi3.Operation
+	Method           string
+	Path             string
+	Endpoint         string
+	RequestBody      []byte
+	Headers          map[string]string // Extra headers from endpoint overrides
+	BodyErr          error             // Set when the request body could not be built
+	Operation        *openapi3.Operation
+	Hints            []string // Generation problems likely to explain a rejected request
+	Example          string   // Name of the request body example sent (empty = generated body)
+	Case             string   // models.CaseValid or models.CaseInvalid in contract runs (empty otherwise)
+	ExpectedStatuses []int    // Statuses that pass instead of 2xx (empty = 2xx)
+	AssumedType      string   // Response Content-Type validated against instead of the one sent (empty = as sent)
+	ResponseMode     string   // How undocumented response body fields are treated, models.ResponseValidation* ("" = lenient)
+	Tags             []string // Tags of the operation, carried onto its result
+	EnumValues       string   // Enum parameter values sent, e.g. "status=active", when expanding enum parameters
+}
+
+// RunOptions configures a test run
+type RunOptions struct {
+	Auth                  *models.AuthConfig
+	TokenCommand          string                // Shell command printing the auth token, run once before the run (empty = use Auth's token)
+	TokenJSONPath         string                // JSONPath of the token in TokenCommand's JSON output, e.g. "$.access_token" (empty = the raw output)
+	Verbose               bool
+	MaxConcurrency        int // 0 = auto-detect
+	MaxRetries            int
+	RetryDelay            int                   // Initial retry delay in milliseconds
+	RetryOn               []string              // Failure classes that are retried, models.RetryOn* names (nil = models.DefaultRetryOn)
+	RetryStatuses         []int                 // Response statuses that are retried, replacing the 5xx class (nil = use RetryOn)
+	Overrides             models.Overrides      // Per-endpoint request overrides
+	Selection             []models.EndpointInfo // Endpoints to test (nil = all)
+	IncludeGlobs          []string              // Only test paths matching one of these globs (empty = all)
+	ExcludeGlobs          []string              // Never test paths matching one of these globs
+	CacheResponses        bool                  // Capture response bodies on results, even when not verbose
+	CacheBodySize         int                   // Bytes of body captured per response (0 = no limit)
+	ForceScheme           string                // Rewrite request URLs to "http" or "https" (empty = as given)
+	LogFile               string                // Append a JSONL record per request to this file (empty = off)
+	SkipDeprecatedParams  bool                  // Leave out query parameters marked deprecated
+	ExclusiveExtension    string                // Operation extension listing mutually exclusive query parameters; only one of each group is sent ("" = off)
+	AcceptEncoding        string                // Accept-Encoding header sent with requests (empty = Go's default gzip)
+	AssumeContentType     string                // Response Content-Type responses are validated as, whatever the server sends (empty = as sent)
+	ResponseMode          string                // "strict" fails undocumented response body fields, "lenient" ignores them ("" = lenient)
+	APIVersionHeader      models.APIVersionHeader // Header picking the API version, sent with every request (empty name = off)
+	RequestHeaders        models.RequestHeaders // Prefer, If-Match and If-None-Match headers sent with every request
+	RealisticData         bool                  // Fill well-known body fields (email, name, phone, ...) with plausible values
+	RandomData            bool                  // Fill other body values with random values the schema allows, instead of placeholders
+	DataSeed              int64                 // Seed for realistic and random data, so runs are reproducible
+	GlobalQuery           map[string]string     // Query parameters added to every request, replacing generated ones of the same name
+	DateTimeFormat        string                // How generated date and date-time values are written ("" = RFC 3339)
+	TestAllExamples       bool                  // Test an operation once per named request body example
+	ExpandEnumParams      bool                  // Test an operation once per combination of its path and query enum values
+	PreflightPath         string                // Path checked with a GET before the run, e.g. "/health"; the run is aborted if it fails (empty = off)
+	OmitOptionalBodies    bool                  // Send no body when the spec's requestBody is not required
+	ContractTests         bool                  // Test each operation with valid input, expecting 2xx, and invalid input, expecting 4xx
+	AllowBodyOnAllMethods bool                  // Generate declared request bodies for every method, not just POST, PUT and PATCH
+	ExpectedStatuses      map[string][]int      // Statuses that pass instead of 2xx, by "METHOD path" key
+	ReplayFile            string                // Answer requests from this HAR or JSON recording instead of the network (empty = off)
+	RecordFile            string                // Save each request's response to this file in the replay format (empty = off)
+	CaptureHeaders        []string              // Response headers kept in verbose logs, secret ones always redacted (empty = all)
+	TestOrder             string                // "spec" runs operations in the order the spec file lists them ("" = by path, then method)
+	MaxRunDuration        time.Duration         // Stop the run once it has taken this long, reporting unfinished endpoints as skipped (0 = no limit)
+	DialTimeout           time.Duration         // Limit on resolving a request's host and connecting, when Transport is nil (0 = the request timeout)
+	Transport             http.RoundTripper     // Transport for test requests (nil = http.DefaultTransport, or the replay file's)
+	Control               *RunControl           // Tracks in-flight requests so they can be cancelled one by one (nil = off)
 }
 
 // TestProgressMsg is sent during parallel execution to update progress
 type TestProgressMsg struct {
 	Completed int
 	Total     int
+	Active    int                // Requests still in flight when the latest one finished
 	Latest    *models.TestResult // Most recent result
+	Endpoint  string             // "METHOD /path" of the most recent result
+	Summary   string             // Summary of that operation in the spec (empty when it has none)
 }
 
 // RunTestsParallel executes API tests concurrently with a worker pool
 // maxConcurrency: maximum number of concurrent requests (0 = auto-detect)
 func RunTestsParallel(specPath, baseURL string, auth *models.AuthConfig, verbose bool, maxConcurrency int, maxRetries int, retryDelay int, progressChan chan<- tea.Msg) ([]models.TestResult, error) {
+	return RunTestsWithOptions(specPath, baseURL, RunOptions{
+		Auth:           auth,
+		Verbose:        verbose,
+		MaxConcurrency: maxConcurrency,
+		MaxRetries:     maxRetries,
+		RetryDelay:     retryDelay,
+	}, progressChan)
+}
+
+// RunTestsWithOptions executes API tests concurrently using the given run options
+func RunTestsWithOptions(specPath, baseURL string, opts RunOptions, progressChan chan<- tea.Msg) ([]models.TestResult, error) {
 	// Load and validate the OpenAPI spec
 	loader := &openapi3.Loader{IsExternalRefsAllowed: true}
 	doc, err := loader.LoadFromFile(specPath)
 	if err != nil {
 		return nil, errors.EnhanceFileError(err, specPath)
 	}
+	if err := validation.CheckOpenAPIDocument(doc, specPath); err != nil {
+		return nil, err
+	}
+
+	baseURL, err = ApplyScheme(baseURL, opts.ForceScheme)
+	if err != nil {
+		return nil, err
+	}
 
-	// Auto-detect concurrency if not specified
-	if maxConcurrency <= 0 {
-		maxConcurrency = runtime.NumCPU()
-		// Cap at reasonable maximum to avoid overwhelming servers
-		if maxConcurrency > 10 {
-			maxConcurrency = 10
+	// Replay recorded responses instead of sending requests
+	if opts.ReplayFile != "" && opts.Transport == nil {
+		replay, err := LoadReplayFile(opts.ReplayFile)
+		if err != nil {
+			return nil, errors.EnhanceFileError(err, opts.ReplayFile)
 		}
+		opts.Transport = replay
+	}
+	if opts.Transport == nil && opts.DialTimeout > 0 {
+		opts.Transport = dialTransport(opts.DialTimeout)
 	}
 
-	// Collect all test jobs
-	var jobs []TestJob
-	if doc.Paths != nil {
-		for path, pathItem := range doc.Paths.Map() {
-			for method, operation := range pathItem.Operations() {
-				// Construct full endpoint URL
-				endpoint := baseURL + ReplacePlaceholders(path)
-				endpoint += BuildQueryParams(operation)
-
-				// Generate request body if needed
-				var requestBody []byte
-				if strings.ToUpper(method) == "POST" || strings.ToUpper(method) == "PUT" || strings.ToUpper(method) == "PATCH" {
-					requestBody, err = GenerateRequestBody(operation)
-					if err != nil {
-						// Add error result and continue
-						jobs = append(jobs, TestJob{
-							Method:      method,
-							Path:        path,
-							Endpoint:    endpoint,
-							RequestBody: nil,
-							Operation:   nil, // Signal error
-						})
-						continue
-					}
-				}
+	// Fetch a fresh token for the whole run
+	if opts.TokenCommand != "" {
+		auth, err := withCommandToken(opts.Auth, opts.TokenCommand, opts.TokenJSONPath)
+		if err != nil {
+			return nil, err
+		}
+		opts.Auth = auth
+	}
 
-				jobs = append(jobs, TestJob{
-					Method:      method,
-					Path:        path,
-					Endpoint:    endpoint,
-					RequestBody: requestBody,
-					Operation:   operation,
-				})
-			}
+	// Record responses for later replay, whatever the verbose setting,
+	// starting with the preflight check
+	if opts.RecordFile != "" {
+		recording, err := openRecorder(opts.RecordFile, opts.Transport)
+		if err != nil {
+			return nil, errors.EnhanceFileError(err, opts.RecordFile)
+		}
+		defer recording.Close()
+		opts.Transport = recording
+	}
+
+	// Stop sending requests, the preflight check included, and cancel those
+	// in flight once the run's time budget is spent
+	runCtx := context.Background()
+	if opts.MaxRunDuration > 0 {
+		var cancel context.CancelFunc
+		runCtx, cancel = context.WithTimeoutCause(runCtx, opts.MaxRunDuration, errTimeBudget)
+		defer cancel()
+	}
+	// Stop it the same way when it is cancelled through opts.Control
+	runCtx, release := opts.Control.runContext(runCtx)
+	defer release()
+
+	// Check the server is up before sending every request
+	if err := preflight(runCtx, baseURL, opts); err != nil {
+		return nil, err
+	}
+
+	maxConcurrency := ResolveConcurrency(opts)
+
+	// Collect all test jobs
+	jobs := buildJobs(doc, baseURL, opts)
+	if opts.TestOrder == models.TestOrderSpec {
+		// Keep the sorted order if the file can't be read as YAML or JSON
+		if order, err := validation.OperationOrder(specPath); err == nil {
+			orderJobs(jobs, order)
 		}
 	}
 
@@ -92,6 +196,16 @@ func RunTestsParallel(specPath, baseURL string, auth *models.AuthConfig, verbose
 		return []models.TestResult{}, nil
 	}
 
+	// Open the structured run log if configured
+	var requestLog *runLog
+	if opts.LogFile != "" {
+		requestLog, err = openRunLog(opts.LogFile)
+		if err != nil {
+			return nil, errors.EnhanceFileError(err, opts.LogFile)
+		}
+		defer requestLog.Close()
+	}
+
 	// Create worker pool with indexed jobs for maintaining order
 	type IndexedJob struct {
 		Index int
@@ -105,6 +219,8 @@ func RunTestsParallel(specPath, baseURL string, auth *models.AuthConfig, verbose
 	jobChan := make(chan IndexedJob, totalJobs)
 	resultChan := make(chan IndexedResult, totalJobs)
 	var wg sync.WaitGroup
+	var completed atomic.Int32
+	var active atomic.Int32
 
 	// Start workers
 	for i := 0; i < maxConcurrency; i++ {
@@ -112,17 +228,28 @@ func RunTestsParallel(specPath, baseURL string, auth *models.AuthConfig, verbose
 		go func() {
 			defer wg.Done()
 			for indexedJob := range jobChan {
-				result := executeTestJob(indexedJob.Job, auth, verbose, maxRetries, retryDelay)
+				start := time.Now()
+				active.Add(1)
+				result := executeTestJob(runCtx, indexedJob.Job, opts)
+				inFlight := active.Add(-1)
+				requestLog.record(start, indexedJob.Job.Endpoint, result)
 				resultChan <- IndexedResult{Index: indexedJob.Index, Result: result}
 				
 				// Send progress update if channel provided
+				done := completed.Add(1)
 				if progressChan != nil {
-					select {
-					case progressChan <- TestProgressMsg{
-						Completed: indexedJob.Index + 1,
+					progress := TestProgressMsg{
+						Completed: int(done),
 						Total:     totalJobs,
+						Active:    int(inFlight),
 						Latest:    &result,
-					}:
+						Endpoint:  models.EndpointKey(indexedJob.Job.Method, indexedJob.Job.Path),
+					}
+					if indexedJob.Job.Operation != nil {
+						progress.Summary = indexedJob.Job.Operation.Summary
+					}
+					select {
+					case progressChan <- progress:
 					default:
 						// Don't block if UI isn't ready
 					}
@@ -156,30 +283,397 @@ func RunTestsParallel(specPath, baseURL string, auth *models.AuthConfig, verbose
 	return results, nil
 }
 
-// executeTestJob runs a single test job with retry support
-func executeTestJob(job TestJob, auth *models.AuthConfig, verbose bool, maxRetries int, retryDelay int) models.TestResult {
+// Auto-detected concurrency: requests mostly wait on the network, so several
+// workers per CPU keep the machine busy, capped to avoid overwhelming servers
+const (
+	concurrencyPerCPU  = 4
+	maxAutoConcurrency = 32
+)
+
+// ResolveConcurrency returns the number of workers for a run: opts.MaxConcurrency
+// when set, otherwise concurrencyPerCPU workers per CPU, capped at maxAutoConcurrency
+func ResolveConcurrency(opts RunOptions) int {
+	return resolveConcurrency(opts.MaxConcurrency, runtime.NumCPU())
+}
+
+// resolveConcurrency is ResolveConcurrency for a given CPU count
+func resolveConcurrency(requested, cpus int) int {
+	if requested > 0 {
+		return requested
+	}
+	return min(cpus*concurrencyPerCPU, maxAutoConcurrency)
+}
+
+// preflight sends a GET to opts.PreflightPath on the base URL and reports an
+// error unless it gets a 2xx or 3xx response, so an unreachable or unhealthy
+// server fails the run once instead of with an error per endpoint
+func preflight(ctx context.Context, baseURL string, opts RunOptions) error {
+	if opts.PreflightPath == "" {
+		return nil
+	}
+
+	target := strings.TrimRight(baseURL, "/") + "/" + strings.TrimLeft(opts.PreflightPath, "/")
+	target = ApplyGlobalQuery(target, opts.GlobalQuery)
+	status, resp, _, err := sendRequest(ctx, "GET", target, nil, RunHeaders(opts.APIVersionHeader, opts.RequestHeaders), nil, opts.Auth, false, opts.Transport)
+	if err != nil {
+		return fmt.Errorf("preflight check failed, no endpoints were tested: %w", err)
+	}
+	resp.Body.Close()
+	if status >= 400 {
+		return fmt.Errorf("preflight check failed, no endpoints were tested: GET %s returned %d", target, status)
+	}
+	return nil
+}
+
+// buildJobs collects the test jobs for every operation in the spec, sorted
+// by path and then method, restricted to opts.Selection when set and to paths
+// passing the include and exclude globs, with endpoint overrides applied
+// Operations with their own servers are sent there instead of to baseURL
+func buildJobs(doc *openapi3.T, baseURL string, opts RunOptions) []TestJob {
+	var jobs []TestJob
+	if doc.Paths == nil {
+		return jobs
+	}
+	templateCounter := 0 // Counter value for the next body template rendered
+
+	// Create a map of selected endpoints for quick lookup
+	var selectedMap map[string]bool
+	if opts.Selection != nil {
+		selectedMap = make(map[string]bool)
+		for _, ep := range opts.Selection {
+			selectedMap[models.EndpointKey(ep.Method, ep.Path)] = true
+		}
+	}
+
+	paths := doc.Paths.Map()
+	for _, path := range sortedKeys(paths) {
+		operations := paths[path].Operations()
+		for _, method := range sortedKeys(operations) {
+			operation := operations[method]
+			if selectedMap != nil && !selectedMap[models.EndpointKey(method, path)] {
+				continue
+			}
+			if !validation.MatchesGlobs(path, opts.IncludeGlobs, opts.ExcludeGlobs) {
+				continue
+			}
+			if skippedByExtension(operation) {
+				continue
+			}
+
+			// Construct full endpoint URL
+			filledPath, unsatisfiable := fillPathParams(path, paths[path], operation)
+			base := operationBaseURL(baseURL, paths[path], operation, opts.ForceScheme)
+			endpoint := base + filledPath
+			query, notes := buildQueryParams(operation, opts.SkipDeprecatedParams, opts.ExclusiveExtension)
+			endpoint += query
+
+			job := TestJob{
+				Method:    method,
+				Path:      path,
+				Endpoint:  endpoint,
+				Operation: operation,
+
+				ExpectedStatuses: opts.ExpectedStatuses[models.EndpointKey(method, path)],
+				AssumedType:      opts.AssumeContentType,
+				ResponseMode:     opts.ResponseMode,
+				Tags:             operation.Tags,
+			}
+			// A guessed path parameter explains a 404 better than anything else
+			for _, name := range unsatisfiable {
+				job.Hints = append(job.Hints, fmt.Sprintf("unsatisfiable path parameter %q: the spec gives no example, enum or default, so placeholder \"1\" was sent", name))
+			}
+			for _, note := range notes {
+				job.Hints = append(job.Hints, note.Message)
+			}
+
+			// Generate request body if needed
+			upper := strings.ToUpper(method)
+			bodyMethod := upper == "POST" || upper == "PUT" || upper == "PATCH" || opts.AllowBodyOnAllMethods
+			if bodyMethod && (bodyRequired(operation) || !opts.OmitOptionalBodies) {
+				var contentType string
+				sample := sampleOptions{dateTimeFormat: opts.DateTimeFormat}
+				if opts.RealisticData {
+					sample.faker = NewFaker(opts.DataSeed, models.EndpointKey(method, path))
+				}
+				if opts.RandomData {
+					sample.rng = seededRand(opts.DataSeed, models.EndpointKey(method, path))
+				}
+				job.RequestBody, contentType, job.BodyErr = generateRequestBodyWithType(operation, sample)
+				if contentType != "" && contentType != "application/json" {
+					job.Headers = map[string]string{"Content-Type": contentType}
+				}
+				if _, literal := extensionBody(operation); job.BodyErr == nil && len(job.RequestBody) == 0 && bodyRequired(operation) && !literal {
+					job.Hints = append(job.Hints, "request body is required but none could be generated from its media types")
+				}
+			}
+
+			if opts.AcceptEncoding != "" {
+				if job.Headers == nil {
+					job.Headers = make(map[string]string)
+				}
+				job.Headers["Accept-Encoding"] = opts.AcceptEncoding
+			}
+
+			// Bodies and headers the spec supplies through vendor extensions,
+			// which endpoint overrides can still replace
+			if body, ok := extensionBody(operation); ok {
+				job.RequestBody, job.BodyErr = overrideBody(body)
+				delete(job.Headers, "Content-Type")
+			}
+			for k, v := range extensionHeaders(operation) {
+				if job.Headers == nil {
+					job.Headers = make(map[string]string)
+				}
+				job.Headers[k] = v
+			}
+
+			// Global query parameters, which endpoint overrides can still replace
+			job.Endpoint = ApplyGlobalQuery(job.Endpoint, opts.GlobalQuery)
+
+			// Apply per-endpoint overrides
+			if override, ok := opts.Overrides.Lookup(method, path); ok {
+				if override.Body != nil {
+					job.RequestBody, job.BodyErr = overrideBody(override.Body)
+					// The generated media type described the generated body
+					delete(job.Headers, "Content-Type")
+				}
+				if override.BodyTemplate != "" {
+					templateCounter++
+					var body string
+					body, job.BodyErr = RenderBody(override.BodyTemplate, templateCounter)
+					if job.BodyErr == nil {
+						if err := ValidateRequestBody(override.Headers, body); err != nil {
+							job.BodyErr = fmt.Errorf("body template did not render valid JSON: %w", err)
+						}
+					}
+					job.RequestBody = []byte(body)
+					delete(job.Headers, "Content-Type")
+				}
+				if len(override.Query) > 0 {
+					job.Endpoint = mergeQuery(job.Endpoint, override.Query)
+				}
+				if override.AssumeContentType != "" {
+					job.AssumedType = override.AssumeContentType
+				}
+				for k, v := range override.Headers {
+					if job.Headers == nil {
+						job.Headers = make(map[string]string)
+					}
+					// Canonical keys so an override replaces, not duplicates, a header
+					job.Headers[http.CanonicalHeaderKey(k)] = v
+				}
+			}
+
+			// An operation sent without the credentials it requires will be rejected
+			if AppliedAuth(opts.Auth) == "none" && validation.HeaderValue(job.Headers, "Authorization") == "" && requiresAuth(doc, operation) {
+				job.Hints = append(job.Hints, "the operation requires authentication but no auth is configured")
+			}
+
+			expanded := []TestJob{job}
+			if opts.ExpandEnumParams {
+				fixed := make(map[string]bool)
+				for name := range opts.GlobalQuery {
+					fixed[name] = true
+				}
+				if override, ok := opts.Overrides.Lookup(method, path); ok {
+					for name := range override.Query {
+						fixed[name] = true
+					}
+				}
+				sent := url.Values{}
+				if u, err := url.Parse(job.Endpoint); err == nil {
+					sent = u.Query()
+				}
+				expanded = enumJobs(job, base, filledPath, path, paths[path], enumParams(path, paths[path], operation, sent, fixed))
+			}
+
+			for _, job := range expanded {
+				variants := []TestJob{job}
+				if _, literal := extensionBody(operation); opts.TestAllExamples && job.BodyErr == nil && !literal && !bodyOverridden(opts.Overrides, method, path) {
+					variants = exampleJobs(job)
+				}
+				if opts.ContractTests {
+					variants = contractJobs(job, variants)
+				}
+				jobs = append(jobs, variants...)
+			}
+		}
+	}
+
+	return jobs
+}
+
+// exampleJobs expands a job into one job per named example of its JSON
+// request body, each sending that example and labeled with its name
+// Operations without named examples keep the single generated job.
+func exampleJobs(job TestJob) []TestJob {
+	mediaType := "application/json"
+	if contentType, ok := job.Headers["Content-Type"]; ok {
+		mediaType = contentType
+	}
+	examples := requestBodyExamples(job.Operation, mediaType)
+	if len(examples) == 0 {
+		return []TestJob{job}
+	}
+
+	jobs := make([]TestJob, 0, len(examples))
+	for _, example := range examples {
+		expanded := job
+		expanded.Example = example.name
+		expanded.RequestBody, expanded.BodyErr = json.Marshal(example.value)
+		jobs = append(jobs, expanded)
+	}
+	return jobs
+}
+
+// bodyOverridden reports whether an endpoint override replaces the request body
+func bodyOverridden(overrides models.Overrides, method, path string) bool {
+	override, ok := overrides.Lookup(method, path)
+	return ok && (override.Body != nil || override.BodyTemplate != "")
+}
+
+// overrideBody encodes an override body: strings are sent verbatim, anything else as JSON
+func overrideBody(body interface{}) ([]byte, error) {
+	if s, ok := body.(string); ok {
+		return []byte(s), nil
+	}
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal override body: %v", err)
+	}
+	return data, nil
+}
+
+// mergeQuery sets the given query parameters on endpoint, replacing existing values
+func mergeQuery(endpoint string, query map[string]string) string {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return endpoint
+	}
+	q := u.Query()
+	for k, v := range query {
+		q.Set(k, v)
+	}
+	u.RawQuery = q.Encode()
+	return u.String()
+}
+
+// executeTestJob runs a single test job with retry support, unless runCtx
+// is already done, in which case the job is reported as skipped
+func executeTestJob(runCtx context.Context, job TestJob, opts RunOptions) models.TestResult {
+	if runCtx.Err() != nil {
+		return models.TestResult{
+			Method:   job.Method,
+			Endpoint: job.Path,
+			Status:   models.StatusSkipped,
+			Message:  context.Cause(runCtx).Error(),
+			Case:     job.Case,
+			Tags:     job.Tags,
+
+			ExpectedStatuses: job.ExpectedStatuses,
+		}
+	}
+
 	// Handle jobs that failed during body generation
-	if job.Operation == nil && job.RequestBody == nil {
+	if job.BodyErr != nil {
 		return models.TestResult{
 			Method:     job.Method,
 			Endpoint:   job.Path,
 			Status:     "ERR",
-			Message:    "Failed to generate request body",
+			Message:    fmt.Sprintf("Failed to generate request body: %v", job.BodyErr),
 			RetryCount: 0,
+			Case:       job.Case,
+			Tags:       job.Tags,
+
+			ExpectedStatuses: job.ExpectedStatuses,
 		}
 	}
 
 	// Execute the test with retry logic
+	ctx, done := opts.Control.begin(runCtx, job.Method, job.Path)
+	defer done()
+	// Retries replace the attempt before them in a recording
+	ctx = withRecordSlot(ctx)
+	if slices.ContainsFunc(job.ExpectedStatuses, func(status int) bool { return status >= 300 && status < 400 }) {
+		// An expected redirect is the response under test, so don't follow it
+		ctx = withoutRedirects(ctx)
+	}
+	runHeaders := RunHeaders(opts.APIVersionHeader, opts.RequestHeaders)
 	startTime := time.Now()
-	status, resp, logEntry, retryCount, err := TestEndpointWithRetry(job.Method, job.Endpoint, job.RequestBody, auth, verbose, maxRetries, retryDelay)
+	status, resp, logEntry, retryCount, err := retryRequest(func() (int, *http.Response, *models.LogEntry, error) {
+		status, resp, logEntry, err := sendRequest(ctx, job.Method, job.Endpoint, job.RequestBody, runHeaders, job.Headers, opts.Auth, opts.Verbose, opts.Transport)
+		if ctx.Err() != nil {
+			// Cancelled by the user or the time budget: report it plainly
+			// and don't retry
+			if resp != nil && resp.Body != nil {
+				resp.Body.Close()
+			}
+			if runCtx.Err() != nil {
+				return status, nil, logEntry, context.Cause(runCtx)
+			}
+			return status, nil, logEntry, errRequestCancelled
+		}
+		return status, resp, logEntry, err
+	}, opts.MaxRetries, opts.RetryDelay, opts.RetryOn, opts.RetryStatuses)
 	duration := time.Since(startTime)
 
+	if logEntry != nil {
+		logEntry.ResponseHeaders = filterResponseHeaders(logEntry.ResponseHeaders, opts.CaptureHeaders)
+	}
+
+	// Keep the full logged body within the same limit as cached responses
+	if logEntry != nil && opts.CacheBodySize > 0 {
+		var cut bool
+		logEntry.FullResponseBody, cut = truncateUTF8(logEntry.FullResponseBody, opts.CacheBodySize)
+		logEntry.FullBodyTruncated = logEntry.FullBodyTruncated || cut
+	}
+
+	// Capture the response for the cache before validation drains the body
+	var cached *models.CachedResponse
+	if opts.CacheResponses && err == nil && resp != nil {
+		cached = captureResponse(resp, status, opts.CacheBodySize)
+	}
+
 	message := "OK"
+	var responseFields, responseBytes, responseTopFields int
+	var undeclaredContentType string
+	statusDocumented := false
 	if err != nil {
 		message = err.Error()
 	} else if resp != nil {
+		// Buffer the body for size metrics and the example diff, leaving it
+		// readable for validation
+		var body []byte
+		if resp.Body != nil {
+			body, _ = io.ReadAll(resp.Body)
+			resp.Body = struct {
+				io.Reader
+				io.Closer
+			}{bytes.NewReader(body), resp.Body}
+		}
+		responseFields, responseBytes = analyzeJSON(body)
+		responseTopFields = topLevelFields(body)
+
+		// Compare with the documented example for the log view
+		if logEntry != nil {
+			if example := validation.ResponseExample(job.Operation, status); example != nil {
+				logEntry.HasExample = true
+				logEntry.ExampleDiff = validation.DiffExampleVsActual(example, body)
+			}
+		}
+
 		// Validate response against spec
-		validationResult := validation.ValidateResponse(resp, job.Operation, status)
+		validationResult := validation.ValidateResponseWithOptions(resp, job.Operation, status, validation.ResponseOptions{
+			AssumedContentType: job.AssumedType,
+			Mode:               job.ResponseMode,
+		})
+		statusDocumented = validationResult.StatusValid
+		if validationResult.ContentTypeMismatch {
+			undeclaredContentType = validationResult.ContentType
+			if undeclaredContentType == "" {
+				undeclaredContentType = "(none)"
+			}
+		}
 		
 		// Close response body after validation
 		if resp.Body != nil {
@@ -201,10 +695,40 @@ func executeTestJob(job TestJob, auth *models.AuthConfig, verbose bool, maxRetri
 		}
 	}
 
+	// Invalid input passes when it is rejected with a documented 4xx
+	if job.Case == models.CaseInvalid && err == nil {
+		message = invalidInputMessage(status, documentedStatus(job.Operation, status))
+	}
+
+	// Configured expected statuses replace 2xx as the definition of success
+	if len(job.ExpectedStatuses) > 0 && job.Case != models.CaseInvalid && err == nil {
+		switch {
+		case !slices.Contains(job.ExpectedStatuses, status):
+			message = fmt.Sprintf("Expected status %s, got %d", joinStatuses(job.ExpectedStatuses), status)
+		case !statusDocumented:
+			message = fmt.Sprintf("OK (expected %d)", status)
+		}
+	}
+
+	// Record the auth sent, so endpoints hit without credentials stand out
+	auth := AppliedAuth(opts.Auth)
+	if auth == "none" && validation.HeaderValue(job.Headers, "Authorization") != "" {
+		auth = "Authorization header"
+	}
+
+	// Point a rejected request at the guessed value most likely to blame
+	if len(job.Hints) > 0 && (err != nil || status >= 400) {
+		message += " (hint: " + job.Hints[0] + ")"
+	}
+
 	// Format status for display
 	statusStr := fmt.Sprintf("%d", status)
 	if err != nil {
 		statusStr = "ERR"
+		if stderrors.Is(err, errRunCancelled) || stderrors.Is(err, errTimeBudget) {
+			// Stopped in flight by the run's cancellation or time budget
+			statusStr = models.StatusSkipped
+		}
 	}
 
 	return models.TestResult{
@@ -215,7 +739,64 @@ func executeTestJob(job TestJob, auth *models.AuthConfig, verbose bool, maxRetri
 		Duration:   duration,
 		LogEntry:   logEntry,
 		RetryCount: retryCount,
+		Response:   cached,
+		Hints:      job.Hints,
+
+		RequestURL:        job.Endpoint,
+		RequestBytes:      len(job.RequestBody),
+		ResponseBytes:     responseBytes,
+		Example:           job.Example,
+		EnumValues:        job.EnumValues,
+		Case:              job.Case,
+		ExpectedStatuses:  job.ExpectedStatuses,
+		Auth:              auth,
+		Tags:              job.Tags,
+		ResponseFields:    responseFields,
+		ResponseTopFields: responseTopFields,
+
+		UndeclaredContentType: undeclaredContentType,
+		UndocumentedStatus:    err == nil && !documentedStatus(job.Operation, status),
+	}
+}
+
+// joinStatuses lists status codes as "303 or 307"
+func joinStatuses(statuses []int) string {
+	parts := make([]string, len(statuses))
+	for i, status := range statuses {
+		parts[i] = strconv.Itoa(status)
 	}
+	return strings.Join(parts, " or ")
+}
+
+// captureResponse reads up to maxBodySize bytes of the body (0 = no limit)
+// and leaves the rest readable for the caller
+func captureResponse(resp *http.Response, status int, maxBodySize int) *models.CachedResponse {
+	cached := &models.CachedResponse{
+		Status:    fmt.Sprintf("%d", status),
+		Timestamp: time.Now(),
+	}
+	if resp.Body == nil {
+		return cached
+	}
+
+	var read []byte
+	if maxBodySize > 0 {
+		read, _ = io.ReadAll(io.LimitReader(resp.Body, int64(maxBodySize)+1))
+	} else {
+		read, _ = io.ReadAll(resp.Body)
+	}
+	body := read
+	if maxBodySize > 0 && len(body) > maxBodySize {
+		body = body[:maxBodySize]
+		cached.Truncated = true
+	}
+	cached.Body = string(body)
+
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(read), resp.Body), resp.Body}
+	return cached
 }
 
 // RunTestParallelCmd wraps RunTestsParallel in a Bubble Tea command
@@ -230,10 +811,13 @@ func RunTestParallelCmd(specPath, baseURL string, auth *models.AuthConfig, verbo
 	}
 }
 
-// RunTestParallelCmdWithSelection executes tests for only selected endpoints
-func RunTestParallelCmdWithSelection(specPath, baseURL string, auth *models.AuthConfig, verbose bool, maxConcurrency int, maxRetries int, retryDelay int, selectedEndpoints []models.EndpointInfo) tea.Cmd {
+// RunTestParallelCmdWithProgress is RunTestParallelCmdWithOptions that also
+// reports each finished request on progress, for WaitForProgress to deliver
+// progress is closed once the run is over
+func RunTestParallelCmdWithProgress(specPath, baseURL string, opts RunOptions, progress chan tea.Msg) tea.Cmd {
 	return func() tea.Msg {
-		results, err := RunTestsParallelWithSelection(specPath, baseURL, auth, verbose, maxConcurrency, maxRetries, retryDelay, nil, selectedEndpoints)
+		defer close(progress)
+		results, err := RunTestsWithOptions(specPath, baseURL, opts, progress)
 		if err != nil {
 			return TestErrorMsg{Err: err}
 		}
@@ -241,108 +825,81 @@ func RunTestParallelCmdWithSelection(specPath, baseURL string, auth *models.Auth
 	}
 }
 
-// RunTestsParallelWithSelection runs tests for only the selected endpoints
-func RunTestsParallelWithSelection(specPath, baseURL string, auth *models.AuthConfig, verbose bool, maxConcurrency int, maxRetries int, retryDelay int, progressChan chan<- tea.Msg, selectedEndpoints []models.EndpointInfo) ([]models.TestResult, error) {
-	// Load and validate the OpenAPI spec
-	loader := &openapi3.Loader{IsExternalRefsAllowed: true}
-	doc, err := loader.LoadFromFile(specPath)
-	if err != nil {
-		return nil, errors.EnhanceFileError(err, specPath)
-	}
-
-	// Auto-detect concurrency if not specified
-	if maxConcurrency <= 0 {
-		maxConcurrency = runtime.NumCPU()
-		if maxConcurrency > 10 {
-			maxConcurrency = 10
+// WaitForProgress delivers the next progress message of a run started with
+// RunTestParallelCmdWithProgress; nothing once the run is over
+func WaitForProgress(progress <-chan tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		msg, ok := <-progress
+		if !ok {
+			return nil
 		}
+		return msg
 	}
+}
 
-	// Create a map of selected endpoints for quick lookup
-	selectedMap := make(map[string]map[string]bool)
-	for _, ep := range selectedEndpoints {
-		if selectedMap[ep.Path] == nil {
-			selectedMap[ep.Path] = make(map[string]bool)
+// RunTestParallelCmdWithOptions wraps RunTestsWithOptions in a Bubble Tea command
+func RunTestParallelCmdWithOptions(specPath, baseURL string, opts RunOptions) tea.Cmd {
+	return func() tea.Msg {
+		results, err := RunTestsWithOptions(specPath, baseURL, opts, nil)
+		if err != nil {
+			return TestErrorMsg{Err: err}
 		}
-		selectedMap[ep.Path][ep.Method] = true
+		return TestCompleteMsg{Results: results}
 	}
+}
 
-	// Build job queue with only selected endpoints
-	var jobs []TestJob
-	for path, pathItem := range doc.Paths.Map() {
-		if pathMethods, exists := selectedMap[path]; exists {
-			operations := map[string]*openapi3.Operation{
-				"GET":     pathItem.Get,
-				"POST":    pathItem.Post,
-				"PUT":     pathItem.Put,
-				"PATCH":   pathItem.Patch,
-				"DELETE":  pathItem.Delete,
-				"HEAD":    pathItem.Head,
-				"OPTIONS": pathItem.Options,
-			}
-
-			for method, operation := range operations {
-				if operation != nil && pathMethods[method] {
-					// Generate request body if needed
-					requestBody, _ := GenerateRequestBody(operation)
-
-					// Build full endpoint URL
-					endpoint := baseURL + ReplacePlaceholders(path)
-					queryParams := BuildQueryParams(operation)
-					if queryParams != "" {
-						endpoint += queryParams
-					}
-
-					jobs = append(jobs, TestJob{
-						Method:      method,
-						Path:        path,
-						Endpoint:    endpoint,
-						RequestBody: requestBody,
-						Operation:   operation,
-					})
-				}
-			}
+// RunTestParallelCmdWithSelection executes tests for only selected endpoints
+func RunTestParallelCmdWithSelection(specPath, baseURL string, auth *models.AuthConfig, verbose bool, maxConcurrency int, maxRetries int, retryDelay int, selectedEndpoints []models.EndpointInfo) tea.Cmd {
+	return func() tea.Msg {
+		results, err := RunTestsParallelWithSelection(specPath, baseURL, auth, verbose, maxConcurrency, maxRetries, retryDelay, nil, selectedEndpoints)
+		if err != nil {
+			return TestErrorMsg{Err: err}
 		}
+		return TestCompleteMsg{Results: results}
 	}
+}
 
-	// Execute jobs with worker pool
-	results := make([]models.TestResult, len(jobs))
-	var wg sync.WaitGroup
-	jobChan := make(chan struct {
-		job   TestJob
-		index int
-	}, len(jobs))
-
-	// Send all jobs to channel
-	for i, job := range jobs {
-		jobChan <- struct {
-			job   TestJob
-			index int
-		}{job, i}
+// RunTestsParallelWithSelection runs tests for only the selected endpoints
+func RunTestsParallelWithSelection(specPath, baseURL string, auth *models.AuthConfig, verbose bool, maxConcurrency int, maxRetries int, retryDelay int, progressChan chan<- tea.Msg, selectedEndpoints []models.EndpointInfo) ([]models.TestResult, error) {
+	// An empty selection means nothing is tested, not everything
+	if selectedEndpoints == nil {
+		selectedEndpoints = []models.EndpointInfo{}
 	}
-	close(jobChan)
-
-	// Start workers
-	for i := 0; i < maxConcurrency; i++ {
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-			for jobWithIndex := range jobChan {
-				result := executeTestJob(jobWithIndex.job, auth, verbose, maxRetries, retryDelay)
-				results[jobWithIndex.index] = result
+	return RunTestsWithOptions(specPath, baseURL, RunOptions{
+		Auth:           auth,
+		Verbose:        verbose,
+		MaxConcurrency: maxConcurrency,
+		MaxRetries:     maxRetries,
+		RetryDelay:     retryDelay,
+		Selection:      selectedEndpoints,
+	}, progressChan)
+}
 
-				// Send progress update if channel provided
-				if progressChan != nil {
-					progressChan <- TestProgressMsg{
-						Completed: jobWithIndex.index + 1,
-						Total:     len(jobs),
-						Latest:    &result,
-					}
-				}
-			}
-		}()
+// sortedKeys returns the keys of m in ascending order, so jobs are built in
+// the same order on every run
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
 	}
+	sort.Strings(keys)
+	return keys
+}
 
-	wg.Wait()
-	return results, nil
+// orderJobs stably reorders jobs to follow order, a list of "METHOD path"
+// keys; jobs for operations missing from it keep their place after the rest
+func orderJobs(jobs []TestJob, order []string) {
+	rank := make(map[string]int, len(order))
+	for i, key := range order {
+		rank[key] = i
+	}
+	position := func(job TestJob) int {
+		if i, ok := rank[models.EndpointKey(job.Method, job.Path)]; ok {
+			return i
+		}
+		return len(order)
+	}
+	sort.SliceStable(jobs, func(i, j int) bool {
+		return position(jobs[i]) < position(jobs[j])
+	})
 }
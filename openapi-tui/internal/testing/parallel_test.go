@@ -1,9 +1,18 @@
 package testing
 
 import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"os"
+	"reflect"
+	"runtime"
+	"sort"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -11,8 +20,34 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/Traves-Theberge/OpenAPI-Toolkit/openapi-tui/internal/models"
+	"github.com/Traves-Theberge/OpenAPI-Toolkit/openapi-tui/internal/validation"
+	"github.com/getkin/kin-openapi/openapi3"
 )
 
+// TestResolveConcurrency verifies the worker count scales with CPUs unless set
+func TestResolveConcurrency(t *testing.T) {
+	for _, tt := range []struct {
+		requested, cpus, want int
+	}{
+		{0, 1, 4},
+		{0, 4, 16},
+		{0, 64, maxAutoConcurrency},
+		{3, 64, 3},
+		{50, 1, 50},
+	} {
+		if got := resolveConcurrency(tt.requested, tt.cpus); got != tt.want {
+			t.Errorf("resolveConcurrency(%d, %d) = %d, want %d", tt.requested, tt.cpus, got, tt.want)
+		}
+	}
+
+	if got, want := ResolveConcurrency(RunOptions{}), min(runtime.NumCPU()*concurrencyPerCPU, maxAutoConcurrency); got != want {
+		t.Errorf("ResolveConcurrency() = %d, want %d", got, want)
+	}
+	if got := ResolveConcurrency(RunOptions{MaxConcurrency: 2}); got != 2 {
+		t.Errorf("Expected an explicit MaxConcurrency to win, got %d", got)
+	}
+}
+
 // TestRunTestsParallel_AutoDetectConcurrency verifies auto-detection of CPU count
 func TestRunTestsParallel_AutoDetectConcurrency(t *testing.T) {
 	// Create a simple test server
@@ -196,6 +231,141 @@ paths:
 }
 
 // TestRunTestsParallel_ErrorHandling verifies error handling with failing endpoints
+func TestRunTestsWithOptions_ResultTags(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	specPath := createTempSpec(t, `
+openapi: 3.0.0
+info:
+  title: Tags
+  version: 1.0.0
+paths:
+  /users:
+    get:
+      tags: [users, admin]
+      responses:
+        '200':
+          description: OK
+  /health:
+    get:
+      responses:
+        '200':
+          description: OK
+`)
+	results, err := RunTestsWithOptions(specPath, server.URL, RunOptions{}, nil)
+	if err != nil {
+		t.Fatalf("RunTestsWithOptions() error = %v", err)
+	}
+	for _, r := range results {
+		want := ""
+		if r.Endpoint == "/users" {
+			want = "users,admin"
+		}
+		if got := strings.Join(r.Tags, ","); got != want {
+			t.Errorf("%s: expected tags %q, got %q", r.Endpoint, want, got)
+		}
+	}
+}
+
+func TestRunTestsWithOptions_ExpandEnumParams(t *testing.T) {
+	var mu sync.Mutex
+	var requested []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requested = append(requested, r.URL.RequestURI())
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	specPath := createTempSpec(t, `
+openapi: 3.0.0
+info:
+  title: Enums
+  version: 1.0.0
+paths:
+  /users:
+    get:
+      parameters:
+        - name: status
+          in: query
+          schema:
+            type: string
+            enum: [active, inactive, pending]
+      responses:
+        '200':
+          description: OK
+  /health:
+    get:
+      responses:
+        '200':
+          description: OK
+`)
+
+	results, err := RunTestsWithOptions(specPath, server.URL, RunOptions{ExpandEnumParams: true}, nil)
+	if err != nil {
+		t.Fatalf("RunTestsWithOptions() error = %v", err)
+	}
+	var labels []string
+	for _, r := range results {
+		if r.Endpoint == "/users" {
+			labels = append(labels, r.EnumValues)
+		} else if r.EnumValues != "" {
+			t.Errorf("Expected no enum label on %s, got %q", r.Endpoint, r.EnumValues)
+		}
+	}
+	sort.Strings(labels)
+	if want := []string{"status=active", "status=inactive", "status=pending"}; !reflect.DeepEqual(labels, want) {
+		t.Errorf("Expected one result per enum value, got %q", labels)
+	}
+	sort.Strings(requested)
+	if want := []string{"/health", "/users?status=active", "/users?status=inactive", "/users?status=pending"}; !reflect.DeepEqual(requested, want) {
+		t.Errorf("Expected each value sent once, got %q", requested)
+	}
+
+	// Off by default: only the first value is sent
+	results, err = RunTestsWithOptions(specPath, server.URL, RunOptions{}, nil)
+	if err != nil {
+		t.Fatalf("RunTestsWithOptions() error = %v", err)
+	}
+	if len(results) != 2 {
+		t.Errorf("Expected one result per operation, got %d", len(results))
+	}
+}
+
+func TestEnumJobs_Capped(t *testing.T) {
+	values := make([]interface{}, 10)
+	for i := range values {
+		values[i] = i
+	}
+	schema := &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"integer"}, Enum: values}}
+	operation := &openapi3.Operation{Parameters: openapi3.Parameters{
+		{Value: &openapi3.Parameter{Name: "a", In: "path", Required: true, Schema: schema}},
+		{Value: &openapi3.Parameter{Name: "b", In: "query", Schema: schema}},
+	}}
+	job := TestJob{Method: "GET", Path: "/items/{a}", Endpoint: "http://api/items/0?b=0", Operation: operation}
+
+	params := enumParams("/items/{a}", nil, operation, url.Values{"b": {"0"}}, nil)
+	jobs := enumJobs(job, "http://api", "/items/0", "/items/{a}", nil, params)
+	if len(jobs) != maxEnumCombinations {
+		t.Fatalf("Expected %d jobs, got %d", maxEnumCombinations, len(jobs))
+	}
+	if jobs[11].Endpoint != "http://api/items/1?b=1" || jobs[11].EnumValues != "a=1, b=1" {
+		t.Errorf("Expected the twelfth combination a=1, b=1, got %s (%s)", jobs[11].Endpoint, jobs[11].EnumValues)
+	}
+	if len(jobs[0].Hints) != 1 || !strings.Contains(jobs[0].Hints[0], "100 combinations") {
+		t.Errorf("Expected a hint about the cap, got %q", jobs[0].Hints)
+	}
+
+	// The global query or an override fixes a parameter's value
+	if params := enumParams("/items/{a}", nil, operation, url.Values{"b": {"0"}}, map[string]bool{"b": true}); len(params) != 1 || params[0].name != "a" {
+		t.Errorf("Expected only the path parameter to expand, got %+v", params)
+	}
+}
+
 func TestRunTestsParallel_ErrorHandling(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.URL.Path == "/fail" {
@@ -479,6 +649,102 @@ paths:
 	t.Logf("Received %d progress messages for 3 tests", progressCount)
 }
 
+func TestRunTestsWithOptions_ActiveCount(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(10 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	var spec strings.Builder
+	spec.WriteString("openapi: 3.0.0\ninfo:\n  title: Active\n  version: 1.0.0\npaths:\n")
+	for i := 0; i < 12; i++ {
+		fmt.Fprintf(&spec, "  /items%d:\n    get:\n      responses:\n        '200':\n          description: OK\n", i)
+	}
+	specPath := createTempSpec(t, spec.String())
+
+	const maxConcurrency = 3
+	progressChan := make(chan tea.Msg, 12)
+	if _, err := RunTestsWithOptions(specPath, server.URL, RunOptions{MaxConcurrency: maxConcurrency}, progressChan); err != nil {
+		t.Fatalf("RunTestsWithOptions failed: %v", err)
+	}
+	close(progressChan)
+
+	count := 0
+	for msg := range progressChan {
+		progress := msg.(TestProgressMsg)
+		count++
+		if progress.Active < 0 || progress.Active > maxConcurrency {
+			t.Errorf("Expected at most %d requests in flight, got %d", maxConcurrency, progress.Active)
+		}
+	}
+	if count != 12 {
+		t.Errorf("Expected a progress message per request, got %d", count)
+	}
+}
+
+func TestRunTestsWithOptions_DeterministicOrder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	specPath := createTempSpec(t, `
+openapi: 3.0.0
+info:
+  title: Order
+  version: 1.0.0
+paths:
+  /zebras:
+    post:
+      responses:
+        '200':
+          description: OK
+    get:
+      responses:
+        '200':
+          description: OK
+  /apples:
+    get:
+      responses:
+        '200':
+          description: OK
+  /mangoes:
+    delete:
+      responses:
+        '200':
+          description: OK
+    get:
+      responses:
+        '200':
+          description: OK
+`)
+	order := func(opts RunOptions) []string {
+		opts.MaxConcurrency = 4
+		results, err := RunTestsWithOptions(specPath, server.URL, opts, nil)
+		if err != nil {
+			t.Fatalf("RunTestsWithOptions failed: %v", err)
+		}
+		keys := make([]string, len(results))
+		for i, r := range results {
+			keys[i] = r.Method + " " + r.Endpoint
+		}
+		return keys
+	}
+
+	sorted := []string{"GET /apples", "DELETE /mangoes", "GET /mangoes", "GET /zebras", "POST /zebras"}
+	for run := 0; run < 5; run++ {
+		if got := order(RunOptions{}); !reflect.DeepEqual(got, sorted) {
+			t.Fatalf("Run %d: expected results by path then method %v, got %v", run, sorted, got)
+		}
+	}
+
+	inSpec := []string{"POST /zebras", "GET /zebras", "GET /apples", "DELETE /mangoes", "GET /mangoes"}
+	if got := order(RunOptions{TestOrder: models.TestOrderSpec}); !reflect.DeepEqual(got, inSpec) {
+		t.Errorf("Expected results in spec order %v, got %v", inSpec, got)
+	}
+}
+
 // Helper function to create temporary spec file
 func createTempSpec(tb testing.TB, content string) string {
 	tb.Helper()
@@ -548,6 +814,55 @@ paths:
 	}
 }
 
+func TestRunTestParallelCmdWithOptions_ChangedEndpoints(t *testing.T) {
+	var mu sync.Mutex
+	var hits []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		hits = append(hits, r.Method+" "+r.URL.Path)
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	oldSpec := `
+openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /users:
+    get:
+      responses:
+        '200':
+          description: OK
+`
+	oldPath := createTempSpec(t, oldSpec)
+	newPath := createTempSpec(t, oldSpec+`  /posts:
+    get:
+      responses:
+        '200':
+          description: OK
+`)
+
+	diff, err := validation.DiffSpecs(oldPath, newPath)
+	if err != nil {
+		t.Fatalf("DiffSpecs() error = %v", err)
+	}
+	opts := RunOptions{MaxConcurrency: 2, Selection: diff.Changed()}
+	msg := RunTestParallelCmdWithOptions(newPath, server.URL, opts)()
+	complete, ok := msg.(TestCompleteMsg)
+	if !ok {
+		t.Fatalf("Expected TestCompleteMsg, got %#v", msg)
+	}
+	if len(complete.Results) != 1 || complete.Results[0].Endpoint != "/posts" {
+		t.Errorf("Expected only the added endpoint to be tested, got %+v", complete.Results)
+	}
+	if len(hits) != 1 || hits[0] != "GET /posts" {
+		t.Errorf("Expected only GET /posts to be sent, got %v", hits)
+	}
+}
+
 func TestRunTestsParallelWithSelection(t *testing.T) {
 	// Create a test server
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -617,3 +932,947 @@ paths:
 		t.Error("Did not expect /posts to be tested (not selected)")
 	}
 }
+
+// TestRunTestsWithOptions_Overrides verifies endpoint overrides replace generated request data
+func TestRunTestsWithOptions_Overrides(t *testing.T) {
+	var gotBody, gotHeader, gotQuery string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		gotBody = string(body)
+		gotHeader = r.Header.Get("X-Tenant")
+		gotQuery = r.URL.Query().Get("limit")
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	specContent := `
+openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /users:
+    post:
+      parameters:
+        - name: limit
+          in: query
+          schema:
+            type: integer
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                name:
+                  type: string
+      responses:
+        '201':
+          description: Created
+`
+	specPath := createTempSpec(t, specContent)
+
+	opts := RunOptions{
+		MaxConcurrency: 1,
+		RetryDelay:     100,
+		Overrides: models.Overrides{
+			"POST /users": {
+				Body:    map[string]interface{}{"name": "alice"},
+				Headers: map[string]string{"X-Tenant": "acme"},
+				Query:   map[string]string{"limit": "50"},
+			},
+		},
+	}
+
+	results, err := RunTestsWithOptions(specPath, server.URL, opts, nil)
+	if err != nil {
+		t.Fatalf("RunTestsWithOptions failed: %v", err)
+	}
+	if len(results) != 1 || results[0].Status != "201" {
+		t.Fatalf("Expected one 201 result, got %+v", results)
+	}
+
+	if gotBody != `{"name":"alice"}` {
+		t.Errorf("Expected override body, got %s", gotBody)
+	}
+	if gotHeader != "acme" {
+		t.Errorf("Expected X-Tenant header 'acme', got '%s'", gotHeader)
+	}
+	if gotQuery != "50" {
+		t.Errorf("Expected limit=50, got '%s'", gotQuery)
+	}
+}
+
+// TestRunTestsWithOptions_PinnedEndpoints verifies only pinned method+path pairs are run
+func TestRunTestsWithOptions_PinnedEndpoints(t *testing.T) {
+	var mu sync.Mutex
+	requested := make(map[string]bool)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requested[r.Method+" "+r.URL.Path] = true
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	specContent := `
+openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /users:
+    get:
+      responses:
+        '200':
+          description: OK
+    post:
+      responses:
+        '200':
+          description: OK
+  /posts:
+    get:
+      responses:
+        '200':
+          description: OK
+`
+	specPath := createTempSpec(t, specContent)
+
+	pinned := []string{"POST /users", "GET /posts"}
+	opts := RunOptions{
+		MaxConcurrency: 2,
+		RetryDelay:     100,
+		Selection:      validation.PinnedSelection(pinned),
+	}
+
+	results, err := RunTestsWithOptions(specPath, server.URL, opts, nil)
+	if err != nil {
+		t.Fatalf("RunTestsWithOptions failed: %v", err)
+	}
+	if len(results) != len(pinned) {
+		t.Errorf("Expected %d results, got %d", len(pinned), len(results))
+	}
+
+	if len(requested) != len(pinned) {
+		t.Errorf("Expected %d requests, got %v", len(pinned), requested)
+	}
+	for _, key := range pinned {
+		if !requested[key] {
+			t.Errorf("Expected pinned endpoint %s to be tested", key)
+		}
+	}
+}
+
+// TestRunTestsWithOptions_CacheResponses verifies response bodies are captured without verbose mode
+func TestRunTestsWithOptions_CacheResponses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id": 1}`))
+	}))
+	defer server.Close()
+
+	specContent := `
+openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /users:
+    get:
+      responses:
+        '200':
+          description: OK
+`
+	specPath := createTempSpec(t, specContent)
+
+	results, err := RunTestsWithOptions(specPath, server.URL, RunOptions{MaxConcurrency: 1, RetryDelay: 100, CacheResponses: true}, nil)
+	if err != nil {
+		t.Fatalf("RunTestsWithOptions failed: %v", err)
+	}
+	if len(results) != 1 || results[0].Response == nil {
+		t.Fatalf("Expected a captured response, got %+v", results)
+	}
+	if results[0].Response.Status != "200" || results[0].Response.Body != `{"id": 1}` {
+		t.Errorf("Unexpected captured response: %+v", results[0].Response)
+	}
+}
+
+// TestRunTestsWithOptions_CacheBodySize verifies captured bodies honor the cache's limit
+func TestRunTestsWithOptions_CacheBodySize(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id": 12345}`))
+	}))
+	defer server.Close()
+
+	specContent := `
+openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /users:
+    get:
+      responses:
+        '200':
+          description: OK
+`
+	specPath := createTempSpec(t, specContent)
+
+	opts := RunOptions{MaxConcurrency: 1, RetryDelay: 100, CacheResponses: true, CacheBodySize: 4}
+	results, err := RunTestsWithOptions(specPath, server.URL, opts, nil)
+	if err != nil {
+		t.Fatalf("RunTestsWithOptions failed: %v", err)
+	}
+	if len(results) != 1 || results[0].Response == nil {
+		t.Fatalf("Expected a captured response, got %+v", results)
+	}
+	if results[0].Response.Body != `{"id` || !results[0].Response.Truncated {
+		t.Errorf("Expected body capped to 4 bytes and marked truncated, got %+v", results[0].Response)
+	}
+
+	// The full body kept for verbose logs is capped the same way
+	opts.Verbose = true
+	results, err = RunTestsWithOptions(specPath, server.URL, opts, nil)
+	if err != nil {
+		t.Fatalf("RunTestsWithOptions failed: %v", err)
+	}
+	if log := results[0].LogEntry; log == nil || log.FullResponseBody != `{"id` || !log.FullBodyTruncated {
+		t.Errorf("Expected the logged full body capped to 4 bytes, got %+v", log)
+	}
+}
+
+func TestRunTestsWithOptions_ForceScheme(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	specContent := `
+openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /users:
+    get:
+      responses:
+        '200':
+          description: OK
+`
+	specPath := createTempSpec(t, specContent)
+
+	// The spec server says http, but the server only speaks TLS
+	httpURL := "http://" + strings.TrimPrefix(server.URL, "https://")
+
+	// The test server's transport trusts its certificate
+	opts := RunOptions{MaxConcurrency: 1, RetryDelay: 100, ForceScheme: "https", Transport: server.Client().Transport}
+	results, err := RunTestsWithOptions(specPath, httpURL, opts, nil)
+	if err != nil {
+		t.Fatalf("RunTestsWithOptions failed: %v", err)
+	}
+	if len(results) != 1 || results[0].Status != "200" {
+		t.Fatalf("Expected the request to be sent over https, got %+v", results)
+	}
+
+	if _, err := RunTestsWithOptions(specPath, httpURL, RunOptions{ForceScheme: "ftp"}, nil); err == nil {
+		t.Error("Expected an error for an invalid forced scheme")
+	}
+}
+
+func TestRunTestsWithOptions_LogFile(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	specContent := `
+openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /users:
+    get:
+      responses:
+        '200':
+          description: OK
+    post:
+      responses:
+        '200':
+          description: OK
+  /orders:
+    get:
+      responses:
+        '200':
+          description: OK
+  /health:
+    get:
+      responses:
+        '200':
+          description: OK
+`
+	specPath := createTempSpec(t, specContent)
+	logPath := t.TempDir() + "/run.jsonl"
+
+	results, err := RunTestsWithOptions(specPath, server.URL, RunOptions{MaxConcurrency: 4, RetryDelay: 100, LogFile: logPath}, nil)
+	if err != nil {
+		t.Fatalf("RunTestsWithOptions failed: %v", err)
+	}
+
+	file, err := os.Open(logPath)
+	if err != nil {
+		t.Fatalf("Failed to open log file: %v", err)
+	}
+	defer file.Close()
+
+	lines := 0
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var entry map[string]interface{}
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			t.Fatalf("Line %d is not valid JSON: %v", lines+1, err)
+		}
+		for _, field := range []string{"timestamp", "method", "url", "status", "durationMs"} {
+			if _, ok := entry[field]; !ok {
+				t.Errorf("Line %d missing %q: %s", lines+1, field, scanner.Text())
+			}
+		}
+		lines++
+	}
+
+	if lines != len(results) || lines != 4 {
+		t.Errorf("Expected 4 log lines for %d requests, got %d", len(results), lines)
+	}
+}
+
+func TestRunTestsWithOptions_ExampleDiff(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": 1, "name": "Grace"}`))
+	}))
+	defer server.Close()
+
+	specContent := `
+openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /users/1:
+    get:
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              example:
+                id: 1
+                name: Ada
+`
+	specPath := createTempSpec(t, specContent)
+
+	results, err := RunTestsWithOptions(specPath, server.URL, RunOptions{Verbose: true}, nil)
+	if err != nil {
+		t.Fatalf("RunTestsWithOptions failed: %v", err)
+	}
+	if len(results) != 1 || results[0].LogEntry == nil {
+		t.Fatalf("Expected 1 result with a log entry, got %+v", results)
+	}
+
+	log := results[0].LogEntry
+	if !log.HasExample {
+		t.Fatal("Expected the documented example to be compared")
+	}
+	if len(log.ExampleDiff) != 1 || log.ExampleDiff[0] != `~ name: "Ada" → "Grace"` {
+		t.Errorf("Expected a single name difference, got %q", log.ExampleDiff)
+	}
+}
+
+// TestRunTestsWithOptions_ContentTypeMismatch verifies that a server returning
+// text for endpoints declared as JSON is aggregated as a mismatch
+func TestRunTestsWithOptions_ContentTypeMismatch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/health" {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"status": "ok"}`))
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write([]byte(`{"id": 1}`))
+	}))
+	defer server.Close()
+
+	specContent := `
+openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /health:
+    get:
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: object
+  /users:
+    get:
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: object
+`
+	specPath := createTempSpec(t, specContent)
+
+	results, err := RunTestsWithOptions(specPath, server.URL, RunOptions{MaxConcurrency: 1}, nil)
+	if err != nil {
+		t.Fatalf("RunTestsWithOptions failed: %v", err)
+	}
+
+	mismatches := models.DetectContentTypeMismatches(results)
+	if len(mismatches) != 1 {
+		t.Fatalf("Expected 1 mismatch, got %+v", mismatches)
+	}
+	got := mismatches[0]
+	if got.Endpoint != "GET /users" || len(got.ContentTypes) != 1 || got.ContentTypes[0] != "text/plain" || got.Count != 1 || got.Total != 1 {
+		t.Errorf("Unexpected mismatch: %+v", got)
+	}
+}
+
+func TestRunTestsWithOptions_TestAllExamples(t *testing.T) {
+	var mu sync.Mutex
+	received := make(map[string]bool)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		received[string(body)] = true
+		mu.Unlock()
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	specContent := `
+openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /users:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                role:
+                  type: string
+            examples:
+              member:
+                value:
+                  role: member
+              admin:
+                value:
+                  role: admin
+      responses:
+        '201':
+          description: Created
+`
+	specPath := createTempSpec(t, specContent)
+
+	// One generated body by default
+	results, err := RunTestsWithOptions(specPath, server.URL, RunOptions{MaxConcurrency: 1}, nil)
+	if err != nil {
+		t.Fatalf("RunTestsWithOptions failed: %v", err)
+	}
+	if len(results) != 1 || results[0].Example != "" {
+		t.Fatalf("Expected a single unlabeled result, got %+v", results)
+	}
+
+	results, err = RunTestsWithOptions(specPath, server.URL, RunOptions{MaxConcurrency: 1, TestAllExamples: true}, nil)
+	if err != nil {
+		t.Fatalf("RunTestsWithOptions failed: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("Expected one result per example, got %+v", results)
+	}
+	var labels []string
+	for _, r := range results {
+		if r.Status != "201" {
+			t.Errorf("Expected 201 for example %q, got %s (%s)", r.Example, r.Status, r.Message)
+		}
+		labels = append(labels, r.Example)
+	}
+	sort.Strings(labels)
+	if strings.Join(labels, ",") != "admin,member" {
+		t.Errorf("Expected results labeled by example name, got %v", labels)
+	}
+	if !received[`{"role":"admin"}`] || !received[`{"role":"member"}`] {
+		t.Errorf("Expected each example to be sent, got %v", received)
+	}
+}
+
+func TestRunTestsWithOptions_ContractTests(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body["name"] == nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	specContent := `
+openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /users:
+    post:
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [name]
+              properties:
+                name:
+                  type: string
+      responses:
+        '200':
+          description: OK
+        '400':
+          description: Bad Request
+`
+	specPath := createTempSpec(t, specContent)
+
+	results, err := RunTestsWithOptions(specPath, server.URL, RunOptions{MaxConcurrency: 1, ContractTests: true}, nil)
+	if err != nil {
+		t.Fatalf("RunTestsWithOptions failed: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("Expected a valid and an invalid result, got %+v", results)
+	}
+	byCase := make(map[string]models.TestResult)
+	for _, r := range results {
+		byCase[r.Case] = r
+	}
+
+	valid := byCase[models.CaseValid]
+	if valid.Status != "200" || !valid.Passed() {
+		t.Errorf("Expected valid input to pass with 200, got %s (%s)", valid.Status, valid.Message)
+	}
+	invalid := byCase[models.CaseInvalid]
+	if invalid.Status != "400" || !invalid.Passed() {
+		t.Errorf("Expected invalid input to pass when rejected with 400, got %s (%s)", invalid.Status, invalid.Message)
+	}
+	if invalid.Message != "OK (invalid input rejected)" {
+		t.Errorf("Expected the rejection to be classified as documented, got %q", invalid.Message)
+	}
+}
+
+func TestRunTestsWithOptions_ContractTestsWithExamples(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body["role"] == nil {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	specContent := `
+openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /users:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [role]
+              properties:
+                role:
+                  type: string
+            examples:
+              member:
+                value:
+                  role: member
+              admin:
+                value:
+                  role: admin
+      responses:
+        '201':
+          description: Created
+`
+	specPath := createTempSpec(t, specContent)
+
+	results, err := RunTestsWithOptions(specPath, server.URL, RunOptions{MaxConcurrency: 1, ContractTests: true, TestAllExamples: true}, nil)
+	if err != nil {
+		t.Fatalf("RunTestsWithOptions failed: %v", err)
+	}
+	var keys []string
+	for _, r := range results {
+		keys = append(keys, r.Key())
+	}
+	sort.Strings(keys)
+	want := "POST /users [admin, valid],POST /users [invalid],POST /users [member, valid]"
+	if strings.Join(keys, ",") != want {
+		t.Fatalf("Expected each example as valid input plus one invalid request, got %v", keys)
+	}
+
+	// The spec documents no 422, so rejecting the invalid input doesn't pass
+	for _, r := range results {
+		if r.Case == models.CaseInvalid && (r.Status != "422" || r.Passed()) {
+			t.Errorf("Expected an undocumented 422 to fail the invalid case, got %s (%s)", r.Status, r.Message)
+		}
+		if r.Case == models.CaseValid && !r.Passed() {
+			t.Errorf("Expected example %q to pass, got %s (%s)", r.Example, r.Status, r.Message)
+		}
+	}
+}
+
+func TestRunTestsWithOptions_ExpectedStatuses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/login", "/logout":
+			http.Redirect(w, r, "/home", http.StatusSeeOther)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+
+	specContent := `
+openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /login:
+    post:
+      responses:
+        '200':
+          description: OK
+  /logout:
+    post:
+      responses:
+        '200':
+          description: OK
+`
+	specPath := createTempSpec(t, specContent)
+
+	opts := RunOptions{
+		MaxConcurrency:   1,
+		ExpectedStatuses: map[string][]int{"POST /login": {303}},
+	}
+	results, err := RunTestsWithOptions(specPath, server.URL, opts, nil)
+	if err != nil {
+		t.Fatalf("RunTestsWithOptions failed: %v", err)
+	}
+	byPath := make(map[string]models.TestResult)
+	for _, r := range results {
+		byPath[r.Endpoint] = r
+	}
+
+	login := byPath["/login"]
+	if login.Status != "303" || !login.Passed() {
+		t.Errorf("Expected the configured 303 to pass, got %s (%s)", login.Status, login.Message)
+	}
+	if login.Message != "OK (expected 303)" {
+		t.Errorf("Expected the undocumented 303 to be reported as expected, got %q", login.Message)
+	}
+	// Without an expected status the redirect is followed as before
+	if logout := byPath["/logout"]; logout.Status != "200" || !logout.Passed() {
+		t.Errorf("Expected /logout to follow the redirect, got %s (%s)", logout.Status, logout.Message)
+	}
+}
+
+func TestRunTestsWithOptions_AppliedAuth(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/private" && r.Header.Get("Authorization") == "" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	specContent := `
+openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  securitySchemes:
+    bearerAuth:
+      type: http
+      scheme: bearer
+security:
+  - bearerAuth: []
+paths:
+  /private:
+    get:
+      responses:
+        '200':
+          description: OK
+        '401':
+          description: Unauthorized
+  /public:
+    get:
+      security: []
+      responses:
+        '200':
+          description: OK
+`
+	specPath := createTempSpec(t, specContent)
+
+	byPath := func(results []models.TestResult) map[string]models.TestResult {
+		m := make(map[string]models.TestResult)
+		for _, r := range results {
+			m[r.Endpoint] = r
+		}
+		return m
+	}
+
+	results, err := RunTestsWithOptions(specPath, server.URL, RunOptions{MaxConcurrency: 1}, nil)
+	if err != nil {
+		t.Fatalf("RunTestsWithOptions failed: %v", err)
+	}
+	private := byPath(results)["/private"]
+	if private.Auth != "none" {
+		t.Errorf("Expected no auth to be recorded, got %q", private.Auth)
+	}
+	if !strings.Contains(private.Message, "requires authentication but no auth is configured") {
+		t.Errorf("Expected a hint about the missing auth, got %q", private.Message)
+	}
+	if public := byPath(results)["/public"]; public.Auth != "none" || strings.Contains(public.Message, "hint") {
+		t.Errorf("Expected the public endpoint without auth or hint, got %q (%s)", public.Auth, public.Message)
+	}
+
+	auth := &models.AuthConfig{AuthType: "bearer", Token: "secret-token"}
+	results, err = RunTestsWithOptions(specPath, server.URL, RunOptions{MaxConcurrency: 1, Auth: auth}, nil)
+	if err != nil {
+		t.Fatalf("RunTestsWithOptions failed: %v", err)
+	}
+	for _, r := range results {
+		if r.Auth != "bearer" {
+			t.Errorf("Expected bearer auth recorded for %s, got %q", r.Endpoint, r.Auth)
+		}
+		if strings.Contains(r.Auth, "secret-token") {
+			t.Error("Expected the token to be left out of the recorded auth")
+		}
+	}
+}
+
+func TestRunTestsWithOptions_CaptureHeaders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-42")
+		w.Header().Set("Set-Cookie", "session=abc123")
+		w.Header().Set("X-Ratelimit-Remaining", "99")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	specPath := createTempSpec(t, `
+openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /users:
+    get:
+      responses:
+        '200':
+          description: OK
+`)
+
+	opts := RunOptions{MaxConcurrency: 1, Verbose: true, CaptureHeaders: []string{"x-request-id", "Set-Cookie", "X-Missing"}}
+	results, err := RunTestsWithOptions(specPath, server.URL, opts, nil)
+	if err != nil {
+		t.Fatalf("RunTestsWithOptions failed: %v", err)
+	}
+	want := map[string]string{"X-Request-Id": "req-42", "Set-Cookie": "[REDACTED]"}
+	if got := results[0].LogEntry.ResponseHeaders; !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected only the allowlisted headers %v, got %v", want, got)
+	}
+
+	// Without an allowlist every header is captured, secret ones still redacted
+	opts.CaptureHeaders = nil
+	results, err = RunTestsWithOptions(specPath, server.URL, opts, nil)
+	if err != nil {
+		t.Fatalf("RunTestsWithOptions failed: %v", err)
+	}
+	if got := results[0].LogEntry.ResponseHeaders; got["Set-Cookie"] != "[REDACTED]" || got["X-Ratelimit-Remaining"] != "99" {
+		t.Errorf("Expected all headers with Set-Cookie redacted, got %v", got)
+	}
+}
+
+func TestRunTestsWithOptions_AllowBodyOnAllMethods(t *testing.T) {
+	var mu sync.Mutex
+	received := make(map[string]string)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		received[r.Method] = string(body)
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	specContent := `
+openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /search:
+    get:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                query:
+                  type: string
+      responses:
+        '200':
+          description: OK
+`
+	specPath := createTempSpec(t, specContent)
+
+	// By default a GET is sent without a body
+	if _, err := RunTestsWithOptions(specPath, server.URL, RunOptions{MaxConcurrency: 1}, nil); err != nil {
+		t.Fatalf("RunTestsWithOptions failed: %v", err)
+	}
+	if received["GET"] != "" {
+		t.Errorf("Expected no GET body by default, got %s", received["GET"])
+	}
+
+	opts := RunOptions{MaxConcurrency: 1, AllowBodyOnAllMethods: true}
+	if _, err := RunTestsWithOptions(specPath, server.URL, opts, nil); err != nil {
+		t.Fatalf("RunTestsWithOptions failed: %v", err)
+	}
+	if received["GET"] != `{"query":"sample"}` {
+		t.Errorf("Expected the generated body on the GET, got %q", received["GET"])
+	}
+}
+
+func TestRunTestsWithOptions_Preflight(t *testing.T) {
+	var healthy, suiteHits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/slow-health" {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(5 * time.Second):
+			}
+			return
+		}
+		if r.URL.Path == "/health" {
+			if healthy.Load() == 0 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}
+			return
+		}
+		suiteHits.Add(1)
+	}))
+	defer server.Close()
+
+	specContent := `
+openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /users:
+    get:
+      responses:
+        '200':
+          description: OK
+`
+	specPath := createTempSpec(t, specContent)
+
+	// A failing preflight aborts before any endpoint is tested
+	results, err := RunTestsWithOptions(specPath, server.URL, RunOptions{PreflightPath: "/health"}, nil)
+	if err == nil || !strings.Contains(err.Error(), "preflight") || !strings.Contains(err.Error(), "503") {
+		t.Fatalf("Expected a preflight error, got %v", err)
+	}
+	if results != nil || suiteHits.Load() != 0 {
+		t.Errorf("Expected the suite not to run, got %d results and %d requests", len(results), suiteHits.Load())
+	}
+
+	// A passing preflight lets the run go ahead
+	healthy.Store(1)
+	results, err = RunTestsWithOptions(specPath, server.URL, RunOptions{PreflightPath: "health"}, nil)
+	if err != nil {
+		t.Fatalf("RunTestsWithOptions failed: %v", err)
+	}
+	if len(results) != 1 || suiteHits.Load() != 1 {
+		t.Errorf("Expected the suite to run after a passing preflight, got %+v", results)
+	}
+
+	// A hanging preflight is bounded by the run's time budget
+	start := time.Now()
+	_, err = RunTestsWithOptions(specPath, server.URL, RunOptions{PreflightPath: "/slow-health", MaxRunDuration: 100 * time.Millisecond}, nil)
+	if err == nil || !strings.Contains(err.Error(), "preflight") {
+		t.Errorf("Expected a preflight error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("Expected the preflight to stop with the time budget, took %v", elapsed)
+	}
+}
+
+func TestRunTestParallelCmdWithProgress(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	specContent := `
+openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /users:
+    get:
+      summary: List users
+      responses:
+        '200':
+          description: OK
+`
+	specPath := createTempSpec(t, specContent)
+
+	progress := make(chan tea.Msg, 8)
+	if _, ok := RunTestParallelCmdWithProgress(specPath, server.URL, RunOptions{MaxConcurrency: 1}, progress)().(TestCompleteMsg); !ok {
+		t.Fatal("Expected the run to complete")
+	}
+
+	msg, ok := WaitForProgress(progress)().(TestProgressMsg)
+	if !ok {
+		t.Fatal("Expected a progress message")
+	}
+	if msg.Endpoint != "GET /users" || msg.Summary != "List users" || msg.Completed != 1 || msg.Total != 1 {
+		t.Errorf("Expected progress naming GET /users, got %+v", msg)
+	}
+	// The channel is closed once the run is over
+	if next := WaitForProgress(progress)(); next != nil {
+		t.Errorf("Expected no more progress after the run, got %+v", next)
+	}
+}
@@ -0,0 +1,81 @@
+package testing
+
+import (
+	"net/http"
+
+	"github.com/Traves-Theberge/OpenAPI-Toolkit/openapi-tui/internal/models"
+)
+
+// RequestMutator changes an outgoing request before it is sent, e.g. to add
+// headers or credentials. An error stops the request from being sent
+type RequestMutator func(*http.Request) error
+
+// ApplyMutators runs mutators on req in order, stopping at the first error
+func ApplyMutators(req *http.Request, mutators ...RequestMutator) error {
+	for _, mutate := range mutators {
+		if err := mutate(req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// requestPipeline returns the mutators sendRequest applies, in order. Later
+// steps win when they set the same header:
+//  1. the default JSON Content-Type, when there is a body
+//  2. run-wide headers from the config (see RunHeaders)
+//  3. per-endpoint headers (overrides, x-test-headers, Accept-Encoding)
+//  4. authentication from the config
+//
+// New request options (signing, cookies, ...) belong here as further steps
+func requestPipeline(hasBody bool, runHeaders, headers map[string]string, auth *models.AuthConfig) []RequestMutator {
+	return []RequestMutator{
+		defaultContentType(hasBody),
+		setHeaders(runHeaders),
+		setHeaders(headers),
+		authMutator(auth),
+	}
+}
+
+// RunHeaders returns the headers the config sends with every request, test
+// runs, the preflight check and custom requests alike: the API version
+// header and the standard request headers, which win when they share a name.
+// Per-endpoint and custom request headers replace them
+func RunHeaders(version models.APIVersionHeader, standard models.RequestHeaders) map[string]string {
+	headers := make(map[string]string)
+	if version.Name != "" {
+		headers[http.CanonicalHeaderKey(version.Name)] = version.Value
+	}
+	for name, value := range standard.Headers() {
+		headers[name] = value
+	}
+	return headers
+}
+
+// defaultContentType sends bodies as JSON unless a later step says otherwise
+func defaultContentType(hasBody bool) RequestMutator {
+	return func(req *http.Request) error {
+		if hasBody {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		return nil
+	}
+}
+
+// setHeaders sets each header, replacing any value already set
+func setHeaders(headers map[string]string) RequestMutator {
+	return func(req *http.Request) error {
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+		return nil
+	}
+}
+
+// authMutator applies the configured authentication with ApplyAuth
+func authMutator(auth *models.AuthConfig) RequestMutator {
+	return func(req *http.Request) error {
+		ApplyAuth(req, auth)
+		return nil
+	}
+}
@@ -0,0 +1,96 @@
+package testing
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/Traves-Theberge/OpenAPI-Toolkit/openapi-tui/internal/models"
+)
+
+func TestApplyMutators_Order(t *testing.T) {
+	req, _ := http.NewRequest("GET", "http://localhost/users", nil)
+
+	var order []string
+	step := func(name string) RequestMutator {
+		return func(req *http.Request) error {
+			order = append(order, name)
+			req.Header.Set("X-Step", name)
+			return nil
+		}
+	}
+
+	if err := ApplyMutators(req, step("first"), step("second"), step("third")); err != nil {
+		t.Fatalf("ApplyMutators failed: %v", err)
+	}
+	if !reflect.DeepEqual(order, []string{"first", "second", "third"}) {
+		t.Errorf("Expected mutators in order, got %v", order)
+	}
+	if got := req.Header.Get("X-Step"); got != "third" {
+		t.Errorf("Expected the last mutator to win, got %q", got)
+	}
+}
+
+func TestApplyMutators_StopsOnError(t *testing.T) {
+	req, _ := http.NewRequest("GET", "http://localhost/users", nil)
+
+	failure := errors.New("signing key missing")
+	ran := false
+	err := ApplyMutators(req,
+		func(*http.Request) error { return failure },
+		func(*http.Request) error { ran = true; return nil },
+	)
+	if !errors.Is(err, failure) {
+		t.Errorf("Expected the mutator's error, got %v", err)
+	}
+	if ran {
+		t.Error("Expected mutators after the failing one to be skipped")
+	}
+}
+
+func TestRequestPipeline(t *testing.T) {
+	req, _ := http.NewRequest("POST", "http://localhost/users", nil)
+	headers := map[string]string{
+		"Content-Type":  "application/merge-patch+json",
+		"Authorization": "Bearer from-override",
+	}
+	auth := &models.AuthConfig{AuthType: "bearer", Token: "from-config"}
+
+	if err := ApplyMutators(req, requestPipeline(true, nil, headers, auth)...); err != nil {
+		t.Fatalf("ApplyMutators failed: %v", err)
+	}
+	// Endpoint headers replace the default Content-Type; auth is applied last
+	if got := req.Header.Get("Content-Type"); got != "application/merge-patch+json" {
+		t.Errorf("Expected the endpoint Content-Type, got %q", got)
+	}
+	if got := req.Header.Get("Authorization"); got != "Bearer from-config" {
+		t.Errorf("Expected the configured auth to win, got %q", got)
+	}
+
+	// Run-wide headers are sent unless the endpoint sets its own
+	req, _ = http.NewRequest("GET", "http://localhost/users", nil)
+	runHeaders := RunHeaders(models.APIVersionHeader{Name: "api-version", Value: "2"}, models.RequestHeaders{})
+	ApplyMutators(req, requestPipeline(false, runHeaders, nil, nil)...)
+	if got := req.Header.Get("Api-Version"); got != "2" {
+		t.Errorf("Expected the run-wide Api-Version, got %q", got)
+	}
+	req, _ = http.NewRequest("GET", "http://localhost/users", nil)
+	ApplyMutators(req, requestPipeline(false, runHeaders, map[string]string{"Api-Version": "3"}, nil)...)
+	if got := req.Header.Get("Api-Version"); got != "3" {
+		t.Errorf("Expected the endpoint Api-Version to win, got %q", got)
+	}
+
+	// Standard headers are sent alongside the version header
+	runHeaders = RunHeaders(models.APIVersionHeader{Name: "api-version", Value: "2"}, models.RequestHeaders{Prefer: "respond-async"})
+	if runHeaders["Prefer"] != "respond-async" || runHeaders["Api-Version"] != "2" {
+		t.Errorf("Expected Prefer and Api-Version, got %v", runHeaders)
+	}
+
+	// Without a body no Content-Type is set
+	req, _ = http.NewRequest("GET", "http://localhost/users", nil)
+	ApplyMutators(req, requestPipeline(false, nil, nil, nil)...)
+	if got := req.Header.Get("Content-Type"); got != "" {
+		t.Errorf("Expected no Content-Type without a body, got %q", got)
+	}
+}
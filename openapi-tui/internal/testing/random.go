@@ -0,0 +1,137 @@
+package testing
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// randomAlphabet is what random strings are drawn from
+const randomAlphabet = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+// seededRand returns a generator seeded from seed and key, e.g. an
+// operation's method and path, so each operation gets its own reproducible
+// sequence whatever order operations are generated in
+func seededRand(seed int64, key string) *rand.Rand {
+	h := fnv.New64a()
+	h.Write([]byte(key))
+	return rand.New(rand.NewSource(seed ^ int64(h.Sum64())))
+}
+
+// randomScalar returns a random value the schema accepts, for strings,
+// numbers, integers, booleans and enums, or false for other types. Length
+// and range constraints are respected; patterns are not
+func randomScalar(schema *openapi3.Schema, rng *rand.Rand) (interface{}, bool) {
+	if len(schema.Enum) > 0 {
+		return schema.Enum[rng.Intn(len(schema.Enum))], true
+	}
+
+	switch sampleType(schema) {
+	case "string":
+		return randomString(schema, rng), true
+	case "integer":
+		low, high := numberRange(schema, 1, 1000)
+		lo, hi := int64(math.Ceil(low)), int64(math.Floor(high))
+		if hi < lo {
+			return lo, true
+		}
+		return lo + rng.Int63n(hi-lo+1), true
+	case "number":
+		low, high := numberRange(schema, 0, 1000)
+		value := math.Round((low+rng.Float64()*(high-low))*100) / 100
+		return math.Min(math.Max(value, low), high), true
+	case "boolean":
+		return rng.Intn(2) == 1, true
+	}
+	return nil, false
+}
+
+// randomString returns a random string for the schema's format, or random
+// letters and digits within its length bounds
+func randomString(schema *openapi3.Schema, rng *rand.Rand) string {
+	switch schema.Format {
+	case "date":
+		return randomTime(rng).Format("2006-01-02")
+	case "date-time":
+		return randomTime(rng).Format(time.RFC3339)
+	case "email":
+		return randomLetters(rng, 8) + "@example.com"
+	case "uri", "url":
+		return "https://example.com/" + randomLetters(rng, 8)
+	case "uuid":
+		b := make([]byte, 16)
+		rng.Read(b)
+		b[6] = b[6]&0x0f | 0x40 // Version 4
+		b[8] = b[8]&0x3f | 0x80 // RFC 4122 variant
+		return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+	}
+
+	minLength := int(schema.MinLength)
+	maxLength := max(minLength, 12)
+	if schema.MaxLength != nil {
+		maxLength = max(minLength, int(*schema.MaxLength))
+	}
+	minLength = max(minLength, 1)
+	maxLength = max(maxLength, minLength)
+	return randomLetters(rng, minLength+rng.Intn(maxLength-minLength+1))
+}
+
+// randomLetters returns n random letters and digits
+func randomLetters(rng *rand.Rand, n int) string {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = randomAlphabet[rng.Intn(len(randomAlphabet))]
+	}
+	return string(b)
+}
+
+// randomTime returns a random whole second between 2000 and 2030, in UTC
+func randomTime(rng *rand.Rand) time.Time {
+	start := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC).Unix()
+	end := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC).Unix()
+	return time.Unix(start+rng.Int63n(end-start), 0).UTC()
+}
+
+// randomArrayLength returns how many items a random array gets: 1 to 3,
+// within the schema's minItems and maxItems
+func randomArrayLength(schema *openapi3.Schema, rng *rand.Rand) int {
+	low := max(int(schema.MinItems), 1)
+	high := max(low, 3)
+	if schema.MaxItems != nil {
+		high = min(high, int(*schema.MaxItems))
+	}
+	if high < low {
+		return int(schema.MinItems)
+	}
+	return low + rng.Intn(high-low+1)
+}
+
+// numberRange returns the inclusive range a random number is drawn from: the
+// schema's minimum and maximum, nudged inward when exclusive, falling back to
+// defLow and defHigh (shifted to keep a non-empty range next to one bound)
+func numberRange(schema *openapi3.Schema, defLow, defHigh float64) (float64, float64) {
+	low, high := defLow, defHigh
+	if schema.Min != nil {
+		low = *schema.Min
+		if schema.ExclusiveMin {
+			low++
+		}
+		if schema.Max == nil && high < low {
+			high = low + (defHigh - defLow)
+		}
+	}
+	if schema.Max != nil {
+		high = *schema.Max
+		if schema.ExclusiveMax {
+			high--
+		}
+		if schema.Min == nil && low > high {
+			low = high - (defHigh - defLow)
+		}
+	}
+	return low, high
+}
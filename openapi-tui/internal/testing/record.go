@@ -0,0 +1,124 @@
+package testing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+)
+
+// recorder captures every request/response pair of a run in the replay file
+// format, so the run can be replayed later with RunOptions.ReplayFile. The
+// attempts of a retried request share one entry, holding the final response
+// Safe for use from multiple workers
+type recorder struct {
+	next    http.RoundTripper // Transport that sends the requests (nil = http.DefaultTransport)
+	file    *os.File
+	mu      sync.Mutex
+	entries []replayEntry
+}
+
+// openRecorder creates (or truncates) path, recording requests sent through next
+func openRecorder(path string, next http.RoundTripper) (*recorder, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	return &recorder{next: next, file: file}, nil
+}
+
+// recordSlotKey marks a request context whose attempts are recorded in one
+// entry, each replacing the last
+type recordSlotKey struct{}
+
+// recordSlot is the index of the entry a request's attempts are recorded in,
+// -1 until the first attempt is recorded
+type recordSlot struct {
+	index int
+}
+
+// withRecordSlot returns a context whose requests, such as the attempts of one
+// retried job, are recorded as a single entry
+func withRecordSlot(ctx context.Context) context.Context {
+	return context.WithValue(ctx, recordSlotKey{}, &recordSlot{index: -1})
+}
+
+// RoundTrip sends req and records its response. The body is read in full,
+// decoded from any Content-Encoding, and handed back unread to the caller
+func (r *recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	next := r.next
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	resp, err := next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	if err := decodeResponseBody(resp); err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	headers := make(map[string]string, len(resp.Header))
+	for name, values := range resp.Header {
+		if len(values) > 0 {
+			headers[name] = values[0]
+		}
+	}
+	entry := replayEntry{
+		Method:  req.Method,
+		Path:    req.URL.Path,
+		Status:  resp.StatusCode,
+		Headers: headers,
+		Body:    recordedBody(body),
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if slot, ok := req.Context().Value(recordSlotKey{}).(*recordSlot); ok {
+		if slot.index >= 0 {
+			// A retry replaces the attempt before it
+			r.entries[slot.index] = entry
+			return resp, nil
+		}
+		slot.index = len(r.entries)
+	}
+	r.entries = append(r.entries, entry)
+	return resp, nil
+}
+
+// Close writes the recorded entries as a JSON array and closes the file
+// Write errors are ignored so recording never fails a run
+func (r *recorder) Close() error {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	entries := r.entries
+	if entries == nil {
+		entries = []replayEntry{}
+	}
+	if data, err := json.MarshalIndent(entries, "", "  "); err == nil {
+		_, _ = r.file.Write(append(data, '\n'))
+	}
+	return r.file.Close()
+}
+
+// recordedBody stores a body as a JSON string, so it replays byte for byte
+func recordedBody(body []byte) json.RawMessage {
+	if len(body) == 0 {
+		return nil
+	}
+	text, _ := json.Marshal(string(body))
+	return text
+}
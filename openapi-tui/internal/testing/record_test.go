@@ -0,0 +1,156 @@
+package testing
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestRunTestsWithOptions_RecordFile(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/users":
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`[{"id":1}]`))
+		case r.Method == "POST" && r.URL.Path == "/users":
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte("created"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	specPath := filepath.Join(dir, "openapi.yaml")
+	spec := `openapi: 3.0.0
+info:
+  title: Record
+  version: "1.0"
+paths:
+  /users:
+    get:
+      responses:
+        "200":
+          description: OK
+    post:
+      responses:
+        "201":
+          description: Created
+  /orders:
+    get:
+      responses:
+        "200":
+          description: OK
+`
+	if err := os.WriteFile(specPath, []byte(spec), 0644); err != nil {
+		t.Fatal(err)
+	}
+	recordPath := filepath.Join(dir, "recording.json")
+
+	// Not verbose: recording captures bodies regardless
+	opts := RunOptions{MaxConcurrency: 3, RecordFile: recordPath}
+	live, err := RunTestsWithOptions(specPath, server.URL, opts, nil)
+	if err != nil {
+		t.Fatalf("RunTestsWithOptions() error = %v", err)
+	}
+
+	data, err := os.ReadFile(recordPath)
+	if err != nil {
+		t.Fatalf("Failed to read the record file: %v", err)
+	}
+	var entries []replayEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		t.Fatalf("Record file is not a JSON array of entries: %v\n%s", err, data)
+	}
+	if len(entries) != len(live) {
+		t.Fatalf("Expected one entry per request (%d), got %d", len(live), len(entries))
+	}
+	recorded := make(map[string]replayEntry)
+	for _, entry := range entries {
+		recorded[entry.Method+" "+entry.Path] = entry
+	}
+	if got := recorded["GET /users"]; got.Status != 200 || string(replayBody(got.Body)) != `[{"id":1}]` {
+		t.Errorf("Expected the JSON response to be recorded, got %+v", got)
+	}
+	if got := recorded["POST /users"]; got.Status != 201 || string(replayBody(got.Body)) != "created" {
+		t.Errorf("Expected the text response to be recorded, got %+v", got)
+	}
+
+	// The recording replays the same statuses without the server
+	replayed, err := RunTestsWithOptions(specPath, "http://replay.invalid", RunOptions{MaxConcurrency: 1, ReplayFile: recordPath}, nil)
+	if err != nil {
+		t.Fatalf("Replaying the recording failed: %v", err)
+	}
+	liveStatus := make(map[string]string)
+	for _, r := range live {
+		liveStatus[r.Method+" "+r.Endpoint] = r.Status
+	}
+	for _, r := range replayed {
+		if want := liveStatus[r.Method+" "+r.Endpoint]; r.Status != want || strings.HasPrefix(r.Status, "ERR") {
+			t.Errorf("%s %s: expected replayed status %s, got %s (%s)", r.Method, r.Endpoint, want, r.Status, r.Message)
+		}
+	}
+}
+
+func TestRunTestsWithOptions_RecordFileRetriesAndPreflight(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/health":
+			w.Write([]byte("up"))
+		case "/users":
+			// The first attempt fails, the retry succeeds
+			if attempts.Add(1) == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.Write([]byte("ok"))
+		}
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	specPath := createTempSpec(t, `openapi: 3.0.0
+info:
+  title: Record
+  version: "1.0"
+paths:
+  /users:
+    get:
+      responses:
+        "200":
+          description: OK
+`)
+	recordPath := filepath.Join(dir, "recording.json")
+
+	opts := RunOptions{MaxConcurrency: 1, MaxRetries: 1, RecordFile: recordPath, PreflightPath: "/health"}
+	if _, err := RunTestsWithOptions(specPath, server.URL, opts, nil); err != nil {
+		t.Fatalf("RunTestsWithOptions() error = %v", err)
+	}
+
+	data, err := os.ReadFile(recordPath)
+	if err != nil {
+		t.Fatalf("Failed to read the record file: %v", err)
+	}
+	var entries []replayEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		t.Fatalf("Failed to parse the record file: %v", err)
+	}
+	// The preflight is recorded, and the retried request only once, with
+	// its final response
+	if len(entries) != 2 {
+		t.Fatalf("Expected the preflight and one users entry, got %+v", entries)
+	}
+	if entries[0].Path != "/health" || entries[0].Status != 200 {
+		t.Errorf("Expected the preflight first, got %+v", entries[0])
+	}
+	if entries[1].Path != "/users" || entries[1].Status != 200 {
+		t.Errorf("Expected the final attempt to be recorded, got %+v", entries[1])
+	}
+}
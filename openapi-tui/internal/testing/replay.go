@@ -0,0 +1,180 @@
+package testing
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// ReplayTransport answers requests with previously recorded responses instead
+// of sending them, matching on method and URL path (host and query are
+// ignored). Requests with no recording fail without touching the network
+type ReplayTransport struct {
+	responses map[string]recordedResponse // By replayKey
+}
+
+// recordedResponse is a response loaded from a replay file
+type recordedResponse struct {
+	Status  int
+	Headers map[string]string
+	Body    []byte
+}
+
+// replayEntry is one response in the plain JSON replay format
+type replayEntry struct {
+	Method  string            `json:"method"`
+	Path    string            `json:"path"`
+	Status  int               `json:"status"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    json.RawMessage   `json:"body,omitempty"` // A JSON string is sent as its text, anything else as JSON
+}
+
+// harFile is the part of a HAR capture replay needs
+type harFile struct {
+	Log struct {
+		Entries []struct {
+			Request struct {
+				Method string `json:"method"`
+				URL    string `json:"url"`
+			} `json:"request"`
+			Response struct {
+				Status  int `json:"status"`
+				Headers []struct {
+					Name  string `json:"name"`
+					Value string `json:"value"`
+				} `json:"headers"`
+				Content struct {
+					Text     string `json:"text"`
+					Encoding string `json:"encoding"`
+				} `json:"content"`
+			} `json:"response"`
+		} `json:"entries"`
+	} `json:"log"`
+}
+
+// LoadReplayFile reads recorded responses from a HAR capture (as saved by a
+// browser's developer tools) or a JSON array of {method, path, status,
+// headers, body} entries. When a method and path were recorded more than once,
+// the first recording is replayed
+func LoadReplayFile(path string) (*ReplayTransport, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	transport := &ReplayTransport{responses: make(map[string]recordedResponse)}
+
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) > 0 && trimmed[0] == '[' {
+		var entries []replayEntry
+		if err := json.Unmarshal(trimmed, &entries); err != nil {
+			return nil, fmt.Errorf("failed to parse replay file %s: %w", path, err)
+		}
+		for i, entry := range entries {
+			if entry.Method == "" || entry.Path == "" || entry.Status == 0 {
+				return nil, fmt.Errorf("replay file %s: entry %d needs a method, path and status", path, i)
+			}
+			transport.record(entry.Method, entry.Path, recordedResponse{
+				Status:  entry.Status,
+				Headers: entry.Headers,
+				Body:    replayBody(entry.Body),
+			})
+		}
+		return transport, nil
+	}
+
+	var har harFile
+	if err := json.Unmarshal(trimmed, &har); err != nil {
+		return nil, fmt.Errorf("failed to parse replay file %s: %w", path, err)
+	}
+	for _, entry := range har.Log.Entries {
+		u, err := url.Parse(entry.Request.URL)
+		if err != nil || entry.Response.Status == 0 {
+			continue // Aborted or malformed captures have nothing to replay
+		}
+		body := []byte(entry.Response.Content.Text)
+		if entry.Response.Content.Encoding == "base64" {
+			if body, err = base64.StdEncoding.DecodeString(entry.Response.Content.Text); err != nil {
+				return nil, fmt.Errorf("replay file %s: invalid base64 body for %s %s", path, entry.Request.Method, entry.Request.URL)
+			}
+		}
+		headers := make(map[string]string, len(entry.Response.Headers))
+		for _, h := range entry.Response.Headers {
+			headers[h.Name] = h.Value
+		}
+		transport.record(entry.Request.Method, u.Path, recordedResponse{
+			Status:  entry.Response.Status,
+			Headers: headers,
+			Body:    body,
+		})
+	}
+	if len(transport.responses) == 0 {
+		return nil, fmt.Errorf("replay file %s has no recorded responses (expected a HAR capture or a JSON array of responses)", path)
+	}
+	return transport, nil
+}
+
+// record keeps the first response recorded for method and path
+func (t *ReplayTransport) record(method, path string, response recordedResponse) {
+	key := replayKey(method, path)
+	if _, ok := t.responses[key]; !ok {
+		t.responses[key] = response
+	}
+}
+
+// RoundTrip returns the recorded response for the request's method and path
+func (t *ReplayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+	recorded, ok := t.responses[replayKey(req.Method, req.URL.Path)]
+	if !ok {
+		return nil, fmt.Errorf("no recorded response for %s %s in the replay file", strings.ToUpper(req.Method), req.URL.Path)
+	}
+
+	header := make(http.Header, len(recorded.Headers))
+	for name, value := range recorded.Headers {
+		// The body is stored decoded, so its original encoding and length no longer apply
+		if strings.EqualFold(name, "Content-Encoding") || strings.EqualFold(name, "Content-Length") {
+			continue
+		}
+		header.Set(name, value)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", recorded.Status, http.StatusText(recorded.Status)),
+		StatusCode:    recorded.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(recorded.Body)),
+		ContentLength: int64(len(recorded.Body)),
+		Request:       req,
+	}, nil
+}
+
+// replayKey identifies a recording by upper-case method and path, ignoring a
+// trailing slash
+func replayKey(method, path string) string {
+	if len(path) > 1 {
+		path = strings.TrimSuffix(path, "/")
+	}
+	if path == "" {
+		path = "/"
+	}
+	return strings.ToUpper(method) + " " + path
+}
+
+// replayBody returns the text of a JSON string body, or the raw JSON otherwise
+func replayBody(raw json.RawMessage) []byte {
+	var text string
+	if err := json.Unmarshal(raw, &text); err == nil {
+		return []byte(text)
+	}
+	return raw
+}
@@ -0,0 +1,108 @@
+package testing
+
+import (
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunTestsWithOptions_ReplayFile(t *testing.T) {
+	dir := t.TempDir()
+	specPath := filepath.Join(dir, "openapi.yaml")
+	spec := `openapi: 3.0.0
+info:
+  title: Replay
+  version: "1.0"
+paths:
+  /users:
+    get:
+      responses:
+        "200":
+          description: OK
+    post:
+      responses:
+        "201":
+          description: Created
+  /orders:
+    get:
+      responses:
+        "200":
+          description: OK
+`
+	replay := `[
+  {"method": "GET", "path": "/api/users", "status": 200, "headers": {"Content-Type": "application/json"}, "body": [{"id": 1}]},
+  {"method": "post", "path": "/api/users/", "status": 201, "body": "created"}
+]`
+	replayPath := filepath.Join(dir, "replay.json")
+	if err := os.WriteFile(specPath, []byte(spec), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(replayPath, []byte(replay), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// The .invalid host can never resolve, so any real request would fail
+	opts := RunOptions{MaxConcurrency: 1, ReplayFile: replayPath, Verbose: true}
+	results, err := RunTestsWithOptions(specPath, "http://replay.invalid/api", opts, nil)
+	if err != nil {
+		t.Fatalf("RunTestsWithOptions() error = %v", err)
+	}
+
+	got := make(map[string]string)
+	bodies := make(map[string]string)
+	for _, r := range results {
+		got[r.Method+" "+r.Endpoint] = r.Status + " " + r.Message
+		if r.LogEntry != nil {
+			bodies[r.Method+" "+r.Endpoint] = r.LogEntry.ResponseBody
+		}
+	}
+	if !strings.HasPrefix(got["GET /users"], "200") || !strings.HasPrefix(got["POST /users"], "201") {
+		t.Errorf("Expected the recorded statuses, got %v", got)
+	}
+	if bodies["GET /users"] != `[{"id": 1}]` {
+		t.Errorf("Expected the recorded body, got %q", bodies["GET /users"])
+	}
+	if !strings.HasPrefix(got["GET /orders"], "ERR") || !strings.Contains(got["GET /orders"], "no recorded response for GET /api/orders") {
+		t.Errorf("Expected an unrecorded endpoint to fail without a request, got %q", got["GET /orders"])
+	}
+}
+
+func TestLoadReplayFile_HAR(t *testing.T) {
+	har := `{"log": {"entries": [
+  {"request": {"method": "GET", "url": "https://api.example.com/users?page=1"},
+   "response": {"status": 200, "headers": [{"name": "Content-Type", "value": "application/json"}, {"name": "Content-Encoding", "value": "gzip"}],
+                "content": {"text": "W10=", "encoding": "base64"}}},
+  {"request": {"method": "GET", "url": "https://api.example.com/users"},
+   "response": {"status": 500, "content": {"text": "later"}}}
+]}}`
+	path := filepath.Join(t.TempDir(), "capture.har")
+	if err := os.WriteFile(path, []byte(har), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	transport, err := LoadReplayFile(path)
+	if err != nil {
+		t.Fatalf("LoadReplayFile() error = %v", err)
+	}
+	req, _ := http.NewRequest("GET", "http://localhost:8080/users", nil)
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("RoundTrip() error = %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != 200 || string(body) != "[]" {
+		t.Errorf("Expected the first recording with its decoded body, got %d %q", resp.StatusCode, body)
+	}
+	if resp.Header.Get("Content-Type") != "application/json" || resp.Header.Get("Content-Encoding") != "" {
+		t.Errorf("Expected the recorded headers without Content-Encoding, got %v", resp.Header)
+	}
+
+	empty := filepath.Join(t.TempDir(), "empty.har")
+	os.WriteFile(empty, []byte(`{"log": {"entries": []}}`), 0644)
+	if _, err := LoadReplayFile(empty); err == nil {
+		t.Error("Expected an error for a replay file with no responses")
+	}
+}
@@ -0,0 +1,136 @@
+package testing
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/Traves-Theberge/OpenAPI-Toolkit/openapi-tui/internal/errors"
+	"github.com/Traves-Theberge/OpenAPI-Toolkit/openapi-tui/internal/models"
+	"github.com/Traves-Theberge/OpenAPI-Toolkit/openapi-tui/internal/validation"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// ListResolvedRequests returns every request a run with opts would send, as
+// "METHOD URL" lines with path parameters and query strings filled in the
+// same way as during the run, in the order the run sends them
+// Nothing is sent; the list is meant for documentation and handoffs.
+func ListResolvedRequests(specPath, baseURL string, opts RunOptions) ([]string, error) {
+	loader := &openapi3.Loader{IsExternalRefsAllowed: true}
+	doc, err := loader.LoadFromFile(specPath)
+	if err != nil {
+		return nil, errors.EnhanceFileError(err, specPath)
+	}
+	if err := validation.CheckOpenAPIDocument(doc, specPath); err != nil {
+		return nil, err
+	}
+
+	jobs := buildJobs(doc, baseURL, opts)
+	if opts.TestOrder == models.TestOrderSpec {
+		if order, err := validation.OperationOrder(specPath); err == nil {
+			orderJobs(jobs, order)
+		}
+	}
+
+	lines := make([]string, 0, len(jobs))
+	for _, job := range jobs {
+		lines = append(lines, strings.ToUpper(job.Method)+" "+job.Endpoint)
+	}
+	return lines, nil
+}
+
+// CountRequests returns how many requests a run with opts would send to the
+// spec at specPath, after its selection and include and exclude globs,
+// counting both cases of contract tests and every example when testing all
+// examples. Retries are not counted; nothing is sent
+func CountRequests(specPath, baseURL string, opts RunOptions) (int, error) {
+	loader := &openapi3.Loader{IsExternalRefsAllowed: true}
+	doc, err := loader.LoadFromFile(specPath)
+	if err != nil {
+		return 0, errors.EnhanceFileError(err, specPath)
+	}
+	if err := validation.CheckOpenAPIDocument(doc, specPath); err != nil {
+		return 0, err
+	}
+	return len(buildJobs(doc, baseURL, opts)), nil
+}
+
+// GenerateEndpointBody returns the request body a run would send to one
+// endpoint, indented when it is JSON
+// Only POST, PUT and PATCH get a body; other methods return nil.
+func GenerateEndpointBody(specPath, method, path string) ([]byte, error) {
+	upper := strings.ToUpper(method)
+	if upper != "POST" && upper != "PUT" && upper != "PATCH" {
+		return nil, nil
+	}
+
+	loader := &openapi3.Loader{IsExternalRefsAllowed: true}
+	doc, err := loader.LoadFromFile(specPath)
+	if err != nil {
+		return nil, errors.EnhanceFileError(err, specPath)
+	}
+	if err := validation.CheckOpenAPIDocument(doc, specPath); err != nil {
+		return nil, err
+	}
+	var operation *openapi3.Operation
+	if doc.Paths != nil {
+		if pathItem := doc.Paths.Value(path); pathItem != nil {
+			operation = pathItem.GetOperation(upper)
+		}
+	}
+	if operation == nil {
+		return nil, fmt.Errorf("%s %s is not in the spec", upper, path)
+	}
+
+	body, err := GenerateRequestBody(operation)
+	if err != nil || body == nil {
+		return body, err
+	}
+	var indented bytes.Buffer
+	if json.Indent(&indented, body, "", "  ") == nil {
+		return indented.Bytes(), nil
+	}
+	return body, nil
+}
+
+// BodyGenerationReport generates the request bodies a run with opts would
+// send for the spec at specPath, and counts the operations whose declared
+// body came out empty, without sending anything
+func BodyGenerationReport(specPath string, opts RunOptions) (models.BodyGenReport, error) {
+	var report models.BodyGenReport
+	loader := &openapi3.Loader{IsExternalRefsAllowed: true}
+	doc, err := loader.LoadFromFile(specPath)
+	if err != nil {
+		return report, errors.EnhanceFileError(err, specPath)
+	}
+	if err := validation.CheckOpenAPIDocument(doc, specPath); err != nil {
+		return report, err
+	}
+
+	seen := make(map[string]bool)
+	for _, job := range buildJobs(doc, "", opts) {
+		upper := strings.ToUpper(job.Method)
+		bodyMethod := upper == "POST" || upper == "PUT" || upper == "PATCH" || opts.AllowBodyOnAllMethods
+		if !bodyMethod || job.Operation == nil || job.Operation.RequestBody == nil {
+			continue
+		}
+		if opts.OmitOptionalBodies && !bodyRequired(job.Operation) {
+			continue
+		}
+		// Contract and example runs test an operation more than once
+		key := models.EndpointKey(job.Method, job.Path)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		report.Operations++
+		if body := bytes.TrimSpace(job.RequestBody); len(body) > 0 && string(body) != "null" {
+			report.Generated++
+		} else {
+			report.Gaps = append(report.Gaps, key)
+		}
+	}
+	return report, nil
+}
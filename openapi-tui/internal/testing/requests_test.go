@@ -0,0 +1,185 @@
+package testing
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Traves-Theberge/OpenAPI-Toolkit/openapi-tui/internal/models"
+)
+
+func TestListResolvedRequests(t *testing.T) {
+	specContent := `
+openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /users/{id}:
+    delete:
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: integer
+      responses:
+        '204':
+          description: Deleted
+    get:
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: integer
+        - name: fields
+          in: query
+          schema:
+            type: string
+            example: name
+      responses:
+        '200':
+          description: OK
+  /users:
+    get:
+      parameters:
+        - name: limit
+          in: query
+          example: 10
+      responses:
+        '200':
+          description: OK
+`
+	specPath := createTempSpec(t, specContent)
+
+	lines, err := ListResolvedRequests(specPath, "https://api.example.com", RunOptions{})
+	if err != nil {
+		t.Fatalf("ListResolvedRequests() error = %v", err)
+	}
+	want := []string{
+		"GET https://api.example.com/users?limit=10",
+		"DELETE https://api.example.com/users/1",
+		"GET https://api.example.com/users/1?fields=name",
+	}
+	if !reflect.DeepEqual(lines, want) {
+		t.Errorf("ListResolvedRequests() = %q, want %q", lines, want)
+	}
+
+	// The run's options apply: the global query, overrides and selection
+	lines, err = ListResolvedRequests(specPath, "https://api.example.com", RunOptions{
+		GlobalQuery: map[string]string{"tenant": "acme"},
+		Overrides:   models.Overrides{"GET /users": {Query: map[string]string{"limit": "50"}}},
+		Selection:   []models.EndpointInfo{{Method: "GET", Path: "/users"}},
+	})
+	if err != nil {
+		t.Fatalf("ListResolvedRequests() error = %v", err)
+	}
+	want = []string{"GET https://api.example.com/users?limit=50&tenant=acme"}
+	if !reflect.DeepEqual(lines, want) {
+		t.Errorf("ListResolvedRequests() with options = %q, want %q", lines, want)
+	}
+
+	if _, err := ListResolvedRequests(t.TempDir()+"/missing.yaml", "https://api.example.com", RunOptions{}); err == nil {
+		t.Error("Expected an error for a missing spec")
+	}
+}
+
+func TestGenerateEndpointBody(t *testing.T) {
+	specContent := `
+openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /users:
+    get:
+      responses:
+        '200':
+          description: OK
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                name:
+                  type: string
+                  example: Ada
+                age:
+                  type: integer
+      responses:
+        '201':
+          description: Created
+`
+	specPath := createTempSpec(t, specContent)
+
+	body, err := GenerateEndpointBody(specPath, "POST", "/users")
+	if err != nil {
+		t.Fatalf("GenerateEndpointBody() error = %v", err)
+	}
+	want := "{\n  \"age\": 1,\n  \"name\": \"Ada\"\n}"
+	if string(body) != want {
+		t.Errorf("GenerateEndpointBody() = %s, want %s", body, want)
+	}
+
+	// Body-less methods give no body
+	if body, err := GenerateEndpointBody(specPath, "GET", "/users"); err != nil || body != nil {
+		t.Errorf("Expected no body for GET, got %q (%v)", body, err)
+	}
+
+	if _, err := GenerateEndpointBody(specPath, "PUT", "/users"); err == nil {
+		t.Error("Expected an error for an endpoint missing from the spec")
+	}
+}
+
+func TestBodyGenerationReport(t *testing.T) {
+	specContent := `
+openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /users:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/User'
+      responses:
+        '201':
+          description: Created
+    get:
+      responses:
+        '200':
+          description: OK
+  /uploads:
+    put:
+      requestBody:
+        content:
+          application/json: {}
+      responses:
+        '204':
+          description: Stored
+components:
+  schemas:
+    User:
+      type: object
+      properties:
+        name:
+          type: string
+`
+	specPath := createTempSpec(t, specContent)
+
+	report, err := BodyGenerationReport(specPath, RunOptions{})
+	if err != nil {
+		t.Fatalf("BodyGenerationReport() error = %v", err)
+	}
+	if report.Operations != 2 || report.Generated != 1 {
+		t.Errorf("Expected 1 of 2 body-bearing operations generated, got %d of %d", report.Generated, report.Operations)
+	}
+	if !reflect.DeepEqual(report.Gaps, []string{"PUT /uploads"}) {
+		t.Errorf("Expected the schema-less body to be a gap, got %v", report.Gaps)
+	}
+}
@@ -1,56 +1,95 @@
 package testing
 
 import (
+	stderrors "errors"
 	"fmt"
+	"io"
+	"net"
 	"net/http"
+	"slices"
 	"strings"
 	"time"
 
+	"github.com/Traves-Theberge/OpenAPI-Toolkit/openapi-tui/internal/errors"
 	"github.com/Traves-Theberge/OpenAPI-Toolkit/openapi-tui/internal/models"
 )
 
-// isRetryableError determines if an error should trigger a retry
-// Returns true for network errors, timeouts, and server errors (5xx)
-// Returns false for validation errors, client errors (4xx), and successful responses
+// isRetryableError determines if an error should trigger a retry with the
+// default retry classes: connection resets, EOF, timeouts, TLS handshake
+// failures and server errors (5xx)
+// Returns false for refused connections, DNS failures, validation errors,
+// client errors (4xx), and successful responses
 func isRetryableError(err error, statusCode int) bool {
-	// If no error and status is not a server error, don't retry
-	if err == nil && statusCode < 500 {
-		return false
-	}
+	return shouldRetry(err, statusCode, nil, nil)
+}
 
-	// Server errors (5xx) are retryable
-	if statusCode >= 500 && statusCode < 600 {
-		return true
+// shouldRetry reports whether a failed request falls into one of the retry
+// classes in retryOn (nil = models.DefaultRetryOn). When retryStatuses is
+// set, responses are retried only when their status is listed, in place of
+// the 5xx class; transport errors still follow retryOn
+func shouldRetry(err error, statusCode int, retryOn []string, retryStatuses []int) bool {
+	if err == nil && retryStatuses != nil {
+		return slices.Contains(retryStatuses, statusCode)
 	}
-
-	// If there's an error, check if it's a network/timeout error
-	if err != nil {
-		errStr := strings.ToLower(err.Error())
-		
-		// Network connectivity issues
-		if strings.Contains(errStr, "connection refused") ||
-			strings.Contains(errStr, "connection reset") ||
-			strings.Contains(errStr, "no such host") ||
-			strings.Contains(errStr, "network is unreachable") ||
-			strings.Contains(errStr, "broken pipe") {
+	if retryOn == nil {
+		retryOn = models.DefaultRetryOn
+	}
+	class := retryClass(err, statusCode)
+	if class == "" {
+		return false
+	}
+	for _, name := range retryOn {
+		if name == class {
 			return true
 		}
+	}
+	return false
+}
 
-		// Timeout errors
-		if strings.Contains(errStr, "timeout") ||
-			strings.Contains(errStr, "deadline exceeded") ||
-			strings.Contains(errStr, "context canceled") {
-			return true
+// retryClass returns the retry class of a failed request, one of the
+// models.RetryOn* names, or "" when the failure is not transient
+func retryClass(err error, statusCode int) string {
+	if err == nil {
+		if statusCode >= 500 && statusCode < 600 {
+			return models.RetryOnServerError
 		}
+		return ""
+	}
 
-		// TLS/SSL errors that might be temporary
-		if strings.Contains(errStr, "tls handshake") ||
-			strings.Contains(errStr, "eof") {
-			return true
-		}
+	// Match on the underlying error: enhanced errors reword the message
+	if enhanced, ok := err.(*errors.EnhancedError); ok && enhanced.Original != nil {
+		err = enhanced.Original
 	}
 
-	return false
+	// A lookup that timed out or hit a resolver hiccup may well succeed on
+	// the next try, unlike one that found no such host
+	var dnsErr *net.DNSError
+	if stderrors.As(err, &dnsErr) && !dnsErr.IsNotFound && (dnsErr.IsTimeout || dnsErr.IsTemporary) {
+		return models.RetryOnDNSTimeout
+	}
+	errStr := strings.ToLower(err.Error())
+
+	switch {
+	case strings.Contains(errStr, "connection reset") || strings.Contains(errStr, "broken pipe"):
+		return models.RetryOnReset
+	case strings.Contains(errStr, "connection refused"):
+		return models.RetryOnRefused
+	case strings.Contains(errStr, "no such host"):
+		return models.RetryOnDNS
+	case strings.Contains(errStr, "network is unreachable"):
+		return models.RetryOnUnreachable
+	case strings.Contains(errStr, "timeout") ||
+		strings.Contains(errStr, "deadline exceeded") ||
+		strings.Contains(errStr, "context canceled"):
+		return models.RetryOnTimeout
+	case strings.Contains(errStr, "tls handshake"):
+		return models.RetryOnTLS
+	case strings.Contains(errStr, "eof"):
+		// The server or a proxy closed the connection, typically an idle
+		// keep-alive connection reused just as it was dropped
+		return models.RetryOnEOF
+	}
+	return ""
 }
 
 // executeWithRetry executes an HTTP request with automatic retry logic
@@ -63,6 +102,21 @@ func executeWithRetry(
 	verbose bool,
 	maxRetries int,
 	initialDelay int,
+) (int, *http.Response, *models.LogEntry, int, error) {
+	return retryRequest(func() (int, *http.Response, *models.LogEntry, error) {
+		return TestEndpoint(method, url, body, auth, verbose)
+	}, maxRetries, initialDelay, nil, nil)
+}
+
+// retryRequest runs send until it succeeds, fails in a way shouldRetry
+// rejects for retryOn and retryStatuses, or the retry budget is exhausted,
+// backing off exponentially between attempts
+func retryRequest(
+	send func() (int, *http.Response, *models.LogEntry, error),
+	maxRetries int,
+	initialDelay int,
+	retryOn []string,
+	retryStatuses []int,
 ) (int, *http.Response, *models.LogEntry, int, error) {
 	var lastErr error
 	var statusCode int
@@ -83,10 +137,10 @@ func executeWithRetry(
 
 	for attempt := 0; attempt <= maxRetries; attempt++ {
 		// Execute the request
-		statusCode, resp, log, lastErr = TestEndpoint(method, url, body, auth, verbose)
+		statusCode, resp, log, lastErr = send()
 
 		// Check if we should retry
-		shouldRetry := isRetryableError(lastErr, statusCode)
+		shouldRetry := shouldRetry(lastErr, statusCode, retryOn, retryStatuses)
 
 		// If success or non-retryable error, return immediately
 		if !shouldRetry {
@@ -96,6 +150,12 @@ func executeWithRetry(
 		// If this was not the last attempt, increment retry count and wait
 		if attempt < maxRetries {
 			retryCount++
+
+			// Drain and close the discarded response so its connection is reused
+			if resp != nil && resp.Body != nil {
+				io.Copy(io.Discard, resp.Body)
+				resp.Body.Close()
+			}
 			
 			// Calculate exponential backoff delay
 			delay := time.Duration(initialDelay) * time.Millisecond
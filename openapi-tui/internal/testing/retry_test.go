@@ -2,10 +2,18 @@ package testing
 
 import (
 	"errors"
+	"io"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
 	"testing"
 	"time"
+
+	apierrors "github.com/Traves-Theberge/OpenAPI-Toolkit/openapi-tui/internal/errors"
+	"github.com/Traves-Theberge/OpenAPI-Toolkit/openapi-tui/internal/models"
 )
 
 // TestIsRetryableError_ServerErrors verifies 5xx errors are retryable
@@ -84,22 +92,25 @@ func TestIsRetryableError_SuccessfulResponses(t *testing.T) {
 	}
 }
 
-// TestIsRetryableError_NetworkErrors verifies network errors are retryable
+// TestIsRetryableError_NetworkErrors verifies transient network errors are
+// retryable by default, and refused, DNS and unreachable errors are not
 func TestIsRetryableError_NetworkErrors(t *testing.T) {
 	tests := []struct {
 		name string
 		err  error
 		want bool
 	}{
-		{"Connection refused", errors.New("connection refused"), true},
+		{"Connection refused", errors.New("connection refused"), false},
 		{"Connection reset", errors.New("connection reset by peer"), true},
-		{"No such host", errors.New("no such host"), true},
-		{"Network unreachable", errors.New("network is unreachable"), true},
+		{"No such host", errors.New("no such host"), false},
+		{"Network unreachable", errors.New("network is unreachable"), false},
 		{"Broken pipe", errors.New("broken pipe"), true},
 		{"Timeout", errors.New("i/o timeout"), true},
 		{"Deadline exceeded", errors.New("context deadline exceeded"), true},
 		{"TLS handshake", errors.New("tls handshake error"), true},
 		{"EOF", errors.New("EOF"), true},
+		{"Idle connection EOF", errors.New(`Get "http://localhost/users": EOF`), true},
+		{"Enhanced refused", apierrors.EnhanceNetworkError(errors.New("dial tcp: connection refused"), "http://localhost"), false},
 	}
 
 	for _, tt := range tests {
@@ -135,6 +146,178 @@ func TestIsRetryableError_NonRetryableErrors(t *testing.T) {
 	}
 }
 
+// TestShouldRetry_ConfiguredClasses verifies the retryable set can be changed
+func TestShouldRetry_ConfiguredClasses(t *testing.T) {
+	refused := errors.New("dial tcp 127.0.0.1:1: connect: connection refused")
+	reset := errors.New("read tcp: connection reset by peer")
+
+	if !shouldRetry(refused, 0, []string{models.RetryOnRefused}, nil) {
+		t.Error("Expected refused connections to be retried when configured")
+	}
+	if shouldRetry(reset, 0, []string{models.RetryOnRefused}, nil) {
+		t.Error("Expected resets not to be retried when left out of the set")
+	}
+	if shouldRetry(nil, 503, []string{models.RetryOnReset}, nil) {
+		t.Error("Expected 5xx not to be retried when left out of the set")
+	}
+	if shouldRetry(nil, 404, models.RetryClasses, nil) {
+		t.Error("Expected a 404 never to be retried")
+	}
+}
+
+// TestRetryRequest_RetryStatuses verifies only the configured statuses are
+// retried once retryStatuses is set
+func TestRetryRequest_RetryStatuses(t *testing.T) {
+	statuses := []int{503}
+	for _, tt := range []struct {
+		status  int
+		retries int
+	}{
+		{503, 2},
+		{500, 0},
+	} {
+		status, _, _, retryCount, _ := retryRequest(func() (int, *http.Response, *models.LogEntry, error) {
+			return tt.status, nil, nil, nil
+		}, 2, 10, nil, statuses)
+		if status != tt.status || retryCount != tt.retries {
+			t.Errorf("Status %d: expected %d retries, got %d", tt.status, tt.retries, retryCount)
+		}
+	}
+
+	reset := errors.New("read tcp: connection reset by peer")
+	if !shouldRetry(reset, 0, nil, statuses) {
+		t.Error("Expected transport errors to still follow retryOn")
+	}
+}
+
+// closeRecorder is a response body that records whether it was closed
+type closeRecorder struct {
+	io.Reader
+	closed bool
+}
+
+func (c *closeRecorder) Close() error {
+	c.closed = true
+	return nil
+}
+
+// TestRetryRequest_ClosesRetriedBodies verifies the body of each retried
+// response is closed, and the final one is handed back open
+func TestRetryRequest_ClosesRetriedBodies(t *testing.T) {
+	var bodies []*closeRecorder
+	_, resp, _, _, _ := retryRequest(func() (int, *http.Response, *models.LogEntry, error) {
+		body := &closeRecorder{Reader: strings.NewReader("unavailable")}
+		bodies = append(bodies, body)
+		return 503, &http.Response{StatusCode: 503, Body: body}, nil, nil
+	}, 2, 10, nil, nil)
+	if len(bodies) != 3 {
+		t.Fatalf("Expected three attempts, got %d", len(bodies))
+	}
+	if !bodies[0].closed || !bodies[1].closed {
+		t.Error("Expected the retried responses to be closed")
+	}
+	if bodies[2].closed || resp.Body != bodies[2] {
+		t.Error("Expected the final response to be returned open")
+	}
+}
+
+// TestRetryRequest_ResetAndEOF verifies connection resets and dropped
+// connections are retried until the request succeeds
+func TestRetryRequest_ResetAndEOF(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			// Drop the connection without a response, as a flaky proxy does
+			conn, _, _ := w.(http.Hijacker).Hijack()
+			conn.Close()
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	status, resp, _, retryCount, err := executeWithRetry("GET", server.URL, nil, nil, false, 3, 10)
+	if resp != nil {
+		resp.Body.Close()
+	}
+	if err != nil || status != 200 || retryCount != 1 {
+		t.Errorf("Expected a dropped connection to be retried once, got status %d, %d retries, err %v", status, retryCount, err)
+	}
+
+	calls := 0
+	status, _, _, retryCount, err = retryRequest(func() (int, *http.Response, *models.LogEntry, error) {
+		calls++
+		if calls < 3 {
+			return 0, nil, nil, errors.New("read tcp 127.0.0.1:50000->127.0.0.1:8080: read: connection reset by peer")
+		}
+		return 200, nil, nil, nil
+	}, 3, 10, nil, nil)
+	if err != nil || status != 200 || retryCount != 2 {
+		t.Errorf("Expected resets to be retried, got status %d, %d retries, err %v", status, retryCount, err)
+	}
+}
+
+// flakyDNSTransport fails the first lookup with a resolver timeout, then
+// sends requests normally
+type flakyDNSTransport struct {
+	mu       sync.Mutex
+	failures int
+}
+
+func (f *flakyDNSTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.failures < 1 {
+		f.failures++
+		return nil, &net.OpError{Op: "dial", Net: "tcp", Err: &net.DNSError{Err: "i/o timeout", Name: req.URL.Hostname(), Server: "10.0.0.53:53", IsTimeout: true}}
+	}
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+// TestRunTestsWithOptions_DNSTimeoutRetry verifies a lookup that timed out is
+// retried, while a host that doesn't exist is not
+func TestRunTestsWithOptions_DNSTimeoutRetry(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	specPath := createTempSpec(t, `
+openapi: 3.0.0
+info:
+  title: Lookup
+  version: 1.0.0
+paths:
+  /users:
+    get:
+      responses:
+        '200':
+          description: OK
+`)
+
+	transport := &flakyDNSTransport{}
+	results, err := RunTestsWithOptions(specPath, server.URL, RunOptions{MaxRetries: 2, RetryDelay: 100, Transport: transport}, nil)
+	if err != nil {
+		t.Fatalf("RunTestsWithOptions() error = %v", err)
+	}
+	if len(results) != 1 || results[0].Status != "200" || results[0].RetryCount != 1 {
+		t.Errorf("Expected the DNS timeout to be retried once, got %+v", results)
+	}
+
+	timeout := &url.Error{Op: "Get", URL: "http://api.example", Err: &net.OpError{Op: "dial", Err: &net.DNSError{Err: "server misbehaving", Name: "api.example", IsTemporary: true}}}
+	if class := retryClass(apierrors.EnhanceNetworkError(timeout, "http://api.example"), 0); class != models.RetryOnDNSTimeout {
+		t.Errorf("Expected a temporary lookup failure to be a DNS timeout, got %q", class)
+	}
+	notFound := &url.Error{Op: "Get", URL: "http://api.example", Err: &net.OpError{Op: "dial", Err: &net.DNSError{Err: "no such host", Name: "api.example", IsNotFound: true}}}
+	if shouldRetry(notFound, 0, nil, nil) {
+		t.Error("Expected a host that doesn't exist not to be retried")
+	}
+	if shouldRetry(timeout, 0, []string{models.RetryOnTimeout}, nil) {
+		t.Error("Expected DNS timeouts not to be retried when left out of the set")
+	}
+}
+
 // TestExecuteWithRetry_SuccessFirstAttempt verifies no retry on immediate success
 func TestExecuteWithRetry_SuccessFirstAttempt(t *testing.T) {
 	attempts := 0
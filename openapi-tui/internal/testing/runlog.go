@@ -0,0 +1,63 @@
+package testing
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/Traves-Theberge/OpenAPI-Toolkit/openapi-tui/internal/models"
+)
+
+// runLogEntry is one JSONL record in the run log file
+type runLogEntry struct {
+	Timestamp  string `json:"timestamp"`
+	Method     string `json:"method"`
+	URL        string `json:"url"`
+	Status     string `json:"status"`
+	DurationMs int64  `json:"durationMs"`
+}
+
+// runLog appends one JSON object per request to a file
+// Safe for use from multiple workers
+type runLog struct {
+	mu      sync.Mutex
+	file    *os.File
+	encoder *json.Encoder
+}
+
+// openRunLog opens path for appending, creating it if needed
+func openRunLog(path string) (*runLog, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return &runLog{file: file, encoder: json.NewEncoder(file)}, nil
+}
+
+// record writes the result of a request sent to url at start
+// Write errors are ignored so logging never fails a run
+func (l *runLog) record(start time.Time, url string, result models.TestResult) {
+	if l == nil {
+		return
+	}
+	entry := runLogEntry{
+		Timestamp:  start.UTC().Format(time.RFC3339Nano),
+		Method:     result.Method,
+		URL:        url,
+		Status:     result.Status,
+		DurationMs: result.Duration.Milliseconds(),
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_ = l.encoder.Encode(entry)
+}
+
+// Close closes the underlying file
+func (l *runLog) Close() error {
+	if l == nil {
+		return nil
+	}
+	return l.file.Close()
+}
@@ -0,0 +1,55 @@
+package testing
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// operationBaseURL returns the base URL an operation is sent to. When the
+// operation, or else its path item, declares its own servers, the first one
+// replaces baseURL: an absolute server URL is used as-is (with forceScheme
+// still applied), while a relative one such as "/v2" keeps baseURL's scheme
+// and host. Server variables take their defaults. Otherwise baseURL is
+// returned unchanged
+func operationBaseURL(baseURL string, pathItem *openapi3.PathItem, operation *openapi3.Operation, forceScheme string) string {
+	var servers openapi3.Servers
+	if operation != nil && operation.Servers != nil {
+		servers = *operation.Servers
+	}
+	if len(servers) == 0 && pathItem != nil {
+		servers = pathItem.Servers
+	}
+	if len(servers) == 0 || servers[0] == nil || servers[0].URL == "" {
+		return baseURL
+	}
+
+	server := expandServerURL(servers[0])
+	if strings.Contains(server, "://") {
+		if forced, err := ApplyScheme(server, forceScheme); err == nil {
+			server = forced
+		}
+		return strings.TrimRight(server, "/")
+	}
+
+	// A relative server URL is resolved against the base URL's host
+	base, err := url.Parse(baseURL)
+	if err != nil || base.Host == "" {
+		return baseURL
+	}
+	base.Path = "/" + strings.Trim(server, "/")
+	base.RawPath, base.RawQuery, base.Fragment = "", "", ""
+	return strings.TrimRight(base.String(), "/")
+}
+
+// expandServerURL fills a server URL's {variables} with their defaults
+func expandServerURL(server *openapi3.Server) string {
+	serverURL := server.URL
+	for name, variable := range server.Variables {
+		if variable != nil {
+			serverURL = strings.ReplaceAll(serverURL, "{"+name+"}", variable.Default)
+		}
+	}
+	return serverURL
+}
@@ -0,0 +1,108 @@
+package testing
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestRunTestsWithOptions_OperationServers(t *testing.T) {
+	var mu sync.Mutex
+	hits := map[string][]string{}
+	handler := func(name string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			hits[name] = append(hits[name], r.Method+" "+r.URL.Path)
+			mu.Unlock()
+			w.WriteHeader(http.StatusOK)
+		}
+	}
+	main := httptest.NewServer(handler("main"))
+	defer main.Close()
+	uploads := httptest.NewServer(handler("uploads"))
+	defer uploads.Close()
+
+	specPath := createTempSpec(t, `
+openapi: 3.0.0
+info:
+  title: Servers
+  version: 1.0.0
+servers:
+  - url: https://api.example.com
+paths:
+  /users:
+    get:
+      responses:
+        '200':
+          description: OK
+  /files:
+    post:
+      servers:
+        - url: `+uploads.URL+`/{version}
+          variables:
+            version:
+              default: v1
+      responses:
+        '200':
+          description: OK
+  /reports:
+    servers:
+      - url: /beta
+    get:
+      responses:
+        '200':
+          description: OK
+`)
+
+	results, err := RunTestsWithOptions(specPath, main.URL, RunOptions{MaxConcurrency: 1}, nil)
+	if err != nil {
+		t.Fatalf("RunTestsWithOptions() error = %v", err)
+	}
+	for _, r := range results {
+		if r.Status != "200" {
+			t.Errorf("%s %s: expected 200, got %s (%s)", r.Method, r.Endpoint, r.Status, r.Message)
+		}
+	}
+
+	sort.Strings(hits["main"])
+	if got := strings.Join(hits["main"], ", "); got != "GET /beta/reports, GET /users" {
+		t.Errorf("Expected the base URL's host to get /users and the relative server's path, got %s", got)
+	}
+	if got := strings.Join(hits["uploads"], ", "); got != "POST /v1/files" {
+		t.Errorf("Expected the operation's server to get the upload, got %s", got)
+	}
+}
+
+func TestOperationBaseURL(t *testing.T) {
+	withServers := func(urls ...string) *openapi3.Operation {
+		servers := openapi3.Servers{}
+		for _, u := range urls {
+			servers = append(servers, &openapi3.Server{URL: u})
+		}
+		return &openapi3.Operation{Servers: &servers}
+	}
+
+	tests := []struct {
+		name        string
+		operation   *openapi3.Operation
+		forceScheme string
+		want        string
+	}{
+		{"no override", &openapi3.Operation{}, "", "http://localhost:8080/api"},
+		{"absolute", withServers("https://uploads.example.com/", "https://other.example.com"), "", "https://uploads.example.com"},
+		{"absolute with forced scheme", withServers("https://uploads.example.com"), "http", "http://uploads.example.com"},
+		{"relative", withServers("/v2/"), "", "http://localhost:8080/v2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := operationBaseURL("http://localhost:8080/api", nil, tt.operation, tt.forceScheme); got != tt.want {
+				t.Errorf("operationBaseURL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package testing
+
+import (
+	"crypto/rand"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// IsBodyTemplate reports whether a request body contains Go template actions
+func IsBodyTemplate(body string) bool {
+	return strings.Contains(body, "{{")
+}
+
+// BodyTemplateContext returns the values available to a body template:
+// now (RFC 3339, UTC), unix (seconds), uuid (a random version 4 UUID) and
+// counter (incremented by the caller for each rendered body)
+func BodyTemplateContext(counter int) map[string]any {
+	now := time.Now().UTC()
+	return map[string]any{
+		"now":     now.Format(time.RFC3339),
+		"unix":    now.Unix(),
+		"uuid":    randomUUID(),
+		"counter": counter,
+	}
+}
+
+// RenderBody renders a body template with BodyTemplateContext(counter).
+// Bodies without template actions are returned unchanged. The output isn't
+// checked, since ${NAME} tokens may still be expanded into it; callers check
+// the final body with ValidateRequestBody
+func RenderBody(body string, counter int) (string, error) {
+	if !IsBodyTemplate(body) {
+		return body, nil
+	}
+	return renderBodyTemplate(body, BodyTemplateContext(counter))
+}
+
+// renderBodyTemplate executes tmpl as a text/template with ctx. Referencing a
+// value missing from ctx is an error rather than "<no value>"
+func renderBodyTemplate(tmpl string, ctx map[string]any) (string, error) {
+	t, err := template.New("body").Option("missingkey=error").Parse(tmpl)
+	if err != nil {
+		return "", fmt.Errorf("invalid body template: %w", err)
+	}
+	var b strings.Builder
+	if err := t.Execute(&b, ctx); err != nil {
+		return "", fmt.Errorf("failed to render body template: %w", err)
+	}
+	return b.String(), nil
+}
+
+// randomUUID returns a random version 4 UUID
+func randomUUID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	b[6] = b[6]&0x0f | 0x40 // Version 4
+	b[8] = b[8]&0x3f | 0x80 // RFC 4122 variant
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
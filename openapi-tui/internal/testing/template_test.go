@@ -0,0 +1,95 @@
+package testing
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/Traves-Theberge/OpenAPI-Toolkit/openapi-tui/internal/models"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+var uuidRe = regexp.MustCompile(`[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}`)
+
+func TestRenderBodyTemplate(t *testing.T) {
+	rendered, err := renderBodyTemplate(`{"id": "{{.uuid}}", "seq": {{.counter}}}`, BodyTemplateContext(7))
+	if err != nil {
+		t.Fatalf("renderBodyTemplate failed: %v", err)
+	}
+	if !uuidRe.MatchString(rendered) {
+		t.Errorf("Expected a UUID in the rendered body, got %s", rendered)
+	}
+	if !strings.Contains(rendered, `"seq": 7`) {
+		t.Errorf("Expected the counter in the rendered body, got %s", rendered)
+	}
+
+	if _, err := renderBodyTemplate(`{"id": "{{.missing}}"}`, BodyTemplateContext(1)); err == nil {
+		t.Error("Expected an error for a value missing from the context")
+	}
+	if _, err := renderBodyTemplate(`{"id": "{{.uuid"}`, BodyTemplateContext(1)); err == nil {
+		t.Error("Expected an error for an unparsable template")
+	}
+}
+
+func TestRenderBody(t *testing.T) {
+	if body, err := RenderBody(`{"plain": true}`, 1); err != nil || body != `{"plain": true}` {
+		t.Errorf("Expected a body without actions to be unchanged, got %q, %v", body, err)
+	}
+
+	// The output isn't checked yet: ${NAME} tokens are expanded after rendering
+	body, err := RenderBody(`{"id": "{{.uuid}}", "limit": ${LIMIT}}`, 1)
+	if err != nil || !uuidRe.MatchString(body) {
+		t.Fatalf("Expected the template to render, got %q, %v", body, err)
+	}
+	_, expanded := ExpandRequestVariables(nil, body, map[string]string{"LIMIT": "10"})
+	if err := ValidateRequestBody(nil, expanded); err != nil {
+		t.Errorf("Expected the expanded body to be valid JSON, got %v", err)
+	}
+}
+
+func TestValidateRequestBody(t *testing.T) {
+	// The body is checked as JSON unless a non-JSON Content-Type is set
+	if err := ValidateRequestBody(nil, `{"id": abc}`); err == nil {
+		t.Error("Expected invalid JSON to be rejected")
+	}
+	if err := ValidateRequestBody(map[string]string{"content-type": "application/xml"}, `<id>abc</id>`); err != nil {
+		t.Errorf("Expected an XML body to be accepted, got %v", err)
+	}
+	if err := ValidateRequestBody(nil, ""); err != nil {
+		t.Errorf("Expected an empty body to be accepted, got %v", err)
+	}
+}
+
+func TestBuildJobs_BodyTemplateOverride(t *testing.T) {
+	spec := `openapi: 3.0.0
+info:
+  title: Templates
+  version: "1.0"
+paths:
+  /orders:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+      responses:
+        "201":
+          description: Created
+`
+	doc, err := openapi3.NewLoader().LoadFromData([]byte(spec))
+	if err != nil {
+		t.Fatalf("Failed to load spec: %v", err)
+	}
+
+	opts := RunOptions{Overrides: models.Overrides{
+		"POST /orders": {BodyTemplate: `{"ref": "{{.uuid}}", "seq": {{.counter}}}`},
+	}}
+	jobs := buildJobs(doc, "http://localhost", opts)
+	if len(jobs) != 1 {
+		t.Fatalf("Expected one job, got %d", len(jobs))
+	}
+	if jobs[0].BodyErr != nil || !uuidRe.Match(jobs[0].RequestBody) || !strings.Contains(string(jobs[0].RequestBody), `"seq": 1`) {
+		t.Errorf("Expected the rendered template as the body, got %s (%v)", jobs[0].RequestBody, jobs[0].BodyErr)
+	}
+}
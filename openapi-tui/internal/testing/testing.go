@@ -2,13 +2,23 @@ package testing
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
+	"math/rand"
+	"mime/multipart"
+	"net"
 	"net/http"
+	"net/http/httptrace"
+	"net/textproto"
+	"net/url"
 	"regexp"
+	"slices"
+	"sort"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/Traves-Theberge/OpenAPI-Toolkit/openapi-tui/internal/models"
@@ -24,123 +34,618 @@ func ReplacePlaceholders(path string) string {
 	return re.ReplaceAllString(path, "1")
 }
 
+// placeholderPattern matches a {param} placeholder in a path template
+var placeholderPattern = regexp.MustCompile(`\{([^}]+)\}`)
+
+// fillPathParams replaces path's {param} placeholders with values from the
+// spec: the parameter's example, or its schema's example, first enum value or
+// default, or 1 for a numeric parameter. A parameter with none of those, such
+// as an opaque token, still gets "1" but is returned as unsatisfiable, since
+// the request will almost certainly be answered with a 404
+func fillPathParams(path string, pathItem *openapi3.PathItem, operation *openapi3.Operation) (string, []string) {
+	return fillPathWith(path, pathItem, operation, nil)
+}
+
+// fillPathWith fills path parameters like fillPathParams, sending the given
+// values for the parameters named in values instead
+func fillPathWith(path string, pathItem *openapi3.PathItem, operation *openapi3.Operation, values map[string]string) (string, []string) {
+	var unsatisfiable []string
+	filled := placeholderPattern.ReplaceAllStringFunc(path, func(placeholder string) string {
+		name := placeholder[1 : len(placeholder)-1]
+		if value, ok := values[name]; ok {
+			return url.PathEscape(value)
+		}
+		param := pathParameter(name, pathItem, operation)
+		if param == nil {
+			return "1"
+		}
+		value, ok := pathParamValue(param)
+		if !ok {
+			unsatisfiable = append(unsatisfiable, name)
+			return "1"
+		}
+		return url.PathEscape(fmt.Sprintf("%v", value))
+	})
+	return filled, unsatisfiable
+}
+
+// pathParameter returns the path parameter called name, declared on the
+// operation or else on its path item, or nil when neither declares it
+func pathParameter(name string, pathItem *openapi3.PathItem, operation *openapi3.Operation) *openapi3.Parameter {
+	if operation != nil {
+		if param := operation.Parameters.GetByInAndName(openapi3.ParameterInPath, name); param != nil {
+			return param
+		}
+	}
+	if pathItem != nil {
+		return pathItem.Parameters.GetByInAndName(openapi3.ParameterInPath, name)
+	}
+	return nil
+}
+
+// pathParamValue returns the value sent for a path parameter, and false when
+// the spec gives nothing meaningful to send
+func pathParamValue(param *openapi3.Parameter) (interface{}, bool) {
+	if example, ok := parameterExample(param); ok {
+		return example, true
+	}
+	if param.Schema == nil || param.Schema.Value == nil {
+		return nil, false
+	}
+	schema := param.Schema.Value
+	switch {
+	case schema.Example != nil:
+		return schema.Example, true
+	case len(schema.Enum) > 0:
+		return schema.Enum[0], true
+	case schema.Default != nil:
+		return schema.Default, true
+	}
+	if schemaType := sampleType(schema); schemaType == "integer" || schemaType == "number" {
+		return 1, true
+	}
+	return nil, false
+}
+
+// ApplyScheme rewrites the scheme of rawURL to scheme ("http" or "https")
+// An empty scheme leaves the URL unchanged
+func ApplyScheme(rawURL, scheme string) (string, error) {
+	if scheme == "" {
+		return rawURL, nil
+	}
+
+	scheme = strings.ToLower(scheme)
+	if scheme != "http" && scheme != "https" {
+		return "", fmt.Errorf("invalid forced scheme %q: must be http or https", scheme)
+	}
+
+	// Without "://", url.Parse reads a host such as "localhost:8080" as the scheme
+	toParse := rawURL
+	if !strings.Contains(toParse, "://") {
+		toParse = "//" + strings.TrimPrefix(toParse, "//")
+	}
+
+	u, err := url.Parse(toParse)
+	if err != nil {
+		return "", fmt.Errorf("invalid URL %q: %w", rawURL, err)
+	}
+	u.Scheme = scheme
+	return u.String(), nil
+}
+
+// ApplyGlobalQuery adds the configured global query parameters to rawURL,
+// replacing any parameter of the same name already in the URL
+// An empty query leaves the URL unchanged
+func ApplyGlobalQuery(rawURL string, query map[string]string) string {
+	if len(query) == 0 {
+		return rawURL
+	}
+	return mergeQuery(rawURL, query)
+}
+
+// QueryParamNote flags a generated query parameter the server is likely to reject
+type QueryParamNote struct {
+	Param   string
+	Message string
+}
+
 // buildQueryParams constructs query parameters from operation parameters
 func BuildQueryParams(operation *openapi3.Operation) string {
+	query, _ := buildQueryParams(operation, false, "")
+	return query
+}
+
+// buildQueryParams is BuildQueryParams that also reports required parameters
+// whose value had to be guessed because the spec gives neither a schema nor an
+// example, with the option to leave out parameters marked deprecated in the
+// spec, and all but one of each group of mutually exclusive parameters the
+// operation lists under exclusiveExtension
+func buildQueryParams(operation *openapi3.Operation, skipDeprecated bool, exclusiveExtension string) (string, []QueryParamNote) {
 	if operation == nil || operation.Parameters == nil {
-		return ""
+		return "", nil
 	}
+	omit := exclusiveOmissions(operation, exclusiveExtension)
 
 	var params []string
+	var notes []QueryParamNote
 	for _, paramRef := range operation.Parameters {
 		param := paramRef.Value
 		if param == nil || param.In != "query" {
 			continue
 		}
+		if skipDeprecated && param.Deprecated {
+			continue
+		}
+		if omit[param.Name] {
+			continue
+		}
 
-		// Generate sample value based on schema
-		value := "1" // Default
-		if param.Schema != nil && param.Schema.Value != nil {
+		// Prefer examples declared on the parameter, then fall back to the schema
+		var value interface{} = "1" // Default
+		if example, ok := parameterExample(param); ok {
+			value = example
+		} else if param.Schema != nil && param.Schema.Value != nil {
 			schema := param.Schema.Value
+			schemaType := sampleType(schema)
 			// Check if type contains specific values
-			if schema.Type.Is("string") {
+			if schemaType == "string" {
 				if len(schema.Enum) > 0 {
-					value = fmt.Sprintf("%v", schema.Enum[0])
+					value = schema.Enum[0]
 				} else if schema.Example != nil {
-					value = fmt.Sprintf("%v", schema.Example)
+					value = schema.Example
 				} else {
 					value = "test"
 				}
-			} else if schema.Type.Is("integer") || schema.Type.Is("number") {
+			} else if schemaType == "integer" || schemaType == "number" {
 				if schema.Example != nil {
-					value = fmt.Sprintf("%v", schema.Example)
+					value = schema.Example
 				} else {
 					value = "1"
 				}
-			} else if schema.Type.Is("boolean") {
+			} else if schemaType == "boolean" {
 				value = "true"
-			} else if schema.Type.Is("array") {
-				value = "1,2,3" // Simple array representation
+			} else if schemaType == "array" {
+				value = []interface{}{1, 2, 3}
+			} else if schemaType == "object" && len(schema.Properties) > 0 {
+				value = generateSample(schema, param.Name, sampleOptions{})
 			}
+		} else if param.Required {
+			notes = append(notes, QueryParamNote{
+				Param:   param.Name,
+				Message: fmt.Sprintf("required query parameter %q has no schema or example; sent placeholder \"%v\"", param.Name, value),
+			})
 		}
 
-		params = append(params, fmt.Sprintf("%s=%s", param.Name, value))
+		params = append(params, serializeQueryParam(param, value)...)
 	}
 
 	if len(params) == 0 {
-		return ""
+		return "", notes
 	}
-	return "?" + strings.Join(params, "&")
+	return "?" + strings.Join(params, "&"), notes
 }
 
-// generateRequestBody creates a sample JSON request body from an OpenAPI schema
+// serializeQueryParam renders a query parameter's value as name=value pairs
+// following its style and explode settings (form and explode by default):
+// arrays become ids=1&ids=2 when exploded, or ids=1,2 (1%202, 1|2 for
+// spaceDelimited, pipeDelimited) when not; objects become one pair per
+// property when exploded, id[role]=admin for deepObject, or id=role,admin
+func serializeQueryParam(param *openapi3.Parameter, value interface{}) []string {
+	method, err := param.SerializationMethod()
+	if err != nil {
+		method = &openapi3.SerializationMethod{Style: openapi3.SerializationForm, Explode: true}
+	}
+	delimiter := ","
+	switch method.Style {
+	case openapi3.SerializationSpaceDelimited:
+		delimiter = "%20"
+	case openapi3.SerializationPipeDelimited:
+		delimiter = "|"
+	}
+
+	switch v := value.(type) {
+	case []interface{}:
+		items := make([]string, len(v))
+		for i, item := range v {
+			items[i] = fmt.Sprintf("%v", item)
+		}
+		if !method.Explode {
+			return []string{param.Name + "=" + strings.Join(items, delimiter)}
+		}
+		pairs := make([]string, len(items))
+		for i, item := range items {
+			pairs[i] = param.Name + "=" + item
+		}
+		return pairs
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		var pairs, flat []string
+		for _, key := range keys {
+			switch {
+			case method.Style == openapi3.SerializationDeepObject:
+				pairs = append(pairs, fmt.Sprintf("%s[%s]=%v", param.Name, key, v[key]))
+			case method.Explode:
+				pairs = append(pairs, fmt.Sprintf("%s=%v", key, v[key]))
+			default:
+				flat = append(flat, key, fmt.Sprintf("%v", v[key]))
+			}
+		}
+		if flat != nil {
+			return []string{param.Name + "=" + strings.Join(flat, delimiter)}
+		}
+		return pairs
+	}
+	return []string{fmt.Sprintf("%s=%v", param.Name, value)}
+}
+
+// parameterExample returns the parameter-level example, or the first entry
+// (by name) of its examples map
+func parameterExample(param *openapi3.Parameter) (interface{}, bool) {
+	if param.Example != nil {
+		return param.Example, true
+	}
+
+	names := make([]string, 0, len(param.Examples))
+	for name := range param.Examples {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		ref := param.Examples[name]
+		if ref != nil && ref.Value != nil && ref.Value.Value != nil {
+			return ref.Value.Value, true
+		}
+	}
+	return nil, false
+}
+
+// namedExample is a named example value from the spec
+type namedExample struct {
+	name  string
+	value interface{}
+}
+
+// requestBodyExamples returns the named examples declared for the request
+// body's mediaType, sorted by name
+// Multipart bodies are built part by part, so their examples are not used.
+func requestBodyExamples(operation *openapi3.Operation, mediaType string) []namedExample {
+	if operation == nil || operation.RequestBody == nil || operation.RequestBody.Value == nil ||
+		strings.HasPrefix(mediaType, MediaTypeMultipart) {
+		return nil
+	}
+	content := operation.RequestBody.Value.Content.Get(mediaType)
+	if content == nil {
+		return nil
+	}
+
+	names := make([]string, 0, len(content.Examples))
+	for name := range content.Examples {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var examples []namedExample
+	for _, name := range names {
+		ref := content.Examples[name]
+		if ref != nil && ref.Value != nil && ref.Value.Value != nil {
+			examples = append(examples, namedExample{name: name, value: ref.Value.Value})
+		}
+	}
+	return examples
+}
+
+// Patch media types that need a different sample shape than plain JSON
+const (
+	MediaTypeMergePatch = "application/merge-patch+json" // RFC 7396
+	MediaTypeJSONPatch  = "application/json-patch+json"  // RFC 6902
+	MediaTypeMultipart  = "multipart/form-data"
+)
+
+// GenerateRequestBody creates a sample JSON request body from an OpenAPI schema
 // Generates realistic sample data based on schema properties, types, and examples
 func GenerateRequestBody(operation *openapi3.Operation) ([]byte, error) {
+	body, _, err := generateRequestBodyWithType(operation, sampleOptions{})
+	return body, err
+}
+
+// generateRequestBodyWithType builds the sample body and reports the media type it was
+// generated for. application/json is preferred, then the JSON patch formats,
+// then multipart/form-data (whose media type includes the boundary)
+// opts can fill in realistic values and change how dates are written.
+func generateRequestBodyWithType(operation *openapi3.Operation, opts sampleOptions) ([]byte, string, error) {
 	if operation == nil || operation.RequestBody == nil {
-		return nil, nil
+		return nil, "", nil
 	}
 
 	// Get the request body content for JSON
 	requestBody := operation.RequestBody.Value
 	if requestBody == nil || requestBody.Content == nil {
-		return nil, nil
+		return nil, "", nil
 	}
 
-	// Look for JSON content type
-	jsonContent := requestBody.Content.Get("application/json")
-	if jsonContent == nil || jsonContent.Schema == nil || jsonContent.Schema.Value == nil {
-		return nil, nil
+	for _, mediaType := range []string{"application/json", MediaTypeMergePatch, MediaTypeJSONPatch, MediaTypeMultipart} {
+		content := requestBody.Content.Get(mediaType)
+		if content == nil {
+			continue
+		}
+
+		var schema *openapi3.Schema
+		if content.Schema != nil {
+			schema = content.Schema.Value
+		}
+
+		if mediaType == MediaTypeMultipart {
+			if schema == nil {
+				continue
+			}
+			return multipartBody(schema, content.Encoding, opts)
+		}
+
+		// Generate sample data from schema
+		var sample interface{}
+		switch {
+		case mediaType == MediaTypeJSONPatch:
+			sample = jsonPatchSample(schema)
+		case schema != nil:
+			// A merge patch is a partial object, so the resource sample fits as-is
+			sample = generateSample(schema, "", opts)
+		default:
+			continue
+		}
+
+		// Marshal to JSON
+		jsonData, err := json.Marshal(sample)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to marshal request body: %v", err)
+		}
+		return jsonData, mediaType, nil
 	}
 
-	schema := jsonContent.Schema.Value
+	return nil, "", nil
+}
 
-	// Generate sample data from schema
-	sample := GenerateSampleFromSchema(schema)
-	
-	// Marshal to JSON
-	jsonData, err := json.Marshal(sample)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request body: %v", err)
+// bodyRequired reports whether an operation's request body is marked required
+func bodyRequired(operation *openapi3.Operation) bool {
+	return operation != nil && operation.RequestBody != nil && operation.RequestBody.Value != nil && operation.RequestBody.Value.Required
+}
+
+// jsonPatchSample builds a single-operation JSON Patch document
+// An example on the schema wins; otherwise path and value come from the
+// schema's item properties when they describe them
+func jsonPatchSample(schema *openapi3.Schema) interface{} {
+	if schema != nil && schema.Example != nil {
+		return schema.Example
 	}
 
-	return jsonData, nil
+	op := map[string]interface{}{"op": "replace", "path": "/name", "value": "sample"}
+	if schema != nil && schema.Items != nil && schema.Items.Value != nil {
+		props := schema.Items.Value.Properties
+		if ref := props["path"]; ref != nil && ref.Value != nil {
+			if path, ok := GenerateSampleFromSchema(ref.Value).(string); ok && strings.HasPrefix(path, "/") {
+				op["path"] = path
+			}
+		}
+		if ref := props["value"]; ref != nil && ref.Value != nil {
+			if value := GenerateSampleFromSchema(ref.Value); value != nil {
+				op["value"] = value
+			}
+		}
+	}
+	return []interface{}{op}
+}
+
+// multipartBody builds a multipart/form-data body with one part per schema property
+// Each part's content type comes from the declared encoding, falling back to
+// the OpenAPI defaults for the property type
+func multipartBody(schema *openapi3.Schema, encoding map[string]*openapi3.Encoding, opts sampleOptions) ([]byte, string, error) {
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+
+	names := make([]string, 0, len(schema.Properties))
+	for name := range schema.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	quote := strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+	for _, name := range names {
+		propRef := schema.Properties[name]
+		if propRef == nil || propRef.Value == nil {
+			continue
+		}
+		prop := propRef.Value
+		binary := prop.Format == "binary" || prop.Format == "base64"
+
+		contentType := defaultPartContentType(prop, binary)
+		if enc := encoding[name]; enc != nil && enc.ContentType != "" {
+			// The encoding may list several types; the first is as good as any
+			contentType = strings.TrimSpace(strings.Split(enc.ContentType, ",")[0])
+		}
+
+		disposition := fmt.Sprintf(`form-data; name="%s"`, quote.Replace(name))
+		if binary {
+			disposition += fmt.Sprintf(`; filename="%s"`, quote.Replace(name))
+		}
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Disposition", disposition)
+		header.Set("Content-Type", contentType)
+
+		part, err := writer.CreatePart(header)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to create multipart part %q: %v", name, err)
+		}
+
+		var value []byte
+		switch {
+		case binary:
+			value = []byte("sample file content")
+		case strings.Contains(contentType, "json"):
+			if value, err = json.Marshal(generateSample(prop, name, opts)); err != nil {
+				return nil, "", fmt.Errorf("failed to marshal multipart part %q: %v", name, err)
+			}
+		default:
+			if sample := generateSample(prop, name, opts); sample != nil {
+				value = []byte(fmt.Sprintf("%v", sample))
+			}
+		}
+		if _, err := part.Write(value); err != nil {
+			return nil, "", fmt.Errorf("failed to write multipart part %q: %v", name, err)
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, "", fmt.Errorf("failed to finish multipart body: %v", err)
+	}
+	return buf.Bytes(), writer.FormDataContentType(), nil
+}
+
+// defaultPartContentType is the OpenAPI default content type for a multipart
+// property: octet-stream for binary, JSON for objects and arrays, text otherwise
+func defaultPartContentType(prop *openapi3.Schema, binary bool) string {
+	if binary {
+		return "application/octet-stream"
+	}
+	switch sampleType(prop) {
+	case "object", "array":
+		return "application/json"
+	}
+	return "text/plain"
 }
 
 // generateSampleFromSchema recursively generates sample data from an OpenAPI schema
 func GenerateSampleFromSchema(schema *openapi3.Schema) interface{} {
+	return generateSample(schema, "", sampleOptions{})
+}
+
+// sampleOptions tunes generated sample values
+type sampleOptions struct {
+	faker          *Faker     // Realistic values for well-known fields (nil = placeholders)
+	rng            *rand.Rand // Random valid values for everything else (nil = placeholders)
+	dateTimeFormat string     // How date and date-time values are written ("" = RFC 3339)
+}
+
+// formatDate rewrites a generated RFC 3339 date or date-time string in the
+// configured format; values it cannot parse are returned unchanged
+func (o sampleOptions) formatDate(value interface{}) interface{} {
+	s, ok := value.(string)
+	if !ok || o.dateTimeFormat != models.DateTimeFormatUnix {
+		return value
+	}
+	for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.Unix()
+		}
+	}
+	return value
+}
+
+// generateSample is GenerateSampleFromSchema with options for realistic or
+// random values and date formats; name is the property the schema describes
+// ("" at the root)
+// Examples, defaults and enums still win over faked values; examples and
+// defaults win over random ones, and faked values come before them.
+func generateSample(schema *openapi3.Schema, name string, opts sampleOptions) interface{} {
 	if schema == nil {
 		return nil
 	}
 
+	// A JSON Schema const, such as a discriminator's value, is the only
+	// valid value. The loader keeps it among the extensions
+	if value, ok := schema.Extensions["const"]; ok {
+		return value
+	}
+
 	// Use example if available
 	if schema.Example != nil {
 		return schema.Example
 	}
 
+	// OpenAPI 3.1 schemas carry a JSON Schema "examples" array instead
+	if examples, ok := schema.Extensions["examples"].([]interface{}); ok && len(examples) > 0 {
+		return examples[0]
+	}
+
 	// Use default if available
 	if schema.Default != nil {
 		return schema.Default
 	}
 
+	// A oneOf or anyOf value is one of its variants: the first is generated,
+	// with a discriminator property naming it
+	if variant := firstVariant(schema); variant != nil {
+		sample := generateSample(variant.Value, name, opts)
+		if obj, ok := sample.(map[string]interface{}); ok && schema.Discriminator != nil && schema.Discriminator.PropertyName != "" {
+			if value, ok := discriminatorValue(schema.Discriminator, variant.Ref); ok && allowsProperty(variant.Value, schema.Discriminator.PropertyName) {
+				obj[schema.Discriminator.PropertyName] = value
+			}
+		}
+		return sample
+	}
+
+	if opts.faker != nil && len(schema.Enum) == 0 {
+		if value, ok := opts.faker.Value(name, schema); ok {
+			if schema.Format == "date" || schema.Format == "date-time" {
+				return opts.formatDate(value)
+			}
+			return value
+		}
+	}
+
+	if opts.rng != nil {
+		if value, ok := randomScalar(schema, opts.rng); ok {
+			if schema.Format == "date" || schema.Format == "date-time" {
+				return opts.formatDate(value)
+			}
+			return value
+		}
+	}
+
 	// Generate based on type
-	if schema.Type.Is("object") {
+	schemaType := sampleType(schema)
+
+	if len(schema.AllOf) > 0 && (schemaType == "" || schemaType == "object") {
+		return allOfSample(schema, name, opts)
+	}
+
+	if schemaType == "object" {
+		// Name order keeps faked values reproducible for a seed
+		names := make([]string, 0, len(schema.Properties))
+		for propName := range schema.Properties {
+			names = append(names, propName)
+		}
+		sort.Strings(names)
+
 		obj := make(map[string]interface{})
-		for propName, propRef := range schema.Properties {
-			if propRef != nil && propRef.Value != nil {
-				obj[propName] = GenerateSampleFromSchema(propRef.Value)
+		for _, propName := range names {
+			if propRef := schema.Properties[propName]; propRef != nil && propRef.Value != nil {
+				obj[propName] = generateSample(propRef.Value, propName, opts)
 			}
 		}
 		return obj
 	}
 
-	if schema.Type.Is("array") {
+	if schemaType == "array" {
 		if schema.Items != nil && schema.Items.Value != nil {
+			if opts.rng != nil {
+				items := make([]interface{}, randomArrayLength(schema, opts.rng))
+				for i := range items {
+					items[i] = generateSample(schema.Items.Value, name, opts)
+				}
+				return items
+			}
 			// Generate a single-item array
-			return []interface{}{GenerateSampleFromSchema(schema.Items.Value)}
+			return []interface{}{generateSample(schema.Items.Value, name, opts)}
 		}
 		return []interface{}{}
 	}
 
-	if schema.Type.Is("string") {
+	if schemaType == "string" {
 		if len(schema.Enum) > 0 {
 			return schema.Enum[0]
 		}
@@ -151,29 +656,29 @@ func GenerateSampleFromSchema(schema *openapi3.Schema) interface{} {
 			return "https://example.com"
 		}
 		if schema.Format == "date" {
-			return "2024-01-01"
+			return opts.formatDate("2024-01-01")
 		}
 		if schema.Format == "date-time" {
-			return "2024-01-01T00:00:00Z"
+			return opts.formatDate("2024-01-01T00:00:00Z")
 		}
 		return "sample"
 	}
 
-	if schema.Type.Is("integer") {
+	if schemaType == "integer" {
 		if schema.Min != nil {
 			return int(*schema.Min)
 		}
 		return 1
 	}
 
-	if schema.Type.Is("number") {
+	if schemaType == "number" {
 		if schema.Min != nil {
 			return *schema.Min
 		}
 		return 1.0
 	}
 
-	if schema.Type.Is("boolean") {
+	if schemaType == "boolean" {
 		return true
 	}
 
@@ -181,6 +686,126 @@ func GenerateSampleFromSchema(schema *openapi3.Schema) interface{} {
 	return nil
 }
 
+// allOfSample generates a value satisfying every allOf member of schema and
+// its own properties. Member objects are merged, the first member to
+// generate a key keeping it and own properties winning, so each key appears
+// once. A wrapper around a single non-object member, such as a ref given a
+// description, yields that member's value. When the composition is closed
+// with additionalProperties: false, keys it doesn't declare are dropped
+func allOfSample(schema *openapi3.Schema, name string, opts sampleOptions) interface{} {
+	obj := make(map[string]interface{})
+	for _, member := range schema.AllOf {
+		if member == nil || member.Value == nil {
+			continue
+		}
+		sample := generateSample(member.Value, name, opts)
+		memberObj, ok := sample.(map[string]interface{})
+		if !ok {
+			if len(schema.AllOf) == 1 && len(schema.Properties) == 0 {
+				return sample
+			}
+			continue
+		}
+		for key, value := range memberObj {
+			if _, ok := obj[key]; !ok {
+				obj[key] = value
+			}
+		}
+	}
+
+	names := make([]string, 0, len(schema.Properties))
+	for propName := range schema.Properties {
+		names = append(names, propName)
+	}
+	sort.Strings(names)
+	for _, propName := range names {
+		if propRef := schema.Properties[propName]; propRef != nil && propRef.Value != nil {
+			obj[propName] = generateSample(propRef.Value, propName, opts)
+		}
+	}
+
+	for key := range obj {
+		if !allowsProperty(schema, key) {
+			delete(obj, key)
+		}
+	}
+	return obj
+}
+
+// allowsProperty reports whether an object described by schema may have the
+// named property: always, unless schema or one of its allOf members sets
+// additionalProperties: false and none of them declares it
+func allowsProperty(schema *openapi3.Schema, name string) bool {
+	declared, closed := declaredProperties(schema)
+	return !closed || declared[name]
+}
+
+// declaredProperties returns the properties declared by schema and its allOf
+// members, and whether any of them closes the object to others
+func declaredProperties(schema *openapi3.Schema) (map[string]bool, bool) {
+	declared := make(map[string]bool)
+	closed := schema.AdditionalProperties.Has != nil && !*schema.AdditionalProperties.Has
+	for propName := range schema.Properties {
+		declared[propName] = true
+	}
+	for _, member := range schema.AllOf {
+		if member == nil || member.Value == nil {
+			continue
+		}
+		memberDeclared, memberClosed := declaredProperties(member.Value)
+		for propName := range memberDeclared {
+			declared[propName] = true
+		}
+		closed = closed || memberClosed
+	}
+	return declared, closed
+}
+
+// firstVariant returns the first oneOf, or else anyOf, subschema of schema,
+// or nil when it has neither
+func firstVariant(schema *openapi3.Schema) *openapi3.SchemaRef {
+	for _, variants := range []openapi3.SchemaRefs{schema.OneOf, schema.AnyOf} {
+		if len(variants) > 0 && variants[0] != nil && variants[0].Value != nil {
+			return variants[0]
+		}
+	}
+	return nil
+}
+
+// discriminatorValue returns the discriminator value naming the variant at
+// ref: the mapping key pointing at it, or else the name of the schema, which
+// is the implicit value. Inline variants, without a ref, have no value
+func discriminatorValue(discriminator *openapi3.Discriminator, ref string) (string, bool) {
+	if ref == "" {
+		return "", false
+	}
+	schemaName := ref[strings.LastIndex(ref, "/")+1:]
+
+	keys := make([]string, 0, len(discriminator.Mapping))
+	for key := range discriminator.Mapping {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if target := discriminator.Mapping[key]; target == ref || target == schemaName {
+			return key, true
+		}
+	}
+	return schemaName, true
+}
+
+// sampleType returns the type used to generate a sample for the schema.
+// OpenAPI 3.1 allows multiple types (e.g. ["integer", "null"]); the first
+// non-null type wins so nullable fields still get a meaningful value.
+func sampleType(schema *openapi3.Schema) string {
+	for _, t := range schema.Type.Slice() {
+		if t != "null" {
+			return t
+		}
+	}
+	return ""
+}
+
 // applyAuth applies authentication configuration to an HTTP request
 func ApplyAuth(req *http.Request, auth *models.AuthConfig) {
 	if auth == nil || auth.AuthType == "none" || auth.AuthType == "" {
@@ -190,7 +815,7 @@ func ApplyAuth(req *http.Request, auth *models.AuthConfig) {
 	switch auth.AuthType {
 	case "bearer":
 		if auth.Token != "" {
-			req.Header.Set("Authorization", "Bearer "+auth.Token)
+			req.Header.Set("Authorization", bearerPrefix(auth)+" "+auth.Token)
 		}
 	case "apiKey":
 		if auth.APIKeyName != "" && auth.Token != "" {
@@ -204,16 +829,142 @@ func ApplyAuth(req *http.Request, auth *models.AuthConfig) {
 			}
 		}
 	case "basic":
-		if auth.Username != "" {
+		if basicAuthConfigured(auth) {
 			req.SetBasicAuth(auth.Username, auth.Password)
 		}
 	}
 }
 
+// bearerPrefix returns the scheme sent before a bearer token: "Bearer"
+// unless the config names another, such as "Token" or "JWT"
+func bearerPrefix(auth *models.AuthConfig) string {
+	if prefix := strings.TrimSpace(auth.BearerPrefix); prefix != "" {
+		return prefix
+	}
+	return "Bearer"
+}
+
+// basicAuthConfigured reports whether auth has basic credentials to send
+// An empty username with a password is valid and sent as ":password".
+// SetBasicAuth encodes the credentials' UTF-8 bytes as RFC 7617 requires, so
+// non-ASCII passwords and passwords containing colons reach the server intact
+// (servers split at the first colon); usernames cannot contain a colon.
+func basicAuthConfigured(auth *models.AuthConfig) bool {
+	return auth.Username != "" || auth.Password != ""
+}
+
+// AppliedAuth names the auth ApplyAuth adds to a request, without secrets:
+// "bearer", "apiKey (header)", "apiKey (query)", "basic" or "none" when the
+// configuration is missing or incomplete
+func AppliedAuth(auth *models.AuthConfig) string {
+	if auth == nil {
+		return "none"
+	}
+	switch auth.AuthType {
+	case "bearer":
+		if auth.Token != "" {
+			return "bearer"
+		}
+	case "apiKey":
+		if auth.APIKeyName != "" && auth.Token != "" && (auth.APIKeyIn == "header" || auth.APIKeyIn == "query") {
+			return "apiKey (" + auth.APIKeyIn + ")"
+		}
+	case "basic":
+		if basicAuthConfigured(auth) {
+			return "basic"
+		}
+	}
+	return "none"
+}
+
+// requiresAuth reports whether an operation's security requirements, or the
+// spec's when the operation sets none, demand credentials. An empty
+// requirement ({}) makes auth optional
+func requiresAuth(doc *openapi3.T, operation *openapi3.Operation) bool {
+	requirements := doc.Security
+	if operation.Security != nil {
+		requirements = *operation.Security
+	}
+	if len(requirements) == 0 {
+		return false
+	}
+	for _, requirement := range requirements {
+		if len(requirement) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
 // testEndpoint performs an HTTP request to test an API endpoint
 // Supports GET, POST, PUT, PATCH, DELETE methods with optional request bodies
 // Returns status code, response object, log entry, and error
 func TestEndpoint(method, url string, body []byte, auth *models.AuthConfig, verbose bool) (int, *http.Response, *models.LogEntry, error) {
+	return sendRequest(context.Background(), method, url, body, nil, nil, auth, verbose, nil)
+}
+
+// truncateUTF8 cuts s to at most n bytes without splitting a UTF-8 sequence,
+// and reports whether it was cut
+func truncateUTF8(s string, n int) (string, bool) {
+	if len(s) <= n {
+		return s, false
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n], true
+}
+
+// noRedirectsKey marks a request context whose redirects are returned as the
+// response instead of being followed
+type noRedirectsKey struct{}
+
+// withoutRedirects returns a context for requests that don't follow redirects
+func withoutRedirects(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noRedirectsKey{}, true)
+}
+
+// redactedHeader replaces the values of secret response headers in logs
+const redactedHeader = "[REDACTED]"
+
+// secretHeaderNames are name fragments marking a response header as secret,
+// matched case-insensitively
+var secretHeaderNames = []string{"auth", "cookie", "token", "secret", "key", "password", "session"}
+
+// filterResponseHeaders keeps only the allowlisted headers, matched
+// case-insensitively, or all of them when allow is empty, and always redacts
+// the values of secret ones such as Set-Cookie
+func filterResponseHeaders(headers map[string]string, allow []string) map[string]string {
+	filtered := make(map[string]string)
+	for name, value := range headers {
+		if len(allow) > 0 && !slices.ContainsFunc(allow, func(allowed string) bool { return strings.EqualFold(allowed, name) }) {
+			continue
+		}
+		lower := strings.ToLower(name)
+		if slices.ContainsFunc(secretHeaderNames, func(fragment string) bool { return strings.Contains(lower, fragment) }) {
+			value = redactedHeader
+		}
+		filtered[name] = value
+	}
+	return filtered
+}
+
+// dialTransport returns a copy of http.DefaultTransport whose connections,
+// host name lookup included, give up after timeout. A lookup cut short this
+// way is a DNS timeout, which the dns-timeout retry class retries
+func dialTransport(timeout time.Duration) http.RoundTripper {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	dialer := &net.Dialer{Timeout: timeout, KeepAlive: 30 * time.Second}
+	transport.DialContext = dialer.DialContext
+	return transport
+}
+
+// sendRequest performs the HTTP request behind TestEndpoint
+// Headers and auth are applied by requestPipeline, so extra headers can
+// replace the default Content-Type and run-wide headers, and auth comes last
+// A nil transport uses http.DefaultTransport
+// Redirects are followed unless ctx comes from withoutRedirects
+func sendRequest(ctx context.Context, method, url string, body []byte, runHeaders, headers map[string]string, auth *models.AuthConfig, verbose bool, transport http.RoundTripper) (int, *http.Response, *models.LogEntry, error) {
 	var req *http.Request
 	var err error
 
@@ -222,28 +973,42 @@ func TestEndpoint(method, url string, body []byte, auth *models.AuthConfig, verb
 	
 	if body != nil && len(body) > 0 {
 		// Create request with body
-		req, err = http.NewRequest(method, url, bytes.NewReader(body))
+		req, err = http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
 		if err != nil {
 			return 0, nil, nil, err
 		}
-		req.Header.Set("Content-Type", "application/json")
 	} else {
 		// Create request without body
-		req, err = http.NewRequest(method, url, nil)
+		req, err = http.NewRequestWithContext(ctx, method, url, nil)
 		if err != nil {
 			return 0, nil, nil, err
 		}
 	}
 
-	// Apply authentication if configured
-	ApplyAuth(req, auth)
+	// Apply headers and authentication in their defined order
+	if err := ApplyMutators(req, requestPipeline(len(body) > 0, runHeaders, headers, auth)...); err != nil {
+		return 0, nil, nil, err
+	}
+
+	// Trace connection phases for the log timing breakdown
+	var timing *requestTiming
+	if verbose {
+		timing = &requestTiming{}
+		req = req.WithContext(httptrace.WithClientTrace(req.Context(), timing.clientTrace()))
+	}
 
 	// Capture start time for duration measurement
 	startTime := time.Now()
 
 	// Execute request with timeout to prevent hanging
 	client := &http.Client{
-		Timeout: 10 * time.Second,
+		Timeout:   10 * time.Second,
+		Transport: transport,
+	}
+	if ctx.Value(noRedirectsKey{}) != nil {
+		client.CheckRedirect = func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		}
 	}
 	resp, err := client.Do(req)
 	duration := time.Since(startTime)
@@ -251,6 +1016,10 @@ func TestEndpoint(method, url string, body []byte, auth *models.AuthConfig, verb
 	if err != nil {
 		return 0, nil, nil, errors.EnhanceNetworkError(err, url)
 	}
+	if err := decodeResponseBody(resp); err != nil {
+		resp.Body.Close()
+		return 0, nil, nil, err
+	}
 
 	// Create log entry if verbose mode is enabled
 	var log *models.LogEntry
@@ -262,6 +1031,7 @@ func TestEndpoint(method, url string, body []byte, auth *models.AuthConfig, verb
 			RequestHeaders: make(map[string]string),
 			ResponseHeaders: make(map[string]string),
 		}
+		timing.apply(log, startTime)
 
 		// Capture request headers
 		for k, v := range req.Header {
@@ -291,6 +1061,9 @@ func TestEndpoint(method, url string, body []byte, auth *models.AuthConfig, verb
 			bodyBytes, err := io.ReadAll(resp.Body)
 			resp.Body.Close()
 			if err == nil {
+				// Keep the full body within the default capture limit, so large
+				// responses don't pile up in memory over a run
+				log.FullResponseBody, log.FullBodyTruncated = truncateUTF8(string(bodyBytes), models.DefaultCacheBodySize)
 				log.ResponseBody = string(bodyBytes)
 				// Truncate if too large
 				if len(log.ResponseBody) > 500 {
@@ -315,86 +1088,21 @@ func RunTests(specPath, baseURL string, auth *models.AuthConfig, verbose bool, m
 	if err != nil {
 		return nil, errors.EnhanceFileError(err, specPath)
 	}
+	if err := validation.CheckOpenAPIDocument(doc, specPath); err != nil {
+		return nil, err
+	}
 
-	var results []models.TestResult
-
-	// Iterate through all paths and operations in the spec
-	if doc.Paths != nil {
-		for path, pathItem := range doc.Paths.Map() {
-			// Iterate through all paths and operations in the spec
-			for method, operation := range pathItem.Operations() {
-				// Construct full endpoint URL with placeholder replacement
-				endpoint := baseURL + ReplacePlaceholders(path)
-				
-				// Add query parameters if defined
-				queryParams := BuildQueryParams(operation)
-				endpoint += queryParams
-
-				// Generate request body if needed
-				var requestBody []byte
-				if strings.ToUpper(method) == "POST" || strings.ToUpper(method) == "PUT" || strings.ToUpper(method) == "PATCH" {
-					requestBody, err = GenerateRequestBody(operation)
-					if err != nil {
-						// Log error but continue testing
-						results = append(results, models.TestResult{
-							Method:     method,
-							Endpoint:   path,
-							Status:     "ERR",
-							Message:    fmt.Sprintf("Failed to generate request body: %v", err),
-							RetryCount: 0,
-						})
-						continue
-					}
-				}
-
-				// Test the endpoint with retry logic
-				startTime := time.Now()
-				status, resp, logEntry, retryCount, err := TestEndpointWithRetry(method, endpoint, requestBody, auth, verbose, maxRetries, retryDelay)
-				duration := time.Since(startTime)
-				message := "OK"
-				if err != nil {
-					message = err.Error()
-				} else if resp != nil {
-					// Validate response against spec
-					validationResult := validation.ValidateResponse(resp, operation, status)
-					
-					// Close response body after validation
-					if resp.Body != nil {
-						io.Copy(io.Discard, resp.Body) // Drain body
-						resp.Body.Close()
-					}
-					
-					if !validationResult.Valid {
-						message = "Response validation failed"
-						if len(validationResult.SchemaErrors) > 0 {
-							message = validationResult.SchemaErrors[0] // Show first error
-						}
-					} else if validationResult.StatusValid {
-						message = "OK (validated)"
-						if retryCount > 0 {
-							message = fmt.Sprintf("OK (validated, %d retries)", retryCount)
-						}
-					}
-				}
-
-				// Format status for display
-				statusStr := fmt.Sprintf("%d", status)
-				if err != nil {
-					statusStr = "ERR"
-				}
+	opts := RunOptions{
+		Auth:       auth,
+		Verbose:    verbose,
+		MaxRetries: maxRetries,
+		RetryDelay: retryDelay,
+	}
 
-				// Add result to collection
-				results = append(results, models.TestResult{
-					Method:     method,
-					Endpoint:   path,
-					Status:     statusStr,
-					Message:    message,
-					Duration:   duration,
-					LogEntry:   logEntry,
-					RetryCount: retryCount,
-				})
-			}
-		}
+	// Test each endpoint sequentially
+	var results []models.TestResult
+	for _, job := range buildJobs(doc, baseURL, opts) {
+		results = append(results, executeTestJob(context.Background(), job, opts))
 	}
 
 	return results, nil
@@ -1,12 +1,21 @@
 package testing
 
 import (
+	"bytes"
 	"encoding/json"
+	"io"
+	"math/rand"
+	"mime"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"sort"
 	"strings"
+	"sync"
 	"testing"
 	"time"
+	"unicode/utf8"
 
 	"github.com/Traves-Theberge/OpenAPI-Toolkit/openapi-tui/internal/models"
 	"github.com/getkin/kin-openapi/openapi3"
@@ -40,6 +49,179 @@ func TestReplacePlaceholders(t *testing.T) {
 	}
 }
 
+// TestRunTestsWithOptions_UnsatisfiablePathParams tests that path parameters
+// with nothing to send are flagged instead of leaving a bare 404
+func TestRunTestsWithOptions_UnsatisfiablePathParams(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/users/alice" || r.URL.Path == "/orders/1" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	specPath := createTempSpec(t, `
+openapi: 3.0.0
+info:
+  title: Path Params
+  version: 1.0.0
+paths:
+  /orders/{id}:
+    get:
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: integer
+      responses:
+        '200':
+          description: OK
+  /tokens/{token}:
+    get:
+      parameters:
+        - name: token
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        '200':
+          description: OK
+  /users/{name}:
+    parameters:
+      - name: name
+        in: path
+        required: true
+        schema:
+          type: string
+        example: alice
+    get:
+      responses:
+        '200':
+          description: OK
+`)
+
+	results, err := RunTestsWithOptions(specPath, server.URL, RunOptions{MaxConcurrency: 1}, nil)
+	if err != nil {
+		t.Fatalf("RunTestsWithOptions() error = %v", err)
+	}
+	for _, r := range results {
+		switch r.Endpoint {
+		case "/tokens/{token}":
+			if r.Status != "404" || !strings.Contains(r.Message, `unsatisfiable path parameter "token"`) {
+				t.Errorf("Expected the 404 to name the unsatisfiable parameter, got %s %q", r.Status, r.Message)
+			}
+		default:
+			if r.Status != "200" || len(r.Hints) != 0 {
+				t.Errorf("Expected %s to be sent a usable value, got %s %q %v", r.Endpoint, r.Status, r.Message, r.Hints)
+			}
+		}
+	}
+}
+
+// TestRunTestsWithOptions_APIVersionHeader tests that the version header is
+// sent with every request, the preflight check included
+func TestRunTestsWithOptions_APIVersionHeader(t *testing.T) {
+	var mu sync.Mutex
+	var accepts []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		accepts = append(accepts, r.Header.Get("Accept"))
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	specPath := createTempSpec(t, `
+openapi: 3.0.0
+info:
+  title: Versioned
+  version: 1.0.0
+paths:
+  /users:
+    get:
+      responses:
+        '200':
+          description: OK
+    post:
+      responses:
+        '200':
+          description: OK
+`)
+
+	// The preflight check gets the header too
+	header := models.APIVersionHeader{Name: "accept", Value: "application/vnd.company.v2+json"}
+	opts := RunOptions{APIVersionHeader: header, PreflightPath: "/health"}
+	if _, err := RunTestsWithOptions(specPath, server.URL, opts, nil); err != nil {
+		t.Fatalf("RunTestsWithOptions() error = %v", err)
+	}
+	if len(accepts) != 3 {
+		t.Fatalf("Expected 3 requests, got %d", len(accepts))
+	}
+	for _, accept := range accepts {
+		if accept != header.Value {
+			t.Errorf("Expected Accept %q, got %q", header.Value, accept)
+		}
+	}
+}
+
+// TestRunTestsWithOptions_RequestHeaders tests that configured standard
+// headers are sent, and that x-test-headers still win for their endpoints
+func TestRunTestsWithOptions_RequestHeaders(t *testing.T) {
+	var mu sync.Mutex
+	prefer := map[string]string{}
+	ifMatch := map[string]string{}
+	var preflightPrefer string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if r.URL.Path == "/health" {
+			preflightPrefer = r.Header.Get("Prefer")
+			return
+		}
+		prefer[r.Method] = r.Header.Get("Prefer")
+		ifMatch[r.Method] = r.Header.Get("If-Match")
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	specPath := createTempSpec(t, `
+openapi: 3.0.0
+info:
+  title: Async
+  version: 1.0.0
+paths:
+  /reports:
+    get:
+      responses:
+        '202':
+          description: Accepted
+    put:
+      x-test-headers:
+        Prefer: return=minimal
+      responses:
+        '202':
+          description: Accepted
+`)
+
+	// The preflight check gets them too
+	headers := models.RequestHeaders{Prefer: "respond-async", IfMatch: `"v1"`}
+	if _, err := RunTestsWithOptions(specPath, server.URL, RunOptions{RequestHeaders: headers, PreflightPath: "/health"}, nil); err != nil {
+		t.Fatalf("RunTestsWithOptions() error = %v", err)
+	}
+	if prefer["GET"] != "respond-async" || ifMatch["GET"] != `"v1"` {
+		t.Errorf("Expected Prefer: respond-async and If-Match: \"v1\", got %q and %q", prefer["GET"], ifMatch["GET"])
+	}
+	if prefer["PUT"] != "return=minimal" || ifMatch["PUT"] != `"v1"` {
+		t.Errorf("Expected x-test-headers to replace Prefer only, got %q and %q", prefer["PUT"], ifMatch["PUT"])
+	}
+	if preflightPrefer != "respond-async" {
+		t.Errorf("Expected the preflight to send Prefer: respond-async, got %q", preflightPrefer)
+	}
+}
+
 // TestBuildQueryParams tests query parameter generation
 func TestBuildQueryParams(t *testing.T) {
 	t.Run("Nil operation", func(t *testing.T) {
@@ -196,6 +378,84 @@ func TestBuildQueryParams(t *testing.T) {
 			t.Errorf("Expected result to contain 'status=active', got: %s", result)
 		}
 	})
+
+	t.Run("Parameter-level example", func(t *testing.T) {
+		strSchema := openapi3.NewStringSchema()
+		strSchema.Example = "schema-value"
+
+		operation := &openapi3.Operation{
+			Parameters: openapi3.Parameters{
+				&openapi3.ParameterRef{
+					Value: &openapi3.Parameter{
+						Name:    "q",
+						In:      "query",
+						Example: "param-value",
+						Schema:  &openapi3.SchemaRef{Value: strSchema},
+					},
+				},
+			},
+		}
+
+		result := BuildQueryParams(operation)
+		if !strings.Contains(result, "q=param-value") {
+			t.Errorf("Expected result to contain 'q=param-value', got: %s", result)
+		}
+	})
+
+	t.Run("Parameter examples map", func(t *testing.T) {
+		intSchema := openapi3.NewIntegerSchema()
+
+		operation := &openapi3.Operation{
+			Parameters: openapi3.Parameters{
+				&openapi3.ParameterRef{
+					Value: &openapi3.Parameter{
+						Name: "limit",
+						In:   "query",
+						Examples: openapi3.Examples{
+							"small": &openapi3.ExampleRef{Value: openapi3.NewExample(10)},
+							"large": &openapi3.ExampleRef{Value: openapi3.NewExample(500)},
+						},
+						Schema: &openapi3.SchemaRef{Value: intSchema},
+					},
+				},
+			},
+		}
+
+		// Examples are taken in name order, so "large" comes first
+		result := BuildQueryParams(operation)
+		if !strings.Contains(result, "limit=500") {
+			t.Errorf("Expected result to contain 'limit=500', got: %s", result)
+		}
+	})
+
+	t.Run("Required param without schema", func(t *testing.T) {
+		operation := &openapi3.Operation{
+			Parameters: openapi3.Parameters{
+				&openapi3.ParameterRef{
+					Value: &openapi3.Parameter{Name: "token", In: "query", Required: true},
+				},
+				&openapi3.ParameterRef{
+					Value: &openapi3.Parameter{Name: "debug", In: "query"},
+				},
+			},
+		}
+
+		result, notes := buildQueryParams(operation, false, "")
+		if !strings.Contains(result, "token=1") {
+			t.Errorf("Expected a placeholder value for token, got: %s", result)
+		}
+		// Only the required parameter is worth a warning
+		if len(notes) != 1 || notes[0].Param != "token" {
+			t.Fatalf("Expected one note for token, got: %+v", notes)
+		}
+
+		doc := &openapi3.T{Paths: openapi3.NewPaths()}
+		doc.Paths.Set("/search", &openapi3.PathItem{Get: operation})
+		jobs := buildJobs(doc, "http://localhost", RunOptions{})
+		if len(jobs) != 1 || len(jobs[0].Hints) != 1 || !strings.Contains(jobs[0].Hints[0], `"token"`) {
+			t.Errorf("Expected the job to carry the token hint, got: %+v", jobs)
+		}
+	})
 }
 
 // TestGenerateRequestBody tests request body generation
@@ -291,6 +551,389 @@ func TestGenerateRequestBody(t *testing.T) {
 	})
 }
 
+// TestApplyScheme tests forcing the request URL scheme
+func TestApplyScheme(t *testing.T) {
+	testCases := []struct {
+		name     string
+		url      string
+		scheme   string
+		expected string
+		wantErr  bool
+	}{
+		{"Empty scheme keeps URL", "http://api.example.com/v1", "", "http://api.example.com/v1", false},
+		{"http to https", "http://api.example.com/v1", "https", "https://api.example.com/v1", false},
+		{"https to http", "https://localhost:8443/api", "http", "http://localhost:8443/api", false},
+		{"Case insensitive", "http://api.example.com", "HTTPS", "https://api.example.com", false},
+		{"Invalid scheme", "http://api.example.com", "ftp", "", true},
+		{"Host and port without scheme", "localhost:8080", "https", "https://localhost:8080", false},
+		{"Host and path without scheme", "api.example.com/v1", "http", "http://api.example.com/v1", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := ApplyScheme(tc.url, tc.scheme)
+			if tc.wantErr {
+				if err == nil {
+					t.Errorf("Expected error for scheme %q", tc.scheme)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if result != tc.expected {
+				t.Errorf("Expected %s, got %s", tc.expected, result)
+			}
+		})
+	}
+}
+
+// TestGenerateRequestBody_PatchMediaTypes tests JSON Merge Patch and JSON Patch bodies
+func TestGenerateRequestBody_PatchMediaTypes(t *testing.T) {
+	patchOperation := func(mediaType string, schema *openapi3.Schema) *openapi3.Operation {
+		return &openapi3.Operation{
+			RequestBody: &openapi3.RequestBodyRef{
+				Value: &openapi3.RequestBody{
+					Content: openapi3.Content{
+						mediaType: &openapi3.MediaType{
+							Schema: &openapi3.SchemaRef{Value: schema},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	t.Run("Merge patch", func(t *testing.T) {
+		schema := openapi3.NewObjectSchema()
+		schema.Properties = openapi3.Schemas{
+			"email": &openapi3.SchemaRef{Value: openapi3.NewStringSchema()},
+		}
+
+		body, mediaType, err := generateRequestBodyWithType(patchOperation(MediaTypeMergePatch, schema), sampleOptions{})
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if mediaType != MediaTypeMergePatch {
+			t.Errorf("Expected media type %s, got: %s", MediaTypeMergePatch, mediaType)
+		}
+
+		var patch map[string]interface{}
+		if err := json.Unmarshal(body, &patch); err != nil {
+			t.Fatalf("Expected a JSON object, got: %s", string(body))
+		}
+		if _, ok := patch["email"]; !ok {
+			t.Errorf("Expected 'email' in merge patch, got: %s", string(body))
+		}
+	})
+
+	t.Run("JSON patch", func(t *testing.T) {
+		opSchema := openapi3.NewObjectSchema()
+		opSchema.Properties = openapi3.Schemas{
+			"op":    &openapi3.SchemaRef{Value: openapi3.NewStringSchema()},
+			"path":  &openapi3.SchemaRef{Value: openapi3.NewStringSchema()},
+			"value": &openapi3.SchemaRef{Value: openapi3.NewIntegerSchema()},
+		}
+		schema := openapi3.NewArraySchema()
+		schema.Items = &openapi3.SchemaRef{Value: opSchema}
+
+		body, mediaType, err := generateRequestBodyWithType(patchOperation(MediaTypeJSONPatch, schema), sampleOptions{})
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if mediaType != MediaTypeJSONPatch {
+			t.Errorf("Expected media type %s, got: %s", MediaTypeJSONPatch, mediaType)
+		}
+
+		var patch []map[string]interface{}
+		if err := json.Unmarshal(body, &patch); err != nil {
+			t.Fatalf("Expected an array of operations, got: %s", string(body))
+		}
+		if len(patch) != 1 {
+			t.Fatalf("Expected 1 operation, got: %d", len(patch))
+		}
+		if patch[0]["op"] != "replace" || patch[0]["path"] != "/name" || patch[0]["value"] != float64(1) {
+			t.Errorf("Unexpected patch operation: %v", patch[0])
+		}
+	})
+
+	t.Run("Content-Type header on job", func(t *testing.T) {
+		doc := &openapi3.T{Paths: openapi3.NewPaths()}
+		doc.Paths.Set("/users/{id}", &openapi3.PathItem{
+			Patch: patchOperation(MediaTypeMergePatch, openapi3.NewObjectSchema()),
+		})
+
+		jobs := buildJobs(doc, "http://localhost", RunOptions{})
+		if len(jobs) != 1 || jobs[0].Headers["Content-Type"] != MediaTypeMergePatch {
+			t.Errorf("Expected merge patch Content-Type header, got: %+v", jobs)
+		}
+	})
+
+	t.Run("Override header replaces generated Content-Type", func(t *testing.T) {
+		doc := &openapi3.T{Paths: openapi3.NewPaths()}
+		doc.Paths.Set("/users/{id}", &openapi3.PathItem{
+			Patch: patchOperation(MediaTypeMergePatch, openapi3.NewObjectSchema()),
+		})
+		overrides := models.Overrides{
+			"PATCH /users/{id}": {Headers: map[string]string{"content-type": "application/vnd.custom+json"}},
+		}
+
+		jobs := buildJobs(doc, "http://localhost", RunOptions{Overrides: overrides})
+		if len(jobs) != 1 {
+			t.Fatalf("Expected 1 job, got: %d", len(jobs))
+		}
+		if len(jobs[0].Headers) != 1 || jobs[0].Headers["Content-Type"] != "application/vnd.custom+json" {
+			t.Errorf("Expected a single overridden Content-Type header, got: %v", jobs[0].Headers)
+		}
+	})
+
+	t.Run("Override body drops generated Content-Type", func(t *testing.T) {
+		doc := &openapi3.T{Paths: openapi3.NewPaths()}
+		doc.Paths.Set("/users/{id}", &openapi3.PathItem{
+			Patch: patchOperation(MediaTypeMergePatch, openapi3.NewObjectSchema()),
+		})
+		overrides := models.Overrides{
+			"PATCH /users/{id}": {Body: map[string]interface{}{"name": "x"}},
+		}
+
+		jobs := buildJobs(doc, "http://localhost", RunOptions{Overrides: overrides})
+		if len(jobs) != 1 {
+			t.Fatalf("Expected 1 job, got: %d", len(jobs))
+		}
+		if _, ok := jobs[0].Headers["Content-Type"]; ok {
+			t.Errorf("Expected no generated Content-Type for an overridden body, got: %v", jobs[0].Headers)
+		}
+	})
+}
+
+func TestBuildJobs_Globs(t *testing.T) {
+	doc := &openapi3.T{Paths: openapi3.NewPaths()}
+	for _, path := range []string{"/admin/users", "/admin/internal", "/users"} {
+		doc.Paths.Set(path, &openapi3.PathItem{Get: &openapi3.Operation{}})
+	}
+
+	jobs := buildJobs(doc, "http://localhost", RunOptions{
+		IncludeGlobs: []string{"/admin/*"},
+		ExcludeGlobs: []string{"*/internal", "/admin/internal"},
+	})
+	if len(jobs) != 1 || jobs[0].Path != "/admin/users" {
+		t.Errorf("Expected only /admin/users, got: %+v", jobs)
+	}
+}
+
+func TestBuildJobs_OptionalBody(t *testing.T) {
+	doc := &openapi3.T{Paths: openapi3.NewPaths()}
+	doc.Paths.Set("/users", &openapi3.PathItem{Post: &openapi3.Operation{
+		RequestBody: &openapi3.RequestBodyRef{Value: openapi3.NewRequestBody().WithJSONSchema(openapi3.NewObjectSchema())},
+	}})
+
+	if jobs := buildJobs(doc, "http://localhost", RunOptions{}); len(jobs[0].RequestBody) == 0 {
+		t.Error("Expected an optional body to be sent by default")
+	}
+	jobs := buildJobs(doc, "http://localhost", RunOptions{OmitOptionalBodies: true})
+	if len(jobs[0].RequestBody) != 0 || len(jobs[0].Hints) != 0 {
+		t.Errorf("Expected the optional body to be omitted quietly, got %q %v", jobs[0].RequestBody, jobs[0].Hints)
+	}
+
+	// Required bodies are sent either way
+	doc.Paths.Value("/users").Post.RequestBody.Value.Required = true
+	if jobs := buildJobs(doc, "http://localhost", RunOptions{OmitOptionalBodies: true}); len(jobs[0].RequestBody) == 0 {
+		t.Error("Expected a required body to be sent")
+	}
+}
+
+func TestBuildJobs_RequiredBodyNotGenerated(t *testing.T) {
+	doc := &openapi3.T{Paths: openapi3.NewPaths()}
+	body := openapi3.NewRequestBody().WithRequired(true).WithContent(openapi3.NewContentWithSchema(openapi3.NewStringSchema(), []string{"application/xml"}))
+	doc.Paths.Set("/users", &openapi3.PathItem{Post: &openapi3.Operation{RequestBody: &openapi3.RequestBodyRef{Value: body}}})
+
+	jobs := buildJobs(doc, "http://localhost", RunOptions{})
+	if len(jobs) != 1 || len(jobs[0].RequestBody) != 0 {
+		t.Fatalf("Expected one job without a body, got %+v", jobs)
+	}
+	if len(jobs[0].Hints) != 1 || !strings.Contains(jobs[0].Hints[0], "request body is required") {
+		t.Errorf("Expected a hint about the missing required body, got %v", jobs[0].Hints)
+	}
+}
+
+func TestBuildJobs_VendorExtensions(t *testing.T) {
+	spec := `openapi: 3.0.0
+info:
+  title: Extensions
+  version: "1.0"
+paths:
+  /users:
+    get:
+      x-test-skip: true
+      responses:
+        "200":
+          description: OK
+    post:
+      x-test-body:
+        name: Ada
+        role: admin
+      x-test-headers:
+        x-tenant: acme
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                name:
+                  type: string
+      responses:
+        "201":
+          description: Created
+  /reports:
+    post:
+      x-test-body: "<report/>"
+      responses:
+        "201":
+          description: Created
+`
+	doc, err := openapi3.NewLoader().LoadFromData([]byte(spec))
+	if err != nil {
+		t.Fatalf("Failed to load spec: %v", err)
+	}
+
+	jobs := buildJobs(doc, "http://localhost", RunOptions{})
+	byKey := make(map[string]TestJob)
+	for _, job := range jobs {
+		byKey[models.EndpointKey(job.Method, job.Path)] = job
+	}
+
+	if _, ok := byKey["GET /users"]; ok || len(jobs) != 2 {
+		t.Errorf("Expected x-test-skip to leave GET /users out, got %d jobs", len(jobs))
+	}
+	users := byKey["POST /users"]
+	if string(users.RequestBody) != `{"name":"Ada","role":"admin"}` {
+		t.Errorf("Expected the x-test-body instead of a generated body, got %s", users.RequestBody)
+	}
+	if users.Headers["X-Tenant"] != "acme" {
+		t.Errorf("Expected the x-test-headers to be sent, got %v", users.Headers)
+	}
+	if reports := byKey["POST /reports"]; string(reports.RequestBody) != "<report/>" {
+		t.Errorf("Expected a string x-test-body to be sent verbatim, got %s", reports.RequestBody)
+	}
+
+	// Endpoint overrides still win over the spec
+	overridden := buildJobs(doc, "http://localhost", RunOptions{Overrides: models.Overrides{
+		"POST /reports": {Body: "<override/>"},
+	}})
+	for _, job := range overridden {
+		if job.Path == "/reports" && string(job.RequestBody) != "<override/>" {
+			t.Errorf("Expected the override body to win, got %s", job.RequestBody)
+		}
+	}
+}
+
+func TestBuildJobs_GlobalQuery(t *testing.T) {
+	param := func(name, example string) *openapi3.ParameterRef {
+		return &openapi3.ParameterRef{Value: &openapi3.Parameter{Name: name, In: "query", Example: example}}
+	}
+	doc := &openapi3.T{Paths: openapi3.NewPaths()}
+	doc.Paths.Set("/users", &openapi3.PathItem{Get: &openapi3.Operation{
+		Parameters: openapi3.Parameters{param("limit", "10"), param("api-version", "2020-01-01")},
+	}})
+
+	jobs := buildJobs(doc, "http://localhost", RunOptions{
+		GlobalQuery: map[string]string{"api-version": "2024-01-01", "tenant": "acme"},
+	})
+	if len(jobs) != 1 {
+		t.Fatalf("Expected 1 job, got %d", len(jobs))
+	}
+
+	// Generated params stay; the global value wins on a collision
+	u, err := url.Parse(jobs[0].Endpoint)
+	if err != nil {
+		t.Fatalf("Invalid endpoint %q: %v", jobs[0].Endpoint, err)
+	}
+	query := u.Query()
+	if query.Get("limit") != "10" || query.Get("tenant") != "acme" || query["api-version"][0] != "2024-01-01" || len(query["api-version"]) != 1 {
+		t.Errorf("Expected generated and global query params, got %q", jobs[0].Endpoint)
+	}
+
+	if got := ApplyGlobalQuery("http://localhost/users?limit=10", nil); got != "http://localhost/users?limit=10" {
+		t.Errorf("Expected no global query to leave the URL unchanged, got %q", got)
+	}
+}
+
+// TestGenerateRequestBody_MultipartEncoding tests per-property encoding content types
+func TestGenerateRequestBody_MultipartEncoding(t *testing.T) {
+	metadata := openapi3.NewObjectSchema()
+	metadata.Properties = openapi3.Schemas{
+		"title": &openapi3.SchemaRef{Value: openapi3.NewStringSchema()},
+	}
+	file := openapi3.NewStringSchema()
+	file.Format = "binary"
+
+	schema := openapi3.NewObjectSchema()
+	schema.Properties = openapi3.Schemas{
+		"metadata": &openapi3.SchemaRef{Value: metadata},
+		"file":     &openapi3.SchemaRef{Value: file},
+		"note":     &openapi3.SchemaRef{Value: openapi3.NewStringSchema()},
+	}
+
+	operation := &openapi3.Operation{
+		RequestBody: &openapi3.RequestBodyRef{
+			Value: &openapi3.RequestBody{
+				Content: openapi3.Content{
+					MediaTypeMultipart: &openapi3.MediaType{
+						Schema: &openapi3.SchemaRef{Value: schema},
+						Encoding: map[string]*openapi3.Encoding{
+							"metadata": {ContentType: "application/vnd.api+json"},
+							"file":     {ContentType: "image/png, image/jpeg"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	body, mediaType, err := generateRequestBodyWithType(operation, sampleOptions{})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	parsed, params, err := mime.ParseMediaType(mediaType)
+	if err != nil || parsed != MediaTypeMultipart || params["boundary"] == "" {
+		t.Fatalf("Expected multipart media type with boundary, got: %s", mediaType)
+	}
+
+	reader := multipart.NewReader(bytes.NewReader(body), params["boundary"])
+	contentTypes := map[string]string{}
+	values := map[string]string{}
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Failed to read part: %v", err)
+		}
+		data, _ := io.ReadAll(part)
+		contentTypes[part.FormName()] = part.Header.Get("Content-Type")
+		values[part.FormName()] = string(data)
+	}
+
+	expected := map[string]string{
+		"metadata": "application/vnd.api+json",
+		"file":     "image/png",
+		"note":     "text/plain",
+	}
+	for name, want := range expected {
+		if contentTypes[name] != want {
+			t.Errorf("Expected %s part content type %s, got: %s", name, want, contentTypes[name])
+		}
+	}
+
+	var meta map[string]interface{}
+	if err := json.Unmarshal([]byte(values["metadata"]), &meta); err != nil {
+		t.Errorf("Expected JSON metadata part, got: %s", values["metadata"])
+	}
+}
+
 // TestGenerateSampleFromSchema tests schema-based sample generation
 func TestGenerateSampleFromSchema(t *testing.T) {
 	t.Run("Nil schema", func(t *testing.T) {
@@ -466,6 +1109,16 @@ func TestApplyAuth(t *testing.T) {
 		}
 	})
 
+	t.Run("Bearer token with custom prefix", func(t *testing.T) {
+		for _, prefix := range []string{"Token", "JWT"} {
+			req := httptest.NewRequest("GET", "https://api.example.com/users", nil)
+			ApplyAuth(req, &models.AuthConfig{AuthType: "bearer", Token: "test-token-123", BearerPrefix: prefix})
+			if got, want := req.Header.Get("Authorization"), prefix+" test-token-123"; got != want {
+				t.Errorf("Expected %q, got: %s", want, got)
+			}
+		}
+	})
+
 	t.Run("API key in header", func(t *testing.T) {
 		req := httptest.NewRequest("GET", "https://api.example.com/users", nil)
 		auth := &models.AuthConfig{
@@ -520,6 +1173,58 @@ func TestApplyAuth(t *testing.T) {
 	})
 }
 
+// TestApplyAuth_BasicSpecialCharacters checks the credentials a server decodes
+func TestApplyAuth_BasicSpecialCharacters(t *testing.T) {
+	type credentials struct {
+		username, password string
+		ok                 bool
+	}
+	received := make(chan credentials, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		username, password, ok := r.BasicAuth()
+		received <- credentials{username, password, ok}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		username string
+		password string
+	}{
+		{"Password with colons", "admin", "pa:ss:word"},
+		{"Unicode password", "José", "pässwörd-密码-🔑"},
+		{"Empty username", "", "only-a-password"},
+		{"Spaces kept", "user", " padded secret "},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auth := &models.AuthConfig{AuthType: "basic", Username: tt.username, Password: tt.password}
+			req, _ := http.NewRequest("GET", server.URL, nil)
+			ApplyAuth(req, auth)
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("Request failed: %v", err)
+			}
+			resp.Body.Close()
+
+			got := <-received
+			if !got.ok || got.username != tt.username || got.password != tt.password {
+				t.Errorf("Expected the server to decode %q / %q, got %q / %q (ok=%v)", tt.username, tt.password, got.username, got.password, got.ok)
+			}
+			if applied := AppliedAuth(auth); applied != "basic" {
+				t.Errorf("Expected AppliedAuth to report basic, got %q", applied)
+			}
+		})
+	}
+
+	// Without a username or password nothing is sent
+	req := httptest.NewRequest("GET", "https://api.example.com/users", nil)
+	ApplyAuth(req, &models.AuthConfig{AuthType: "basic"})
+	if req.Header.Get("Authorization") != "" {
+		t.Errorf("Expected no Authorization header, got %q", req.Header.Get("Authorization"))
+	}
+}
+
 // TestTestEndpoint tests endpoint testing with mock server
 func TestTestEndpoint(t *testing.T) {
 	t.Run("Successful GET request", func(t *testing.T) {
@@ -664,6 +1369,141 @@ func TestBuildQueryParams_WithExample(t *testing.T) {
 	}
 }
 
+// TestBuildQueryParams_StyleExplode tests array and object serialization per style and explode
+func TestBuildQueryParams_StyleExplode(t *testing.T) {
+	explode := func(b bool) *bool { return &b }
+	arraySchema := openapi3.NewArraySchema().WithItems(openapi3.NewIntegerSchema())
+	objectSchema := openapi3.NewObjectSchema().
+		WithProperty("role", openapi3.NewStringSchema()).
+		WithProperty("limit", openapi3.NewIntegerSchema())
+
+	tests := []struct {
+		name    string
+		style   string
+		explode *bool
+		schema  *openapi3.Schema
+		example interface{}
+		want    string
+	}{
+		{name: "form array exploded by default", schema: arraySchema, want: "?ids=1&ids=2&ids=3"},
+		{name: "form array not exploded", explode: explode(false), schema: arraySchema, want: "?ids=1,2,3"},
+		{name: "pipe-delimited array", style: "pipeDelimited", explode: explode(false), schema: arraySchema, want: "?ids=1|2|3"},
+		{name: "space-delimited array", style: "spaceDelimited", explode: explode(false), schema: arraySchema, want: "?ids=1%202%203"},
+		{name: "array example exploded", schema: arraySchema, example: []interface{}{"a", "b"}, want: "?ids=a&ids=b"},
+		{name: "form object exploded", schema: objectSchema, want: "?limit=1&role=sample"},
+		{name: "form object not exploded", explode: explode(false), schema: objectSchema, want: "?ids=limit,1,role,sample"},
+		{name: "deepObject", style: "deepObject", schema: objectSchema, want: "?ids[limit]=1&ids[role]=sample"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			operation := &openapi3.Operation{
+				Parameters: openapi3.Parameters{
+					&openapi3.ParameterRef{Value: &openapi3.Parameter{
+						Name:    "ids",
+						In:      "query",
+						Style:   tt.style,
+						Explode: tt.explode,
+						Example: tt.example,
+						Schema:  &openapi3.SchemaRef{Value: tt.schema},
+					}},
+				},
+			}
+			if got := BuildQueryParams(operation); got != tt.want {
+				t.Errorf("BuildQueryParams() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestBuildQueryParams_SkipDeprecated tests leaving out deprecated query parameters
+func TestBuildQueryParams_SkipDeprecated(t *testing.T) {
+	operation := &openapi3.Operation{
+		Parameters: openapi3.Parameters{
+			&openapi3.ParameterRef{
+				Value: &openapi3.Parameter{
+					Name:   "page",
+					In:     "query",
+					Schema: &openapi3.SchemaRef{Value: openapi3.NewIntegerSchema()},
+				},
+			},
+			&openapi3.ParameterRef{
+				Value: &openapi3.Parameter{
+					Name:       "offset",
+					In:         "query",
+					Deprecated: true,
+					Schema:     &openapi3.SchemaRef{Value: openapi3.NewIntegerSchema()},
+				},
+			},
+		},
+	}
+
+	if result := BuildQueryParams(operation); result != "?page=1&offset=1" {
+		t.Errorf("Expected deprecated parameter by default, got: %s", result)
+	}
+
+	if result, _ := buildQueryParams(operation, true, ""); result != "?page=1" {
+		t.Errorf("Expected deprecated parameter to be omitted, got: %s", result)
+	}
+}
+
+// TestRunTestsWithOptions_ExclusiveParams tests that only one of a group of
+// mutually exclusive query parameters is sent
+func TestRunTestsWithOptions_ExclusiveParams(t *testing.T) {
+	var mu sync.Mutex
+	queries := map[string]url.Values{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		queries[r.URL.Path] = r.URL.Query()
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	specPath := createTempSpec(t, `
+openapi: 3.0.0
+info:
+  title: Exclusive
+  version: 1.0.0
+paths:
+  /search:
+    get:
+      x-mutually-exclusive: [[id, email], [after, before]]
+      parameters:
+        - {name: id, in: query, schema: {type: integer}}
+        - {name: email, in: query, schema: {type: string}}
+        - {name: after, in: query, schema: {type: string}}
+        - {name: before, in: query, required: true, schema: {type: string}}
+        - {name: limit, in: query, schema: {type: integer}}
+      responses:
+        '200':
+          description: OK
+`)
+
+	check := func(extension string, want, unwanted []string) {
+		t.Helper()
+		if _, err := RunTestsWithOptions(specPath, server.URL, RunOptions{MaxConcurrency: 1, ExclusiveExtension: extension}, nil); err != nil {
+			t.Fatalf("RunTestsWithOptions() error = %v", err)
+		}
+		query := queries["/search"]
+		for _, name := range want {
+			if !query.Has(name) {
+				t.Errorf("Expected %q to be sent, got %v", name, query)
+			}
+		}
+		for _, name := range unwanted {
+			if query.Has(name) {
+				t.Errorf("Expected %q to be left out, got %v", name, query)
+			}
+		}
+	}
+
+	// Off unless the extension is named
+	check("", []string{"id", "email", "after", "before", "limit"}, nil)
+	// The first of a group is kept, unless another member is required
+	check("x-mutually-exclusive", []string{"id", "before", "limit"}, []string{"email", "after"})
+}
+
 // TestGenerateSampleFromSchema_NestedObject tests nested object generation
 func TestGenerateSampleFromSchema_NestedObject(t *testing.T) {
 	addressSchema := openapi3.NewObjectSchema()
@@ -697,6 +1537,209 @@ func TestGenerateSampleFromSchema_NestedObject(t *testing.T) {
 	}
 }
 
+// TestGenerateSampleFromSchema_OpenAPI31 tests multi-type and array-form examples
+func TestGenerateSampleFromSchema_OpenAPI31(t *testing.T) {
+	t.Run("Nullable integer type", func(t *testing.T) {
+		schema := &openapi3.Schema{Type: &openapi3.Types{"integer", "null"}}
+		result := GenerateSampleFromSchema(schema)
+		if result != 1 {
+			t.Errorf("Expected 1, got: %v", result)
+		}
+	})
+
+	t.Run("Null listed first", func(t *testing.T) {
+		schema := &openapi3.Schema{Type: &openapi3.Types{"null", "string"}}
+		result := GenerateSampleFromSchema(schema)
+		if result != "sample" {
+			t.Errorf("Expected 'sample', got: %v", result)
+		}
+	})
+
+	t.Run("Array examples from document", func(t *testing.T) {
+		var schema openapi3.Schema
+		data := []byte(`{"type": ["integer", "null"], "examples": [42, 7]}`)
+		if err := json.Unmarshal(data, &schema); err != nil {
+			t.Fatalf("Failed to unmarshal schema: %v", err)
+		}
+
+		result := GenerateSampleFromSchema(&schema)
+		if result != float64(42) {
+			t.Errorf("Expected first example 42, got: %v", result)
+		}
+	})
+
+	t.Run("Const discriminator property", func(t *testing.T) {
+		var schema openapi3.Schema
+		data := []byte(`{"type": "object", "required": ["petType"], "properties": {
+			"petType": {"type": "string", "const": "cat", "example": "dog"},
+			"name": {"type": "string"}}}`)
+		if err := json.Unmarshal(data, &schema); err != nil {
+			t.Fatalf("Failed to unmarshal schema: %v", err)
+		}
+
+		// The const wins over examples and random values
+		pet := GenerateSampleFromSchema(&schema).(map[string]interface{})
+		if pet["petType"] != "cat" || pet["name"] != "sample" {
+			t.Errorf("Expected petType cat, got: %v", pet)
+		}
+		random := generateSample(&schema, "", sampleOptions{rng: rand.New(rand.NewSource(1))}).(map[string]interface{})
+		if random["petType"] != "cat" {
+			t.Errorf("Expected petType cat with random data, got: %v", random)
+		}
+	})
+
+	t.Run("Nullable query parameter", func(t *testing.T) {
+		operation := &openapi3.Operation{
+			Parameters: openapi3.Parameters{
+				&openapi3.ParameterRef{
+					Value: &openapi3.Parameter{
+						Name: "active",
+						In:   "query",
+						Schema: &openapi3.SchemaRef{
+							Value: &openapi3.Schema{Type: &openapi3.Types{"boolean", "null"}},
+						},
+					},
+				},
+			},
+		}
+
+		result := BuildQueryParams(operation)
+		if result != "?active=true" {
+			t.Errorf("Expected '?active=true', got: %s", result)
+		}
+	})
+}
+
+// TestGenerateSampleFromSchema_Discriminator tests that a discriminated
+// oneOf sends its first variant, named by the discriminator property
+func TestGenerateSampleFromSchema_Discriminator(t *testing.T) {
+	spec := `
+openapi: 3.0.0
+info:
+  title: Pets
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Pet:
+      oneOf:
+        - $ref: '#/components/schemas/Cat'
+        - $ref: '#/components/schemas/Dog'
+      discriminator:
+        propertyName: petType
+        mapping:
+          dog: '#/components/schemas/Dog'
+          kitty: Cat
+    Animal:
+      anyOf:
+        - $ref: '#/components/schemas/Dog'
+      discriminator:
+        propertyName: petType
+    Cat:
+      type: object
+      properties:
+        petType:
+          type: string
+        lives:
+          type: integer
+    Dog:
+      type: object
+      properties:
+        petType:
+          type: string
+        bark:
+          type: boolean
+`
+	doc, err := openapi3.NewLoader().LoadFromData([]byte(spec))
+	if err != nil {
+		t.Fatalf("Failed to load spec: %v", err)
+	}
+
+	pet, ok := GenerateSampleFromSchema(doc.Components.Schemas["Pet"].Value).(map[string]interface{})
+	if !ok || pet["petType"] != "kitty" || pet["lives"] != 1 {
+		t.Errorf("Expected the Cat variant with its mapping key, got: %v", pet)
+	}
+
+	// Without a mapping the schema name is the discriminator value
+	animal, ok := GenerateSampleFromSchema(doc.Components.Schemas["Animal"].Value).(map[string]interface{})
+	if !ok || animal["petType"] != "Dog" || animal["bark"] != true {
+		t.Errorf("Expected the Dog variant named by its schema, got: %v", animal)
+	}
+}
+
+// TestGenerateSampleFromSchema_ClosedAllOf tests that allOf members are
+// merged without extra keys when the composition is closed
+func TestGenerateSampleFromSchema_ClosedAllOf(t *testing.T) {
+	spec := `
+openapi: 3.0.0
+info:
+  title: Users
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Base:
+      type: object
+      properties:
+        id:
+          type: integer
+        name:
+          type: string
+      example:
+        id: 7
+        name: Ada
+        legacy: true
+    Status:
+      type: string
+      enum: [active, disabled]
+    NewUser:
+      allOf:
+        - $ref: '#/components/schemas/Base'
+        - type: object
+          properties:
+            name:
+              type: string
+              example: Grace
+            email:
+              type: string
+              format: email
+            status:
+              allOf:
+                - $ref: '#/components/schemas/Status'
+              description: Account status
+          additionalProperties: false
+    OpenUser:
+      allOf:
+        - $ref: '#/components/schemas/Base'
+`
+	doc, err := openapi3.NewLoader().LoadFromData([]byte(spec))
+	if err != nil {
+		t.Fatalf("Failed to load spec: %v", err)
+	}
+
+	user, ok := GenerateSampleFromSchema(doc.Components.Schemas["NewUser"].Value).(map[string]interface{})
+	if !ok {
+		t.Fatalf("Expected an object, got %v", user)
+	}
+	keys := make([]string, 0, len(user))
+	for key := range user {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	if strings.Join(keys, ",") != "email,id,name,status" {
+		t.Errorf("Expected only declared properties, each once, got %v", user)
+	}
+	if user["id"] != float64(7) || user["name"] != "Ada" || user["status"] != "active" {
+		t.Errorf("Expected the first member to keep its keys and the status wrapper its enum value, got %v", user)
+	}
+
+	// An open composition keeps whatever its members generate
+	open, ok := GenerateSampleFromSchema(doc.Components.Schemas["OpenUser"].Value).(map[string]interface{})
+	if !ok || open["legacy"] != true {
+		t.Errorf("Expected the open composition to keep the example's extra key, got %v", open)
+	}
+}
+
 // TestTestEndpoint_Timeout tests that timeout is enforced
 func TestTestEndpoint_Timeout(t *testing.T) {
 	// Create server that delays longer than timeout
@@ -719,3 +1762,82 @@ func TestTestEndpoint_Timeout(t *testing.T) {
 		t.Errorf("Expected timeout around 10s, took: %v", duration)
 	}
 }
+
+// TestTestEndpoint_FullBodyCapped tests that the full logged body is capped
+// at the default capture limit without splitting a character
+func TestTestEndpoint_FullBodyCapped(t *testing.T) {
+	// Two-byte characters, offset by one byte so the limit falls inside one
+	body := "x" + strings.Repeat("é", models.DefaultCacheBodySize)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	_, resp, log, err := TestEndpoint("GET", server.URL, nil, nil, true)
+	if err != nil {
+		t.Fatalf("TestEndpoint failed: %v", err)
+	}
+	resp.Body.Close()
+	if !log.FullBodyTruncated || len(log.FullResponseBody) != models.DefaultCacheBodySize-1 {
+		t.Errorf("Expected the full body capped below %d bytes, got %d (truncated %v)", models.DefaultCacheBodySize, len(log.FullResponseBody), log.FullBodyTruncated)
+	}
+	if !utf8.ValidString(log.FullResponseBody) {
+		t.Error("Expected the capped body to end on a character boundary")
+	}
+}
+
+func TestTruncateUTF8(t *testing.T) {
+	tests := []struct {
+		s    string
+		n    int
+		want string
+		cut  bool
+	}{
+		{"abc", 5, "abc", false},
+		{"abc", 3, "abc", false},
+		{"abcd", 2, "ab", true},
+		{"aé", 2, "a", true}, // é is two bytes
+		{"aé", 3, "aé", false},
+		{"€", 2, "", true}, // € is three bytes
+	}
+	for _, tt := range tests {
+		got, cut := truncateUTF8(tt.s, tt.n)
+		if got != tt.want || cut != tt.cut {
+			t.Errorf("truncateUTF8(%q, %d) = %q, %v, want %q, %v", tt.s, tt.n, got, cut, tt.want, tt.cut)
+		}
+	}
+}
+
+// TestTestEndpoint_TimingBreakdown tests that verbose logs include phase timings
+func TestTestEndpoint_TimingBreakdown(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(5 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	_, resp, log, err := TestEndpoint("GET", server.URL, nil, nil, true)
+	if err != nil {
+		t.Fatalf("TestEndpoint failed: %v", err)
+	}
+	resp.Body.Close()
+
+	if log == nil {
+		t.Fatal("Expected log entry in verbose mode")
+	}
+	if log.TTFB <= 0 {
+		t.Errorf("Expected positive TTFB, got %v", log.TTFB)
+	}
+	if log.TTFB > log.Duration {
+		t.Errorf("Expected TTFB (%v) to be within total duration (%v)", log.TTFB, log.Duration)
+	}
+	for name, d := range map[string]time.Duration{
+		"DNSLookup":    log.DNSLookup,
+		"Connect":      log.Connect,
+		"TLSHandshake": log.TLSHandshake,
+	} {
+		if d < 0 {
+			t.Errorf("Expected non-negative %s, got %v", name, d)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package testing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Traves-Theberge/OpenAPI-Toolkit/openapi-tui/internal/models"
+)
+
+// tokenCommandTimeout limits how long a token command may run
+const tokenCommandTimeout = 30 * time.Second
+
+// withCommandToken returns auth with its token replaced by the output of
+// command, for credentials that expire, e.g. "gcloud auth print-access-token".
+// When jsonPath is set the output is parsed as JSON and the token read from
+// that field; otherwise the trimmed output is the token
+func withCommandToken(auth *models.AuthConfig, command, jsonPath string) (*models.AuthConfig, error) {
+	output, err := runTokenCommand(command)
+	if err != nil {
+		return nil, err
+	}
+	token := strings.TrimSpace(string(output))
+	if jsonPath != "" {
+		if token, err = extractJSONPath(output, jsonPath); err != nil {
+			return nil, fmt.Errorf("token command output: %w", err)
+		}
+	}
+	if token == "" {
+		return nil, fmt.Errorf("token command %q printed no token", command)
+	}
+
+	resolved := models.AuthConfig{AuthType: "bearer"}
+	if auth != nil && auth.AuthType != "" && auth.AuthType != "none" {
+		resolved = *auth
+	}
+	resolved.Token = token
+	return &resolved, nil
+}
+
+// runTokenCommand runs command through the shell and returns its output
+func runTokenCommand(command string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), tokenCommandTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", command)
+	}
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return nil, fmt.Errorf("token command %q failed: %w: %s", command, err, message)
+		}
+		return nil, fmt.Errorf("token command %q failed: %w", command, err)
+	}
+	return output, nil
+}
+
+// extractJSONPath reads the string at a simple JSONPath, such as
+// "$.access_token" or "$.data.tokens[0].value", from a JSON document.
+// The leading "$." is optional. Numbers are returned as written
+func extractJSONPath(data []byte, path string) (string, error) {
+	var value interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		return "", fmt.Errorf("not valid JSON: %w", err)
+	}
+
+	trimmed := strings.TrimPrefix(strings.TrimPrefix(path, "$"), ".")
+	for _, segment := range strings.Split(trimmed, ".") {
+		name, indexes, _ := strings.Cut(segment, "[")
+		if name != "" {
+			object, ok := value.(map[string]interface{})
+			if !ok {
+				return "", fmt.Errorf("%s: %q is not inside an object", path, name)
+			}
+			if value, ok = object[name]; !ok {
+				return "", fmt.Errorf("%s: no field %q", path, name)
+			}
+		}
+		if indexes == "" {
+			continue
+		}
+		for _, index := range strings.Split(strings.TrimSuffix(indexes, "]"), "][") {
+			i, err := strconv.Atoi(index)
+			if err != nil {
+				return "", fmt.Errorf("%s: invalid index %q", path, index)
+			}
+			array, ok := value.([]interface{})
+			if !ok || i < 0 || i >= len(array) {
+				return "", fmt.Errorf("%s: index %d out of range", path, i)
+			}
+			value = array[i]
+		}
+	}
+
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case json.Number:
+		return v.String(), nil
+	}
+	return "", fmt.Errorf("%s is not a string", path)
+}
@@ -0,0 +1,97 @@
+package testing
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/Traves-Theberge/OpenAPI-Toolkit/openapi-tui/internal/models"
+)
+
+func TestExtractJSONPath(t *testing.T) {
+	data := []byte(`{"access_token": "abc", "expires_in": 3600, "data": {"tokens": [{"value": "first"}, {"value": "second"}]}}`)
+	tests := []struct {
+		path    string
+		want    string
+		wantErr bool
+	}{
+		{path: "$.access_token", want: "abc"},
+		{path: "access_token", want: "abc"},
+		{path: "$.expires_in", want: "3600"},
+		{path: "$.data.tokens[1].value", want: "second"},
+		{path: "$.missing", wantErr: true},
+		{path: "$.data.tokens[2].value", wantErr: true},
+		{path: "$.data", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := extractJSONPath(data, tt.path)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("extractJSONPath(%q) = %q, %v; want %q (error %v)", tt.path, got, err, tt.want, tt.wantErr)
+		}
+	}
+
+	if _, err := extractJSONPath([]byte("not json"), "$.token"); err == nil {
+		t.Error("Expected an error for output that isn't JSON")
+	}
+}
+
+func TestRunTestsWithOptions_TokenCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("stub commands use sh")
+	}
+
+	var authorization string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	specPath := createTempSpec(t, `
+openapi: 3.0.0
+info:
+  title: Tokens
+  version: 1.0.0
+paths:
+  /me:
+    get:
+      responses:
+        '200':
+          description: OK
+`)
+
+	// JSON output is parsed for the configured field
+	opts := RunOptions{
+		Auth:          &models.AuthConfig{AuthType: "bearer", Token: "stale"},
+		TokenCommand:  `printf '{"token": "fresh-json", "expires_in": 3600}'`,
+		TokenJSONPath: "$.token",
+	}
+	if _, err := RunTestsWithOptions(specPath, server.URL, opts, nil); err != nil {
+		t.Fatalf("RunTestsWithOptions() error = %v", err)
+	}
+	if authorization != "Bearer fresh-json" {
+		t.Errorf("Expected the token from the JSON output, got %q", authorization)
+	}
+	if opts.Auth.Token != "stale" {
+		t.Errorf("Expected the configured auth to be left unchanged, got %q", opts.Auth.Token)
+	}
+
+	// Without a JSON path the trimmed output is the token
+	if _, err := RunTestsWithOptions(specPath, server.URL, RunOptions{TokenCommand: "echo fresh-raw"}, nil); err != nil {
+		t.Fatalf("RunTestsWithOptions() error = %v", err)
+	}
+	if authorization != "Bearer fresh-raw" {
+		t.Errorf("Expected the raw output as a bearer token, got %q", authorization)
+	}
+
+	// A failing command or a missing field stops the run
+	if _, err := RunTestsWithOptions(specPath, server.URL, RunOptions{TokenCommand: "echo denied >&2; exit 1"}, nil); err == nil || !strings.Contains(err.Error(), "denied") {
+		t.Errorf("Expected the command's error output, got %v", err)
+	}
+	opts.TokenJSONPath = "$.access_token"
+	if _, err := RunTestsWithOptions(specPath, server.URL, opts, nil); err == nil || !strings.Contains(err.Error(), "access_token") {
+		t.Errorf("Expected an error for a missing field, got %v", err)
+	}
+}
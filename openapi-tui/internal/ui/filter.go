@@ -8,20 +8,28 @@ import (
 
 // FilterResults filters test results based on a query string
 // Supports filtering by:
-// - Status code (e.g., "200", "404", "500")
-// - HTTP method (e.g., "GET", "POST")
-// - Endpoint path (partial match, e.g., "users", "/api/")
-// - Special keywords: "pass", "fail", "error", "success"
+//   - Status code (e.g., "200", "404", "500")
+//   - HTTP method (e.g., "GET", "POST")
+//   - Endpoint path (partial match, e.g., "users", "/api/")
+//   - Special keywords: "pass", "fail", "error", "success", "skipped"
+//   - Exact method with a "method:" prefix (e.g., "method:POST"); repeat it,
+//     or separate methods with commas, to show any of several methods
+//
+// A method prefix combines with the rest of the query, so "method:POST fail"
+// shows only failing POST requests.
 func FilterResults(results []models.TestResult, query string) []models.TestResult {
 	if query == "" {
 		return results
 	}
 
-	query = strings.ToLower(strings.TrimSpace(query))
+	methods, query := parseMethodFilter(strings.ToLower(strings.TrimSpace(query)))
 	var filtered []models.TestResult
 
 	for _, result := range results {
-		if matchesFilter(result, query) {
+		if len(methods) > 0 && !methods[strings.ToLower(result.Method)] {
+			continue
+		}
+		if query == "" || matchesFilter(result, query) {
 			filtered = append(filtered, result)
 		}
 	}
@@ -29,16 +37,96 @@ func FilterResults(results []models.TestResult, query string) []models.TestResul
 	return filtered
 }
 
+// methodFilterPrefix marks a filter term naming an exact HTTP method
+const methodFilterPrefix = "method:"
+
+// parseMethodFilter splits the "method:" terms out of a lowercase query,
+// returning the methods they name and the rest of the query
+func parseMethodFilter(query string) (map[string]bool, string) {
+	methods := make(map[string]bool)
+	var rest []string
+	for _, term := range strings.Fields(query) {
+		names, ok := strings.CutPrefix(term, methodFilterPrefix)
+		if !ok {
+			rest = append(rest, term)
+			continue
+		}
+		for _, name := range strings.Split(names, ",") {
+			if name != "" {
+				methods[name] = true
+			}
+		}
+	}
+	return methods, strings.Join(rest, " ")
+}
+
+// VisibleResults returns the results shown in the results table,
+// after the text filter and the failures-only and collapse toggles are
+// applied, ordered by tag when grouped
+func VisibleResults(tm models.TestModel) []models.TestResult {
+	results := filteredResults(tm)
+	if tm.ShowOnlyFailures || tm.CollapsePassing {
+		results = FailuresOnly(results)
+	}
+	if tm.GroupByTag {
+		results = orderByTag(results)
+	}
+	return results
+}
+
+// CollapsedPassing returns how many passing results are folded away by
+// CollapsePassing; 0 when not collapsing or when failures-only hides them
+func CollapsedPassing(tm models.TestModel) int {
+	if !tm.CollapsePassing || tm.ShowOnlyFailures {
+		return 0
+	}
+	results := filteredResults(tm)
+	return len(results) - len(FailuresOnly(results))
+}
+
+// filteredResults returns the results matching the active text filter
+func filteredResults(tm models.TestModel) []models.TestResult {
+	if query := tm.FilterInput.Value(); tm.FilterActive && query != "" {
+		return FilterResults(tm.Results, query)
+	}
+	return tm.Results
+}
+
+// FailuresOnly returns only the failing results (non-2xx status or ERR)
+func FailuresOnly(results []models.TestResult) []models.TestResult {
+	var failures []models.TestResult
+	for _, result := range results {
+		if matchesFilter(result, "fail") {
+			failures = append(failures, result)
+		}
+	}
+	return failures
+}
+
+// FirstFailure returns the index of the first failing result, or -1 when
+// every result passed
+func FirstFailure(results []models.TestResult) int {
+	for i, result := range results {
+		if matchesFilter(result, "fail") {
+			return i
+		}
+	}
+	return -1
+}
+
 // matchesFilter checks if a result matches the filter query
 func matchesFilter(result models.TestResult, query string) bool {
 	// First check if query matches special keywords (full word match only)
 	switch query {
 	case "pass", "passed", "success", "successful":
-		// Match 2xx status codes
-		return len(result.Status) > 0 && result.Status[0] == '2'
+		// Match 2xx status codes (4xx for invalid contract input)
+		return result.Passed()
 	case "fail", "failed", "err":
 		// Match non-2xx status codes or "ERR" status
-		return len(result.Status) > 0 && (result.Status[0] != '2' || result.Status == "ERR")
+		return len(result.Status) > 0 && result.Failed()
+	case "skip", "skipped":
+		// Match requests skipped by a cancelled or timed-out run
+		return result.Skipped()
 	}
 
 	// Then check regular substring matches
@@ -126,6 +126,42 @@ func TestFilterResults(t *testing.T) {
 			expectedCount: 3,
 			description:   "Should trim whitespace",
 		},
+		{
+			name:          "method prefix GET",
+			query:         "method:GET",
+			expectedCount: 3,
+			description:   "Should return only GET requests",
+		},
+		{
+			name:          "method prefix POST",
+			query:         "method:post",
+			expectedCount: 1,
+			description:   "Should match the method exactly, not /posts endpoints",
+		},
+		{
+			name:          "method prefix combined with fail",
+			query:         "method:POST fail",
+			expectedCount: 0,
+			description:   "The only POST passed",
+		},
+		{
+			name:          "method prefix GET combined with fail",
+			query:         "fail method:GET",
+			expectedCount: 1,
+			description:   "Should return only the failing GET",
+		},
+		{
+			name:          "several methods",
+			query:         "method:delete,put",
+			expectedCount: 2,
+			description:   "Should return DELETE and PUT requests",
+		},
+		{
+			name:          "method prefix combined with endpoint",
+			query:         "method:GET method:DELETE users",
+			expectedCount: 2,
+			description:   "Should return GET and DELETE requests to users endpoints",
+		},
 	}
 
 	for _, tt := range tests {
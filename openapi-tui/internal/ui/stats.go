@@ -2,10 +2,24 @@ package ui
 
 import (
 	"fmt"
+	"slices"
+	"sort"
+	"strings"
 	"time"
 
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/Traves-Theberge/OpenAPI-Toolkit/openapi-tui/internal/models"
+	"github.com/Traves-Theberge/OpenAPI-Toolkit/openapi-tui/internal/validation"
+)
+
+// maxUntestedShown caps the expanded untested list so it fits on screen
+const maxUntestedShown = 15
+
+// ResultsBackKeys leave the results screen for the main menu
+var ResultsBackKeys = key.NewBinding(
+	key.WithKeys("enter", "esc", "ctrl+c"),
+	key.WithHelp("Enter/Esc", "return"),
 )
 
 // TestStats holds calculated statistics for test results
@@ -13,14 +27,24 @@ type TestStats struct {
 	Total          int
 	Passed         int
 	Failed         int
+	Skipped        int // Results skipped by a cancelled or timed-out run, neither passed nor failed
 	AverageTime    time.Duration
 	TotalTime      time.Duration
 	FastestTime    time.Duration
 	SlowestTime    time.Duration
 	FastestEndpoint string
 	SlowestEndpoint string
+	DurationUnit    string // Display unit for timings (see models.DurationUnitAuto)
+	Largest         []models.TestResult // Results with the biggest response bodies, largest first
+	RequestSize     models.SizeStats    // Request body sizes (Count 0 when none were captured)
+	ResponseSize    models.SizeStats    // Response body sizes (Count 0 when none were captured)
+	FailureCategories []models.FailureCategory // Failures by cause, most frequent first
+	Durations       []time.Duration     // Every result's duration, in result order, for the sparkline
 }
 
+// maxLargestShown is how many of the biggest responses the summary lists
+const maxLargestShown = 3
+
 // CalculateStats computes statistics from test results
 func CalculateStats(results []models.TestResult) TestStats {
 	stats := TestStats{
@@ -38,15 +62,19 @@ func CalculateStats(results []models.TestResult) TestStats {
 
 	for _, result := range results {
 		// Count passed/failed based on status
-		// Passed: 2xx status codes (200, 201, 204, etc.) or "OK" status
-		if result.Status[0] == '2' || result.Status == "OK" {
+		// Passed: 2xx status codes (200, 201, 204, etc.), a 4xx for invalid
+		// contract input, or "OK" status
+		if result.Passed() || result.Status == "OK" {
 			stats.Passed++
+		} else if result.Skipped() {
+			stats.Skipped++
 		} else {
 			stats.Failed++
 		}
 
 		// Track timing
 		totalDuration += result.Duration
+		stats.Durations = append(stats.Durations, result.Duration)
 
 		// Find fastest
 		if result.Duration < stats.FastestTime && result.Duration > 0 {
@@ -61,6 +89,22 @@ func CalculateStats(results []models.TestResult) TestStats {
 		}
 	}
 
+	// Largest response bodies, for spotting bloated endpoints
+	for _, result := range results {
+		if result.ResponseBytes > 0 {
+			stats.Largest = append(stats.Largest, result)
+		}
+	}
+	sort.SliceStable(stats.Largest, func(i, j int) bool {
+		return stats.Largest[i].ResponseBytes > stats.Largest[j].ResponseBytes
+	})
+	if len(stats.Largest) > maxLargestShown {
+		stats.Largest = stats.Largest[:maxLargestShown]
+	}
+
+	stats.RequestSize, stats.ResponseSize = models.SummarizeSizes(results)
+	stats.FailureCategories = models.SortFailureCategories(models.CategorizeFailures(results))
+
 	stats.TotalTime = totalDuration
 	if stats.Total > 0 {
 		stats.AverageTime = totalDuration / time.Duration(stats.Total)
@@ -105,28 +149,71 @@ func FormatStats(stats TestStats) string {
 			lipgloss.NewStyle().Foreground(successColor).Bold(true).Render(fmt.Sprintf("%d", stats.Passed))),
 		fmt.Sprintf("Failed:       %s",
 			lipgloss.NewStyle().Foreground(errorColor).Bold(true).Render(fmt.Sprintf("%d", stats.Failed))),
+	}
+	if stats.Skipped > 0 {
+		statsLines = append(statsLines, fmt.Sprintf("Skipped:      %s",
+			lipgloss.NewStyle().Foreground(neutralColor).Bold(true).Render(fmt.Sprintf("%d", stats.Skipped))))
+	}
+	statsLines = append(statsLines,
 		fmt.Sprintf("Pass Rate:    %s",
 			lipgloss.NewStyle().Foreground(passRateColor).Bold(true).Render(fmt.Sprintf("%.1f%%", passRate))),
 		"",
 		lipgloss.NewStyle().Foreground(neutralColor).Render("⏱️  Timing:"),
-		fmt.Sprintf("  Total:      %s", formatDuration(stats.TotalTime)),
-		fmt.Sprintf("  Average:    %s", formatDuration(stats.AverageTime)),
+		fmt.Sprintf("  Total:      %s", formatDurationUnit(stats.TotalTime, stats.DurationUnit)),
+		fmt.Sprintf("  Average:    %s", formatDurationUnit(stats.AverageTime, stats.DurationUnit)),
+	)
+
+	// Relative latencies at a glance, squeezed to fit the summary
+	if sparkline := RenderSparkline(compressDurations(stats.Durations, maxSparklineWidth)); sparkline != "" {
+		statsLines = append(statsLines,
+			fmt.Sprintf("  Spread:     %s", lipgloss.NewStyle().Foreground(lipgloss.Color("#4ECDC4")).Render(sparkline)))
 	}
 
 	// Add fastest/slowest if available
 	if stats.FastestTime > 0 {
 		statsLines = append(statsLines,
 			fmt.Sprintf("  Fastest:    %s %s",
-				formatDuration(stats.FastestTime),
+				formatDurationUnit(stats.FastestTime, stats.DurationUnit),
 				lipgloss.NewStyle().Foreground(neutralColor).Render("("+stats.FastestEndpoint+")")))
 	}
 	if stats.SlowestTime > 0 {
 		statsLines = append(statsLines,
 			fmt.Sprintf("  Slowest:    %s %s",
-				formatDuration(stats.SlowestTime),
+				formatDurationUnit(stats.SlowestTime, stats.DurationUnit),
 				lipgloss.NewStyle().Foreground(neutralColor).Render("("+stats.SlowestEndpoint+")")))
 	}
 
+	if len(stats.FailureCategories) > 0 {
+		statsLines = append(statsLines, "", lipgloss.NewStyle().Foreground(neutralColor).Render("🧭 Failures by Category:"))
+		for _, category := range stats.FailureCategories {
+			statsLines = append(statsLines, fmt.Sprintf("  %-20s %s", category.Name,
+				lipgloss.NewStyle().Foreground(errorColor).Render(fmt.Sprintf("%d", category.Count))))
+		}
+	}
+
+	if stats.RequestSize.Count > 0 || stats.ResponseSize.Count > 0 {
+		statsLines = append(statsLines, "", lipgloss.NewStyle().Foreground(neutralColor).Render("📏 Body Sizes:"))
+		for _, size := range []struct {
+			label string
+			stats models.SizeStats
+		}{{"Requests: ", stats.RequestSize}, {"Responses:", stats.ResponseSize}} {
+			if size.stats.Count > 0 {
+				statsLines = append(statsLines, "  "+size.label+" "+formatSizeStats(size.stats))
+			}
+		}
+	}
+
+	if len(stats.Largest) > 0 {
+		statsLines = append(statsLines, "", lipgloss.NewStyle().Foreground(neutralColor).Render("📦 Largest Responses:"))
+		for _, result := range stats.Largest {
+			statsLines = append(statsLines,
+				fmt.Sprintf("  %-10s %s %s",
+					models.FormatBytes(result.ResponseBytes),
+					lipgloss.NewStyle().Foreground(neutralColor).Render(fmt.Sprintf("%d fields (%d top-level)", result.ResponseFields, result.ResponseTopFields)),
+					result.Method+" "+result.Endpoint))
+		}
+	}
+
 	// Join all lines
 	content := lipgloss.JoinVertical(lipgloss.Left, statsLines...)
 
@@ -138,6 +225,211 @@ func FormatStats(stats TestStats) string {
 		Render(content)
 }
 
+// Outcome banner backgrounds
+var (
+	bannerPassColor = lipgloss.Color("#2E8B57")
+	bannerFailColor = lipgloss.Color("#C0392B")
+)
+
+// outcomeBanner returns the banner text and background color for a run:
+// "ALL PASSED" in green, "PASSED WITHIN THRESHOLD" in green when the
+// failures are within threshold, or "N FAILED" in red
+func outcomeBanner(stats TestStats, threshold models.FailureThreshold) (string, lipgloss.Color) {
+	if stats.Failed == 0 {
+		return fmt.Sprintf("✔ ALL PASSED  (%d/%d)", stats.Passed, stats.Total), bannerPassColor
+	}
+	if !threshold.IsSet() {
+		return fmt.Sprintf("✘ %d FAILED  (%d/%d passed)", stats.Failed, stats.Passed, stats.Total), bannerFailColor
+	}
+	if threshold.Allows(stats.Passed, stats.Failed) {
+		return fmt.Sprintf("✔ PASSED WITHIN THRESHOLD  (%d failed, %d/%d passed)", stats.Failed, stats.Passed, stats.Total), bannerPassColor
+	}
+	return fmt.Sprintf("✘ %d FAILED  (%d/%d passed, threshold exceeded)", stats.Failed, stats.Passed, stats.Total), bannerFailColor
+}
+
+// FormatOutcomeBanner renders the overall pass/fail banner shown at the top of the results
+func FormatOutcomeBanner(stats TestStats) string {
+	return FormatOutcomeBannerWithThreshold(stats, models.FailureThreshold{})
+}
+
+// FormatOutcomeBannerWithThreshold renders the pass/fail banner, passing a
+// run whose failures stay within threshold
+func FormatOutcomeBannerWithThreshold(stats TestStats, threshold models.FailureThreshold) string {
+	text, color := outcomeBanner(stats, threshold)
+	banner := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#FAFAFA")).
+		Background(color).
+		Padding(0, 3).
+		Render(text)
+	help := ResultsBackKeys.Help()
+	hint := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#888")).
+		Render(fmt.Sprintf("  %s to %s", help.Key, help.Desc))
+	return banner + hint
+}
+
+// FormatCoverage renders how many spec endpoints a run tested, e.g.
+// "Coverage: 12/40 endpoints tested", and lists the untested ones when expanded
+func FormatCoverage(all []models.EndpointInfo, results []models.TestResult, expanded bool) string {
+	untested := validation.UntestedEndpoints(all, results)
+	tested := len(all) - len(untested)
+
+	color := lipgloss.Color("#4ECDC4")
+	if len(untested) > 0 {
+		color = lipgloss.Color("#F9CA24")
+	}
+	lines := []string{
+		lipgloss.NewStyle().Foreground(color).Bold(true).
+			Render(fmt.Sprintf("📋 Coverage: %d/%d endpoints tested", tested, len(all))),
+	}
+
+	if len(untested) == 0 {
+		return lines[0]
+	}
+
+	hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#888"))
+	if !expanded {
+		lines = append(lines, hintStyle.Render(fmt.Sprintf("   Press 'u' to list %d untested", len(untested))))
+		return lipgloss.JoinVertical(lipgloss.Left, lines...)
+	}
+
+	for i, ep := range untested {
+		if i == maxUntestedShown {
+			lines = append(lines, hintStyle.Render(fmt.Sprintf("   ... and %d more", len(untested)-maxUntestedShown)))
+			break
+		}
+		lines = append(lines, fmt.Sprintf("   %-7s %s", ep.Method, ep.Path))
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
+
+// FormatFlaky renders the endpoints whose outcome flipped across recent runs
+// Returns an empty string when none did
+func FormatFlaky(flaky []string) string {
+	if len(flaky) == 0 {
+		return ""
+	}
+	lines := []string{
+		lipgloss.NewStyle().Foreground(lipgloss.Color("#F9CA24")).Bold(true).
+			Render(fmt.Sprintf("⚠ Flaky: %d endpoints flipped between pass and fail in recent runs", len(flaky))),
+	}
+	for _, key := range flaky {
+		lines = append(lines, "   "+key)
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
+
+// FormatRegressions renders how the last run compares to the baseline:
+// endpoints that passed there and fail now, and ones that slowed down
+// beyond the latency tolerance. Returns an empty string without a baseline
+func FormatRegressions(report *models.RegressionReport) string {
+	if report == nil {
+		return ""
+	}
+	failStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FF6B6B")).Bold(true)
+	if report.Err != nil {
+		return failStyle.Render(fmt.Sprintf("✗ Baseline: %v", report.Err))
+	}
+	if !report.Failed() {
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("#4ECDC4")).Bold(true).
+			Render(fmt.Sprintf("✓ Baseline: no regressions in %d endpoints", report.Compared))
+	}
+
+	lines := []string{
+		failStyle.Render(fmt.Sprintf("✗ Baseline: %d regressed, %d slower in %d endpoints",
+			len(report.Regressions), len(report.Slowdowns), report.Compared)),
+	}
+	for _, r := range report.Regressions {
+		lines = append(lines, fmt.Sprintf("   %s: %s → %s", r.Endpoint, r.BaselineStatus, r.Status))
+	}
+	for _, r := range report.Slowdowns {
+		lines = append(lines, fmt.Sprintf("   %s: %v → %v", r.Endpoint, r.BaselineDuration, r.Duration))
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
+
+// FormatBodyGaps renders the operations whose request body came out empty,
+// with how many of the run's body-bearing operations got one
+// Returns an empty string when every body was generated
+func FormatBodyGaps(report models.BodyGenReport) string {
+	if len(report.Gaps) == 0 {
+		return ""
+	}
+	lines := []string{
+		lipgloss.NewStyle().Foreground(lipgloss.Color("#F9CA24")).Bold(true).
+			Render(fmt.Sprintf("⚠ Request bodies: %d/%d generated, %d sent empty", report.Generated, report.Operations, len(report.Gaps))),
+	}
+	for _, key := range report.Gaps {
+		lines = append(lines, "   "+key)
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
+
+// FormatContentTypeMismatches renders the endpoints that responded with a
+// Content-Type their spec does not declare
+// Returns an empty string when none did
+func FormatContentTypeMismatches(results []models.TestResult) string {
+	mismatches := models.DetectContentTypeMismatches(results)
+	if len(mismatches) == 0 {
+		return ""
+	}
+	lines := []string{
+		lipgloss.NewStyle().Foreground(lipgloss.Color("#F9CA24")).Bold(true).
+			Render(fmt.Sprintf("⚠ Content-Type mismatches: %d endpoints returned undeclared content types", len(mismatches))),
+	}
+	for _, m := range mismatches {
+		lines = append(lines, fmt.Sprintf("   %s → %s (%d/%d responses)",
+			m.Endpoint, strings.Join(m.ContentTypes, ", "), m.Count, m.Total))
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
+
+// sparkBlocks are the sparkline's bar heights, lowest first
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// maxSparklineWidth caps the summary sparkline so it fits beside the timings
+const maxSparklineWidth = 60
+
+// RenderSparkline draws one block character per duration, scaled between the
+// shortest (▁) and longest (█). Equal durations, including a single one, are
+// drawn at mid height. No durations give an empty string
+func RenderSparkline(durations []time.Duration) string {
+	if len(durations) == 0 {
+		return ""
+	}
+	low, high := slices.Min(durations), slices.Max(durations)
+	var b strings.Builder
+	for _, d := range durations {
+		level := len(sparkBlocks) / 2
+		if high > low {
+			level = int(float64(d-low) / float64(high-low) * float64(len(sparkBlocks)-1))
+		}
+		b.WriteRune(sparkBlocks[level])
+	}
+	return b.String()
+}
+
+// compressDurations shrinks durations to at most width values, keeping the
+// longest of each run of neighbours so slow outliers stay visible
+func compressDurations(durations []time.Duration, width int) []time.Duration {
+	if len(durations) <= width {
+		return durations
+	}
+	compressed := make([]time.Duration, width)
+	for i := range compressed {
+		start, end := i*len(durations)/width, (i+1)*len(durations)/width
+		compressed[i] = slices.Max(durations[start:end])
+	}
+	return compressed
+}
+
+// formatSizeStats formats size aggregates as min/avg/max/total
+func formatSizeStats(s models.SizeStats) string {
+	return fmt.Sprintf("min %s | avg %s | max %s | total %s",
+		models.FormatBytes(s.Min), models.FormatBytes(s.Average), models.FormatBytes(s.Max), models.FormatBytes(s.Total))
+}
+
 // formatDuration formats a duration in a human-readable way
 func formatDuration(d time.Duration) string {
 	if d == 0 {
@@ -151,3 +443,8 @@ func formatDuration(d time.Duration) string {
 	}
 	return fmt.Sprintf("%.2fs", d.Seconds())
 }
+
+// formatDurationUnit formats a duration in the configured unit, falling back to formatDuration
+func formatDurationUnit(d time.Duration, unit string) string {
+	return models.FormatDurationUnit(d, unit, formatDuration)
+}
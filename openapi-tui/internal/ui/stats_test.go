@@ -1,9 +1,12 @@
 package ui
 
 import (
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/charmbracelet/lipgloss"
 	"github.com/Traves-Theberge/OpenAPI-Toolkit/openapi-tui/internal/models"
 )
 
@@ -200,3 +203,295 @@ func TestFormatStats(t *testing.T) {
 		t.Errorf("FormatStats() result seems too short: %d characters", len(result))
 	}
 }
+
+func TestFormatDurationUnit(t *testing.T) {
+	duration := 1500 * time.Millisecond
+	tests := []struct {
+		unit string
+		want string
+	}{
+		{unit: "auto", want: "1.50s"},
+		{unit: "", want: "1.50s"},
+		{unit: "ms", want: "1500ms"},
+		{unit: "s", want: "1.500s"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.unit, func(t *testing.T) {
+			got := formatDurationUnit(duration, tt.unit)
+			if got != tt.want {
+				t.Errorf("formatDurationUnit(%q) = %v, want %v", tt.unit, got, tt.want)
+			}
+		})
+	}
+
+	// FormatStats honors the configured unit
+	stats := TestStats{Total: 1, Passed: 1, TotalTime: duration, AverageTime: duration, DurationUnit: "ms"}
+	if output := FormatStats(stats); !strings.Contains(output, "1500ms") {
+		t.Error("Expected FormatStats to render durations in milliseconds")
+	}
+}
+
+func TestFormatCoverage(t *testing.T) {
+	all := []models.EndpointInfo{
+		{Method: "GET", Path: "/users"},
+		{Method: "POST", Path: "/users"},
+		{Method: "GET", Path: "/orders"},
+	}
+	results := []models.TestResult{{Method: "GET", Endpoint: "/users", Status: "200"}}
+
+	collapsed := FormatCoverage(all, results, false)
+	if !strings.Contains(collapsed, "Coverage: 1/3 endpoints tested") {
+		t.Errorf("Expected coverage summary, got: %s", collapsed)
+	}
+	if strings.Contains(collapsed, "/orders") {
+		t.Error("Expected untested list to be hidden when collapsed")
+	}
+
+	expanded := FormatCoverage(all, results, true)
+	if !strings.Contains(expanded, "/orders") || !strings.Contains(expanded, "POST") {
+		t.Errorf("Expected untested endpoints to be listed, got: %s", expanded)
+	}
+}
+
+func TestFormatOutcomeBanner(t *testing.T) {
+	tests := []struct {
+		name      string
+		results   []models.TestResult
+		wantText  string
+		wantColor lipgloss.Color
+	}{
+		{
+			name: "all passed",
+			results: []models.TestResult{
+				{Status: "200"}, {Status: "201"}, {Status: "204"},
+			},
+			wantText:  "ALL PASSED",
+			wantColor: bannerPassColor,
+		},
+		{
+			name: "some failed",
+			results: []models.TestResult{
+				{Status: "200"}, {Status: "404"}, {Status: "500"}, {Status: "ERR"},
+			},
+			wantText:  "3 FAILED",
+			wantColor: bannerFailColor,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stats := CalculateStats(tt.results)
+			text, color := outcomeBanner(stats, models.FailureThreshold{})
+			if !strings.Contains(text, tt.wantText) {
+				t.Errorf("outcomeBanner() text = %q, want it to contain %q", text, tt.wantText)
+			}
+			if color != tt.wantColor {
+				t.Errorf("outcomeBanner() color = %v, want %v", color, tt.wantColor)
+			}
+			if !strings.Contains(FormatOutcomeBanner(stats), tt.wantText) {
+				t.Errorf("FormatOutcomeBanner() missing %q", tt.wantText)
+			}
+		})
+	}
+}
+
+func TestFormatOutcomeBannerWithThreshold(t *testing.T) {
+	results := []models.TestResult{{Status: "200"}, {Status: "200"}, {Status: "200"}, {Status: "500"}}
+	stats := CalculateStats(results)
+
+	text, color := outcomeBanner(stats, models.FailureThreshold{MaxAllowedFailures: 1})
+	if !strings.Contains(text, "PASSED WITHIN THRESHOLD") || color != bannerPassColor {
+		t.Errorf("Expected a pass within the threshold, got %q %v", text, color)
+	}
+	text, color = outcomeBanner(stats, models.FailureThreshold{MinPassRate: 90})
+	if !strings.Contains(text, "1 FAILED") || !strings.Contains(text, "threshold exceeded") || color != bannerFailColor {
+		t.Errorf("Expected the pass rate threshold to be exceeded, got %q %v", text, color)
+	}
+	if !strings.Contains(FormatOutcomeBannerWithThreshold(stats, models.FailureThreshold{MaxAllowedFailures: 1}), "PASSED WITHIN THRESHOLD") {
+		t.Error("FormatOutcomeBannerWithThreshold() missing the threshold pass")
+	}
+}
+
+func TestFormatFlaky(t *testing.T) {
+	if out := FormatFlaky(nil); out != "" {
+		t.Errorf("Expected no output without flaky endpoints, got %q", out)
+	}
+
+	out := FormatFlaky([]string{"GET /orders", "POST /users"})
+	for _, want := range []string{"Flaky: 2 endpoints", "GET /orders", "POST /users"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected flaky report to contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestFormatBodyGaps(t *testing.T) {
+	if out := FormatBodyGaps(models.BodyGenReport{Operations: 2, Generated: 2}); out != "" {
+		t.Errorf("Expected no output when every body was generated, got %q", out)
+	}
+
+	out := FormatBodyGaps(models.BodyGenReport{Operations: 3, Generated: 2, Gaps: []string{"PUT /uploads"}})
+	for _, want := range []string{"Request bodies: 2/3 generated, 1 sent empty", "PUT /uploads"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected body report to contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestFormatContentTypeMismatches(t *testing.T) {
+	if out := FormatContentTypeMismatches([]models.TestResult{{Method: "GET", Endpoint: "/ok", Status: "200"}}); out != "" {
+		t.Errorf("Expected no output without mismatches, got %q", out)
+	}
+
+	out := FormatContentTypeMismatches([]models.TestResult{
+		{Method: "GET", Endpoint: "/users", Status: "200", UndeclaredContentType: "text/plain"},
+	})
+	for _, want := range []string{"Content-Type mismatches: 1 endpoints", "GET /users", "text/plain", "1/1 responses"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected mismatch report to contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestCalculateStats_Largest(t *testing.T) {
+	results := []models.TestResult{
+		{Method: "GET", Endpoint: "/small", Status: "200", ResponseBytes: 10},
+		{Method: "GET", Endpoint: "/empty", Status: "204"},
+		{Method: "GET", Endpoint: "/huge", Status: "200", ResponseBytes: 5 * 1024 * 1024, ResponseFields: 900, ResponseTopFields: 3},
+		{Method: "GET", Endpoint: "/medium", Status: "200", ResponseBytes: 2048},
+		{Method: "GET", Endpoint: "/large", Status: "200", ResponseBytes: 4096},
+	}
+
+	stats := CalculateStats(results)
+	var got []string
+	for _, r := range stats.Largest {
+		got = append(got, r.Endpoint)
+	}
+	if strings.Join(got, ",") != "/huge,/large,/medium" {
+		t.Errorf("Expected the 3 largest responses, biggest first, got %v", got)
+	}
+
+	out := FormatStats(stats)
+	for _, want := range []string{"Largest Responses", "5.0 MB", "900 fields (3 top-level)", "GET /huge", "4.0 KB"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected summary to contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestCalculateStats_Sizes(t *testing.T) {
+	results := []models.TestResult{
+		{Method: "POST", Endpoint: "/users", Status: "201", RequestBytes: 100, ResponseBytes: 300},
+		{Method: "PUT", Endpoint: "/users/1", Status: "200", RequestBytes: 200, ResponseBytes: 1000},
+		{Method: "GET", Endpoint: "/users", Status: "200", ResponseBytes: 2000},
+		{Method: "DELETE", Endpoint: "/users/1", Status: "204"},
+	}
+
+	stats := CalculateStats(results)
+	if want := (models.SizeStats{Count: 2, Min: 100, Max: 200, Average: 150, Total: 300}); stats.RequestSize != want {
+		t.Errorf("Expected request sizes %+v, got %+v", want, stats.RequestSize)
+	}
+	if want := (models.SizeStats{Count: 3, Min: 300, Max: 2000, Average: 1100, Total: 3300}); stats.ResponseSize != want {
+		t.Errorf("Expected response sizes %+v, got %+v", want, stats.ResponseSize)
+	}
+
+	out := FormatStats(stats)
+	for _, want := range []string{"Body Sizes", "Requests:  min 100 B | avg 150 B | max 200 B | total 300 B", "Responses: min 300 B | avg 1.1 KB | max 2.0 KB | total 3.2 KB"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected summary to contain %q, got:\n%s", want, out)
+		}
+	}
+
+	// Without size data the section is left out
+	stats = CalculateStats(results[3:])
+	if stats.RequestSize.Count != 0 || stats.ResponseSize.Count != 0 {
+		t.Errorf("Expected no size data, got %+v %+v", stats.RequestSize, stats.ResponseSize)
+	}
+	if strings.Contains(FormatStats(stats), "Body Sizes") {
+		t.Error("Expected no size section without size data")
+	}
+}
+
+func TestCalculateStats_FailureCategories(t *testing.T) {
+	results := []models.TestResult{
+		{Method: "GET", Endpoint: "/users", Status: "500", Message: "OK"},
+		{Method: "GET", Endpoint: "/orders", Status: "ERR", Message: "Connection Refused: Cannot connect to: http://localhost:1"},
+		{Method: "GET", Endpoint: "/items", Status: "503", Message: "OK"},
+		{Method: "GET", Endpoint: "/health", Status: "200", Message: "OK (validated)"},
+	}
+
+	stats := CalculateStats(results)
+	want := []models.FailureCategory{{Name: "5xx", Count: 2}, {Name: "connection refused", Count: 1}}
+	if !reflect.DeepEqual(stats.FailureCategories, want) {
+		t.Errorf("Expected categories %+v, got %+v", want, stats.FailureCategories)
+	}
+	if out := FormatStats(stats); !strings.Contains(out, "Failures by Category") || !strings.Contains(out, "connection refused") {
+		t.Errorf("Expected the summary to list failure categories, got:\n%s", out)
+	}
+	if strings.Contains(FormatStats(CalculateStats(results[3:])), "Failures by Category") {
+		t.Error("Expected no category section for a passing run")
+	}
+}
+
+func TestCalculateStats_Skipped(t *testing.T) {
+	results := []models.TestResult{
+		{Method: "GET", Endpoint: "/users", Status: "200", Message: "OK"},
+		{Method: "GET", Endpoint: "/orders", Status: "500", Message: "OK"},
+		{Method: "GET", Endpoint: "/items", Status: models.StatusSkipped, Message: "skipped (time budget)"},
+	}
+
+	stats := CalculateStats(results)
+	if stats.Passed != 1 || stats.Failed != 1 || stats.Skipped != 1 {
+		t.Errorf("Expected 1 passed, 1 failed and 1 skipped, got %+v", stats)
+	}
+	if len(stats.FailureCategories) != 1 || stats.FailureCategories[0].Name != "5xx" {
+		t.Errorf("Expected skipped results left out of failure categories, got %+v", stats.FailureCategories)
+	}
+	if out := FormatStats(stats); !strings.Contains(out, "Skipped:") {
+		t.Errorf("Expected the summary to list skipped results, got:\n%s", out)
+	}
+	if got := OutcomeTitle([]models.TestResult{results[0], results[2]}); got != "1 passed, 1 skipped" {
+		t.Errorf("Expected a skipped-aware title, got %q", got)
+	}
+}
+
+func TestRenderSparkline(t *testing.T) {
+	ms := func(values ...int) []time.Duration {
+		durations := make([]time.Duration, len(values))
+		for i, v := range values {
+			durations[i] = time.Duration(v) * time.Millisecond
+		}
+		return durations
+	}
+
+	sparkline := RenderSparkline(ms(10, 50, 100, 30, 80))
+	if got := []rune(sparkline); len(got) != 5 {
+		t.Fatalf("Expected one block per duration, got %q", sparkline)
+	}
+	for _, r := range sparkline {
+		if r < '▁' || r > '█' {
+			t.Errorf("Expected only block characters, got %q in %q", r, sparkline)
+		}
+	}
+	if got := []rune(sparkline); got[0] != '▁' || got[2] != '█' {
+		t.Errorf("Expected the fastest as ▁ and the slowest as █, got %q", sparkline)
+	}
+
+	if got := RenderSparkline(nil); got != "" {
+		t.Errorf("Expected an empty sparkline for no durations, got %q", got)
+	}
+	if got := RenderSparkline(ms(42)); got != "▅" {
+		t.Errorf("Expected a single duration at mid height, got %q", got)
+	}
+	if got := RenderSparkline(ms(7, 7, 7)); got != "▅▅▅" {
+		t.Errorf("Expected equal durations at mid height, got %q", got)
+	}
+
+	// Long runs are squeezed into the summary, keeping slow outliers
+	long := ms(make([]int, 150)...)
+	long[149] = time.Second
+	if got := []rune(RenderSparkline(compressDurations(long, maxSparklineWidth))); len(got) != maxSparklineWidth || got[len(got)-1] != '█' {
+		t.Errorf("Expected %d blocks ending in the outlier, got %q", maxSparklineWidth, string(got))
+	}
+}
@@ -0,0 +1,94 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/Traves-Theberge/OpenAPI-Toolkit/openapi-tui/internal/models"
+)
+
+// untaggedGroup names the group of results whose operation has no tags
+const untaggedGroup = "untagged"
+
+// TagGroup is the results of the operations sharing a tag, with subtotals
+type TagGroup struct {
+	Tag     string
+	Results []models.TestResult
+	Passed  int
+	Failed  int
+}
+
+// resultTag returns the tag a result is grouped under: its operation's
+// first tag, so each result is listed once
+func resultTag(r models.TestResult) string {
+	if len(r.Tags) == 0 || r.Tags[0] == "" {
+		return untaggedGroup
+	}
+	return r.Tags[0]
+}
+
+// GroupResultsByTag groups results under their operation's first tag,
+// sorted by tag with untagged results last. Results keep their order
+// within a group
+func GroupResultsByTag(results []models.TestResult) []TagGroup {
+	index := make(map[string]int)
+	var groups []TagGroup
+	for _, r := range results {
+		tag := resultTag(r)
+		i, ok := index[tag]
+		if !ok {
+			i = len(groups)
+			index[tag] = i
+			groups = append(groups, TagGroup{Tag: tag})
+		}
+		groups[i].Results = append(groups[i].Results, r)
+		if r.Passed() {
+			groups[i].Passed++
+		} else if r.Failed() {
+			groups[i].Failed++
+		}
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		if (groups[i].Tag == untaggedGroup) != (groups[j].Tag == untaggedGroup) {
+			return groups[j].Tag == untaggedGroup
+		}
+		return groups[i].Tag < groups[j].Tag
+	})
+	return groups
+}
+
+// orderByTag returns results ordered by their tag group
+func orderByTag(results []models.TestResult) []models.TestResult {
+	ordered := make([]models.TestResult, 0, len(results))
+	for _, group := range GroupResultsByTag(results) {
+		ordered = append(ordered, group.Results...)
+	}
+	return ordered
+}
+
+// FormatTagGroups renders the per-tag subtotals shown above the results
+// table when results are grouped by tag
+func FormatTagGroups(groups []TagGroup) string {
+	if len(groups) == 0 {
+		return ""
+	}
+	width := 0
+	for _, group := range groups {
+		width = max(width, len(group.Tag))
+	}
+
+	lines := []string{lipgloss.NewStyle().Bold(true).Render("Results by tag:")}
+	for _, group := range groups {
+		line := fmt.Sprintf("  %-*s  %d passed, %d failed", width, group.Tag, group.Passed, group.Failed)
+		color := lipgloss.Color("#4ECDC4")
+		if group.Failed > 0 {
+			color = lipgloss.Color("#FF6B6B")
+		}
+		lines = append(lines, lipgloss.NewStyle().Foreground(color).Render(line))
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,62 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Traves-Theberge/OpenAPI-Toolkit/openapi-tui/internal/models"
+)
+
+func TestGroupResultsByTag(t *testing.T) {
+	results := []models.TestResult{
+		{Method: "GET", Endpoint: "/users", Status: "200", Tags: []string{"users"}},
+		{Method: "GET", Endpoint: "/health", Status: "500"},
+		{Method: "POST", Endpoint: "/orders", Status: "201", Tags: []string{"orders", "users"}},
+		{Method: "POST", Endpoint: "/users", Status: "400", Tags: []string{"users"}},
+		{Method: "DELETE", Endpoint: "/users/1", Status: "ERR", Tags: []string{"users"}},
+		{Method: "GET", Endpoint: "/orders", Status: "200", Tags: []string{"orders"}},
+	}
+
+	groups := GroupResultsByTag(results)
+	if len(groups) != 3 {
+		t.Fatalf("Expected 3 groups, got %+v", groups)
+	}
+	want := []struct {
+		tag            string
+		passed, failed int
+		first          string
+	}{
+		{"orders", 2, 0, "POST /orders"},
+		{"users", 1, 2, "GET /users"},
+		{untaggedGroup, 0, 1, "GET /health"},
+	}
+	for i, w := range want {
+		g := groups[i]
+		if g.Tag != w.tag || g.Passed != w.passed || g.Failed != w.failed || len(g.Results) != w.passed+w.failed {
+			t.Errorf("Group %d: expected %s with %d passed and %d failed, got %s with %d/%d (%d results)",
+				i, w.tag, w.passed, w.failed, g.Tag, g.Passed, g.Failed, len(g.Results))
+		}
+		if first := g.Results[0].Method + " " + g.Results[0].Endpoint; first != w.first {
+			t.Errorf("Group %s: expected results in run order starting with %s, got %s", g.Tag, w.first, first)
+		}
+	}
+
+	view := FormatTagGroups(groups)
+	if !strings.Contains(view, "users     1 passed, 2 failed") || !strings.Contains(view, "untagged  0 passed, 1 failed") {
+		t.Errorf("Expected aligned per-tag subtotals, got:\n%s", view)
+	}
+
+	// Grouping reorders the table and adds a Tag column
+	tm := models.TestModel{Results: results, GroupByTag: true}
+	visible := VisibleResults(tm)
+	if visible[0].Endpoint != "/orders" || visible[len(visible)-1].Endpoint != "/health" {
+		t.Errorf("Expected visible results ordered by tag, got %+v", visible)
+	}
+	columns := ResultColumns(tm)
+	if columns[0].Title != "Tag" {
+		t.Errorf("Expected a leading Tag column, got %+v", columns)
+	}
+	if rows := resultRows(visible, columns, ""); rows[0][0] != "orders" {
+		t.Errorf("Expected the Tag cell to hold the group, got %v", rows[0])
+	}
+}
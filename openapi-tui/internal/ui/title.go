@@ -0,0 +1,42 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/Traves-Theberge/OpenAPI-Toolkit/openapi-tui/internal/models"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ProgressTitle is the terminal window title while a run is in progress,
+// e.g. "Testing 12/48"
+func ProgressTitle(completed, total int) string {
+	return fmt.Sprintf("Testing %d/%d", completed, total)
+}
+
+// OutcomeTitle is the terminal window title once a run is done: how many
+// results failed, "N passed, M skipped" when the run was cut short, or
+// "All N passed"
+func OutcomeTitle(results []models.TestResult) string {
+	stats := CalculateStats(results)
+	if stats.Failed > 0 {
+		return fmt.Sprintf("%d failed", stats.Failed)
+	}
+	if stats.Skipped > 0 {
+		return fmt.Sprintf("%d passed, %d skipped", stats.Passed, stats.Skipped)
+	}
+	return fmt.Sprintf("All %d passed", stats.Total)
+}
+
+// WindowTitle returns a command that sets the terminal window title, which
+// Bubble Tea writes as an OSC escape sequence. Control characters are left
+// out of the title, so an ESC or BEL in it can't end the sequence early
+func WindowTitle(title string) tea.Cmd {
+	return tea.SetWindowTitle(strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, title))
+}
@@ -0,0 +1,26 @@
+package ui
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Traves-Theberge/OpenAPI-Toolkit/openapi-tui/internal/models"
+)
+
+func TestWindowTitle(t *testing.T) {
+	tests := []struct {
+		title string
+		want  string
+	}{
+		{ProgressTitle(12, 48), "Testing 12/48"},
+		{OutcomeTitle([]models.TestResult{{Status: "200"}, {Status: "500"}, {Status: "ERR"}}), "2 failed"},
+		{OutcomeTitle([]models.TestResult{{Status: "200"}, {Status: "204"}}), "All 2 passed"},
+		{"evil\x1b]2;pwned\a title", "evil]2;pwned title"},
+	}
+	for _, tt := range tests {
+		// The command's message carries the title Bubble Tea writes out
+		if got := fmt.Sprint(WindowTitle(tt.title)()); got != tt.want {
+			t.Errorf("WindowTitle(%q) sets %q, want %q", tt.title, got, tt.want)
+		}
+	}
+}
@@ -4,11 +4,25 @@ import (
 "fmt"
 "github.com/charmbracelet/bubbles/spinner"
 "github.com/charmbracelet/bubbles/table"
+"github.com/charmbracelet/bubbles/textarea"
 "github.com/charmbracelet/bubbles/textinput"
 "github.com/charmbracelet/lipgloss"
 "github.com/Traves-Theberge/OpenAPI-Toolkit/openapi-tui/internal/models"
 )
 
+// resultsTableStyles styles the results table, shared with ResultsTableView
+var resultsTableStyles = table.Styles{
+	Header: lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#FAFAFA")).
+		Background(lipgloss.Color("#7D56F4")).
+		Bold(true),
+	Cell: lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#4ECDC4")),
+	Selected: lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#FF6B6B")).
+		Bold(true),
+}
+
 // InitialValidateModel creates and configures the validation model
 func InitialValidateModel() models.ValidateModel {
 ti := textinput.New()
@@ -53,17 +67,7 @@ table.WithFocused(false),
 table.WithHeight(10),
 )
 
-	t.SetStyles(table.Styles{
-		Header: lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FAFAFA")).
-			Background(lipgloss.Color("#7D56F4")).
-			Bold(true),
-		Cell: lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#4ECDC4")),
-		Selected: lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FF6B6B")).
-			Bold(true),
-	})
+	t.SetStyles(resultsTableStyles)
 
 	// Custom request inputs
 	methodTi := textinput.New()
@@ -96,12 +100,18 @@ table.WithHeight(10),
 	filterTi.CharLimit = 100
 	filterTi.Width = 60
 
+	profileTi := textinput.New()
+	profileTi.Placeholder = "Profile name (e.g., staging)"
+	profileTi.CharLimit = 50
+	profileTi.Width = 40
+
 	return models.TestModel{
-		SpecInput:   specTi,
-		UrlInput:    urlTi,
-		Spinner:     s,
-		Table:       t,
-		FilterInput: filterTi,
+		SpecInput:    specTi,
+		UrlInput:     urlTi,
+		Spinner:      s,
+		Table:        t,
+		FilterInput:  filterTi,
+		ProfileInput: profileTi,
 	}
 }
 
@@ -128,10 +138,7 @@ func InitialCustomRequestModel() models.CustomRequestModel {
 	headerValueTi.CharLimit = 256
 	headerValueTi.Width = 60
 
-	bodyTi := textinput.New()
-	bodyTi.Placeholder = `Request body (JSON, e.g., {"key": "value"}) or leave empty`
-	bodyTi.CharLimit = 0 // No limit for body
-	bodyTi.Width = 60
+	bodyTa := NewBodyInput()
 
 	filterTi := textinput.New()
 	filterTi.Placeholder = "Filter results"
@@ -172,7 +179,7 @@ func InitialCustomRequestModel() models.CustomRequestModel {
 		EndpointInput:    endpointTi,
 		HeaderKeyInput:   headerKeyTi,
 		HeaderValueInput: headerValueTi,
-		BodyInput:        bodyTi,
+		BodyInput:        bodyTa,
 		FilterInput:      filterTi,
 		Spinner:          s,
 		Table:            t,
@@ -180,6 +187,69 @@ func InitialCustomRequestModel() models.CustomRequestModel {
 	}
 }
 
+// NewBodyInput creates the custom request's multiline body editor, where
+// Enter starts a new line and pasted JSON keeps its formatting
+func NewBodyInput() textarea.Model {
+	bodyTa := textarea.New()
+	bodyTa.Placeholder = `Request body (JSON, e.g., {"key": "value"}) or leave empty`
+	bodyTa.CharLimit = 0 // No limit for body
+	bodyTa.ShowLineNumbers = false
+	bodyTa.SetWidth(60)
+	bodyTa.SetHeight(8)
+	return bodyTa
+}
+
+// Below these terminal dimensions the bordered, centered layout clips content
+const (
+	MinFramedWidth  = 60
+	MinFramedHeight = 16
+)
+
+// isCompact reports whether the terminal is too small for the framed layout
+// Zero dimensions mean no size has been reported yet, so the frame is kept.
+func isCompact(m models.Model) bool {
+	if m.Width == 0 && m.Height == 0 {
+		return false
+	}
+	return m.Width < MinFramedWidth || m.Height < MinFramedHeight
+}
+
+// renderCompact renders content without border, padding or centering, under
+// a one-line hint, so a tiny terminal shows as much as possible
+func renderCompact(m models.Model, content string) string {
+	hint := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#F9CA24")).
+		Render(fmt.Sprintf("⚠ Terminal too small (%dx%d, need %dx%d)", m.Width, m.Height, MinFramedWidth, MinFramedHeight))
+	return hint + "\n" + content
+}
+
+// renderFramed borders content and centers it in the terminal, falling back to
+// renderCompact when the terminal is too small
+func renderFramed(m models.Model, content string) string {
+	if isCompact(m) {
+		return renderCompact(m, content)
+	}
+
+	// Center the entire content with dynamic sizing and border
+	borderedContent := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("#4ECDC4")).
+		Padding(1, 2).
+		Render(content)
+
+	return lipgloss.Place(
+		m.Width, m.Height,
+		lipgloss.Center, lipgloss.Center,
+		borderedContent,
+		lipgloss.WithWhitespaceChars(" "),
+		lipgloss.WithWhitespaceForeground(lipgloss.Color("#333")),
+	)
+}
+
+// EndpointSelectorHeight is how many endpoints the selector lists at once;
+// only this window of the filtered list is rendered
+const EndpointSelectorHeight = 15
+
 // InitialEndpointSelectorModel creates and configures the endpoint selector model
 func InitialEndpointSelectorModel() models.EndpointSelectorModel {
 	searchTi := textinput.New()
@@ -314,6 +384,13 @@ func InitialConfigEditorModel(cfg models.Config) models.ConfigEditorModel {
 		retryDelayTi.SetValue(fmt.Sprintf("%d", cfg.RetryDelay))
 	}
 
+	// API Version Header input
+	versionHeaderTi := textinput.New()
+	versionHeaderTi.Placeholder = "Accept: application/vnd.company.v2+json"
+	versionHeaderTi.CharLimit = 200
+	versionHeaderTi.Width = 50
+	versionHeaderTi.SetValue(cfg.APIVersionHeader.String())
+
 	// Focus first field
 	specPathTi.Focus()
 
@@ -331,6 +408,7 @@ func InitialConfigEditorModel(cfg models.Config) models.ConfigEditorModel {
 		VerboseInput:    verboseTi,
 		MaxRetriesInput: maxRetriesTi,
 		RetryDelayInput: retryDelayTi,
+		VersionHeaderInput: versionHeaderTi,
 		OriginalConfig:  cfg,
 	}
 }
\ No newline at end of file
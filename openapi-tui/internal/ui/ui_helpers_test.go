@@ -1,6 +1,7 @@
 package ui
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -123,3 +124,32 @@ func TestFormatStats_AllBranches(t *testing.T) {
 		})
 	}
 }
+
+func TestRenderFramed_Compact(t *testing.T) {
+	tests := []struct {
+		name          string
+		width, height int
+		compact       bool
+	}{
+		{"unsized", 0, 0, false},
+		{"roomy", 100, 40, false},
+		{"narrow", 40, 40, true},
+		{"short", 100, 10, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := models.Model{Width: tt.width, Height: tt.height}
+			if got := isCompact(m); got != tt.compact {
+				t.Fatalf("isCompact() = %v, want %v", got, tt.compact)
+			}
+
+			output := ViewMenu(m)
+			hasHint := strings.Contains(output, "Terminal too small")
+			hasBorder := strings.Contains(output, "╭")
+			if hasHint != tt.compact || hasBorder == tt.compact {
+				t.Errorf("Expected compact=%v layout, got hint=%v border=%v", tt.compact, hasHint, hasBorder)
+			}
+		})
+	}
+}
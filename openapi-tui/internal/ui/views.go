@@ -1,7 +1,10 @@
 package ui
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -10,8 +13,24 @@ import (
 	
 	"github.com/Traves-Theberge/OpenAPI-Toolkit/openapi-tui/internal/errors"
 	"github.com/Traves-Theberge/OpenAPI-Toolkit/openapi-tui/internal/models"
+	"github.com/Traves-Theberge/OpenAPI-Toolkit/openapi-tui/internal/validation"
 )
 
+// menuLabels are the main menu entries shown for each menu item
+var menuLabels = map[string]string{
+	models.MenuValidate:      "📋 Validate OpenAPI Spec",
+	models.MenuTestAll:       "🧪 Test All Endpoints",
+	models.MenuSelectTest:    "🎯 Select & Test Endpoints",
+	models.MenuFavorites:     "⭐ Run Favorites",
+	models.MenuTestChanged:   "🔀 Test Changed Endpoints",
+	models.MenuCustomRequest: "✏️  Custom Request",
+	models.MenuListRequests:  "🔗 List Request URLs",
+	models.MenuHistory:       "📜 History",
+	models.MenuSettings:      "⚙️  Settings",
+	models.MenuHelp:          "❓ Help",
+	models.MenuQuit:          "👋 Quit",
+}
+
 // ViewMenu renders the main menu screen
 func ViewMenu(m models.Model) string {
 	// Title with styling
@@ -25,9 +44,8 @@ func ViewMenu(m models.Model) string {
 
 	// Menu options with styling - highlight selected item
 	var menuItems []string
-	options := []string{"📋 Validate OpenAPI Spec", "🧪 Test All Endpoints", "🎯 Select & Test Endpoints", "✏️  Custom Request", "📜 History", "⚙️  Settings", "❓ Help", "👋 Quit"}
-
-	for i, option := range options {
+	for i, item := range models.MenuItems(m.Config) {
+		option := menuLabels[item]
 		var cursor string
 		var style lipgloss.Style
 
@@ -70,25 +88,22 @@ func ViewMenu(m models.Model) string {
 		BorderTop(true).
 		Padding(0, 1).
 		MarginTop(1).
-		Render("Press h or ? for help • v to toggle verbose • ↑↓/jk to navigate • Enter to select" + verboseStatus)
+		Render("Press h or ? for help • v to toggle verbose • i for config file • ↑↓/jk to navigate • Enter to select" + verboseStatus)
 
 	// Combine sections vertically centered
-	content := lipgloss.JoinVertical(lipgloss.Center, title, menu, statusBar)
+	sections := []string{title, menu}
+	if len(m.Config.Warnings) > 0 {
+		// Config problems found at startup
+		warnings := lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#F9CA24")).
+			MarginTop(1).
+			Render("⚠ " + strings.Join(m.Config.Warnings, "\n⚠ "))
+		sections = append(sections, warnings)
+	}
+	sections = append(sections, statusBar)
+	content := lipgloss.JoinVertical(lipgloss.Center, sections...)
 
-	// Center the entire content with dynamic sizing and border
-	borderedContent := lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("#4ECDC4")).
-		Padding(1, 2).
-		Render(content)
-
-	return lipgloss.Place(
-		m.Width, m.Height,
-		lipgloss.Center, lipgloss.Center,
-		borderedContent,
-		lipgloss.WithWhitespaceChars(" "),
-		lipgloss.WithWhitespaceForeground(lipgloss.Color("#333")),
-	)
+	return renderFramed(m, content)
 }
 
 // ViewHelp renders the help screen with keyboard shortcuts and usage information
@@ -124,20 +139,51 @@ Tips:
 
 	content := lipgloss.JoinVertical(lipgloss.Left, title, "", helpText, footer)
 
-	// Center the entire content with dynamic sizing and border
-	borderedContent := lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("#4ECDC4")).
-		Padding(1, 2).
-		Render(content)
-
-	return lipgloss.Place(
-		m.Width, m.Height,
-		lipgloss.Center, lipgloss.Center,
-		borderedContent,
-		lipgloss.WithWhitespaceChars(" "),
-		lipgloss.WithWhitespaceForeground(lipgloss.Color("#333")),
-	)
+	return renderFramed(m, content)
+}
+
+// ViewConfigInfo renders the config file's location and masked contents
+func ViewConfigInfo(m models.Model) string {
+	info := m.ConfigInfo
+	title := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#7D56F4")).
+		Render("🗂️  Config File")
+
+	labelStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#4ECDC4"))
+	valueStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#FAFAFA"))
+	dimStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#888"))
+
+	content := title + "\n\n" + labelStyle.Render("Path: ") + valueStyle.Render(info.Path) + "\n\n"
+	switch {
+	case info.Err != nil:
+		content += errors.FormatEnhancedError(info.Err)
+	case info.Contents == "":
+		content += dimStyle.Render("No config file yet. It is created when settings are saved.")
+	default:
+		content += labelStyle.Render("Contents (secrets masked):") + "\n" + valueStyle.Render(strings.TrimRight(info.Contents, "\n"))
+	}
+
+	if info.Notice != "" {
+		content += "\n\n" + lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#4ECDC4")).
+			Render(info.Notice)
+	}
+
+	if info.ConfirmReset {
+		content += "\n\n" + lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#F9CA24")).
+			Bold(true).
+			Render("⚠ Reset the config to defaults? The current file is backed up first. 'y' to reset, any other key to cancel")
+		return renderFramed(m, content)
+	}
+
+	content += "\n\n" + dimStyle.Render("'y' copy path | 'R' reset to defaults | Esc or Enter to return to menu")
+	return renderFramed(m, content)
 }
 
 // ViewValidate renders the validation screen
@@ -155,11 +201,40 @@ func ViewValidate(m models.Model) string {
 				Foreground(lipgloss.Color("#4ECDC4")).
 				Bold(true).
 				Render(m.ValidateModel.Result)
+			// Lint warnings don't make the spec invalid, so they follow the success message
+			if len(m.ValidateModel.Warnings) > 0 {
+				lines := []string{fmt.Sprintf("⚠️  %d warning(s):", len(m.ValidateModel.Warnings))}
+				for _, w := range m.ValidateModel.Warnings {
+					lines = append(lines, "  • "+w)
+				}
+				content += "\n\n" + lipgloss.NewStyle().
+					Foreground(lipgloss.Color("#F9CA24")).
+					Render(strings.Join(lines, "\n"))
+			}
+			// Webhooks are sent by the API, not to it, so they are listed but never tested
+			if len(m.ValidateModel.Webhooks) > 0 {
+				lines := []string{fmt.Sprintf("🪝 %d webhook operation(s), validated but not tested:", len(m.ValidateModel.Webhooks))}
+				for _, w := range m.ValidateModel.Webhooks {
+					lines = append(lines, "  • "+w)
+				}
+				content += "\n\n" + lipgloss.NewStyle().
+					Foreground(lipgloss.Color("#888")).
+					Render(strings.Join(lines, "\n"))
+			}
+		}
+		if m.ValidateModel.ExportSuccess != "" {
+			content += "\n\n" + lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#4ECDC4")).
+				Render(m.ValidateModel.ExportSuccess)
 		}
 		// Add exit instruction
+		instructions := "Press Enter or Esc to return to menu"
+		if m.ValidateModel.Err == nil {
+			instructions = "Press 'b' to export generated request bodies | " + instructions
+		}
 		content += "\n\n" + lipgloss.NewStyle().
 			Foreground(lipgloss.Color("#888")).
-			Render("Press Enter or Esc to return to menu")
+			Render(instructions)
 	} else {
 		// Show input field for spec file path
 		input := lipgloss.NewStyle().
@@ -168,31 +243,169 @@ func ViewValidate(m models.Model) string {
 			Padding(1, 2).
 			Render("> " + m.ValidateModel.TextInput.View())
 
-		if m.ValidateModel.Err != nil {
+		if m.ValidateModel.Fetching {
+			content = input + "\n\n" + lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#888")).
+				Render("Downloading the spec... • Esc: cancel")
+		} else if m.ValidateModel.Err != nil {
 			// Show enhanced input error with suggestions
 			content = input + "\n\n" + errors.FormatEnhancedError(m.ValidateModel.Err)
 		} else {
 			// Show input instructions
 			content = input + "\n\n" + lipgloss.NewStyle().
 				Foreground(lipgloss.Color("#888")).
-				Render("Enter path to OpenAPI spec file and press Enter")
+				Render("Enter path to OpenAPI spec file and press Enter • ctrl+p: use a spec from the clipboard")
 		}
 	}
 
-	// Center the entire content with dynamic sizing and border
-	borderedContent := lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("#4ECDC4")).
-		Padding(1, 2).
-		Render(content)
-
-	return lipgloss.Place(
-		m.Width, m.Height,
-		lipgloss.Center, lipgloss.Center,
-		borderedContent,
-		lipgloss.WithWhitespaceChars(" "),
-		lipgloss.WithWhitespaceForeground(lipgloss.Color("#333")),
+	return renderFramed(m, content)
+}
+
+// ResultRows converts test results into results table rows with the
+// default columns
+func ResultRows(results []models.TestResult) []table.Row {
+	return resultRows(results, ResultColumns(models.TestModel{}), "")
+}
+
+// ResultColumns returns the results table columns, with the Duration and
+// Message columns shown or hidden as toggled on the results screen, and a
+// leading Tag column when grouped by tag
+func ResultColumns(tm models.TestModel) []table.Column {
+	var columns []table.Column
+	if tm.GroupByTag {
+		columns = append(columns, table.Column{Title: "Tag", Width: 14})
+	}
+	columns = append(columns,
+		table.Column{Title: "Method", Width: 8},
+		table.Column{Title: "Endpoint", Width: 40},
+		table.Column{Title: "Status", Width: 10},
 	)
+	if tm.ShowDurationColumn {
+		columns = append(columns, table.Column{Title: "Duration", Width: 10})
+	}
+	if !tm.HideMessageColumn {
+		columns = append(columns, table.Column{Title: "Message", Width: 30})
+	}
+	return columns
+}
+
+// resultRows converts test results into table rows for the given columns
+func resultRows(results []models.TestResult, columns []table.Column, durationUnit string) []table.Row {
+	var rows []table.Row
+	for _, r := range results {
+		row := make(table.Row, len(columns))
+		for i, col := range columns {
+			row[i] = resultCell(r, col.Title, durationUnit)
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// resultCell returns a result's value for the results table column titled title
+func resultCell(r models.TestResult, title, durationUnit string) string {
+	switch title {
+	case "Tag":
+		return resultTag(r)
+	case "Method":
+		return r.Method
+	case "Endpoint":
+		return r.LabeledEndpoint()
+	case "Status":
+		return r.Status
+	case "Duration":
+		return formatDurationUnit(r.Duration, durationUnit)
+	case "Message":
+		return r.Message
+	}
+	return ""
+}
+
+// ResultsTableView renders the results table with color-coded status cells
+// bubbles/table can't style single cells, so rows are drawn here using the
+// table's columns, rows, cursor and height; the selected row keeps one highlight
+func ResultsTableView(tbl table.Model, results []models.TestResult) string {
+	columns := tbl.Columns()
+	rows := tbl.Rows()
+
+	header := make([]string, 0, len(columns))
+	for _, col := range columns {
+		header = append(header, resultsTableStyles.Header.Render(fitCell(col.Title, col.Width)))
+	}
+	lines := []string{lipgloss.JoinHorizontal(lipgloss.Top, header...)}
+
+	// Scroll just far enough to keep the cursor in view
+	cursor := tbl.Cursor()
+	start := 0
+	if height := tbl.Height(); height > 0 && cursor >= height {
+		start = cursor - height + 1
+	}
+	end := len(results)
+	if height := tbl.Height(); height > 0 && start+height < end {
+		end = start + height
+	}
+
+	for i := start; i < end; i++ {
+		r := results[i]
+		var values table.Row
+		if i < len(rows) {
+			values = rows[i]
+		}
+
+		cells := make([]string, 0, len(columns))
+		for c, col := range columns {
+			value := ""
+			if c < len(values) {
+				value = values[c]
+			}
+			value = fitCell(value, col.Width)
+			switch {
+			case i == cursor:
+				cells = append(cells, value)
+			case col.Title == "Status":
+				cells = append(cells, resultsTableStyles.Cell.Foreground(statusColor(r.Status)).Render(value))
+			default:
+				cells = append(cells, resultsTableStyles.Cell.Render(value))
+			}
+		}
+
+		row := lipgloss.JoinHorizontal(lipgloss.Top, cells...)
+		if i == cursor {
+			row = resultsTableStyles.Selected.Render(row)
+		}
+		lines = append(lines, row)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// fitCell pads or truncates a value to exactly width terminal cells
+func fitCell(value string, width int) string {
+	if lipgloss.Width(value) > width {
+		runes := []rune(value)
+		for len(runes) > 0 && lipgloss.Width(string(runes))+1 > width {
+			runes = runes[:len(runes)-1]
+		}
+		value = string(runes) + "…"
+	}
+	return lipgloss.NewStyle().Width(width).MaxWidth(width).Inline(true).Render(value)
+}
+
+// statusColor picks a status color: green for 2xx, yellow for 3xx/4xx, red for 5xx/ERR
+func statusColor(status string) lipgloss.Color {
+	switch {
+	case strings.HasPrefix(status, "2"):
+		return lipgloss.Color("10") // Green
+	case strings.HasPrefix(status, "3"), strings.HasPrefix(status, "4"):
+		return lipgloss.Color("11") // Yellow
+	default:
+		return lipgloss.Color("9") // Red
+	}
+}
+
+// statusCell colors a status code with its statusColor
+func statusCell(status string) string {
+	return lipgloss.NewStyle().Foreground(statusColor(status)).Render(status)
 }
 
 // ViewTest renders the testing screen
@@ -208,9 +421,23 @@ func ViewTest(m models.Model) string {
 			Padding(1, 2).
 			Render("> " + m.TestModel.SpecInput.View())
 
-		if m.TestModel.Err != nil {
+		if m.TestModel.FetchingSpec {
+			content = input + "\n\n" + lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#888")).
+				Render("Downloading the spec... • Esc: cancel")
+		} else if m.TestModel.Err != nil {
 			// Show enhanced input error for spec file with suggestions
 			content = input + "\n\n" + errors.FormatEnhancedError(m.TestModel.Err)
+		} else if len(m.TestModel.SpecWarnings) > 0 {
+			// Lint warnings found by validating before the test
+			warnings := []string{fmt.Sprintf("⚠ The spec is valid but has %d warning(s):", len(m.TestModel.SpecWarnings))}
+			for _, w := range m.TestModel.SpecWarnings {
+				warnings = append(warnings, "  "+w)
+			}
+			warnings = append(warnings, "Press Enter again to test anyway, or Esc to go back.")
+			content = input + "\n\n" + lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#F9CA24")).
+				Render(strings.Join(warnings, "\n"))
 		} else {
 			// Show spec file input instructions
 			content = input + "\n\n" + lipgloss.NewStyle().
@@ -227,6 +454,12 @@ func ViewTest(m models.Model) string {
 		if m.TestModel.Err != nil {
 			// Show enhanced input error for base URL with suggestions
 			content = input + "\n\n" + errors.FormatEnhancedError(m.TestModel.Err)
+		} else if m.TestModel.ConfirmRequests > 0 {
+			// The run is larger than confirmLargeRuns
+			content = input + "\n\n" + lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#F9CA24")).
+				Render(fmt.Sprintf("⚠ This will send %s requests to %s.\nPress Enter again to start the run, or Esc to go back.",
+					formatCount(m.TestModel.ConfirmRequests), m.TestModel.UrlInput.Value()))
 		} else {
 			// Show base URL input instructions
 			content = input + "\n\n" + lipgloss.NewStyle().
@@ -236,36 +469,57 @@ func ViewTest(m models.Model) string {
 	case 2: // Testing in progress
 		// Show animated spinner with testing message
 		spinnerView := m.TestModel.Spinner.View() + " Testing API endpoints..."
+		if m.TestModel.FetchingSpec {
+			spinnerView = m.TestModel.Spinner.View() + " Downloading the spec..."
+		}
+		hint := "Press Ctrl+C to cancel"
+		if len(m.TestModel.InFlight) > 0 {
+			hint = "↑/↓ select • 'c' cancel selected request • 's' cancel slowest • Ctrl+C cancel run"
+		}
 		content = lipgloss.NewStyle().
 			Foreground(lipgloss.Color("#4ECDC4")).
 			Bold(true).
-			Render(spinnerView) + "\n\n" + lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#888")).
-			Render("Press Ctrl+C to cancel")
+			Render(spinnerView) + "\n\n" +
+			formatProgress(m.TestModel) +
+			formatInFlight(m.TestModel.InFlight, m.TestModel.InFlightCursor, time.Now()) +
+			lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#888")).
+				Render(hint)
 	case 3: // Results display
 		if m.TestModel.Err != nil {
 			// Show enhanced testing error with actionable suggestions
 			content = errors.FormatEnhancedError(m.TestModel.Err)
+		} else if len(m.TestModel.Results) == 0 {
+			// Spec loaded but there was nothing to run
+			content = errors.FormatEnhancedError(&errors.EnhancedError{
+				Title:       "No endpoints to test",
+				Description: "The spec loaded successfully but contains no operations to test",
+				Suggestions: []string{
+					"Check that the spec defines operations under 'paths'",
+					"Make sure each path has at least one HTTP method (get, post, ...)",
+					"If you tested selected or pinned endpoints, check they exist in this spec",
+				},
+			})
 		} else {
-			// Determine which results to display
-			resultsToShow := m.TestModel.Results
-			filterQuery := m.TestModel.FilterInput.Value()
-			
-			// Apply filter if active and query is not empty
-			if m.TestModel.FilterActive && filterQuery != "" {
-				resultsToShow = FilterResults(m.TestModel.Results, filterQuery)
-			}
+			// Determine which results to display (filter and failures-only applied)
+			resultsToShow := VisibleResults(m.TestModel)
 			
 			// Calculate and display summary statistics
-			stats := CalculateStats(resultsToShow)
+			// Collapsed passing results still count, they are only folded away
+			statsResults := resultsToShow
+			if CollapsedPassing(m.TestModel) > 0 {
+				statsResults = filteredResults(m.TestModel)
+			}
+			stats := CalculateStats(statsResults)
+			stats.DurationUnit = m.Config.DurationUnit
 			statsView := FormatStats(stats)
 
-			// Populate table with results (filtered or all)
-			var rows []table.Row
-			for _, r := range resultsToShow {
-				rows = append(rows, table.Row{r.Method, r.Endpoint, r.Status, r.Message})
-			}
-			m.TestModel.Table.SetRows(rows)
+			// Populate table with results (filtered or all) in the toggled
+			// columns; rows are cleared first so they never outnumber the columns
+			columns := ResultColumns(m.TestModel)
+			m.TestModel.Table.SetRows(nil)
+			m.TestModel.Table.SetColumns(columns)
+			m.TestModel.Table.SetRows(resultRows(resultsToShow, columns, m.Config.DurationUnit))
 
 			// Show filter input if active
 			filterView := ""
@@ -275,18 +529,77 @@ func ViewTest(m models.Model) string {
 					Bold(true)
 				filterView = filterStyle.Render("🔍 Filter: ") + m.TestModel.FilterInput.View() + 
 					"\n" + lipgloss.NewStyle().Foreground(lipgloss.Color("#888")).
-					Render(fmt.Sprintf("(Showing %d of %d results. Keywords: pass, fail, err)", 
+					Render(fmt.Sprintf("(Showing %d of %d results. Keywords: pass, fail, err, method:GET. Enter applies, Esc clears)", 
 						len(resultsToShow), len(m.TestModel.Results))) + "\n\n"
 			}
 
-			// Show success message, filter, stats, and results table
-			content = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("#4ECDC4")).
-				Bold(true).
-				Render("✅ Testing Complete!") + "\n\n" + 
+			// Ask for a name to save the run's inputs under
+			if m.TestModel.ProfilePrompt {
+				filterView += lipgloss.NewStyle().
+					Foreground(lipgloss.Color("#4ECDC4")).
+					Bold(true).
+					Render("💾 Save these settings as profile? ") + m.TestModel.ProfileInput.View() +
+					"\n" + lipgloss.NewStyle().Foreground(lipgloss.Color("#888")).
+					Render("(Enter to save the spec, base URL and auth, Esc to cancel)") + "\n\n"
+			}
+
+			// Fold passing results into one line
+			if collapsed := CollapsedPassing(m.TestModel); collapsed > 0 {
+				filterView += lipgloss.NewStyle().
+					Foreground(lipgloss.Color("#4ECDC4")).
+					Bold(true).
+					Render(fmt.Sprintf("✓ %d passed (collapsed, 'a' to expand)", collapsed)) + "\n\n"
+			}
+
+			// Show failures-only indicator
+			if m.TestModel.ShowOnlyFailures {
+				filterView += lipgloss.NewStyle().
+					Foreground(lipgloss.Color("#FF6B6B")).
+					Bold(true).
+					Render(fmt.Sprintf("⚠ Showing failures only (%d)", len(resultsToShow))) + "\n\n"
+			}
+
+			// Show endpoint coverage for selective runs
+			coverageView := ""
+			if m.TestModel.SpecEndpoints != nil {
+				coverageView = FormatCoverage(m.TestModel.SpecEndpoints, m.TestModel.Results, m.TestModel.ShowUntested) + "\n\n"
+			}
+
+			// Show passed and failed subtotals per tag; collapsed passing
+			// results still count
+			if m.TestModel.GroupByTag {
+				coverageView += FormatTagGroups(GroupResultsByTag(statsResults)) + "\n\n"
+			}
+
+			// Show endpoints whose outcome flipped across recent runs
+			if flakyView := FormatFlaky(m.TestModel.Flaky); flakyView != "" {
+				coverageView += flakyView + "\n\n"
+			}
+
+			// Show endpoints that got worse since the baseline run
+			if regressionView := FormatRegressions(m.TestModel.Regression); regressionView != "" {
+				coverageView += regressionView + "\n\n"
+			}
+
+			// Show operations sent without the request body they declare
+			if gapsView := FormatBodyGaps(m.TestModel.BodyReport); gapsView != "" {
+				coverageView += gapsView + "\n\n"
+			}
+
+			// Show endpoints returning content types the spec does not declare
+			if mismatchView := FormatContentTypeMismatches(m.TestModel.Results); mismatchView != "" {
+				coverageView += mismatchView + "\n\n"
+			}
+
+			// Show outcome banner, filter, stats, and results table
+			// Overall outcome uses every result, regardless of filters
+			banner := FormatOutcomeBannerWithThreshold(CalculateStats(m.TestModel.Results), models.RunThreshold(m.Config))
+
+			content = banner + "\n\n" +
 				filterView +
 				statsView + "\n\n" +
-				m.TestModel.Table.View()
+				coverageView +
+				ResultsTableView(m.TestModel.Table, resultsToShow)
 			
 			// Show export success message if results were exported
 			if m.TestModel.ExportSuccess != "" {
@@ -296,37 +609,189 @@ func ViewTest(m models.Model) string {
 			}
 		}
 		// Add instructions
-		instructions := "Press 'v' toggle verbose | 'f' filter | 'e' JSON | 'h' HTML | 'j' JUnit XML | 'r' history"
+		instructions := "Press 'v' toggle verbose | 'f' filter | 'x' failures only | 'a' expand/collapse passing | 't' group by tag | 'e' JSON | 'h' HTML | 'j' JUnit XML | 'J' JSON lines | 'm' copy as Markdown | 'g' coverage | 'r' history | 'o' spec definition | 'M'/'D' message/duration column | 'F' first failure | 'P' save profile"
 		if m.VerboseMode {
-			instructions += " | 'l' logs"
+			instructions += " | 'l' logs | 'p' repro"
+		} else {
+			instructions += " | 'V' re-run verbose"
 		}
-		instructions += " | Enter to return"
+		if m.Config.CacheResponses {
+			instructions += " | 'c' last response"
+		}
+		if m.TestModel.SpecEndpoints != nil {
+			instructions += " | 'u' untested"
+		}
+		if m.TestModel.LastExportPath != "" {
+			instructions += " | 'y' copy export path"
+		}
+		instructions += fmt.Sprintf(" | %s to %s", ResultsBackKeys.Help().Key, ResultsBackKeys.Help().Desc)
 		content += "\n\n" + lipgloss.NewStyle().
 			Foreground(lipgloss.Color("#888")).
 			Render(instructions)
 	case 4: // Log detail view
-		if m.TestModel.SelectedLog >= 0 && m.TestModel.SelectedLog < len(m.TestModel.Results) {
-			result := m.TestModel.Results[m.TestModel.SelectedLog]
+		visible := VisibleResults(m.TestModel)
+		if m.TestModel.SelectedLog >= 0 && m.TestModel.SelectedLog < len(visible) {
+			result := visible[m.TestModel.SelectedLog]
 			if result.LogEntry != nil {
 				content = ViewLogDetail(m, result, result.LogEntry)
 			}
 		}
+	case 5: // Cached response view
+		visible := VisibleResults(m.TestModel)
+		if m.TestModel.SelectedLog >= 0 && m.TestModel.SelectedLog < len(visible) {
+			content = ViewCachedResponse(m, visible[m.TestModel.SelectedLog])
+		}
+	case 6: // Resolved request list
+		content = ViewRequestList(m)
+	case 7: // Operation definition view
+		visible := VisibleResults(m.TestModel)
+		if m.TestModel.SelectedLog >= 0 && m.TestModel.SelectedLog < len(visible) {
+			content = ViewOperationDetail(visible[m.TestModel.SelectedLog], m.TestModel.OperationDetail)
+		}
 	}
 
-	// Center the entire content with dynamic sizing and border
-	borderedContent := lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("#4ECDC4")).
-		Padding(1, 2).
-		Render(content)
-
-	return lipgloss.Place(
-		m.Width, m.Height,
-		lipgloss.Center, lipgloss.Center,
-		borderedContent,
-		lipgloss.WithWhitespaceChars(" "),
-		lipgloss.WithWhitespaceForeground(lipgloss.Color("#333")),
-	)
+	return renderFramed(m, content)
+}
+
+// formatProgress reports how many requests have finished and are still in
+// flight, and names the most recent one, with its operation summary when the
+// spec has one
+// Returns an empty string until the first request finishes
+func formatProgress(tm models.TestModel) string {
+	if tm.Total == 0 {
+		return ""
+	}
+	line := fmt.Sprintf("%d/%d done", tm.Completed, tm.Total)
+	if tm.Active > 0 {
+		line += fmt.Sprintf(" • %d in flight", tm.Active)
+	}
+	if tm.LatestEndpoint != "" {
+		line += " • last: " + tm.LatestEndpoint
+		if tm.LatestSummary != "" {
+			line += " — " + tm.LatestSummary
+		}
+	}
+	return lipgloss.NewStyle().Foreground(lipgloss.Color("#FAFAFA")).Render(line) + "\n\n"
+}
+
+// formatCount writes n with thousands separators, e.g. 1,240
+func formatCount(n int) string {
+	digits := strconv.Itoa(n)
+	for i := len(digits) - 3; i > 0; i -= 3 {
+		digits = digits[:i] + "," + digits[i:]
+	}
+	return digits
+}
+
+// MaxInFlightShown caps the in-flight requests listed in the progress view
+const MaxInFlightShown = 8
+
+// formatInFlight lists the in-flight requests, slowest first, with how long
+// each has been waiting; the selected one is marked
+// Returns an empty string when nothing is in flight
+func formatInFlight(requests []models.InFlightRequest, cursor int, now time.Time) string {
+	if len(requests) == 0 {
+		return ""
+	}
+	lines := []string{fmt.Sprintf("In flight (%d):", len(requests))}
+	for i, r := range requests {
+		if i == MaxInFlightShown {
+			lines = append(lines, fmt.Sprintf("   ... and %d more", len(requests)-MaxInFlightShown))
+			break
+		}
+		line := fmt.Sprintf("%-7s %s  %s", r.Method, r.Endpoint, now.Sub(r.Started).Truncate(100*time.Millisecond))
+		if i == cursor {
+			lines = append(lines, lipgloss.NewStyle().Foreground(lipgloss.Color("#F9CA24")).Render(" ▸ "+line))
+		} else {
+			lines = append(lines, "   "+line)
+		}
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, lines...) + "\n\n"
+}
+
+// ViewRequestList renders the resolved request URLs for the entered spec
+// and base URL
+func ViewRequestList(m models.Model) string {
+	title := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#7D56F4")).
+		Render(fmt.Sprintf("🔗 Request URLs - %d requests", len(m.TestModel.RequestList)))
+
+	body := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#FAFAFA")).
+		Render(strings.Join(m.TestModel.RequestList, "\n"))
+	if len(m.TestModel.RequestList) == 0 {
+		body = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#888")).
+			Render("The spec defines no operations")
+	}
+
+	status := ""
+	if m.TestModel.ExportSuccess != "" {
+		status = "\n\n" + lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#4ECDC4")).
+			Render(m.TestModel.ExportSuccess)
+	}
+
+	footer := "\n\n" + lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#888")).
+		Render("'y' copy list | Esc or Enter to return to menu")
+
+	return title + "\n\n" + body + status + footer
+}
+
+// ViewCachedResponse renders the last cached response for a result's endpoint
+func ViewCachedResponse(m models.Model, result models.TestResult) string {
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#7D56F4"))
+
+	labelStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#4ECDC4"))
+
+	valueStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#FAFAFA"))
+
+	footer := "\n\n" + lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#888")).
+		Render("Press Esc or Enter to return to results")
+
+	title := titleStyle.Render(fmt.Sprintf("💾 Last Response: %s %s", result.Method, result.Endpoint))
+
+	cached, ok := m.ResponseCache.Get(result.Method, result.Endpoint)
+	if !ok {
+		return title + "\n\n" + valueStyle.Render("No cached response for this endpoint") + footer
+	}
+
+	content := labelStyle.Render("Status: ") + valueStyle.Render(cached.Status) + "\n"
+	content += labelStyle.Render("Captured: ") + valueStyle.Render(cached.Timestamp.Format(time.RFC3339)) + "\n"
+	if cached.Body != "" {
+		content += "\n" + labelStyle.Render("Body:") + "\n" + valueStyle.Render(cached.Body)
+		if cached.Truncated {
+			content += "\n" + lipgloss.NewStyle().Foreground(lipgloss.Color("#888")).Render("... (truncated)")
+		}
+	}
+
+	return title + "\n\n" + content + footer
+}
+
+// ViewOperationDetail renders the spec's raw definition of a result's operation
+func ViewOperationDetail(result models.TestResult, definition string) string {
+	title := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#7D56F4")).
+		Render(fmt.Sprintf("📖 Spec Definition: %s %s", result.Method, result.Endpoint))
+
+	body := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#FAFAFA")).
+		Render(strings.TrimRight(definition, "\n"))
+
+	footer := "\n\n" + lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#888")).
+		Render("Press Esc or Enter to return to results")
+
+	return title + "\n\n" + body + footer
 }
 
 // viewLogDetail renders the detailed log view for a test result
@@ -352,7 +817,10 @@ func ViewLogDetail(m models.Model, result models.TestResult, log *models.LogEntr
 	requestSection := labelStyle.Render("🔼 REQUEST") + "\n"
 	requestSection += labelStyle.Render("URL: ") + valueStyle.Render(log.RequestURL) + "\n"
 	requestSection += labelStyle.Render("Timestamp: ") + valueStyle.Render(log.Timestamp.Format(time.RFC3339)) + "\n"
-	requestSection += labelStyle.Render("Duration: ") + valueStyle.Render(log.Duration.String()) + "\n"
+	unit := m.Config.DurationUnit
+	requestSection += labelStyle.Render("Duration: ") + valueStyle.Render(formatDurationUnit(log.Duration, unit)) + "\n"
+	requestSection += labelStyle.Render("Timing: ") + valueStyle.Render(fmt.Sprintf("DNS %s | Connect %s | TLS %s | TTFB %s",
+		formatDurationUnit(log.DNSLookup, unit), formatDurationUnit(log.Connect, unit), formatDurationUnit(log.TLSHandshake, unit), formatDurationUnit(log.TTFB, unit))) + "\n"
 	
 	// Request headers
 	if len(log.RequestHeaders) > 0 {
@@ -362,14 +830,21 @@ func ViewLogDetail(m models.Model, result models.TestResult, log *models.LogEntr
 		}
 	}
 	
+	// Wide terminals show JSON bodies side by side, indented, for comparison
+	sideBySide := m.Width >= minSideBySideWidth && isJSONBody(log.RequestBody) && isJSONBody(log.ResponseBody)
+	requestBody, responseBody := log.RequestBody, log.ResponseBody
+	if sideBySide {
+		requestBody, responseBody = indentJSON(requestBody), indentJSON(responseBody)
+	}
+
 	// Request body
-	if log.RequestBody != "" {
+	if requestBody != "" {
 		requestSection += "\n\n" + headerStyle.Render("Body:")
-		requestSection += "\n" + valueStyle.Render(log.RequestBody)
+		requestSection += "\n" + valueStyle.Render(requestBody)
 	}
 	
 	// Response section
-	responseSection := "\n\n" + labelStyle.Render("🔽 RESPONSE") + "\n"
+	responseSection := labelStyle.Render("🔽 RESPONSE") + "\n"
 	responseSection += labelStyle.Render("Status: ") + valueStyle.Render(result.Status) + " - " + valueStyle.Render(result.Message) + "\n"
 	
 	// Response headers
@@ -381,21 +856,83 @@ func ViewLogDetail(m models.Model, result models.TestResult, log *models.LogEntr
 	}
 	
 	// Response body
-	if log.ResponseBody != "" {
+	if responseBody != "" {
 		responseSection += "\n\n" + headerStyle.Render("Body:")
-		responseSection += "\n" + valueStyle.Render(log.ResponseBody)
+		responseSection += "\n" + valueStyle.Render(responseBody)
+	}
+
+	// Differences from the documented example
+	if log.HasExample {
+		responseSection += "\n\n" + headerStyle.Render("Documented example:")
+		if len(log.ExampleDiff) == 0 {
+			responseSection += "\n  " + valueStyle.Render("✓ matches the response")
+		}
+		for _, d := range log.ExampleDiff {
+			responseSection += "\n  " + valueStyle.Render(d)
+		}
 	}
 	
 	// Footer
-	footer := "\n\n" + lipgloss.NewStyle().
+	footer := "\n\n"
+	if m.TestModel.LogNotice != "" {
+		footer += m.TestModel.LogNotice + "\n"
+	}
+	footer += lipgloss.NewStyle().
 		Foreground(lipgloss.Color("#888")).
-		Render("Press Esc or Enter to return to results")
+		Render("Press y to copy the response body | Esc or Enter to return to results")
 	
-	return title + "\n\n" + requestSection + responseSection + footer
+	if sideBySide {
+		paneWidth := (m.Width - 4) / 2
+		pane := lipgloss.NewStyle().Width(paneWidth)
+		sections := lipgloss.JoinHorizontal(lipgloss.Top,
+			pane.Render(requestSection), "    ", pane.Render(responseSection))
+		return title + "\n\n" + sections + footer
+	}
+	return title + "\n\n" + requestSection + "\n\n" + responseSection + footer
+}
+
+// minSideBySideWidth is the narrowest terminal on which the log detail view
+// puts the request and response side by side
+const minSideBySideWidth = 140
+
+// isJSONBody reports whether a logged body is a JSON object or array
+// Truncated bodies are not valid JSON, so they are shown stacked
+func isJSONBody(body string) bool {
+	trimmed := strings.TrimSpace(body)
+	return (strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[")) && json.Valid([]byte(trimmed))
+}
+
+// indentJSON indents a JSON body two spaces per level
+func indentJSON(body string) string {
+	var indented bytes.Buffer
+	if json.Indent(&indented, []byte(strings.TrimSpace(body)), "", "  ") != nil {
+		return body
+	}
+	return indented.String()
+}
+
+// ResponseBody returns the most complete response body stored for a result,
+// and whether it was cut short: the log's full body, else the cached
+// response, else the log body as displayed
+func ResponseBody(result models.TestResult) (string, bool) {
+	if log := result.LogEntry; log != nil && log.FullResponseBody != "" {
+		return log.FullResponseBody, log.FullBodyTruncated
+	}
+	if result.Response != nil && result.Response.Body != "" {
+		return result.Response.Body, result.Response.Truncated
+	}
+	if log := result.LogEntry; log != nil {
+		return log.ResponseBody, strings.HasSuffix(log.ResponseBody, "... (truncated)")
+	}
+	return "", false
 }
 
 // ViewHistory renders the test run history screen
 func ViewHistory(m models.Model) string {
+	if m.HistoryComparing {
+		return ViewHistoryCompare(m)
+	}
+
 	titleStyle := lipgloss.NewStyle().
 		Bold(true).
 		Foreground(lipgloss.Color("#FAFAFA")).
@@ -422,6 +959,7 @@ func ViewHistory(m models.Model) string {
 
 	// Create table for history entries
 	columns := []table.Column{
+		{Title: "", Width: 2},
 		{Title: "Date & Time", Width: 20},
 		{Title: "Spec", Width: 25},
 		{Title: "Tests", Width: 12},
@@ -431,7 +969,14 @@ func ViewHistory(m models.Model) string {
 	}
 
 	rows := []table.Row{}
-	for _, entry := range m.History.Entries {
+	for i, entry := range m.History.Entries {
+		mark := ""
+		for _, marked := range m.HistoryMarked {
+			if marked == i {
+				mark = "●"
+			}
+		}
+
 		timestamp := entry.Timestamp.Format("2006-01-02 15:04:05")
 		
 		// Truncate spec path if too long
@@ -445,6 +990,7 @@ func ViewHistory(m models.Model) string {
 		failed := fmt.Sprintf("%d", entry.Failed)
 		
 		rows = append(rows, table.Row{
+			mark,
 			timestamp,
 			spec,
 			tests,
@@ -460,6 +1006,7 @@ func ViewHistory(m models.Model) string {
 		table.WithFocused(true),
 		table.WithHeight(10),
 	)
+	t.SetCursor(m.HistoryIndex)
 
 	s := table.DefaultStyles()
 	s.Header = s.Header.
@@ -478,11 +1025,77 @@ func ViewHistory(m models.Model) string {
 	instructions := lipgloss.NewStyle().
 		Foreground(lipgloss.Color("#888")).
 		MarginTop(1).
-		Render("↑/↓: Navigate | Enter: Replay selected test | Esc: Return to results")
+		Render("↑/↓: Navigate | Enter: Replay selected test | Space: Mark for compare | c: Compare marked (2) | Esc: Return to results")
 
 	return title + "\n\n" + t.View() + "\n\n" + instructions
 }
 
+// ViewHistoryCompare renders the two marked history runs side by side,
+// highlighting endpoints whose status changed between them
+func ViewHistoryCompare(m models.Model) string {
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#FAFAFA")).
+		Background(lipgloss.Color("#7D56F4")).
+		Padding(1, 2).
+		MarginBottom(1)
+
+	title := titleStyle.Render("🔀 Compare Test Runs")
+	footer := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#888")).
+		Render("Esc: Back to history")
+
+	if len(m.HistoryMarked) != 2 {
+		return title + "\n\n" + footer
+	}
+
+	// Entries are stored newest first, so the higher index is the older run
+	olderIdx, newerIdx := m.HistoryMarked[0], m.HistoryMarked[1]
+	if olderIdx < newerIdx {
+		olderIdx, newerIdx = newerIdx, olderIdx
+	}
+	if olderIdx >= len(m.History.Entries) || newerIdx < 0 {
+		return title + "\n\n" + footer
+	}
+	older := m.History.Entries[olderIdx]
+	newer := m.History.Entries[newerIdx]
+
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#7D56F4"))
+	changedStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#F9CA24"))
+	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#888"))
+
+	lines := []string{
+		headerStyle.Render(fmt.Sprintf("  %-8s %-40s %-21s %-21s", "Method", "Endpoint",
+			older.Timestamp.Format("2006-01-02 15:04:05"), newer.Timestamp.Format("2006-01-02 15:04:05"))),
+	}
+
+	comparisons := models.CompareRuns(older, newer)
+	changed := 0
+	for _, c := range comparisons {
+		endpoint := c.Endpoint
+		if len(endpoint) > 40 {
+			endpoint = endpoint[:37] + "..."
+		}
+		row := fmt.Sprintf("%-8s %-40s ", c.Method, endpoint) +
+			statusCell(fmt.Sprintf("%-22s", c.OldStatus)) +
+			statusCell(fmt.Sprintf("%-22s", c.NewStatus))
+		if c.Changed() {
+			changed++
+			row = changedStyle.Render("▶ ") + row + changedStyle.Render("changed")
+		} else {
+			row = "  " + row
+		}
+		lines = append(lines, row)
+	}
+
+	summary := dimStyle.Render(fmt.Sprintf("%d of %d endpoints changed", changed, len(comparisons)))
+	if changed > 0 {
+		summary = changedStyle.Render(fmt.Sprintf("%d of %d endpoints changed", changed, len(comparisons)))
+	}
+
+	return title + "\n\n" + lipgloss.JoinVertical(lipgloss.Left, lines...) + "\n\n" + summary + "\n\n" + footer
+}
+
 // ViewCustomRequest renders the custom request screen
 func ViewCustomRequest(m models.Model) string {
 	title := lipgloss.NewStyle().
@@ -555,7 +1168,7 @@ func ViewCustomRequest(m models.Model) string {
 		
 		hint := lipgloss.NewStyle().
 			Foreground(lipgloss.Color("#888")).
-			Render("\nEnter header name, then value. Press Enter on empty key to continue to body.")
+			Render("\nEnter header name, then value. Press Enter on empty key to continue to body.\nSet Content-Type (e.g. text/xml) to send a non-JSON body.")
 		
 		content = fmt.Sprintf("%s\n%s%s\n\n%s%s", stepTitle, methodInfo, headersList, inputs, hint)
 
@@ -580,10 +1193,21 @@ func ViewCustomRequest(m models.Model) string {
 		input := crm.BodyInput.View()
 		hint := lipgloss.NewStyle().
 			Foreground(lipgloss.Color("#888")).
-			Render("\nEnter JSON body (or leave empty). Press Enter to execute request.")
+			Render("\nEnter body (or leave empty); it must be JSON unless a non-JSON Content-Type is set. Enter starts a new line; press Ctrl+S to execute request.")
 		
 		content = fmt.Sprintf("%s\n%s%s\n\nBody:\n%s%s", stepTitle, methodInfo, headersList, input, hint)
 
+		if len(crm.Warnings) > 0 {
+			warnings := []string{"⚠ Request does not match the spec:"}
+			for _, w := range crm.Warnings {
+				warnings = append(warnings, "  "+w)
+			}
+			warnings = append(warnings, "Press Ctrl+S again to send anyway, or edit the body.")
+			content += "\n\n" + lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#F9CA24")).
+				Render(strings.Join(warnings, "\n"))
+		}
+
 	case 4: // Executing
 		statusMsg := lipgloss.NewStyle().
 			Foreground(lipgloss.Color("#4ECDC4")).
@@ -711,15 +1335,16 @@ func ViewEndpointSelector(m models.Model) string {
 
 	// Endpoint list
 	var listItems []string
-	visibleHeight := 15 // Number of visible items
-	endpoints := esm.FilteredEndpoints
+	endpoints := esm.ShownEndpoints()
 	if len(endpoints) == 0 {
-		endpoints = esm.AllEndpoints
+		listItems = append(listItems, lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#888")).
+			Render("No endpoints match the search"))
 	}
 
 	// Calculate visible range with scrolling
 	start := esm.Offset
-	end := start + visibleHeight
+	end := start + EndpointSelectorHeight
 	if end > len(endpoints) {
 		end = len(endpoints)
 	}
@@ -739,6 +1364,12 @@ func ViewEndpointSelector(m models.Model) string {
 			cursor = "▶ "
 		}
 
+		// Pinned marker
+		pin := " "
+		if validation.IsPinned(m.Config.PinnedEndpoints, ep) {
+			pin = "★"
+		}
+
 		// Method with color
 		var methodStyle lipgloss.Style
 		switch ep.Method {
@@ -781,7 +1412,7 @@ func ViewEndpointSelector(m models.Model) string {
 		}
 
 		// Build line
-		line := fmt.Sprintf("%s%s %s %s%s%s", cursor, checkbox, method, path, summary, tags)
+		line := fmt.Sprintf("%s%s %s %s %s%s%s", cursor, checkbox, pin, method, path, summary, tags)
 		
 		// Highlight selected line
 		if i == esm.Cursor {
@@ -813,7 +1444,19 @@ func ViewEndpointSelector(m models.Model) string {
 	instructions := lipgloss.NewStyle().
 		Foreground(lipgloss.Color("#888")).
 		MarginTop(1).
-		Render("↑/↓: Navigate | Space: Toggle | a: Select All | d: Deselect All | Enter: Test Selected | Esc: Cancel")
+		Render("↑/↓: Navigate | Space: Toggle | a: Select All | d: Deselect All | *: Pin Favorite | Ctrl+Y: Copy Body | Enter: Test Selected | Esc: Cancel")
+	if esm.Notice != "" {
+		instructions = "\n" + lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#4ECDC4")).
+			Render(esm.Notice) + instructions
+	}
+	if m.TestModel.ConfirmRequests > 0 {
+		// The selected run is larger than confirmLargeRuns
+		instructions = "\n" + lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#F9CA24")).
+			Render(fmt.Sprintf("⚠ This will send %s requests to %s.\nPress Enter again to start the run, or any other key to cancel.",
+				formatCount(m.TestModel.ConfirmRequests), m.Config.BaseURL)) + instructions
+	}
 
 	return title + "\n\n" + searchBox + "\n" + countText + filterInfo + "\n\n" + scrollIndicator + list + scrollIndicator + "\n" + instructions
 }
@@ -883,6 +1526,11 @@ func ViewConfigEditor(m models.Model) string {
 		Bold(true).
 		Render("\nPerformance Settings")
 
+	requestHeader := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#7D56F4")).
+		Bold(true).
+		Render("\nRequest Settings")
+
 	// Build form fields
 	fields := []string{
 		generalHeader,
@@ -902,6 +1550,9 @@ func ViewConfigEditor(m models.Model) string {
 		renderField("Max Concurrency", ce.MaxConcurrInput.View(), 9),
 		renderField("Max Retries", ce.MaxRetriesInput.View(), 10),
 		renderField("Retry Delay (ms)", ce.RetryDelayInput.View(), 11),
+		"",
+		requestHeader,
+		renderField("API Version Header", ce.VersionHeaderInput.View(), 12),
 	}
 
 	form := lipgloss.JoinVertical(lipgloss.Left, fields...)
@@ -913,9 +1564,10 @@ func ViewConfigEditor(m models.Model) string {
 		MarginTop(1).
 		Render("Auth Type: none, bearer, apikey, or basic\n" +
 			"API Key Location: header or query\n" +
-			"Max Concurrency: 0 for auto-detect (uses CPU count, capped at 10)\n" +
+			"Max Concurrency: 0 for auto-detect (4 per CPU, capped at 32)\n" +
 			"Max Retries: Number of retry attempts for failed requests (default: 3)\n" +
-			"Retry Delay: Initial delay in milliseconds between retries (default: 1000)")
+			"Retry Delay: Initial delay in milliseconds between retries (default: 1000)\n" +
+			"API Version Header: \"Name: value\" sent with every test request (empty = none)")
 
 	// Instructions
 	instructions := lipgloss.NewStyle().
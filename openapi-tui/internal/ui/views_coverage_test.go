@@ -2,13 +2,19 @@ package ui
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/charmbracelet/bubbles/table"
 	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
 	"github.com/Traves-Theberge/OpenAPI-Toolkit/openapi-tui/internal/models"
+	apitesting "github.com/Traves-Theberge/OpenAPI-Toolkit/openapi-tui/internal/testing"
+	"github.com/Traves-Theberge/OpenAPI-Toolkit/openapi-tui/internal/validation"
 )
 
 func TestViewLogDetail(t *testing.T) {
@@ -25,6 +31,7 @@ func TestViewLogDetail(t *testing.T) {
 		ResponseBody: `{"result": "success"}`,
 		Duration:     100 * time.Millisecond,
 		Timestamp:    time.Now(),
+		TTFB:         42 * time.Millisecond,
 	}
 
 	result := models.TestResult{
@@ -54,6 +61,73 @@ func TestViewLogDetail(t *testing.T) {
 	if !strings.Contains(output, "http://example.com/users") {
 		t.Error("ViewLogDetail should contain request URL")
 	}
+	if !strings.Contains(output, "TTFB 42ms") {
+		t.Error("ViewLogDetail should contain the timing breakdown")
+	}
+}
+
+func TestViewLogDetail_SideBySide(t *testing.T) {
+	log := &models.LogEntry{
+		RequestURL:   "http://example.com/users",
+		RequestBody:  `{"name":"Ada"}`,
+		ResponseBody: `{"id":7,"name":"Ada"}`,
+		Timestamp:    time.Now(),
+	}
+	result := models.TestResult{Method: "POST", Endpoint: "/users", Status: "201", Message: "Created", LogEntry: log}
+
+	// Wide terminals put the request and response on the same lines
+	wide := ViewLogDetail(models.Model{Width: 160, Height: 50}, result, log)
+	sameLine := false
+	for _, line := range strings.Split(wide, "\n") {
+		if strings.Contains(line, "REQUEST") && strings.Contains(line, "RESPONSE") {
+			sameLine = true
+		}
+	}
+	if !sameLine {
+		t.Errorf("Expected the request and response side by side, got:\n%s", wide)
+	}
+	for _, want := range []string{`"name": "Ada"`, `"id": 7`} {
+		if !strings.Contains(wide, want) {
+			t.Errorf("Expected the indented bodies to contain %q, got:\n%s", want, wide)
+		}
+	}
+
+	// Narrow terminals, and bodies that aren't JSON, stay stacked
+	narrow := ViewLogDetail(models.Model{Width: 100, Height: 50}, result, log)
+	if !strings.Contains(narrow, `{"id":7,"name":"Ada"}`) || strings.Index(narrow, "RESPONSE") < strings.Index(narrow, `{"name":"Ada"}`) {
+		t.Errorf("Expected the sections stacked on a narrow terminal, got:\n%s", narrow)
+	}
+	plain := *log
+	plain.ResponseBody = "created"
+	if out := ViewLogDetail(models.Model{Width: 160, Height: 50}, result, &plain); !strings.Contains(out, `{"name":"Ada"}`) {
+		t.Errorf("Expected a plain-text response to keep the stacked layout, got:\n%s", out)
+	}
+}
+
+func TestResponseBody(t *testing.T) {
+	full := strings.Repeat("x", 600)
+	log := &models.LogEntry{
+		ResponseBody:     full[:500] + "... (truncated)",
+		FullResponseBody: full,
+	}
+	if body, truncated := ResponseBody(models.TestResult{LogEntry: log}); body != full || truncated {
+		t.Errorf("Expected the stored full body, got %d bytes (truncated %v)", len(body), truncated)
+	}
+
+	// Without a full body the cached response is next, then the displayed body
+	cached := models.TestResult{
+		LogEntry: &models.LogEntry{ResponseBody: "short"},
+		Response: &models.CachedResponse{Body: `{"id`, Truncated: true},
+	}
+	if body, truncated := ResponseBody(cached); body != `{"id` || !truncated {
+		t.Errorf("Expected the cached body marked truncated, got %q (%v)", body, truncated)
+	}
+	if body, truncated := ResponseBody(models.TestResult{LogEntry: &models.LogEntry{ResponseBody: log.ResponseBody}}); body != log.ResponseBody || !truncated {
+		t.Errorf("Expected the displayed body marked truncated, got %q (%v)", body, truncated)
+	}
+	if body, _ := ResponseBody(models.TestResult{}); body != "" {
+		t.Errorf("Expected no body, got %q", body)
+	}
 }
 
 func TestViewCustomRequest(t *testing.T) {
@@ -65,7 +139,7 @@ func TestViewCustomRequest(t *testing.T) {
 	
 	headerKeyTi := textinput.New()
 	headerValueTi := textinput.New()
-	bodyTi := textinput.New()
+	bodyTi := NewBodyInput()
 	filterTi := textinput.New()
 
 	columns := []table.Column{
@@ -944,6 +1018,84 @@ func TestViewHistoryExtended(t *testing.T) {
 	}
 }
 
+func TestViewHistoryCompare(t *testing.T) {
+	entries := []models.HistoryEntry{
+		{
+			ID:        "newer",
+			Timestamp: time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC),
+			Results: []models.TestResult{
+				{Method: "GET", Endpoint: "/users", Status: "200"},
+				{Method: "DELETE", Endpoint: "/users/{id}", Status: "500"},
+			},
+		},
+		{
+			ID:        "older",
+			Timestamp: time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC),
+			Results: []models.TestResult{
+				{Method: "GET", Endpoint: "/users", Status: "200"},
+				{Method: "DELETE", Endpoint: "/users/{id}", Status: "204"},
+			},
+		},
+	}
+
+	m := models.Model{
+		History:          &models.TestHistory{Entries: entries},
+		HistoryMarked:    []int{0, 1},
+		HistoryComparing: true,
+		Width:            120,
+		Height:           50,
+	}
+
+	output := ViewHistory(m)
+	if !strings.Contains(output, "Compare Test Runs") {
+		t.Fatal("Expected ViewHistory to render the comparison when comparing")
+	}
+
+	// Older run is shown first regardless of mark order
+	if strings.Index(output, "2024-01-01") > strings.Index(output, "2024-01-02") {
+		t.Error("Expected the older run in the first column")
+	}
+
+	var changedLines, unchangedLines int
+	for _, line := range strings.Split(output, "\n") {
+		if strings.Contains(line, "/users/{id}") && strings.Contains(line, "changed") {
+			changedLines++
+		}
+		if strings.Contains(line, "GET") && strings.Contains(line, "/users ") && strings.Contains(line, "changed") {
+			unchangedLines++
+		}
+	}
+	if changedLines != 1 {
+		t.Errorf("Expected DELETE /users/{id} to be highlighted as changed, got:\n%s", output)
+	}
+	if unchangedLines != 0 {
+		t.Error("Expected GET /users not to be highlighted")
+	}
+	if !strings.Contains(output, "1 of 2 endpoints changed") {
+		t.Errorf("Expected change summary, got:\n%s", output)
+	}
+}
+
+func TestViewHistoryCompare_LongStatus(t *testing.T) {
+	// History is loaded from disk, so statuses can exceed the column width
+	longStatus := "ERR: connection refused by remote host"
+	m := models.Model{
+		History: &models.TestHistory{Entries: []models.HistoryEntry{
+			{ID: "newer", Results: []models.TestResult{{Method: "GET", Endpoint: "/users", Status: longStatus}}},
+			{ID: "older", Results: []models.TestResult{{Method: "GET", Endpoint: "/users", Status: "200"}}},
+		}},
+		HistoryMarked:    []int{0, 1},
+		HistoryComparing: true,
+		Width:            200,
+		Height:           50,
+	}
+
+	output := ViewHistoryCompare(m)
+	if !strings.Contains(output, longStatus) {
+		t.Errorf("Expected the full status in the comparison, got:\n%s", output)
+	}
+}
+
 func TestViewMenuExtended(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -989,3 +1141,336 @@ func TestViewMenuExtended(t *testing.T) {
 		})
 	}
 }
+
+func TestViewMenuConfigWarnings(t *testing.T) {
+	m := models.Model{
+		Config: models.Config{Warnings: []string{"Unknown durationUnit \"minutes\", using auto"}},
+		Width:  120,
+		Height: 50,
+	}
+
+	if output := ViewMenu(m); !strings.Contains(output, "Unknown durationUnit") {
+		t.Errorf("Expected config warnings on the menu, got:\n%s", output)
+	}
+}
+
+func TestResultRowsStatusColors(t *testing.T) {
+	// Force ANSI output so colors are rendered without a terminal
+	prev := lipgloss.ColorProfile()
+	lipgloss.SetColorProfile(termenv.ANSI)
+	defer lipgloss.SetColorProfile(prev)
+
+	results := []models.TestResult{
+		{Method: "GET", Endpoint: "/ok", Status: "200"},
+		{Method: "GET", Endpoint: "/moved", Status: "301"},
+		{Method: "GET", Endpoint: "/missing", Status: "404"},
+		{Method: "GET", Endpoint: "/broken", Status: "500"},
+		{Method: "GET", Endpoint: "/down", Status: "ERR"},
+	}
+	want := []string{"\x1b[92m", "\x1b[93m", "\x1b[93m", "\x1b[91m", "\x1b[91m"}
+
+	// Table rows stay plain text; colors are applied when rendering
+	rows := ResultRows(results)
+	if len(rows) != len(results) {
+		t.Fatalf("Expected %d rows, got %d", len(results), len(rows))
+	}
+	for i, row := range rows {
+		if row[2] != results[i].Status {
+			t.Errorf("Expected plain status %q in table row, got %q", results[i].Status, row[2])
+		}
+	}
+
+	tbl := InitialTestModel().Table
+	tbl.SetRows(rows)
+	tbl.SetCursor(1)
+	lines := strings.Split(ResultsTableView(tbl, results), "\n")
+	if len(lines) != len(results)+1 {
+		t.Fatalf("Expected header and %d rows, got %d lines", len(results), len(lines))
+	}
+
+	tableWidth := 0
+	for _, col := range tbl.Columns() {
+		tableWidth += col.Width
+	}
+	for i, line := range lines[1:] {
+		if !strings.Contains(line, results[i].Status) {
+			t.Errorf("Expected row %d to contain status %s", i, results[i].Status)
+		}
+		if lipgloss.Width(line) != tableWidth {
+			t.Errorf("Row %d: expected width %d, got %d", i, tableWidth, lipgloss.Width(line))
+		}
+		if i == tbl.Cursor() {
+			// The highlight covers the whole row without a reset partway through
+			if strings.Contains(line, want[i]) || strings.Count(line, "\x1b[0m") != 1 {
+				t.Errorf("Expected selected row to carry a single highlight, got %q", line)
+			}
+			continue
+		}
+		if !strings.Contains(line, want[i]) {
+			t.Errorf("Status %s: expected color code %q, got %q", results[i].Status, want[i], line)
+		}
+	}
+}
+
+func TestViewTestNoEndpoints(t *testing.T) {
+	// A valid spec with an empty paths object
+	specPath := filepath.Join(t.TempDir(), "spec.yaml")
+	spec := "openapi: 3.0.0\ninfo:\n  title: Empty API\n  version: 1.0.0\npaths: {}\n"
+	if err := os.WriteFile(specPath, []byte(spec), 0644); err != nil {
+		t.Fatalf("Failed to write spec: %v", err)
+	}
+
+	results, err := apitesting.RunTestsParallel(specPath, "http://localhost", nil, false, 1, 0, 100, nil)
+	if err != nil {
+		t.Fatalf("RunTestsParallel failed: %v", err)
+	}
+
+	m := models.Model{
+		TestModel: InitialTestModel(),
+		Width:     120,
+		Height:    50,
+	}
+	m.TestModel.Step = 3
+	m.TestModel.Results = results
+
+	output := ViewTest(m)
+	if !strings.Contains(output, "No endpoints to test") {
+		t.Error("Expected 'No endpoints to test' message for spec without operations")
+	}
+	if strings.Contains(output, "ALL PASSED") {
+		t.Error("Did not expect the outcome banner for spec without operations")
+	}
+}
+
+func TestViewTestOutcomeBanner(t *testing.T) {
+	// Force ANSI output so the banner color is rendered without a terminal
+	prev := lipgloss.ColorProfile()
+	lipgloss.SetColorProfile(termenv.ANSI)
+	defer lipgloss.SetColorProfile(prev)
+
+	tests := []struct {
+		name      string
+		results   []models.TestResult
+		wantText  string
+		wantColor lipgloss.Color
+	}{
+		{
+			name:      "all passed",
+			results:   []models.TestResult{{Method: "GET", Endpoint: "/a", Status: "200"}, {Method: "GET", Endpoint: "/b", Status: "204"}},
+			wantText:  "ALL PASSED",
+			wantColor: bannerPassColor,
+		},
+		{
+			name:      "failures",
+			results:   []models.TestResult{{Method: "GET", Endpoint: "/a", Status: "200"}, {Method: "GET", Endpoint: "/b", Status: "500"}, {Method: "GET", Endpoint: "/c", Status: "ERR"}},
+			wantText:  "2 FAILED",
+			wantColor: bannerFailColor,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := models.Model{
+				TestModel: InitialTestModel(),
+				Width:     160,
+				Height:    60,
+			}
+			m.TestModel.Step = 3
+			m.TestModel.Results = tt.results
+
+			output := ViewTest(m)
+			if !strings.Contains(output, tt.wantText) {
+				t.Errorf("Expected %q banner, got:\n%s", tt.wantText, output)
+			}
+			background := lipgloss.NewStyle().Background(tt.wantColor).Render(" ")
+			if code := background[:strings.Index(background, " ")]; !strings.Contains(output, code) {
+				t.Errorf("Expected banner background %q for %s", code, tt.name)
+			}
+			if !strings.Contains(output, "Enter/Esc to return") {
+				t.Error("Expected the return hint to come from the results key bindings")
+			}
+		})
+	}
+}
+
+func TestViewTestShowOnlyFailures(t *testing.T) {
+	m := models.Model{
+		TestModel: InitialTestModel(),
+		Width:     160,
+		Height:    60,
+	}
+	m.TestModel.Step = 3
+	m.TestModel.Results = []models.TestResult{
+		{Method: "GET", Endpoint: "/healthy", Status: "200", Message: "OK"},
+		{Method: "GET", Endpoint: "/missing", Status: "404", Message: "Not Found"},
+		{Method: "POST", Endpoint: "/broken", Status: "ERR", Message: "connection refused"},
+	}
+
+	output := ViewTest(m)
+	if !strings.Contains(output, "/healthy") {
+		t.Fatal("Expected passing result to be shown when toggle is off")
+	}
+
+	m.TestModel.ShowOnlyFailures = true
+	output = ViewTest(m)
+	if strings.Contains(output, "/healthy") {
+		t.Error("Expected passing result to be hidden when showing only failures")
+	}
+	for _, endpoint := range []string{"/missing", "/broken"} {
+		if !strings.Contains(output, endpoint) {
+			t.Errorf("Expected failure %s to be shown", endpoint)
+		}
+	}
+
+	// Composes with the text filter
+	m.TestModel.FilterActive = true
+	m.TestModel.FilterInput.SetValue("post")
+	output = ViewTest(m)
+	if strings.Contains(output, "/missing") || !strings.Contains(output, "/broken") {
+		t.Error("Expected only failures matching the filter to be shown")
+	}
+}
+
+func TestFormatInFlight(t *testing.T) {
+	if out := formatInFlight(nil, 0, time.Now()); out != "" {
+		t.Errorf("Expected no output with nothing in flight, got %q", out)
+	}
+
+	now := time.Now()
+	out := formatInFlight([]models.InFlightRequest{
+		{ID: 1, Method: "GET", Endpoint: "/slow", Started: now.Add(-3 * time.Second)},
+		{ID: 2, Method: "POST", Endpoint: "/users", Started: now.Add(-500 * time.Millisecond)},
+	}, 1, now)
+	for _, want := range []string{"In flight (2)", "GET     /slow  3s", "▸ POST    /users  500ms"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected in-flight list to contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestFormatProgress(t *testing.T) {
+	if out := formatProgress(models.TestModel{}); out != "" {
+		t.Errorf("Expected no progress line before a request finishes, got %q", out)
+	}
+
+	out := formatProgress(models.TestModel{Completed: 3, Total: 8, LatestEndpoint: "GET /users", LatestSummary: "List users"})
+	if !strings.Contains(out, "3/8 done • last: GET /users — List users") {
+		t.Errorf("Expected the progress line to name the latest endpoint, got %q", out)
+	}
+
+	out = formatProgress(models.TestModel{Completed: 3, Total: 8, Active: 2, LatestEndpoint: "GET /users"})
+	if !strings.Contains(out, "3/8 done • 2 in flight • last: GET /users") {
+		t.Errorf("Expected the progress line to count requests in flight, got %q", out)
+	}
+}
+
+func TestViewOperationDetail(t *testing.T) {
+	specPath := filepath.Join(t.TempDir(), "spec.yaml")
+	spec := `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /users:
+    get:
+      summary: List users
+      parameters:
+        - name: limit
+          in: query
+          schema:
+            type: integer
+      responses:
+        '200':
+          description: A page of users
+`
+	if err := os.WriteFile(specPath, []byte(spec), 0644); err != nil {
+		t.Fatalf("Failed to write spec: %v", err)
+	}
+
+	result := models.TestResult{Method: "GET", Endpoint: "/users", Status: "200"}
+	definition, err := validation.OperationDefinition(specPath, result.Method, result.Endpoint)
+	if err != nil {
+		t.Fatalf("OperationDefinition() error = %v", err)
+	}
+
+	output := ViewOperationDetail(result, definition)
+	for _, want := range []string{"GET /users", "name: limit", "summary: List users", "A page of users", "Esc or Enter"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected operation detail to contain %q, got:\n%s", want, output)
+		}
+	}
+}
+
+func TestViewTestCollapsePassing(t *testing.T) {
+	m := models.Model{
+		TestModel: InitialTestModel(),
+		Width:     160,
+		Height:    60,
+	}
+	m.TestModel.Step = 3
+	m.TestModel.Results = []models.TestResult{
+		{Method: "GET", Endpoint: "/healthy", Status: "200", Message: "OK"},
+		{Method: "GET", Endpoint: "/ready", Status: "204", Message: "OK"},
+		{Method: "GET", Endpoint: "/missing", Status: "404", Message: "Not Found"},
+	}
+	m.TestModel.CollapsePassing = true
+
+	output := ViewTest(m)
+	if !strings.Contains(output, "2 passed (collapsed") {
+		t.Errorf("Expected passing rows folded into one line, got:\n%s", output)
+	}
+	if strings.Contains(output, "/healthy") || strings.Contains(output, "/ready") {
+		t.Error("Expected passing rows to be collapsed")
+	}
+	if !strings.Contains(output, "/missing") {
+		t.Error("Expected the failure to be shown")
+	}
+	if visible := VisibleResults(m.TestModel); len(visible) != 1 || visible[0].Endpoint != "/missing" {
+		t.Errorf("Expected only the failure to be selectable, got %+v", visible)
+	}
+
+	// Expanding shows every row again
+	m.TestModel.CollapsePassing = false
+	output = ViewTest(m)
+	if strings.Contains(output, "collapsed") || !strings.Contains(output, "/healthy") {
+		t.Error("Expected all rows when expanded")
+	}
+}
+
+func TestViewTestToggledColumns(t *testing.T) {
+	m := models.Model{
+		TestModel: InitialTestModel(),
+		Width:     160,
+		Height:    60,
+	}
+	m.TestModel.Step = 3
+	m.TestModel.Results = []models.TestResult{
+		{Method: "GET", Endpoint: "/users", Status: "500", Message: "Internal Server Error", Duration: 250 * time.Millisecond},
+	}
+
+	output := ViewTest(m)
+	if !strings.Contains(output, "Message") || !strings.Contains(output, "Internal Server Error") {
+		t.Errorf("Expected the Message column by default, got:\n%s", output)
+	}
+	if strings.Contains(output, "Duration") {
+		t.Error("Expected no Duration column by default")
+	}
+
+	m.TestModel.HideMessageColumn = true
+	m.TestModel.ShowDurationColumn = true
+	output = ViewTest(m)
+	if strings.Contains(output, "Internal Server Error") {
+		t.Errorf("Expected the Message column to be hidden, got:\n%s", output)
+	}
+	if !strings.Contains(output, "Duration") || !strings.Contains(output, "250ms") {
+		t.Errorf("Expected a Duration column, got:\n%s", output)
+	}
+
+	var titles []string
+	for _, col := range ResultColumns(m.TestModel) {
+		titles = append(titles, col.Title)
+	}
+	if got := strings.Join(titles, ","); got != "Method,Endpoint,Status,Duration" {
+		t.Errorf("Expected toggled columns, got %s", got)
+	}
+}
@@ -0,0 +1,234 @@
+package validation
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// minErrorResponses is how many documented error responses a spec needs
+// before their common fields are treated as its standard error schema
+const minErrorResponses = 3
+
+// CheckResponseConsistency reports responses whose JSON schema is out of line
+// with the rest of the spec: an operation whose 2xx responses have unrelated
+// shapes (an object and an array, or objects sharing no fields), and 4xx, 5xx
+// or default responses missing fields that most of the spec's error responses
+// have. Both checks are heuristics, so their messages are warnings, one per
+// response: the 2xx ones first, then the error ones, each sorted by path
+// and method
+func CheckResponseConsistency(doc *openapi3.T) []string {
+	var messages []string
+	for _, p := range checkResponseConsistency(doc) {
+		messages = append(messages, p.Message)
+	}
+	return messages
+}
+
+// documentedResponse is a JSON response schema with where it is documented
+type documentedResponse struct {
+	Label   string
+	Pointer string
+	Status  string
+	Schema  *openapi3.Schema
+}
+
+// checkResponseConsistency returns CheckResponseConsistency's problems with
+// their spec locations
+func checkResponseConsistency(doc *openapi3.T) []specProblem {
+	var problems []specProblem
+	if doc == nil || doc.Paths == nil {
+		return problems
+	}
+
+	var errorResponses []documentedResponse
+	paths := doc.Paths.InMatchingOrder()
+	sort.Strings(paths)
+	for _, path := range paths {
+		operations := doc.Paths.Value(path).Operations()
+		methods := make([]string, 0, len(operations))
+		for method := range operations {
+			methods = append(methods, method)
+		}
+		sort.Strings(methods)
+		for _, method := range methods {
+			var successes []documentedResponse
+			for _, r := range jsonResponses(operations[method], method, path) {
+				if strings.HasPrefix(r.Status, "2") {
+					successes = append(successes, r)
+				} else if r.Status == "default" || strings.HasPrefix(r.Status, "4") || strings.HasPrefix(r.Status, "5") {
+					errorResponses = append(errorResponses, r)
+				}
+			}
+			problems = append(problems, checkSuccessShapes(successes)...)
+		}
+	}
+
+	return append(problems, checkErrorFields(errorResponses)...)
+}
+
+// jsonResponses returns an operation's responses that declare a JSON schema,
+// sorted by status
+func jsonResponses(operation *openapi3.Operation, method, path string) []documentedResponse {
+	if operation.Responses == nil {
+		return nil
+	}
+	statuses := make([]string, 0, operation.Responses.Len())
+	for status := range operation.Responses.Map() {
+		statuses = append(statuses, status)
+	}
+	sort.Strings(statuses)
+
+	var responses []documentedResponse
+	for _, status := range statuses {
+		response := operation.Responses.Value(status)
+		if response == nil || response.Value == nil {
+			continue
+		}
+		mediaTypes := make([]string, 0, len(response.Value.Content))
+		for name := range response.Value.Content {
+			mediaTypes = append(mediaTypes, name)
+		}
+		sort.Strings(mediaTypes)
+		for _, name := range mediaTypes {
+			mediaType := response.Value.Content[name]
+			if !IsJSONContentType(name) || mediaType == nil || mediaType.Schema == nil || mediaType.Schema.Value == nil {
+				continue
+			}
+			responses = append(responses, documentedResponse{
+				Label:   fmt.Sprintf("%s %s %s response", method, path, status),
+				Pointer: "/paths/" + escapePointer(path) + "/" + strings.ToLower(method) + "/responses/" + escapePointer(status),
+				Status:  strings.ToLower(status),
+				Schema:  mediaType.Schema.Value,
+			})
+			break
+		}
+	}
+	return responses
+}
+
+// checkSuccessShapes reports 2xx responses of one operation whose shape is
+// unrelated to the first one's, where a client expects one envelope
+func checkSuccessShapes(successes []documentedResponse) []specProblem {
+	var problems []specProblem
+	if len(successes) < 2 {
+		return problems
+	}
+	first := successes[0]
+	firstKind, firstFields := schemaShape(first.Schema)
+	for _, r := range successes[1:] {
+		kind, fields := schemaShape(r.Schema)
+		if kind == "" || firstKind == "" {
+			continue
+		}
+		if kind != firstKind {
+			problems = append(problems, specProblem{
+				Pointer: r.Pointer,
+				Message: fmt.Sprintf("%s: returns %s %s, but the %s response returns %s %s", r.Label, article(kind), kind, first.Status, article(firstKind), firstKind),
+			})
+			continue
+		}
+		if kind == "object" && len(fields) > 0 && len(firstFields) > 0 && !sharesField(fields, firstFields) {
+			problems = append(problems, specProblem{
+				Pointer: r.Pointer,
+				Message: fmt.Sprintf("%s: shares no fields with the %s response", r.Label, first.Status),
+			})
+		}
+	}
+	return problems
+}
+
+// checkErrorFields works out the spec's standard error fields, those most of
+// its error responses have, and reports error responses without them. Specs
+// with fewer than minErrorResponses error responses aren't checked
+func checkErrorFields(responses []documentedResponse) []specProblem {
+	var problems []specProblem
+	if len(responses) < minErrorResponses {
+		return problems
+	}
+
+	counts := make(map[string]int)
+	for _, r := range responses {
+		_, fields := schemaShape(r.Schema)
+		for field := range fields {
+			counts[field]++
+		}
+	}
+	var common []string
+	for field, count := range counts {
+		if count*2 > len(responses) {
+			common = append(common, field)
+		}
+	}
+	if len(common) == 0 {
+		return problems
+	}
+	sort.Strings(common)
+
+	for _, r := range responses {
+		_, fields := schemaShape(r.Schema)
+		var missing []string
+		for _, field := range common {
+			if !fields[field] {
+				missing = append(missing, field)
+			}
+		}
+		if len(missing) > 0 {
+			problems = append(problems, specProblem{
+				Pointer: r.Pointer,
+				Message: fmt.Sprintf("%s: lacks %s, which the spec's other error responses have", r.Label, strings.Join(missing, ", ")),
+			})
+		}
+	}
+	return problems
+}
+
+// schemaShape returns a schema's kind ("object", "array" or a primitive type,
+// "" when it can't tell) and, for objects, its top-level properties,
+// including those of allOf members
+func schemaShape(schema *openapi3.Schema) (string, map[string]bool) {
+	fields := make(map[string]bool)
+	kind := ""
+	if schema.Type != nil && len(schema.Type.Slice()) == 1 {
+		kind = schema.Type.Slice()[0]
+	}
+	for name := range schema.Properties {
+		fields[name] = true
+	}
+	for _, member := range schema.AllOf {
+		if member == nil || member.Value == nil {
+			continue
+		}
+		memberKind, memberFields := schemaShape(member.Value)
+		if kind == "" {
+			kind = memberKind
+		}
+		for name := range memberFields {
+			fields[name] = true
+		}
+	}
+	if kind == "" && len(fields) > 0 {
+		kind = "object"
+	}
+	return kind, fields
+}
+
+// sharesField reports whether two field sets have a field in common
+func sharesField(a, b map[string]bool) bool {
+	for field := range a {
+		if b[field] {
+			return true
+		}
+	}
+	return false
+}
+
+// article returns the indefinite article for a schema kind
+func article(kind string) string {
+	if strings.ContainsRune("aeiou", rune(kind[0])) {
+		return "an"
+	}
+	return "a"
+}
@@ -0,0 +1,138 @@
+package validation
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+const consistencySpec = `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    Error:
+      type: object
+      properties:
+        code: {type: integer}
+        message: {type: string}
+paths:
+  /users:
+    get:
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  data: {type: array, items: {type: object}}
+        '206':
+          description: Partial
+          content:
+            application/json:
+              schema:
+                type: array
+                items: {type: object}
+        '400':
+          description: Bad request
+          content:
+            application/json:
+              schema: {$ref: '#/components/schemas/Error'}
+  /users/{id}:
+    get:
+      parameters:
+        - {name: id, in: path, required: true, schema: {type: string}}
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  data: {type: object}
+        '404':
+          description: Not found
+          content:
+            application/json:
+              schema:
+                allOf:
+                  - $ref: '#/components/schemas/Error'
+                  - properties:
+                      id: {type: string}
+    delete:
+      parameters:
+        - {name: id, in: path, required: true, schema: {type: string}}
+      responses:
+        '204':
+          description: Deleted
+        '409':
+          description: Conflict
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  error: {type: string}
+        default:
+          description: Error
+          content:
+            application/json:
+              schema: {$ref: '#/components/schemas/Error'}
+`
+
+func TestCheckResponseConsistency(t *testing.T) {
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromData([]byte(consistencySpec))
+	if err != nil {
+		t.Fatalf("LoadFromData() error = %v", err)
+	}
+
+	got := CheckResponseConsistency(doc)
+	want := []string{
+		"GET /users 206 response: returns an array, but the 200 response returns an object",
+		"DELETE /users/{id} 409 response: lacks code, message, which the spec's other error responses have",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("CheckResponseConsistency() = %q, want %q", got, want)
+	}
+
+	// Too few error responses to tell what the standard error schema is
+	doc.Paths.Value("/users").Get.Responses.Delete("400")
+	doc.Paths.Value("/users/{id}").Get.Responses.Delete("404")
+	for _, message := range CheckResponseConsistency(doc) {
+		if strings.Contains(message, "409") {
+			t.Errorf("Expected no error field check with two error responses, got %q", message)
+		}
+	}
+}
+
+func TestValidateSpecWithOptions_ResponseConsistency(t *testing.T) {
+	specPath := filepath.Join(t.TempDir(), "openapi.yaml")
+	if err := os.WriteFile(specPath, []byte(consistencySpec), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, warnings, err := ValidateSpecWithOptions(specPath, SpecOptions{})
+	if err != nil {
+		t.Fatalf("ValidateSpecWithOptions() error = %v", err)
+	}
+	if len(warnings) != 0 {
+		t.Errorf("Expected the check to be off by default, got %q", warnings)
+	}
+
+	_, warnings, err = ValidateSpecWithOptions(specPath, SpecOptions{ResponseConsistency: true})
+	if err != nil {
+		t.Fatalf("ValidateSpecWithOptions() error = %v", err)
+	}
+	if len(warnings) != 2 || !strings.HasPrefix(warnings[1], "DELETE /users/{id} 409 response: lacks code, message") || !strings.Contains(warnings[1], "(line ") {
+		t.Errorf("Expected both warnings with their lines, got %q", warnings)
+	}
+}
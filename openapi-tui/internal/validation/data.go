@@ -0,0 +1,49 @@
+package validation
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"gopkg.in/yaml.v3"
+)
+
+// SpecFromData checks that data holds a valid OpenAPI spec in YAML or JSON
+// and saves it to a temporary file, returning the file's path
+// Lets a spec that only lives in memory (e.g. the clipboard) go through the
+// same path-based test flow as a spec file.
+func SpecFromData(data []byte) (string, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return "", fmt.Errorf("spec is empty")
+	}
+
+	// JSON is a subset of YAML, so one decode covers both formats
+	var fields map[string]interface{}
+	if err := yaml.Unmarshal(data, &fields); err != nil {
+		return "", fmt.Errorf("spec is not valid YAML or JSON: %w", err)
+	}
+	if _, ok := fields["openapi"]; !ok {
+		return "", fmt.Errorf("spec has no openapi version field")
+	}
+
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromData(data)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse OpenAPI spec: %w", err)
+	}
+	if err := doc.Validate(loader.Context); err != nil {
+		return "", fmt.Errorf("invalid OpenAPI spec: %w", err)
+	}
+
+	file, err := os.CreateTemp("", "openapi-tui-spec-*.yaml")
+	if err != nil {
+		return "", fmt.Errorf("failed to save spec: %w", err)
+	}
+	defer file.Close()
+	if _, err := file.Write(data); err != nil {
+		return "", fmt.Errorf("failed to save spec: %w", err)
+	}
+	return file.Name(), nil
+}
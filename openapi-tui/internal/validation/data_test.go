@@ -0,0 +1,55 @@
+package validation
+
+import (
+	"os"
+	"testing"
+)
+
+func TestSpecFromData(t *testing.T) {
+	yamlSpec := `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /users:
+    get:
+      responses:
+        '200':
+          description: OK
+`
+	jsonSpec := `{"openapi": "3.0.0", "info": {"title": "Test API", "version": "1.0.0"},
+		"paths": {"/users": {"get": {"responses": {"200": {"description": "OK"}}}}}}`
+
+	for name, data := range map[string]string{"yaml": yamlSpec, "json": jsonSpec} {
+		t.Run(name, func(t *testing.T) {
+			path, err := SpecFromData([]byte(data))
+			if err != nil {
+				t.Fatalf("SpecFromData() error = %v", err)
+			}
+			defer os.Remove(path)
+
+			// The saved file feeds the regular path-based flow
+			endpoints, err := ExtractEndpoints(path)
+			if err != nil {
+				t.Fatalf("ExtractEndpoints() error = %v", err)
+			}
+			if len(endpoints) == 0 {
+				t.Error("Expected endpoints from the saved spec")
+			}
+		})
+	}
+
+	for name, data := range map[string]string{
+		"empty":      "  \n",
+		"not a spec": "just some copied text: [",
+		"no version": "info:\n  title: x\n",
+		"invalid":    "openapi: 3.0.0\npaths: {}\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			if path, err := SpecFromData([]byte(data)); err == nil {
+				os.Remove(path)
+				t.Error("Expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package validation
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+
+	"github.com/Traves-Theberge/OpenAPI-Toolkit/openapi-tui/internal/models"
+)
+
+// SpecDiff lists the operations that differ between two versions of a spec,
+// each as "METHOD path", sorted
+type SpecDiff struct {
+	Added    []string
+	Removed  []string
+	Modified []string
+}
+
+// Changed returns the added and modified operations as a run selection,
+// for testing only what a spec change touched
+func (d SpecDiff) Changed() []models.EndpointInfo {
+	return PinnedSelection(append(append([]string{}, d.Added...), d.Modified...))
+}
+
+// componentRefPattern matches local component references in marshalled JSON
+var componentRefPattern = regexp.MustCompile(`"\$ref":"#/components/([^/"]+)/([^"]+)"`)
+
+// DiffSpecs compares the operations of two spec versions. An operation is
+// modified when its definition, its path's shared parameters or any local
+// component it references, directly or through other components, changed.
+// Changes inside external files referenced by path are not detected
+func DiffSpecs(oldPath, newPath string) (SpecDiff, error) {
+	var diff SpecDiff
+	before, err := operationFingerprints(oldPath)
+	if err != nil {
+		return diff, fmt.Errorf("failed to load %s: %w", oldPath, err)
+	}
+	after, err := operationFingerprints(newPath)
+	if err != nil {
+		return diff, fmt.Errorf("failed to load %s: %w", newPath, err)
+	}
+
+	for key, fingerprint := range after {
+		old, ok := before[key]
+		switch {
+		case !ok:
+			diff.Added = append(diff.Added, key)
+		case old != fingerprint:
+			diff.Modified = append(diff.Modified, key)
+		}
+	}
+	for key := range before {
+		if _, ok := after[key]; !ok {
+			diff.Removed = append(diff.Removed, key)
+		}
+	}
+	sort.Strings(diff.Added)
+	sort.Strings(diff.Removed)
+	sort.Strings(diff.Modified)
+	return diff, nil
+}
+
+// operationFingerprints returns a canonical JSON rendering of each operation
+// of a spec, keyed "METHOD path", including the components it references
+func operationFingerprints(specPath string) (map[string]string, error) {
+	_, doc, err := loadSpec(specPath)
+	if err != nil {
+		return nil, err
+	}
+
+	components := map[string]map[string]json.RawMessage{}
+	if doc.Components != nil {
+		data, err := json.Marshal(doc.Components)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(data, &components); err != nil {
+			return nil, err
+		}
+	}
+
+	fingerprints := make(map[string]string)
+	if doc.Paths == nil {
+		return fingerprints, nil
+	}
+	for path, pathItem := range doc.Paths.Map() {
+		for method, operation := range pathItem.Operations() {
+			data, err := json.Marshal(struct {
+				Parameters openapi3.Parameters `json:"parameters,omitempty"`
+				Operation  *openapi3.Operation `json:"operation"`
+			}{pathItem.Parameters, operation})
+			if err != nil {
+				return nil, err
+			}
+			fingerprints[models.EndpointKey(strings.ToUpper(method), path)] = string(data) + referencedComponents(data, components)
+		}
+	}
+	return fingerprints, nil
+}
+
+// referencedComponents renders the components data references, and those
+// they reference in turn, in a stable order. Each is rendered once, so
+// recursive schemas terminate
+func referencedComponents(data []byte, components map[string]map[string]json.RawMessage) string {
+	seen := make(map[string]bool)
+	pending := [][]byte{data}
+	var refs []string
+	for len(pending) > 0 {
+		next := pending[0]
+		pending = pending[1:]
+		for _, match := range componentRefPattern.FindAllSubmatch(next, -1) {
+			kind, name := string(match[1]), string(match[2])
+			ref := kind + "/" + name
+			if seen[ref] {
+				continue
+			}
+			seen[ref] = true
+			refs = append(refs, ref)
+			if value, ok := components[kind][name]; ok {
+				pending = append(pending, value)
+			}
+		}
+	}
+
+	sort.Strings(refs)
+	var rendered strings.Builder
+	for _, ref := range refs {
+		kind, name, _ := strings.Cut(ref, "/")
+		rendered.WriteString("\n" + ref + "=")
+		rendered.Write(components[kind][name])
+	}
+	return rendered.String()
+}
@@ -0,0 +1,84 @@
+package validation
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const diffSpec = `openapi: 3.0.0
+info:
+  title: Shop
+  version: 1.0.0
+paths:
+  /users:
+    get:
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/User'
+  /orders:
+    get:
+      responses:
+        '200':
+          description: OK
+  /legacy:
+    get:
+      responses:
+        '200':
+          description: OK
+components:
+  schemas:
+    User:
+      type: object
+      properties:
+        name:
+          type: string
+        friend:
+          $ref: '#/components/schemas/User'
+`
+
+func TestDiffSpecs(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	oldPath := write("old.yaml", diffSpec)
+
+	// A change inside a referenced schema modifies the operations using it
+	changed := strings.Replace(diffSpec, "        name:\n          type: string", "        name:\n          type: string\n          minLength: 1", 1)
+	changed = strings.Replace(changed, "  /legacy:\n    get:\n      responses:\n        '200':\n          description: OK\n", "  /posts:\n    post:\n      responses:\n        '201':\n          description: Created\n", 1)
+	newPath := write("new.yaml", changed)
+
+	diff, err := DiffSpecs(oldPath, newPath)
+	if err != nil {
+		t.Fatalf("DiffSpecs() error = %v", err)
+	}
+	want := SpecDiff{Added: []string{"POST /posts"}, Removed: []string{"GET /legacy"}, Modified: []string{"GET /users"}}
+	if !reflect.DeepEqual(diff, want) {
+		t.Errorf("DiffSpecs() = %+v, want %+v", diff, want)
+	}
+	selection := diff.Changed()
+	if len(selection) != 2 || selection[0].Method != "POST" || selection[0].Path != "/posts" || selection[1].Path != "/users" {
+		t.Errorf("Expected the added and modified operations to be selected, got %+v", selection)
+	}
+
+	// Identical specs have no changes
+	same, err := DiffSpecs(oldPath, write("same.yaml", diffSpec))
+	if err != nil || same.Added != nil || same.Removed != nil || same.Modified != nil {
+		t.Errorf("Expected no changes between identical specs, got %+v, %v", same, err)
+	}
+
+	if _, err := DiffSpecs(filepath.Join(dir, "missing.yaml"), newPath); err == nil {
+		t.Error("Expected an error for a missing spec")
+	}
+}
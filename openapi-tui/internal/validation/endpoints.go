@@ -3,6 +3,8 @@ package validation
 import (
 	"fmt"
 	"os"
+	"path"
+	"sort"
 	"strings"
 
 	"github.com/Traves-Theberge/OpenAPI-Toolkit/openapi-tui/internal/models"
@@ -23,6 +25,9 @@ func ExtractEndpoints(specPath string) ([]models.EndpointInfo, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse OpenAPI spec: %w", err)
 	}
+	if err := CheckOpenAPIDocument(doc, specPath); err != nil {
+		return nil, err
+	}
 
 	// Validate the spec
 	if err := doc.Validate(loader.Context); err != nil {
@@ -63,9 +68,30 @@ func ExtractEndpoints(specPath string) ([]models.EndpointInfo, error) {
 		}
 	}
 
+	IndexEndpoints(endpoints)
 	return endpoints, nil
 }
 
+// IndexEndpoints precomputes the lowercased search fields of each endpoint
+// so filtering does not lowercase every field on every keystroke
+func IndexEndpoints(endpoints []models.EndpointInfo) {
+	for i := range endpoints {
+		endpoints[i].SearchPath, endpoints[i].SearchText = searchFields(endpoints[i])
+	}
+}
+
+// searchFields returns the endpoint's lowercased path and searchable text,
+// using the precomputed values when IndexEndpoints has run
+func searchFields(ep models.EndpointInfo) (string, string) {
+	if ep.SearchText != "" {
+		return ep.SearchPath, ep.SearchText
+	}
+	// Newlines separate the fields so a query cannot match across two of them
+	fields := append([]string{ep.Path, ep.Method, ep.OperationID}, ep.Tags...)
+	fields = append(fields, ep.Summary, ep.Description)
+	return strings.ToLower(ep.Path), strings.ToLower(strings.Join(fields, "\n"))
+}
+
 // FilterEndpoints filters endpoints based on a search query
 func FilterEndpoints(endpoints []models.EndpointInfo, query string) []models.EndpointInfo {
 	if query == "" {
@@ -96,7 +122,7 @@ func FilterEndpoints(endpoints []models.EndpointInfo, query string) []models.End
 						}
 					}
 				case "path", "p":
-					if strings.Contains(strings.ToLower(ep.Path), filterValue) {
+					if searchPath, _ := searchFields(ep); strings.Contains(searchPath, filterValue) {
 						filtered = append(filtered, ep)
 					}
 				}
@@ -119,42 +145,57 @@ func FilterEndpoints(endpoints []models.EndpointInfo, query string) []models.End
 }
 
 // matchesQuery checks if an endpoint matches the search query
+// in its path, method, operation ID, tags, summary or description
 func matchesQuery(ep models.EndpointInfo, query string) bool {
-	query = strings.ToLower(query)
+	_, text := searchFields(ep)
+	return strings.Contains(text, strings.ToLower(query))
+}
 
-	// Search in path
-	if strings.Contains(strings.ToLower(ep.Path), query) {
-		return true
+// RefineFilter filters endpoints for a new search query, reusing the previous
+// results when the query only extends the previous one
+// Plain searches only narrow as the query grows, so the previous matches are
+// the only candidates; "type:value" filters fall back to searching everything
+func RefineFilter(all, previous []models.EndpointInfo, previousQuery, query string) []models.EndpointInfo {
+	prev := strings.TrimSpace(previousQuery)
+	if prev != "" && strings.HasPrefix(query, previousQuery) && !strings.Contains(query, ":") {
+		return FilterEndpoints(previous, query)
 	}
+	return FilterEndpoints(all, query)
+}
 
-	// Search in method
-	if strings.Contains(strings.ToLower(ep.Method), query) {
-		return true
+// MatchesGlobs reports whether an endpoint path passes the include and exclude
+// glob patterns (path.Match syntax). An empty include list allows every path;
+// a path matching any exclude pattern is rejected even if it is also included.
+// Malformed patterns never match.
+func MatchesGlobs(p string, include, exclude []string) bool {
+	for _, pattern := range exclude {
+		if ok, _ := path.Match(pattern, p); ok {
+			return false
+		}
 	}
-
-	// Search in operation ID
-	if strings.Contains(strings.ToLower(ep.OperationID), query) {
+	if len(include) == 0 {
 		return true
 	}
-
-	// Search in tags
-	for _, tag := range ep.Tags {
-		if strings.Contains(strings.ToLower(tag), query) {
+	for _, pattern := range include {
+		if ok, _ := path.Match(pattern, p); ok {
 			return true
 		}
 	}
+	return false
+}
 
-	// Search in summary
-	if strings.Contains(strings.ToLower(ep.Summary), query) {
-		return true
+// FilterByGlobs returns the endpoints whose paths pass the include and exclude globs
+func FilterByGlobs(endpoints []models.EndpointInfo, include, exclude []string) []models.EndpointInfo {
+	if len(include) == 0 && len(exclude) == 0 {
+		return endpoints
 	}
-
-	// Search in description
-	if strings.Contains(strings.ToLower(ep.Description), query) {
-		return true
+	var filtered []models.EndpointInfo
+	for _, ep := range endpoints {
+		if MatchesGlobs(ep.Path, include, exclude) {
+			filtered = append(filtered, ep)
+		}
 	}
-
-	return false
+	return filtered
 }
 
 // GetSelectedEndpoints returns only the selected endpoints
@@ -183,3 +224,85 @@ func DeselectAllEndpoints(endpoints []models.EndpointInfo) []models.EndpointInfo
 	}
 	return endpoints
 }
+
+// IsPinned reports whether an endpoint is in the pinned list
+func IsPinned(pinned []string, ep models.EndpointInfo) bool {
+	key := models.EndpointKey(ep.Method, ep.Path)
+	for _, p := range pinned {
+		if p == key {
+			return true
+		}
+	}
+	return false
+}
+
+// TogglePinned adds an endpoint to the pinned list, or removes it if already pinned
+func TogglePinned(pinned []string, ep models.EndpointInfo) []string {
+	key := models.EndpointKey(ep.Method, ep.Path)
+	var result []string
+	for _, p := range pinned {
+		if p != key {
+			result = append(result, p)
+		}
+	}
+	if len(result) == len(pinned) {
+		result = append(result, key)
+	}
+	return result
+}
+
+// PinnedSelection converts pinned "METHOD path" keys into an endpoint selection
+// Malformed entries are skipped
+func PinnedSelection(pinned []string) []models.EndpointInfo {
+	var selection []models.EndpointInfo
+	for _, p := range pinned {
+		parts := strings.Fields(p)
+		if len(parts) != 2 {
+			continue
+		}
+		selection = append(selection, models.EndpointInfo{
+			Method:   strings.ToUpper(parts[0]),
+			Path:     parts[1],
+			Selected: true,
+		})
+	}
+	return selection
+}
+
+// PinnedEndpoints returns the endpoints of a spec that are pinned, marked as
+// selected, in the spec's order. Pins of endpoints the spec no longer has are
+// left out, so a favorites run only tests real operations
+func PinnedEndpoints(all []models.EndpointInfo, pinned []string) []models.EndpointInfo {
+	var selection []models.EndpointInfo
+	for _, ep := range all {
+		if IsPinned(pinned, ep) {
+			ep.Selected = true
+			selection = append(selection, ep)
+		}
+	}
+	return selection
+}
+
+// UntestedEndpoints returns the endpoints from the spec that have no test result,
+// sorted by path then method
+func UntestedEndpoints(all []models.EndpointInfo, results []models.TestResult) []models.EndpointInfo {
+	tested := make(map[string]bool)
+	for _, r := range results {
+		tested[models.EndpointKey(r.Method, r.Endpoint)] = true
+	}
+
+	var untested []models.EndpointInfo
+	for _, ep := range all {
+		if !tested[models.EndpointKey(ep.Method, ep.Path)] {
+			untested = append(untested, ep)
+		}
+	}
+
+	sort.Slice(untested, func(i, j int) bool {
+		if untested[i].Path != untested[j].Path {
+			return untested[i].Path < untested[j].Path
+		}
+		return untested[i].Method < untested[j].Method
+	})
+	return untested
+}
@@ -0,0 +1,64 @@
+package validation
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Traves-Theberge/OpenAPI-Toolkit/openapi-tui/internal/models"
+)
+
+// generateBenchmarkEndpoints builds n indexed endpoints spread over a few resources
+func generateBenchmarkEndpoints(n int) []models.EndpointInfo {
+	resources := []string{"users", "orders", "products", "invoices", "admin"}
+	methods := []string{"GET", "POST", "PUT", "DELETE"}
+
+	endpoints := make([]models.EndpointInfo, n)
+	for i := range endpoints {
+		resource := resources[i%len(resources)]
+		endpoints[i] = models.EndpointInfo{
+			Path:        fmt.Sprintf("/%s/%d/items/{id}", resource, i),
+			Method:      methods[i%len(methods)],
+			OperationID: fmt.Sprintf("operation%d", i),
+			Tags:        []string{resource},
+			Summary:     fmt.Sprintf("Operation %d on %s", i, resource),
+			Description: "Generated endpoint used to measure filtering over large specs",
+		}
+	}
+	IndexEndpoints(endpoints)
+	return endpoints
+}
+
+// BenchmarkFilterEndpoints measures one search over 5000 endpoints
+func BenchmarkFilterEndpoints(b *testing.B) {
+	endpoints := generateBenchmarkEndpoints(5000)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		FilterEndpoints(endpoints, "orders")
+	}
+}
+
+// BenchmarkRefineFilter measures narrowing a previous search over 5000 endpoints
+func BenchmarkRefineFilter(b *testing.B) {
+	endpoints := generateBenchmarkEndpoints(5000)
+	previous := FilterEndpoints(endpoints, "ord")
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		RefineFilter(endpoints, previous, "ord", "orders")
+	}
+}
+
+// TestFilterEndpoints_Latency keeps filtering 5000 endpoints well within a keystroke
+func TestFilterEndpoints_Latency(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping latency check in short mode")
+	}
+
+	result := testing.Benchmark(BenchmarkFilterEndpoints)
+	// Generous bound so slow CI machines do not flake; typical runs take well under 1ms
+	if perOp := time.Duration(result.NsPerOp()); perOp > 20*time.Millisecond {
+		t.Errorf("Filtering 5000 endpoints took %v per search, want under 20ms", perOp)
+	}
+}
@@ -469,3 +469,140 @@ func TestFilterEndpoints_EdgeCases(t *testing.T) {
 		}
 	})
 }
+
+func TestTogglePinned(t *testing.T) {
+	ep := models.EndpointInfo{Method: "get", Path: "/users"}
+
+	pinned := TogglePinned(nil, ep)
+	if len(pinned) != 1 || pinned[0] != "GET /users" {
+		t.Fatalf("Expected [GET /users], got %v", pinned)
+	}
+	if !IsPinned(pinned, ep) {
+		t.Error("Expected endpoint to be pinned")
+	}
+
+	pinned = TogglePinned(pinned, ep)
+	if len(pinned) != 0 {
+		t.Errorf("Expected endpoint to be unpinned, got %v", pinned)
+	}
+}
+
+func TestPinnedSelection(t *testing.T) {
+	selection := PinnedSelection([]string{"GET /users", "post /users", "invalid"})
+
+	if len(selection) != 2 {
+		t.Fatalf("Expected 2 endpoints, got %d", len(selection))
+	}
+	if selection[1].Method != "POST" || selection[1].Path != "/users" {
+		t.Errorf("Expected POST /users, got %s %s", selection[1].Method, selection[1].Path)
+	}
+}
+
+func TestPinnedEndpoints(t *testing.T) {
+	all := []models.EndpointInfo{
+		{Method: "GET", Path: "/users"},
+		{Method: "POST", Path: "/users"},
+		{Method: "GET", Path: "/health"},
+	}
+	selection := PinnedEndpoints(all, []string{"GET /health", "DELETE /gone", "GET /users"})
+
+	if len(selection) != 2 || selection[0].Path != "/users" || selection[1].Path != "/health" || !selection[0].Selected {
+		t.Errorf("Expected the pinned spec endpoints in spec order, got %+v", selection)
+	}
+}
+
+func TestUntestedEndpoints(t *testing.T) {
+	all := []models.EndpointInfo{
+		{Method: "GET", Path: "/users"},
+		{Method: "POST", Path: "/users"},
+		{Method: "GET", Path: "/users/{id}"},
+		{Method: "DELETE", Path: "/users/{id}"},
+		{Method: "GET", Path: "/health"},
+	}
+
+	// Simulate a selective run that only tested two endpoints
+	selection := []models.EndpointInfo{all[0], all[3]}
+	var results []models.TestResult
+	for _, ep := range selection {
+		results = append(results, models.TestResult{Method: ep.Method, Endpoint: ep.Path, Status: "200"})
+	}
+
+	untested := UntestedEndpoints(all, results)
+
+	expected := []string{"GET /health", "POST /users", "GET /users/{id}"}
+	if len(untested) != len(expected) {
+		t.Fatalf("Expected %d untested endpoints, got %d: %v", len(expected), len(untested), untested)
+	}
+	for i, ep := range untested {
+		if key := models.EndpointKey(ep.Method, ep.Path); key != expected[i] {
+			t.Errorf("untested[%d] = %s, want %s", i, key, expected[i])
+		}
+	}
+
+	if untested := UntestedEndpoints(all, append(results, models.TestResult{Method: "GET", Endpoint: "/health"})); len(untested) != 2 {
+		t.Errorf("Expected 2 untested endpoints after testing /health, got %d", len(untested))
+	}
+}
+
+func TestFilterByGlobs(t *testing.T) {
+	endpoints := []models.EndpointInfo{
+		{Method: "GET", Path: "/admin/users"},
+		{Method: "GET", Path: "/admin/internal"},
+		{Method: "GET", Path: "/users"},
+		{Method: "GET", Path: "/users/internal"},
+	}
+
+	tests := []struct {
+		name     string
+		include  []string
+		exclude  []string
+		expected []string
+	}{
+		{"no patterns", nil, nil, []string{"/admin/users", "/admin/internal", "/users", "/users/internal"}},
+		{"include only", []string{"/admin/*"}, nil, []string{"/admin/users", "/admin/internal"}},
+		{"exclude only", nil, []string{"/*/internal"}, []string{"/admin/users", "/users"}},
+		{"exclude wins over include", []string{"/admin/*"}, []string{"/*/internal"}, []string{"/admin/users"}},
+		{"malformed pattern never matches", []string{"/admin/["}, nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filtered := FilterByGlobs(endpoints, tt.include, tt.exclude)
+			if len(filtered) != len(tt.expected) {
+				t.Fatalf("Expected %v, got %v", tt.expected, filtered)
+			}
+			for i, ep := range filtered {
+				if ep.Path != tt.expected[i] {
+					t.Errorf("filtered[%d] = %s, want %s", i, ep.Path, tt.expected[i])
+				}
+			}
+		})
+	}
+}
+
+func TestRefineFilter(t *testing.T) {
+	all := []models.EndpointInfo{
+		{Method: "GET", Path: "/users", Tags: []string{"users"}},
+		{Method: "GET", Path: "/users/{id}", Tags: []string{"users"}},
+		{Method: "GET", Path: "/orders", Tags: []string{"orders"}},
+	}
+	IndexEndpoints(all)
+
+	previous := FilterEndpoints(all, "user")
+	if len(previous) != 2 {
+		t.Fatalf("Expected 2 matches for 'user', got %d", len(previous))
+	}
+
+	// Extending the query narrows the previous matches
+	if refined := RefineFilter(all, previous, "user", "users/"); len(refined) != 1 || refined[0].Path != "/users/{id}" {
+		t.Errorf("Expected [/users/{id}], got %v", refined)
+	}
+
+	// Any other edit searches everything again
+	if refined := RefineFilter(all, previous, "user", "ord"); len(refined) != 1 || refined[0].Path != "/orders" {
+		t.Errorf("Expected [/orders], got %v", refined)
+	}
+	if refined := RefineFilter(all, previous, "user", "user:"); len(refined) != 0 {
+		t.Errorf("Expected no matches for an unknown filter type, got %v", refined)
+	}
+}
@@ -0,0 +1,314 @@
+package validation
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// ResponseExample returns the documented JSON example for a response status,
+// falling back to the "default" response
+// The media type's example wins, then its first named example, then the
+// schema's example. Returns nil when the spec documents none.
+func ResponseExample(operation *openapi3.Operation, statusCode int) []byte {
+	if operation == nil || operation.Responses == nil {
+		return nil
+	}
+	response := operation.Responses.Status(statusCode)
+	if response == nil {
+		response = operation.Responses.Default()
+	}
+	if response == nil || response.Value == nil {
+		return nil
+	}
+	mediaType := response.Value.Content.Get("application/json")
+	if mediaType == nil {
+		return nil
+	}
+
+	example := mediaType.Example
+	if example == nil && len(mediaType.Examples) > 0 {
+		names := make([]string, 0, len(mediaType.Examples))
+		for name := range mediaType.Examples {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		if ref := mediaType.Examples[names[0]]; ref != nil && ref.Value != nil {
+			example = ref.Value.Value
+		}
+	}
+	if example == nil && mediaType.Schema != nil && mediaType.Schema.Value != nil {
+		example = mediaType.Schema.Value.Example
+	}
+	if example == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(example)
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+// DiffExampleVsActual compares a documented JSON example with an actual JSON
+// response field by field, returning one line per difference: "- path: value"
+// for a field only in the example, "+ path: value" for one only in the actual
+// response, and "~ path: example → actual" for a changed value.
+// Paths use dots for object fields and [i] for array items; "$" is the root.
+// Returns nil when the documents match.
+func DiffExampleVsActual(example, actual []byte) []string {
+	var exampleValue, actualValue interface{}
+	if err := json.Unmarshal(example, &exampleValue); err != nil {
+		return []string{fmt.Sprintf("example is not valid JSON: %v", err)}
+	}
+	if err := json.Unmarshal(actual, &actualValue); err != nil {
+		return []string{fmt.Sprintf("response is not valid JSON: %v", err)}
+	}
+
+	var diffs []string
+	diffJSON("", exampleValue, actualValue, &diffs)
+	return diffs
+}
+
+// diffJSON appends the differences between two decoded JSON values at path
+func diffJSON(path string, example, actual interface{}, diffs *[]string) {
+	switch e := example.(type) {
+	case map[string]interface{}:
+		a, ok := actual.(map[string]interface{})
+		if !ok {
+			break
+		}
+		keys := make(map[string]bool)
+		for k := range e {
+			keys[k] = true
+		}
+		for k := range a {
+			keys[k] = true
+		}
+		sorted := make([]string, 0, len(keys))
+		for k := range keys {
+			sorted = append(sorted, k)
+		}
+		sort.Strings(sorted)
+
+		for _, k := range sorted {
+			child := joinField(path, k)
+			ev, inExample := e[k]
+			av, inActual := a[k]
+			switch {
+			case !inActual:
+				*diffs = append(*diffs, fmt.Sprintf("- %s: %s", rootPath(child), jsonText(ev)))
+			case !inExample:
+				*diffs = append(*diffs, fmt.Sprintf("+ %s: %s", rootPath(child), jsonText(av)))
+			default:
+				diffJSON(child, ev, av, diffs)
+			}
+		}
+		return
+	case []interface{}:
+		a, ok := actual.([]interface{})
+		if !ok {
+			break
+		}
+		for i := 0; i < len(e) || i < len(a); i++ {
+			child := fmt.Sprintf("%s[%d]", path, i)
+			switch {
+			case i >= len(a):
+				*diffs = append(*diffs, fmt.Sprintf("- %s: %s", rootPath(child), jsonText(e[i])))
+			case i >= len(e):
+				*diffs = append(*diffs, fmt.Sprintf("+ %s: %s", rootPath(child), jsonText(a[i])))
+			default:
+				diffJSON(child, e[i], a[i], diffs)
+			}
+		}
+		return
+	}
+
+	if exampleText, actualText := jsonText(example), jsonText(actual); exampleText != actualText {
+		*diffs = append(*diffs, fmt.Sprintf("~ %s: %s → %s", rootPath(path), exampleText, actualText))
+	}
+}
+
+// joinField appends an object field to a diff path
+func joinField(path, field string) string {
+	if path == "" {
+		return field
+	}
+	return path + "." + field
+}
+
+// rootPath names the document root "$" in diff lines
+func rootPath(path string) string {
+	if path == "" || strings.HasPrefix(path, "[") {
+		return "$" + path
+	}
+	return path
+}
+
+// jsonText renders a decoded JSON value compactly for a diff line
+func jsonText(v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(data)
+}
+
+// DefaultExampleMaxDepth is how deeply examples may nest before validation
+// gives up on them, when no limit is configured
+const DefaultExampleMaxDepth = 64
+
+// ValidateExamples checks every request and response body example against
+// its media type's schema, returning one message per problem, sorted by
+// path and method. The walk is cycle-safe: an example that contains itself,
+// which can happen in documents built in code, or one nesting deeper than
+// maxDepth (0 = DefaultExampleMaxDepth) is reported instead of validated
+func ValidateExamples(doc *openapi3.T, maxDepth int) []string {
+	var messages []string
+	for _, p := range checkExamples(doc, maxDepth) {
+		messages = append(messages, p.Message)
+	}
+	return messages
+}
+
+// checkExamples returns ValidateExamples' problems with their spec locations
+func checkExamples(doc *openapi3.T, maxDepth int) []specProblem {
+	if maxDepth <= 0 {
+		maxDepth = DefaultExampleMaxDepth
+	}
+	var problems []specProblem
+	if doc == nil || doc.Paths == nil {
+		return problems
+	}
+	paths := doc.Paths.InMatchingOrder()
+	sort.Strings(paths)
+	for _, path := range paths {
+		operations := doc.Paths.Value(path).Operations()
+		methods := make([]string, 0, len(operations))
+		for method := range operations {
+			methods = append(methods, method)
+		}
+		sort.Strings(methods)
+		for _, method := range methods {
+			operation := operations[method]
+			pointer := "/paths/" + escapePointer(path) + "/" + strings.ToLower(method)
+			label := method + " " + path
+			if body := operation.RequestBody; body != nil && body.Value != nil {
+				problems = append(problems, checkContentExamples(body.Value.Content, pointer+"/requestBody", label+" request body", maxDepth)...)
+			}
+			if operation.Responses == nil {
+				continue
+			}
+			statuses := make([]string, 0, operation.Responses.Len())
+			for status := range operation.Responses.Map() {
+				statuses = append(statuses, status)
+			}
+			sort.Strings(statuses)
+			for _, status := range statuses {
+				if response := operation.Responses.Value(status); response != nil && response.Value != nil {
+					problems = append(problems, checkContentExamples(response.Value.Content, pointer+"/responses/"+status, label+" "+status+" response", maxDepth)...)
+				}
+			}
+		}
+	}
+	return problems
+}
+
+// checkContentExamples checks the examples of each media type in content
+func checkContentExamples(content openapi3.Content, pointer, label string, maxDepth int) []specProblem {
+	var problems []specProblem
+	mediaTypes := make([]string, 0, len(content))
+	for name := range content {
+		mediaTypes = append(mediaTypes, name)
+	}
+	sort.Strings(mediaTypes)
+	for _, name := range mediaTypes {
+		mediaType := content[name]
+		if mediaType == nil || mediaType.Schema == nil || mediaType.Schema.Value == nil {
+			continue
+		}
+		base := pointer + "/content/" + escapePointer(name)
+		if mediaType.Example != nil {
+			if message := checkExample(mediaType.Schema.Value, mediaType.Example, maxDepth); message != "" {
+				problems = append(problems, specProblem{Pointer: base + "/example", Message: label + " example " + message})
+			}
+		}
+		names := make([]string, 0, len(mediaType.Examples))
+		for exampleName := range mediaType.Examples {
+			names = append(names, exampleName)
+		}
+		sort.Strings(names)
+		for _, exampleName := range names {
+			ref := mediaType.Examples[exampleName]
+			if ref == nil || ref.Value == nil || ref.Value.Value == nil {
+				continue
+			}
+			if message := checkExample(mediaType.Schema.Value, ref.Value.Value, maxDepth); message != "" {
+				problems = append(problems, specProblem{
+					Pointer: base + "/examples/" + escapePointer(exampleName),
+					Message: fmt.Sprintf("%s example %q %s", label, exampleName, message),
+				})
+			}
+		}
+	}
+	return problems
+}
+
+// checkExample describes why value is not a usable example of schema, or
+// returns "" when it is valid. The shape is checked first, so the schema
+// validator never walks a cyclic or overly deep value
+func checkExample(schema *openapi3.Schema, value interface{}, maxDepth int) string {
+	if message := exampleShape(value, 0, maxDepth, make(map[uintptr]bool)); message != "" {
+		return message
+	}
+	if err := schema.VisitJSON(value, openapi3.MultiErrors()); err != nil {
+		return "does not match its schema: " + strings.ReplaceAll(err.Error(), "\n", " ")
+	}
+	return ""
+}
+
+// exampleShape walks a decoded example, reporting a value that contains
+// itself or nests deeper than maxDepth. ancestors holds the maps and arrays
+// on the current path, so values shared between siblings are still allowed
+func exampleShape(value interface{}, depth, maxDepth int, ancestors map[uintptr]bool) string {
+	var children []interface{}
+	var id uintptr
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if len(v) == 0 {
+			return ""
+		}
+		id = reflect.ValueOf(v).Pointer()
+		for _, child := range v {
+			children = append(children, child)
+		}
+	case []interface{}:
+		if len(v) == 0 {
+			return ""
+		}
+		id = reflect.ValueOf(v).Pointer()
+		children = v
+	default:
+		return ""
+	}
+
+	if ancestors[id] {
+		return "refers to itself, so it cannot be validated"
+	}
+	if depth >= maxDepth {
+		return fmt.Sprintf("nests deeper than %d levels, so it was not validated", maxDepth)
+	}
+	ancestors[id] = true
+	defer delete(ancestors, id)
+	for _, child := range children {
+		if message := exampleShape(child, depth+1, maxDepth, ancestors); message != "" {
+			return message
+		}
+	}
+	return ""
+}
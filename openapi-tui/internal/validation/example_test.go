@@ -0,0 +1,176 @@
+package validation
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+const exampleSpec = `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /users/{id}:
+    get:
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              example:
+                id: 1
+                name: Ada
+                roles: [admin]
+        default:
+          description: Error
+          content:
+            application/json:
+              schema:
+                type: object
+                example:
+                  error: not found
+  /health:
+    get:
+      responses:
+        '200':
+          description: OK
+`
+
+func TestResponseExample(t *testing.T) {
+	specPath := filepath.Join(t.TempDir(), "spec.yaml")
+	if err := os.WriteFile(specPath, []byte(exampleSpec), 0644); err != nil {
+		t.Fatalf("Failed to write spec: %v", err)
+	}
+	doc, err := openapi3.NewLoader().LoadFromFile(specPath)
+	if err != nil {
+		t.Fatalf("Failed to load spec: %v", err)
+	}
+	users := doc.Paths.Find("/users/{id}").Get
+
+	if got := string(ResponseExample(users, 200)); got != `{"id":1,"name":"Ada","roles":["admin"]}` {
+		t.Errorf("Unexpected 200 example: %s", got)
+	}
+	// Other statuses fall back to the default response's schema example
+	if got := string(ResponseExample(users, 404)); got != `{"error":"not found"}` {
+		t.Errorf("Unexpected default example: %s", got)
+	}
+	if got := ResponseExample(doc.Paths.Find("/health").Get, 200); got != nil {
+		t.Errorf("Expected no example, got %s", got)
+	}
+}
+
+func TestDiffExampleVsActual(t *testing.T) {
+	example := []byte(`{"id": 1, "name": "Ada", "roles": ["admin"]}`)
+
+	tests := []struct {
+		name   string
+		actual string
+		want   []string
+	}{
+		{"matches", `{"roles": ["admin"], "name": "Ada", "id": 1}`, nil},
+		{"one field differs", `{"id": 1, "name": "Grace", "roles": ["admin"]}`, []string{`~ name: "Ada" → "Grace"`}},
+		{"missing and extra fields", `{"id": 1, "email": "a@example.com", "roles": ["admin"]}`, []string{`+ email: "a@example.com"`, `- name: "Ada"`}},
+		{"array items", `{"id": 1, "name": "Ada", "roles": ["admin", "dev"]}`, []string{`+ roles[1]: "dev"`}},
+		{"type change", `{"id": "1", "name": "Ada", "roles": ["admin"]}`, []string{`~ id: 1 → "1"`}},
+		{"root type change", `[1]`, []string{`~ $: {"id":1,"name":"Ada","roles":["admin"]} → [1]`}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DiffExampleVsActual(example, []byte(tt.actual)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DiffExampleVsActual() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if got := DiffExampleVsActual(example, []byte("not json")); len(got) != 1 {
+		t.Errorf("Expected a single line for a non-JSON response, got %q", got)
+	}
+}
+
+// nodeSchema describes a tree node whose children are nodes
+func nodeSchema() *openapi3.SchemaRef {
+	node := openapi3.NewObjectSchema()
+	ref := &openapi3.SchemaRef{Value: node}
+	node.Properties = openapi3.Schemas{
+		"name":     openapi3.NewStringSchema().NewRef(),
+		"children": &openapi3.SchemaRef{Value: openapi3.NewArraySchema().WithItems(node)},
+	}
+	return ref
+}
+
+func TestValidateExamples_SelfReferential(t *testing.T) {
+	// An example containing itself can only be built in code
+	cyclic := map[string]interface{}{"name": "root"}
+	cyclic["children"] = []interface{}{cyclic}
+	leaf := map[string]interface{}{"name": "leaf"}
+	shared := map[string]interface{}{"name": "root", "children": []interface{}{leaf, leaf}}
+
+	content := openapi3.NewContentWithJSONSchemaRef(nodeSchema())
+	content["application/json"].Examples = openapi3.Examples{
+		"cyclic":  {Value: openapi3.NewExample(cyclic)},
+		"shared":  {Value: openapi3.NewExample(shared)},
+		"invalid": {Value: openapi3.NewExample(map[string]interface{}{"name": 42})},
+	}
+	doc := &openapi3.T{Paths: openapi3.NewPaths()}
+	doc.Paths.Set("/tree", &openapi3.PathItem{Post: &openapi3.Operation{
+		RequestBody: &openapi3.RequestBodyRef{Value: openapi3.NewRequestBody().WithContent(content)},
+	}})
+
+	done := make(chan []string, 1)
+	go func() { done <- ValidateExamples(doc, 0) }()
+	var messages []string
+	select {
+	case messages = <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("ValidateExamples did not terminate on a self-referential example")
+	}
+
+	if len(messages) != 2 {
+		t.Fatalf("Expected the cyclic and invalid examples to be reported, got %q", messages)
+	}
+	if !strings.Contains(messages[0], `"cyclic" refers to itself`) {
+		t.Errorf("Expected the cycle to be reported, got %q", messages[0])
+	}
+	if !strings.Contains(messages[1], `"invalid" does not match its schema`) {
+		t.Errorf("Expected the schema mismatch to be reported, got %q", messages[1])
+	}
+}
+
+func TestValidateSpecWithOptions_ExampleMaxDepth(t *testing.T) {
+	spec := `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /tree:
+    get:
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: object
+              example:
+                a: {b: {c: {d: 1}}}
+`
+	specPath := filepath.Join(t.TempDir(), "openapi.yaml")
+	if err := os.WriteFile(specPath, []byte(spec), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := ValidateSpecWithOptions(specPath, SpecOptions{}); err != nil {
+		t.Errorf("Expected the default depth to accept the example, got %v", err)
+	}
+	_, _, err := ValidateSpecWithOptions(specPath, SpecOptions{ExampleMaxDepth: 2})
+	if err == nil || !strings.Contains(err.Error(), "nests deeper than 2 levels") || !strings.Contains(err.Error(), "GET /tree 200 response example") {
+		t.Errorf("Expected the nesting limit to be reported, got %v", err)
+	}
+}
@@ -0,0 +1,239 @@
+package validation
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/Traves-Theberge/OpenAPI-Toolkit/openapi-tui/internal/errors"
+	"github.com/Traves-Theberge/OpenAPI-Toolkit/openapi-tui/internal/models"
+)
+
+// componentSections maps the component kinds named in validation errors
+// to their section under components
+var componentSections = map[string]string{
+	"schema":         "schemas",
+	"parameter":      "parameters",
+	"header":         "headers",
+	"requestBody":    "requestBodies",
+	"response":       "responses",
+	"securityScheme": "securitySchemes",
+	"example":        "examples",
+	"link":           "links",
+	"callback":       "callbacks",
+}
+
+var (
+	componentErrorRe = regexp.MustCompile(`^invalid components: (\w+) "([^"]+)"`)
+	operationErrorRe = regexp.MustCompile(`^invalid paths: invalid path (\S+): invalid operation (\w+)`)
+	pathParamErrorRe = regexp.MustCompile(`^invalid paths: operation (\w+) (\S+) `)
+	pathErrorRe      = regexp.MustCompile(`^invalid paths: invalid path (\S+):`)
+	sectionErrorRe   = regexp.MustCompile(`^invalid (info|servers|tags):`)
+)
+
+// locateInSpec returns the line in the spec file at path where the value
+// addressed by jsonPath starts. jsonPath is a JSON Pointer such as
+// "#/components/schemas/User" or "/paths/~1users/get"
+// Works for both YAML and JSON specs
+func locateInSpec(path string, jsonPath string) (int, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return 0, fmt.Errorf("failed to parse spec: %w", err)
+	}
+
+	_, line, err := findSpecNode(&root, jsonPath)
+	return line, err
+}
+
+// findSpecNode walks a parsed spec to the value addressed by jsonPath
+// Returns the node and the line where it starts
+func findSpecNode(root *yaml.Node, jsonPath string) (*yaml.Node, int, error) {
+	node := root
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+	line := node.Line
+
+	for _, segment := range pointerSegments(jsonPath) {
+		var next *yaml.Node
+		switch node.Kind {
+		case yaml.MappingNode:
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				if node.Content[i].Value == segment {
+					line = node.Content[i].Line
+					next = node.Content[i+1]
+					break
+				}
+			}
+		case yaml.SequenceNode:
+			if idx, err := strconv.Atoi(segment); err == nil && idx >= 0 && idx < len(node.Content) {
+				next = node.Content[idx]
+				line = next.Line
+			}
+		}
+		if next == nil {
+			return nil, 0, fmt.Errorf("%s not found in spec", jsonPath)
+		}
+		node = next
+	}
+
+	return node, line, nil
+}
+
+// OperationDefinition returns the raw definition of the operation for method
+// and path (a spec path template such as "/users/{id}") as YAML, exactly as
+// written in the spec: parameters, request body, responses and descriptions,
+// with $refs left as references. Parameters shared by every operation on the
+// path are appended in their own section
+func OperationDefinition(specPath, method, path string) (string, error) {
+	data, err := os.ReadFile(specPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read spec file: %w", err)
+	}
+
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return "", fmt.Errorf("failed to parse spec: %w", err)
+	}
+
+	pathPointer := "/paths/" + escapePointer(path)
+	operation, _, err := findSpecNode(&root, pathPointer+"/"+strings.ToLower(method))
+	if err != nil {
+		return "", fmt.Errorf("%s %s not found in spec", strings.ToUpper(method), path)
+	}
+
+	definition, err := yaml.Marshal(operation)
+	if err != nil {
+		return "", fmt.Errorf("failed to render operation: %w", err)
+	}
+	text := string(definition)
+
+	if shared, _, err := findSpecNode(&root, pathPointer+"/parameters"); err == nil {
+		params, err := yaml.Marshal(map[string]*yaml.Node{"parameters": shared})
+		if err == nil {
+			text += "\n# Path-level parameters\n" + string(params)
+		}
+	}
+
+	return text, nil
+}
+
+// specMethods are the operation keys of an OpenAPI path item
+var specMethods = map[string]bool{
+	"get": true, "put": true, "post": true, "delete": true,
+	"options": true, "head": true, "patch": true, "trace": true,
+}
+
+// OperationOrder returns the spec's operations as "METHOD path" keys, in the
+// order the spec file lists them, for running tests in the same order as the
+// spec instead of sorted
+func OperationOrder(specPath string) ([]string, error) {
+	data, err := os.ReadFile(specPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read spec file: %w", err)
+	}
+
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("failed to parse spec: %w", err)
+	}
+
+	paths, _, err := findSpecNode(&root, "/paths")
+	if err != nil || paths.Kind != yaml.MappingNode {
+		return nil, nil
+	}
+
+	var order []string
+	for i := 0; i+1 < len(paths.Content); i += 2 {
+		path, item := paths.Content[i].Value, paths.Content[i+1]
+		if item.Kind != yaml.MappingNode {
+			continue
+		}
+		for j := 0; j+1 < len(item.Content); j += 2 {
+			if method := item.Content[j].Value; specMethods[strings.ToLower(method)] {
+				order = append(order, models.EndpointKey(method, path))
+			}
+		}
+	}
+	return order, nil
+}
+
+// pointerSegments splits a JSON Pointer into unescaped reference tokens
+func pointerSegments(jsonPath string) []string {
+	jsonPath = strings.TrimPrefix(jsonPath, "#")
+	if jsonPath == "" || jsonPath == "/" {
+		return nil
+	}
+
+	segments := strings.Split(strings.TrimPrefix(jsonPath, "/"), "/")
+	for i, s := range segments {
+		segments[i] = strings.ReplaceAll(strings.ReplaceAll(s, "~1", "/"), "~0", "~")
+	}
+	return segments
+}
+
+// escapePointer escapes a single JSON Pointer reference token
+func escapePointer(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
+
+// specPointer derives a JSON Pointer from an OpenAPI validation error message
+// Returns "" when the message doesn't identify a location. The patterns follow
+// kin-openapi's message formats, pinned by TestSpecPointer_ValidatorMessages
+func specPointer(err error) string {
+	msg := err.Error()
+
+	if m := componentErrorRe.FindStringSubmatch(msg); m != nil {
+		if section, ok := componentSections[m[1]]; ok {
+			return "/components/" + section + "/" + escapePointer(m[2])
+		}
+		return "/components"
+	}
+	if m := operationErrorRe.FindStringSubmatch(msg); m != nil {
+		return "/paths/" + escapePointer(m[1]) + "/" + strings.ToLower(m[2])
+	}
+	if m := pathParamErrorRe.FindStringSubmatch(msg); m != nil {
+		return "/paths/" + escapePointer(m[2]) + "/" + strings.ToLower(m[1])
+	}
+	if m := pathErrorRe.FindStringSubmatch(msg); m != nil {
+		return "/paths/" + escapePointer(m[1])
+	}
+	if m := sectionErrorRe.FindStringSubmatch(msg); m != nil {
+		return "/" + m[1]
+	}
+	return ""
+}
+
+// withSpecLocation attaches the file and line of pointer to err so users can
+// jump to the problem. err is returned unchanged if the location can't be found
+func withSpecLocation(err error, filePath, pointer string) error {
+	if pointer == "" {
+		return err
+	}
+	line, lerr := locateInSpec(filePath, pointer)
+	if lerr != nil {
+		return err
+	}
+	location := fmt.Sprintf("%s:%d", filePath, line)
+
+	if enhanced, ok := err.(*errors.EnhancedError); ok {
+		located := *enhanced
+		located.Location = location
+		return &located
+	}
+	return &errors.EnhancedError{
+		Title:       "Validation Failed",
+		Description: err.Error(),
+		Location:    location,
+		Original:    err,
+	}
+}
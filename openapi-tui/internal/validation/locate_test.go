@@ -0,0 +1,224 @@
+package validation
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+const locateSpec = `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /users/{id}:
+    get:
+      security:
+        - oauth2: []
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        '200':
+          description: OK
+components:
+  schemas:
+    User:
+      type: object
+      properties:
+        name:
+          type: string
+`
+
+func writeLocateSpec(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write spec: %v", err)
+	}
+	return path
+}
+
+func TestLocateInSpec(t *testing.T) {
+	specPath := writeLocateSpec(t, "spec.yaml", locateSpec)
+
+	tests := []struct {
+		jsonPath string
+		want     int
+	}{
+		{"#/components/schemas/User", 21},
+		{"/components/schemas/User/properties/name", 24},
+		{"/paths/~1users~1{id}/get", 7},
+		{"/paths/~1users~1{id}/get/security/0", 9},
+		{"", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.jsonPath, func(t *testing.T) {
+			line, err := locateInSpec(specPath, tt.jsonPath)
+			if err != nil {
+				t.Fatalf("locateInSpec(%q) failed: %v", tt.jsonPath, err)
+			}
+			if line != tt.want {
+				t.Errorf("locateInSpec(%q) = %d, want %d", tt.jsonPath, line, tt.want)
+			}
+		})
+	}
+
+	if _, err := locateInSpec(specPath, "/components/schemas/Missing"); err == nil {
+		t.Error("Expected error for a path that isn't in the spec")
+	}
+}
+
+func TestLocateInSpec_JSON(t *testing.T) {
+	specPath := writeLocateSpec(t, "spec.json", `{
+  "openapi": "3.0.0",
+  "components": {
+    "schemas": {
+      "User": {"type": "object"}
+    }
+  }
+}`)
+
+	line, err := locateInSpec(specPath, "/components/schemas/User")
+	if err != nil {
+		t.Fatalf("locateInSpec failed: %v", err)
+	}
+	if line != 5 {
+		t.Errorf("Expected line 5, got %d", line)
+	}
+}
+
+func TestSpecPointer(t *testing.T) {
+	tests := []struct {
+		msg  string
+		want string
+	}{
+		{`invalid components: schema "User": unsupported 'type' value 'strin'`, "/components/schemas/User"},
+		{`invalid paths: invalid path /users/{id}: invalid operation GET: value of responses must be an object`, "/paths/~1users~1{id}/get"},
+		{`invalid paths: operation GET /users/{id} must define exactly all path parameters (missing: [id])`, "/paths/~1users~1{id}/get"},
+		{`invalid info: must be an object`, "/info"},
+		{`something unexpected`, ""},
+	}
+
+	for _, tt := range tests {
+		if got := specPointer(errors.New(tt.msg)); got != tt.want {
+			t.Errorf("specPointer(%q) = %q, want %q", tt.msg, got, tt.want)
+		}
+	}
+}
+
+// TestSpecPointer_ValidatorMessages pins the kin-openapi message formats that
+// specPointer parses, one spec per pattern, so a validator upgrade that changes
+// them fails here instead of silently dropping locations
+func TestSpecPointer_ValidatorMessages(t *testing.T) {
+	const header = "openapi: 3.0.0\ninfo: {title: t, version: '1'}\n"
+	tests := []struct {
+		name string
+		spec string
+		want string
+	}{
+		{
+			name: "component",
+			spec: header + "paths: {}\ncomponents:\n  schemas:\n    User: {type: strin}\n",
+			want: "/components/schemas/User",
+		},
+		{
+			name: "operation",
+			spec: header + "paths:\n  /users:\n    get:\n      description: no responses\n",
+			want: "/paths/~1users/get",
+		},
+		{
+			name: "path parameters",
+			spec: header + "paths:\n  /users/{id}:\n    get:\n      responses:\n        '200': {description: OK}\n",
+			want: "/paths/~1users~1{id}/get",
+		},
+		{
+			name: "path",
+			spec: header + "paths:\n  /users:\n    parameters:\n      - {name: q, in: nowhere, schema: {type: string}}\n    get:\n      responses:\n        '200': {description: OK}\n",
+			want: "/paths/~1users",
+		},
+		{
+			name: "info",
+			spec: "openapi: 3.0.0\ninfo: {title: t}\npaths: {}\n",
+			want: "/info",
+		},
+		{
+			name: "servers",
+			spec: header + "servers:\n  - url: ''\npaths: {}\n",
+			want: "/servers",
+		},
+		{
+			name: "tags",
+			spec: header + "tags:\n  - name: a\n    externalDocs: {url: ''}\npaths: {}\n",
+			want: "/tags",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loader := openapi3.NewLoader()
+			doc, err := loader.LoadFromData([]byte(tt.spec))
+			if err != nil {
+				t.Fatalf("Failed to load spec: %v", err)
+			}
+			verr := doc.Validate(loader.Context)
+			if verr == nil {
+				t.Fatal("Expected a validation error")
+			}
+			if got := specPointer(verr); got != tt.want {
+				t.Errorf("specPointer(%q) = %q, want %q", verr.Error(), got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateSpec_ReportsLocation(t *testing.T) {
+	specPath := writeLocateSpec(t, "spec.yaml", strings.Replace(locateSpec, "name:\n          type: string", "name:\n          type: strin", 1))
+
+	_, err := ValidateSpec(specPath)
+	if err == nil {
+		t.Fatal("Expected validation error")
+	}
+	if !strings.Contains(err.Error(), specPath+":21") {
+		t.Errorf("Expected error to point at the User schema, got: %v", err)
+	}
+}
+
+func TestValidateSpec_SecuritySchemeLine(t *testing.T) {
+	specPath := writeLocateSpec(t, "spec.yaml", locateSpec)
+
+	_, err := ValidateSpec(specPath)
+	if err == nil {
+		t.Fatal("Expected undefined security scheme error")
+	}
+	if !strings.Contains(err.Error(), `unknown security scheme "oauth2" (line 9)`) {
+		t.Errorf("Expected the requirement's line, got: %v", err)
+	}
+}
+
+func TestOperationDefinition(t *testing.T) {
+	spec := strings.Replace(locateSpec, "  /users/{id}:\n", "  /users/{id}:\n    parameters:\n      - name: X-Tenant\n        in: header\n        schema:\n          type: string\n", 1)
+	specPath := writeLocateSpec(t, "spec.yaml", spec)
+
+	definition, err := OperationDefinition(specPath, "GET", "/users/{id}")
+	if err != nil {
+		t.Fatalf("OperationDefinition() error = %v", err)
+	}
+	for _, want := range []string{"parameters:", "name: id", "in: path", "description: OK", "# Path-level parameters", "name: X-Tenant"} {
+		if !strings.Contains(definition, want) {
+			t.Errorf("Expected definition to contain %q, got:\n%s", want, definition)
+		}
+	}
+
+	if _, err := OperationDefinition(specPath, "DELETE", "/users/{id}"); err == nil || !strings.Contains(err.Error(), "DELETE /users/{id} not found") {
+		t.Errorf("Expected a not-found error for an undefined operation, got %v", err)
+	}
+}
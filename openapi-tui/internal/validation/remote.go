@@ -0,0 +1,149 @@
+package validation
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/Traves-Theberge/OpenAPI-Toolkit/openapi-tui/internal/errors"
+)
+
+// Guards for specs loaded from a URL
+const (
+	DefaultSpecFetchTimeout = 30 * time.Second // Whole download, including reading the body
+	DefaultSpecMaxBytes     = 10 << 20         // 10 MB
+)
+
+// IsSpecURL reports whether a spec path is an http(s) URL to download
+func IsSpecURL(path string) bool {
+	return strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://")
+}
+
+// FetchSpec downloads a spec from rawURL and saves it to a temporary file,
+// returning the file's path, so remote specs go through the same path-based
+// flows as local files. The download is aborted once it takes longer than
+// timeout or grows past maxBytes, so a hanging or huge endpoint can't stall
+// the UI or exhaust memory; zero values use the defaults.
+// Relative $refs are rewritten against rawURL, since the saved copy no
+// longer sits next to the files they point at; loading it then fetches
+// them from the server. The content is not validated here: validating and
+// loading the saved file report problems the same way as for a local spec
+func FetchSpec(rawURL string, timeout time.Duration, maxBytes int64) (string, error) {
+	if timeout <= 0 {
+		timeout = DefaultSpecFetchTimeout
+	}
+	if maxBytes <= 0 {
+		maxBytes = DefaultSpecMaxBytes
+	}
+
+	client := &http.Client{Timeout: timeout}
+	resp, err := client.Get(rawURL)
+	if err != nil {
+		return "", errors.EnhanceNetworkError(err, rawURL)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", &errors.EnhancedError{
+			Title:       "Spec Download Failed",
+			Description: fmt.Sprintf("GET %s returned %d", rawURL, resp.StatusCode),
+			Suggestions: []string{
+				"Check the spec URL is spelled correctly",
+				"Open the URL in a browser to see if it needs authentication",
+			},
+		}
+	}
+	if resp.ContentLength > maxBytes {
+		return "", specTooLargeError(rawURL, maxBytes)
+	}
+
+	// Read one byte past the cap to tell "exactly at the limit" from "over it"
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxBytes+1))
+	if err != nil {
+		return "", errors.EnhanceNetworkError(err, rawURL)
+	}
+	if int64(len(data)) > maxBytes {
+		return "", specTooLargeError(rawURL, maxBytes)
+	}
+
+	pattern := "openapi-tui-spec-*.yaml"
+	if rewritten, ok := absoluteRefs(data, rawURL); ok {
+		// The rewritten spec is YAML, even when the download was JSON
+		data = rewritten
+	} else if strings.HasSuffix(strings.ToLower(strings.SplitN(rawURL, "?", 2)[0]), ".json") {
+		pattern = "openapi-tui-spec-*.json"
+	}
+	file, err := os.CreateTemp("", pattern)
+	if err != nil {
+		return "", fmt.Errorf("failed to save spec: %w", err)
+	}
+	defer file.Close()
+	if _, err := file.Write(data); err != nil {
+		return "", fmt.Errorf("failed to save spec: %w", err)
+	}
+	return file.Name(), nil
+}
+
+// specTooLargeError reports a spec download that went past the size cap
+func specTooLargeError(rawURL string, maxBytes int64) error {
+	return &errors.EnhancedError{
+		Title:       "Spec Too Large",
+		Description: fmt.Sprintf("%s is larger than the %d byte limit for downloaded specs", rawURL, maxBytes),
+		Suggestions: []string{
+			"Check the URL points to the spec and not to a large file or stream",
+			"Raise specMaxSizeMB in config.yaml if the spec really is this big",
+			"Download the spec and open it as a local file",
+		},
+	}
+}
+
+// absoluteRefs returns a downloaded spec with its relative $refs resolved
+// against specURL, and false when it has none (or can't be parsed, which
+// loading the saved file reports), so such specs are saved byte for byte
+func absoluteRefs(data []byte, specURL string) ([]byte, bool) {
+	base, err := url.Parse(specURL)
+	if err != nil {
+		return nil, false
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil || !resolveRefs(&doc, base) {
+		return nil, false
+	}
+	rewritten, err := yaml.Marshal(&doc)
+	if err != nil {
+		return nil, false
+	}
+	return rewritten, true
+}
+
+// resolveRefs rewrites the relative $ref values under node against base,
+// reporting whether it changed any. Local refs (#/...) are left alone
+func resolveRefs(node *yaml.Node, base *url.URL) bool {
+	changed := false
+	if node.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			if key.Value != "$ref" || value.Kind != yaml.ScalarNode || strings.HasPrefix(value.Value, "#") {
+				continue
+			}
+			ref, err := url.Parse(value.Value)
+			if err != nil || ref.IsAbs() {
+				continue
+			}
+			value.Value = base.ResolveReference(ref).String()
+			changed = true
+		}
+	}
+	for _, child := range node.Content {
+		if resolveRefs(child, base) {
+			changed = true
+		}
+	}
+	return changed
+}
@@ -0,0 +1,150 @@
+package validation
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+
+	"github.com/Traves-Theberge/OpenAPI-Toolkit/openapi-tui/internal/errors"
+)
+
+func TestFetchSpec(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(locateSpec))
+	}))
+	defer server.Close()
+
+	path, err := FetchSpec(server.URL+"/openapi.yaml", time.Second, 0)
+	if err != nil {
+		t.Fatalf("FetchSpec() error = %v", err)
+	}
+	defer os.Remove(path)
+
+	data, err := os.ReadFile(path)
+	if err != nil || string(data) != locateSpec {
+		t.Errorf("Expected the downloaded spec to be saved as-is, got %q (%v)", data, err)
+	}
+}
+
+func TestFetchSpec_SizeGuard(t *testing.T) {
+	// Streams chunks without a Content-Length until the client hangs up
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		chunk := []byte(strings.Repeat("a", 1024))
+		for i := 0; i < 1024; i++ {
+			if _, err := w.Write(chunk); err != nil {
+				return
+			}
+			w.(http.Flusher).Flush()
+		}
+	}))
+	defer server.Close()
+
+	_, err := FetchSpec(server.URL, 5*time.Second, 4096)
+	enhanced, ok := err.(*errors.EnhancedError)
+	if !ok || enhanced.Title != "Spec Too Large" || !strings.Contains(enhanced.Description, "4096 byte limit") {
+		t.Fatalf("Expected the size guard to trip, got %v", err)
+	}
+}
+
+func TestFetchSpec_Timeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	start := time.Now()
+	_, err := FetchSpec(server.URL, 100*time.Millisecond, 0)
+	enhanced, ok := err.(*errors.EnhancedError)
+	if !ok || enhanced.Title != "Request Timeout" {
+		t.Fatalf("Expected a timeout error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("Expected the download to be abandoned quickly, took %s", elapsed)
+	}
+}
+
+func TestIsSpecURL(t *testing.T) {
+	for path, want := range map[string]bool{
+		"https://api.example.com/openapi.yaml": true,
+		"http://localhost:8080/spec.json":      true,
+		"specs/openapi.yaml":                   false,
+		"/tmp/https-spec.yaml":                 false,
+	} {
+		if got := IsSpecURL(path); got != want {
+			t.Errorf("IsSpecURL(%q) = %v, want %v", path, got, want)
+		}
+	}
+}
+
+func TestFetchSpec_RelativeRefs(t *testing.T) {
+	spec := `openapi: 3.0.0
+info:
+  title: Refs API
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      responses:
+        '200':
+          description: A pet
+          content:
+            application/json:
+              schema:
+                $ref: 'schemas/pet.yaml#/Pet'
+        '404':
+          $ref: '#/components/responses/NotFound'
+components:
+  responses:
+    NotFound:
+      description: Not found
+`
+	schemas := `Pet:
+  type: object
+  properties:
+    name:
+      type: string
+`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/openapi.yaml":
+			w.Write([]byte(spec))
+		case "/api/schemas/pet.yaml":
+			w.Write([]byte(schemas))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	path, err := FetchSpec(server.URL+"/api/openapi.yaml", time.Second, 0)
+	if err != nil {
+		t.Fatalf("FetchSpec() error = %v", err)
+	}
+	defer os.Remove(path)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), server.URL+"/api/schemas/pet.yaml#/Pet") {
+		t.Errorf("Expected the relative ref to be resolved against the spec URL, got:\n%s", data)
+	}
+	if !strings.Contains(string(data), "#/components/responses/NotFound") {
+		t.Errorf("Expected the local ref to be kept, got:\n%s", data)
+	}
+
+	loader := &openapi3.Loader{IsExternalRefsAllowed: true}
+	doc, err := loader.LoadFromFile(path)
+	if err != nil {
+		t.Fatalf("Expected the saved spec to load with its remote refs, got %v", err)
+	}
+	schema := doc.Paths.Value("/pets").Get.Responses.Value("200").Value.Content["application/json"].Schema
+	if schema.Value == nil || schema.Value.Properties["name"] == nil {
+		t.Errorf("Expected the remote schema to be resolved, got %+v", schema.Value)
+	}
+}
@@ -0,0 +1,198 @@
+package validation
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// FindOperation finds the spec operation for a method and request URL
+// The URL may be absolute or just a path; a server base path in front of the
+// spec path (e.g. "/v1") is allowed. When several paths match, the one with the
+// most literal segments wins, so "/users/me" beats "/users/{id}".
+// Returns an empty path and nil operation when nothing matches.
+func FindOperation(doc *openapi3.T, method, rawURL string) (string, *openapi3.Operation) {
+	if doc == nil || doc.Paths == nil {
+		return "", nil
+	}
+
+	urlPath := rawURL
+	if u, err := url.Parse(rawURL); err == nil {
+		urlPath = u.Path
+	}
+	segments := splitPath(urlPath)
+
+	bestPath := ""
+	var best *openapi3.Operation
+	bestLiterals := -1
+	for path, pathItem := range doc.Paths.Map() {
+		operation := pathItem.GetOperation(strings.ToUpper(method))
+		if operation == nil {
+			continue
+		}
+		literals, ok := matchPathSuffix(splitPath(path), segments)
+		if !ok {
+			continue
+		}
+		// Sort order of the paths map is random, so break ties by path
+		if literals > bestLiterals || (literals == bestLiterals && path < bestPath) {
+			bestPath, best, bestLiterals = path, operation, literals
+		}
+	}
+	return bestPath, best
+}
+
+// splitPath splits a URL path into its non-empty segments
+func splitPath(p string) []string {
+	var segments []string
+	for _, s := range strings.Split(p, "/") {
+		if s != "" {
+			segments = append(segments, s)
+		}
+	}
+	return segments
+}
+
+// matchPathSuffix reports whether a spec path template matches the end of the
+// URL segments, and how many of its segments matched literally
+func matchPathSuffix(template, segments []string) (int, bool) {
+	if len(template) > len(segments) {
+		return 0, false
+	}
+	offset := len(segments) - len(template)
+	literals := 0
+	for i, t := range template {
+		if strings.HasPrefix(t, "{") && strings.HasSuffix(t, "}") {
+			continue
+		}
+		if t != segments[offset+i] {
+			return 0, false
+		}
+		literals++
+	}
+	return literals, true
+}
+
+// ValidateRequest checks request headers and a JSON body against an operation
+// Returns a readable problem per missing required header or body schema violation
+func ValidateRequest(operation *openapi3.Operation, headers map[string]string, body string) []string {
+	if operation == nil {
+		return nil
+	}
+	var problems []string
+
+	for _, ref := range operation.Parameters {
+		param := ref.Value
+		if param == nil || param.In != openapi3.ParameterInHeader || !param.Required {
+			continue
+		}
+		if !hasHeader(headers, param.Name) {
+			problems = append(problems, fmt.Sprintf("missing required header %q", param.Name))
+		}
+	}
+
+	if operation.RequestBody == nil || operation.RequestBody.Value == nil {
+		return problems
+	}
+	requestBody := operation.RequestBody.Value
+	if body == "" {
+		if requestBody.Required {
+			problems = append(problems, "request body is required")
+		}
+		return problems
+	}
+
+	// Non-JSON bodies (XML, form data, ...) are sent as typed, unchecked
+	contentType := HeaderValue(headers, "Content-Type")
+	if contentType != "" && !IsJSONContentType(contentType) {
+		return problems
+	}
+	mediaType := requestBody.Content.Get("application/json")
+	if mediaType == nil || mediaType.Schema == nil || mediaType.Schema.Value == nil {
+		return problems
+	}
+	var value interface{}
+	if err := json.Unmarshal([]byte(body), &value); err != nil {
+		return append(problems, fmt.Sprintf("body is not valid JSON: %v", err))
+	}
+	if err := mediaType.Schema.Value.VisitJSON(value, openapi3.MultiErrors()); err != nil {
+		problems = append(problems, schemaErrors(err)...)
+	}
+	return problems
+}
+
+// hasHeader reports whether a header is set, ignoring case
+func hasHeader(headers map[string]string, name string) bool {
+	for k := range headers {
+		if strings.EqualFold(k, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// HeaderValue returns a header's value, ignoring the case of its name
+func HeaderValue(headers map[string]string, name string) string {
+	for k, v := range headers {
+		if strings.EqualFold(k, name) {
+			return v
+		}
+	}
+	return ""
+}
+
+// IsJSONContentType reports whether a Content-Type names JSON:
+// application/json or any +json suffix type, parameters ignored
+func IsJSONContentType(contentType string) bool {
+	mediaType := strings.ToLower(strings.TrimSpace(strings.Split(contentType, ";")[0]))
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// schemaErrors flattens a schema validation error into one line per problem
+func schemaErrors(err error) []string {
+	var problems []string
+	if multi, ok := err.(openapi3.MultiError); ok {
+		for _, e := range multi {
+			problems = append(problems, schemaErrors(e)...)
+		}
+		return problems
+	}
+	if schemaErr, ok := err.(*openapi3.SchemaError); ok {
+		field := strings.Join(schemaErr.JSONPointer(), ".")
+		if field == "" {
+			return []string{"body: " + schemaErr.Reason}
+		}
+		return []string{fmt.Sprintf("body.%s: %s", field, schemaErr.Reason)}
+	}
+	return []string{err.Error()}
+}
+
+// CheckCustomRequest validates a custom request against the matching operation
+// in a spec file, for warning before the request is sent
+// Checking is best-effort: a missing or unreadable spec, or a URL that matches
+// no operation, yields no warnings
+func CheckCustomRequest(specPath, method, endpoint string, headers map[string]string, body string) []string {
+	if specPath == "" {
+		return nil
+	}
+	loader := &openapi3.Loader{IsExternalRefsAllowed: true}
+	doc, err := loader.LoadFromFile(specPath)
+	if err != nil {
+		return nil
+	}
+
+	path, operation := FindOperation(doc, method, endpoint)
+	if operation == nil {
+		return nil
+	}
+	problems := ValidateRequest(operation, headers, body)
+	sort.Strings(problems)
+	for i, p := range problems {
+		problems[i] = fmt.Sprintf("%s %s: %s", strings.ToUpper(method), path, p)
+	}
+	return problems
+}
@@ -0,0 +1,160 @@
+package validation
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+const requestSpec = `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /users:
+    post:
+      parameters:
+        - name: X-Request-ID
+          in: header
+          required: true
+          schema:
+            type: string
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [name, email]
+              properties:
+                name:
+                  type: string
+                email:
+                  type: string
+      responses:
+        '201':
+          description: Created
+  /users/{id}:
+    get:
+      responses:
+        '200':
+          description: OK
+  /users/me:
+    get:
+      responses:
+        '200':
+          description: OK
+`
+
+func loadRequestSpec(t *testing.T) (*openapi3.T, string) {
+	t.Helper()
+	specPath := filepath.Join(t.TempDir(), "spec.yaml")
+	if err := os.WriteFile(specPath, []byte(requestSpec), 0644); err != nil {
+		t.Fatalf("Failed to write spec: %v", err)
+	}
+	doc, err := openapi3.NewLoader().LoadFromFile(specPath)
+	if err != nil {
+		t.Fatalf("Failed to load spec: %v", err)
+	}
+	return doc, specPath
+}
+
+func TestFindOperation(t *testing.T) {
+	doc, _ := loadRequestSpec(t)
+
+	tests := []struct {
+		method string
+		url    string
+		want   string
+	}{
+		{"POST", "https://api.example.com/users", "/users"},
+		{"GET", "https://api.example.com/v1/users/42", "/users/{id}"},
+		{"GET", "/users/me", "/users/me"},
+		{"get", "/users/me?fields=name", "/users/me"},
+		{"DELETE", "/users/42", ""},
+		{"GET", "/orders", ""},
+	}
+
+	for _, tt := range tests {
+		path, operation := FindOperation(doc, tt.method, tt.url)
+		if path != tt.want {
+			t.Errorf("FindOperation(%s %s) = %q, want %q", tt.method, tt.url, path, tt.want)
+		}
+		if (operation != nil) != (tt.want != "") {
+			t.Errorf("FindOperation(%s %s) operation = %v", tt.method, tt.url, operation)
+		}
+	}
+}
+
+func TestCheckCustomRequest(t *testing.T) {
+	_, specPath := loadRequestSpec(t)
+	headers := map[string]string{"x-request-id": "abc"}
+
+	t.Run("missing required field warns", func(t *testing.T) {
+		warnings := CheckCustomRequest(specPath, "POST", "https://api.example.com/users", headers, `{"name": "Ada"}`)
+		if len(warnings) != 1 {
+			t.Fatalf("Expected 1 warning, got %v", warnings)
+		}
+		if !strings.HasPrefix(warnings[0], "POST /users: ") || !strings.Contains(warnings[0], `"email" is missing`) {
+			t.Errorf("Unexpected warning: %s", warnings[0])
+		}
+	})
+
+	t.Run("missing header and body", func(t *testing.T) {
+		warnings := CheckCustomRequest(specPath, "POST", "https://api.example.com/users", nil, "")
+		if len(warnings) != 2 {
+			t.Fatalf("Expected 2 warnings, got %v", warnings)
+		}
+		if !strings.Contains(warnings[0], `missing required header "X-Request-ID"`) ||
+			!strings.Contains(warnings[1], "request body is required") {
+			t.Errorf("Unexpected warnings: %v", warnings)
+		}
+	})
+
+	t.Run("valid request", func(t *testing.T) {
+		if warnings := CheckCustomRequest(specPath, "POST", "https://api.example.com/users", headers, `{"name": "Ada", "email": "ada@example.com"}`); len(warnings) != 0 {
+			t.Errorf("Expected no warnings, got %v", warnings)
+		}
+	})
+
+	t.Run("non-JSON body skips schema checks", func(t *testing.T) {
+		xmlHeaders := map[string]string{"x-request-id": "abc", "content-type": "text/xml"}
+		if warnings := CheckCustomRequest(specPath, "POST", "https://api.example.com/users", xmlHeaders, `<user><name>Ada</name></user>`); len(warnings) != 0 {
+			t.Errorf("Expected no warnings for an XML body, got %v", warnings)
+		}
+	})
+
+	t.Run("relative refs resolve", func(t *testing.T) {
+		dir := t.TempDir()
+		spec := "openapi: 3.0.0\ninfo:\n  title: T\n  version: \"1.0\"\npaths:\n  /users:\n    post:\n" +
+			"      requestBody:\n        required: true\n        content:\n          application/json:\n" +
+			"            schema:\n              $ref: 'schemas/user.yaml'\n      responses:\n        \"201\":\n          description: Created\n"
+		user := "type: object\nrequired: [email]\nproperties:\n  email:\n    type: string\n"
+		if err := os.MkdirAll(filepath.Join(dir, "schemas"), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "schemas", "user.yaml"), []byte(user), 0644); err != nil {
+			t.Fatal(err)
+		}
+		path := filepath.Join(dir, "openapi.yaml")
+		if err := os.WriteFile(path, []byte(spec), 0644); err != nil {
+			t.Fatal(err)
+		}
+		warnings := CheckCustomRequest(path, "POST", "/users", nil, `{"name": "Ada"}`)
+		if len(warnings) != 1 || !strings.Contains(warnings[0], `"email" is missing`) {
+			t.Errorf("Expected the referenced schema to be checked, got %v", warnings)
+		}
+	})
+
+	t.Run("no spec or no matching operation", func(t *testing.T) {
+		if warnings := CheckCustomRequest("", "POST", "/users", nil, ""); warnings != nil {
+			t.Errorf("Expected no warnings without a spec, got %v", warnings)
+		}
+		if warnings := CheckCustomRequest(specPath, "POST", "/orders", nil, ""); warnings != nil {
+			t.Errorf("Expected no warnings for an unknown path, got %v", warnings)
+		}
+	})
+}
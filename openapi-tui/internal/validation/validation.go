@@ -1,9 +1,12 @@
 package validation
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"sort"
 	"strings"
 
 	"github.com/getkin/kin-openapi/openapi3"
@@ -14,25 +17,341 @@ import (
 // validateSpec validates an OpenAPI specification file
 // Returns success message or error with helpful suggestions
 func ValidateSpec(filePath string) (string, error) {
-	// Load OpenAPI document with external references allowed
+	message, _, err := ValidateSpecWithWarnings(filePath)
+	return message, err
+}
+
+// ValidateSpecWithWarnings validates an OpenAPI specification file like
+// ValidateSpec, and also returns lint warnings about a valid spec that is
+// likely incomplete, e.g. operations without a documented success response
+func ValidateSpecWithWarnings(filePath string) (string, []string, error) {
+	return ValidateSpecWithOptions(filePath, SpecOptions{})
+}
+
+// SpecOptions tunes spec validation
+type SpecOptions struct {
+	ExampleMaxDepth     int  // Deepest example nesting validated (0 = DefaultExampleMaxDepth)
+	ResponseConsistency bool // Also warn about response schemas out of line with the rest of the spec
+}
+
+// ValidateSpecWithOptions validates an OpenAPI specification file like
+// ValidateSpecWithWarnings, with the given limits
+func ValidateSpecWithOptions(filePath string, opts SpecOptions) (string, []string, error) {
+	loader, doc, err := loadSpec(filePath)
+	if err != nil {
+		return "", nil, err
+	}
+
+	// The loader keeps OpenAPI 3.1 webhooks as unknown data, which the
+	// validator rejects, so they are set aside and validated on their own
+	webhooks, hasWebhooks := doc.Extensions["webhooks"]
+	delete(doc.Extensions, "webhooks")
+
+	// Validate the document's structure, then its body examples with the
+	// cycle-safe checker so every bad example is listed with its location,
+	// then everything else, including examples inside schemas
+	err = doc.Validate(loader.Context, openapi3.DisableExamplesValidation())
+	if err == nil {
+		if problems := checkExamples(doc, opts.ExampleMaxDepth); len(problems) > 0 {
+			messages, location := locateProblems(filePath, problems)
+			return "", nil, &errors.EnhancedError{
+				Title:       "Invalid Example",
+				Description: strings.Join(messages, "\n"),
+				Suggestions: []string{
+					"Make each example match its media type's schema",
+					"Raise exampleMaxDepth in the config if a deeply nested example is intended",
+				},
+				Location: location,
+			}
+		}
+		err = doc.Validate(loader.Context)
+	}
+	if err != nil {
+		return "", nil, withSpecLocation(errors.EnhanceValidationError(err), filePath, specPointer(err))
+	}
+
+	// Lint checks the OpenAPI validator doesn't cover
+	if problems := checkSecuritySchemes(doc); len(problems) > 0 {
+		messages, location := locateProblems(filePath, problems)
+		return "", nil, &errors.EnhancedError{
+			Title:       "Undefined Security Scheme",
+			Description: strings.Join(messages, "\n"),
+			Suggestions: []string{
+				"Define each referenced scheme under components.securitySchemes",
+				"Check security requirement names for typos (names are case-sensitive)",
+			},
+			Location: location,
+		}
+	}
+
+	if hasWebhooks {
+		doc.Extensions["webhooks"] = webhooks
+		if err := validateWebhooks(doc); err != nil {
+			return "", nil, &errors.EnhancedError{
+				Title:       "Invalid Webhook",
+				Description: err.Error(),
+				Suggestions: []string{
+					"Make each webhook a path item with operations, like an entry under paths",
+					"Define each component the webhooks reference under components",
+				},
+				Original: err,
+			}
+		}
+	}
+
+	problems := append(checkSuccessResponses(doc), checkResponseContent(doc)...)
+	if opts.ResponseConsistency {
+		problems = append(problems, checkResponseConsistency(doc)...)
+	}
+	warnings, _ := locateProblems(filePath, problems)
+	return "OpenAPI spec is valid! 🎉", warnings, nil
+}
+
+// ParseOnly checks that a spec file parses as an OpenAPI document and its
+// references resolve, without the full semantic validation, which is slow on
+// very large specs. A spec that passes may still fail ValidateSpec
+func ParseOnly(filePath string) error {
+	_, _, err := loadSpec(filePath)
+	return err
+}
+
+// loadSpec loads an OpenAPI document with external references allowed,
+// reporting undefined component references and non-OpenAPI files clearly
+func loadSpec(filePath string) (*openapi3.Loader, *openapi3.T, error) {
 	loader := &openapi3.Loader{IsExternalRefsAllowed: true}
 	doc, err := loader.LoadFromFile(filePath)
 	if err != nil {
-		return "", errors.EnhanceFileError(err, filePath)
+		if problems := checkLocalRefs(filePath); len(problems) > 0 {
+			messages, location := locateProblems(filePath, problems)
+			return nil, nil, &errors.EnhancedError{
+				Title:       "Undefined Component Reference",
+				Description: strings.Join(messages, "\n"),
+				Suggestions: []string{
+					"Define each referenced component under components (e.g. components.schemas)",
+					"Check $ref names for typos (names are case-sensitive)",
+				},
+				Location: location,
+				Original: err,
+			}
+		}
+		return nil, nil, errors.EnhanceFileError(err, filePath)
+	}
+	if err := CheckOpenAPIDocument(doc, filePath); err != nil {
+		return nil, nil, err
 	}
+	return loader, doc, nil
+}
 
-	// Validate the loaded document
-	err = doc.Validate(loader.Context)
-	if err != nil {
-		return "", errors.EnhanceValidationError(err)
+// CheckOpenAPIDocument returns a friendly error for a file that parsed but
+// has no openapi version field, such as an unrelated JSON or YAML file or a
+// Swagger 2.0 spec, instead of the validator's cryptic one
+func CheckOpenAPIDocument(doc *openapi3.T, filePath string) error {
+	if doc.OpenAPI != "" {
+		return nil
 	}
+	swagger := ""
+	if version, ok := doc.Extensions["swagger"]; ok && version != nil {
+		swagger = fmt.Sprint(version)
+	}
+	return errors.NotOpenAPIError(filePath, swagger, nil)
+}
+
+// locateProblems formats lint findings with the spec line of each, and
+// returns the file location of the first one found
+func locateProblems(filePath string, problems []specProblem) ([]string, string) {
+	var messages []string
+	location := ""
+	for _, p := range problems {
+		message := p.Message
+		if line, err := locateInSpec(filePath, p.Pointer); err == nil {
+			message += fmt.Sprintf(" (line %d)", line)
+			if location == "" {
+				location = fmt.Sprintf("%s:%d", filePath, line)
+			}
+		}
+		messages = append(messages, message)
+	}
+	return messages, location
+}
+
+// checkSuccessResponses reports operations that document no 2xx (or 2XX)
+// and no default response, which usually means the spec is incomplete
+func checkSuccessResponses(doc *openapi3.T) []specProblem {
+	var problems []specProblem
+	if doc.Paths == nil {
+		return problems
+	}
+	paths := doc.Paths.InMatchingOrder()
+	sort.Strings(paths)
+	for _, path := range paths {
+		operations := doc.Paths.Value(path).Operations()
+		methods := make([]string, 0, len(operations))
+		for method := range operations {
+			methods = append(methods, method)
+		}
+		sort.Strings(methods)
+		for _, method := range methods {
+			if hasSuccessResponse(operations[method].Responses) {
+				continue
+			}
+			problems = append(problems, specProblem{
+				Pointer: "/paths/" + escapePointer(path) + "/" + strings.ToLower(method) + "/responses",
+				Message: fmt.Sprintf("%s %s: no 2xx or default response documented", method, path),
+			})
+		}
+	}
+	return problems
+}
+
+// hasSuccessResponse reports whether responses include a 2xx, 2XX or default entry
+func hasSuccessResponse(responses *openapi3.Responses) bool {
+	if responses == nil {
+		return false
+	}
+	for code := range responses.Map() {
+		if code == "default" || strings.HasPrefix(code, "2") {
+			return true
+		}
+	}
+	return false
+}
+
+// dataStatuses are the success statuses of a GET that return a representation
+// of the resource, so their responses should declare content. 204 and 205
+// never have a body, and 201 and 202 often don't
+var dataStatuses = map[string]bool{"200": true, "203": true, "206": true, "2XX": true}
+
+// checkResponseContent reports GET operations whose data-returning success
+// responses declare no content, which usually means the response schema
+// was left out. Other methods are skipped, as they may well return nothing
+func checkResponseContent(doc *openapi3.T) []specProblem {
+	var problems []specProblem
+	if doc.Paths == nil {
+		return problems
+	}
+	paths := doc.Paths.InMatchingOrder()
+	sort.Strings(paths)
+	for _, path := range paths {
+		operation := doc.Paths.Value(path).Get
+		if operation == nil || operation.Responses == nil {
+			continue
+		}
+		responses := operation.Responses.Map()
+		codes := make([]string, 0, len(responses))
+		for code := range responses {
+			codes = append(codes, code)
+		}
+		sort.Strings(codes)
+		for _, code := range codes {
+			response := responses[code]
+			if !dataStatuses[strings.ToUpper(code)] || response == nil || response.Value == nil || len(response.Value.Content) > 0 {
+				continue
+			}
+			problems = append(problems, specProblem{
+				Pointer: "/paths/" + escapePointer(path) + "/get/responses/" + escapePointer(code),
+				Message: fmt.Sprintf("GET %s: %s response declares no content", path, code),
+			})
+		}
+	}
+	return problems
+}
 
-	return "OpenAPI spec is valid! 🎉", nil
+// specProblem is a lint finding with the JSON Pointer of the offending value
+type specProblem struct {
+	Pointer string
+	Message string
+}
+
+// checkSecuritySchemes reports security requirements (global or per-operation)
+// that reference a scheme not defined in components.securitySchemes
+func checkSecuritySchemes(doc *openapi3.T) []specProblem {
+	var defined openapi3.SecuritySchemes
+	if doc.Components != nil {
+		defined = doc.Components.SecuritySchemes
+	}
+
+	check := func(location, pointer string, requirements openapi3.SecurityRequirements) []specProblem {
+		var problems []specProblem
+		for i, requirement := range requirements {
+			names := make([]string, 0, len(requirement))
+			for name := range requirement {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			for _, name := range names {
+				if _, ok := defined[name]; !ok {
+					problems = append(problems, specProblem{
+						Pointer: fmt.Sprintf("%s/%d/%s", pointer, i, escapePointer(name)),
+						Message: fmt.Sprintf("%s: unknown security scheme %q", location, name),
+					})
+				}
+			}
+		}
+		return problems
+	}
+
+	problems := check("global security", "/security", doc.Security)
+
+	if doc.Paths == nil {
+		return problems
+	}
+	paths := doc.Paths.InMatchingOrder()
+	sort.Strings(paths)
+	for _, path := range paths {
+		operations := doc.Paths.Value(path).Operations()
+		methods := make([]string, 0, len(operations))
+		for method := range operations {
+			methods = append(methods, method)
+		}
+		sort.Strings(methods)
+		for _, method := range methods {
+			if security := operations[method].Security; security != nil {
+				pointer := "/paths/" + escapePointer(path) + "/" + strings.ToLower(method) + "/security"
+				problems = append(problems, check(method+" "+path, pointer, *security)...)
+			}
+		}
+	}
+
+	return problems
 }
 
 // validateResponse validates an HTTP response against OpenAPI spec
 // Returns validation result with detailed error information
+// Validators added with RegisterResponseValidator run after the built-in
+// checks and add their messages to SchemaErrors.
 func ValidateResponse(resp *http.Response, operation *openapi3.Operation, statusCode int) models.ValidationResult {
+	return ValidateResponseAs(resp, operation, statusCode, "")
+}
+
+// ValidateResponseAs is ValidateResponse for a server that mislabels its
+// responses: the response is validated as assumedContentType, e.g. JSON sent
+// as text/plain, whatever its Content-Type header says. A body assumed to be
+// JSON must parse as JSON. An empty assumedContentType validates as sent
+func ValidateResponseAs(resp *http.Response, operation *openapi3.Operation, statusCode int, assumedContentType string) models.ValidationResult {
+	return ValidateResponseWithOptions(resp, operation, statusCode, ResponseOptions{AssumedContentType: assumedContentType})
+}
+
+// ResponseOptions tunes response validation
+type ResponseOptions struct {
+	AssumedContentType string // Validate the response as this content type, whatever its header says (empty = as sent)
+	Mode               string // How undocumented body fields are treated, models.ResponseValidation* ("" = lenient)
+}
+
+// ValidateResponseWithOptions validates a response like ValidateResponse,
+// with the given options. JSON bodies are also checked against the schema
+// of their media type
+func ValidateResponseWithOptions(resp *http.Response, operation *openapi3.Operation, statusCode int, opts ResponseOptions) models.ValidationResult {
+	result := validateResponseSpec(resp, operation, statusCode, opts)
+	if messages := runResponseValidators(resp, operation); len(messages) > 0 {
+		result.Valid = false
+		result.SchemaErrors = append(result.SchemaErrors, messages...)
+	}
+	return result
+}
+
+// validateResponseSpec runs the built-in status code and content type checks
+func validateResponseSpec(resp *http.Response, operation *openapi3.Operation, statusCode int, opts ResponseOptions) models.ValidationResult {
+	assumedContentType := opts.AssumedContentType
 	result := models.ValidationResult{
 		Valid:       true,
 		StatusValid: false,
@@ -74,6 +393,15 @@ func ValidateResponse(resp *http.Response, operation *openapi3.Operation, status
 		// Extract base content type (ignore charset, etc.)
 		contentType := strings.Split(result.ContentType, ";")[0]
 		contentType = strings.TrimSpace(contentType)
+		if assumedContentType != "" {
+			contentType = strings.TrimSpace(strings.Split(assumedContentType, ";")[0])
+			if IsJSONContentType(contentType) {
+				if message := checkJSONBody(resp, contentType); message != "" {
+					result.Valid = false
+					result.SchemaErrors = append(result.SchemaErrors, message)
+				}
+			}
+		}
 		
 		// Check if content type is defined in spec
 		mediaType := response.Value.Content.Get(contentType)
@@ -86,25 +414,160 @@ func ValidateResponse(resp *http.Response, operation *openapi3.Operation, status
 		}
 
 		if mediaType == nil && len(response.Value.Content) > 0 {
+			message := fmt.Sprintf("content-type '%s' not defined in spec", result.ContentType)
+			if assumedContentType != "" {
+				message = fmt.Sprintf("assumed content-type '%s' not defined in spec", contentType)
+			}
 			result.Valid = false
-			result.SchemaErrors = append(result.SchemaErrors,
-				fmt.Sprintf("content-type '%s' not defined in spec", result.ContentType))
+			result.ContentTypeMismatch = true
+			result.SchemaErrors = append(result.SchemaErrors, message)
 		}
 
-		// TODO: Add JSON schema validation against response body
-		// This would require parsing the response body and validating against mediaType.Schema
-		// For now, we just validate status code and content type
+		// Check a JSON body against its media type's schema
+		if mediaType != nil && mediaType.Schema != nil && mediaType.Schema.Value != nil && IsJSONContentType(contentType) {
+			if messages := validateJSONBody(resp, mediaType.Schema.Value, opts.Mode); len(messages) > 0 {
+				result.Valid = false
+				result.SchemaErrors = append(result.SchemaErrors, messages...)
+			}
+		}
 	}
 
 	return result
 }
 
-// validateResponseBody validates response body against OpenAPI schema
-// This is a placeholder for future implementation
+// checkJSONBody reports a response body that does not parse as JSON, though
+// it is assumed to be contentType. The body is restored on resp for later readers
+func checkJSONBody(resp *http.Response, contentType string) string {
+	if resp.Body == nil {
+		return ""
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{bytes.NewReader(body), resp.Body}
+	if len(bytes.TrimSpace(body)) > 0 && !json.Valid(body) {
+		return fmt.Sprintf("body is not valid JSON (assumed %s)", contentType)
+	}
+	return ""
+}
+
+// validateJSONBody checks a JSON response body against schema. The body is
+// restored on resp for later readers. A body that is empty or not JSON is
+// left to the content type checks
+func validateJSONBody(resp *http.Response, schema *openapi3.Schema, mode string) []string {
+	if resp.Body == nil {
+		return nil
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{bytes.NewReader(body), resp.Body}
+	if len(bytes.TrimSpace(body)) == 0 || !json.Valid(body) {
+		return nil
+	}
+	return ValidateResponseBodyWithMode(body, schema, mode)
+}
+
+// ValidateResponseBody validates a JSON response body against an OpenAPI
+// schema, leniently
 func ValidateResponseBody(body []byte, schema *openapi3.Schema) []string {
-	// TODO: Implement full JSON schema validation
-	// For now, just return empty array (no errors)
-	return []string{}
+	return ValidateResponseBodyWithMode(body, schema, models.ResponseValidationLenient)
+}
+
+// ValidateResponseBodyWithMode validates a JSON response body against an
+// OpenAPI schema. Fields the schema doesn't document are ignored in lenient
+// mode, even where it sets additionalProperties: false, and reported in
+// strict mode unless additionalProperties allows them
+func ValidateResponseBodyWithMode(body []byte, schema *openapi3.Schema, mode string) []string {
+	problems := []string{}
+	if schema == nil || len(bytes.TrimSpace(body)) == 0 {
+		return problems
+	}
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return append(problems, fmt.Sprintf("body is not valid JSON: %v", err))
+	}
+
+	// Undocumented fields are dropped before the schema check, so
+	// additionalProperties: false only fails them in strict mode, once each
+	for _, field := range dropUndocumented(value, schema, "body") {
+		if mode == models.ResponseValidationStrict {
+			problems = append(problems, fmt.Sprintf("%s: field is not documented in the schema", field))
+		}
+	}
+	if err := schema.VisitJSON(value, openapi3.MultiErrors(), openapi3.VisitAsResponse()); err != nil {
+		problems = append(problems, schemaErrors(err)...)
+	}
+	return problems
+}
+
+// dropUndocumented removes the object fields of value that schema doesn't
+// document and additionalProperties doesn't allow, returning their paths.
+// Properties of allOf, anyOf and oneOf members count as documented, and
+// objects whose schema lists no properties are free-form
+func dropUndocumented(value interface{}, schema *openapi3.Schema, path string) []string {
+	if schema == nil {
+		return nil
+	}
+	var dropped []string
+	switch v := value.(type) {
+	case map[string]interface{}:
+		properties := documentedProperties(schema)
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			field := path + "." + key
+			if property, ok := properties[key]; ok {
+				dropped = append(dropped, dropUndocumented(v[key], property, field)...)
+				continue
+			}
+			switch {
+			case schema.AdditionalProperties.Schema != nil && schema.AdditionalProperties.Schema.Value != nil:
+				dropped = append(dropped, dropUndocumented(v[key], schema.AdditionalProperties.Schema.Value, field)...)
+			case schema.AdditionalProperties.Has != nil && *schema.AdditionalProperties.Has:
+			case len(properties) == 0 && schema.AdditionalProperties.Has == nil:
+			default:
+				delete(v, key)
+				dropped = append(dropped, field)
+			}
+		}
+	case []interface{}:
+		if schema.Items != nil {
+			for i, item := range v {
+				dropped = append(dropped, dropUndocumented(item, schema.Items.Value, fmt.Sprintf("%s.%d", path, i))...)
+			}
+		}
+	}
+	return dropped
+}
+
+// documentedProperties returns the property schemas of schema and of its
+// allOf, anyOf and oneOf members
+func documentedProperties(schema *openapi3.Schema) map[string]*openapi3.Schema {
+	properties := make(map[string]*openapi3.Schema)
+	for name, ref := range schema.Properties {
+		if ref != nil && ref.Value != nil {
+			properties[name] = ref.Value
+		}
+	}
+	for _, members := range []openapi3.SchemaRefs{schema.AllOf, schema.AnyOf, schema.OneOf} {
+		for _, member := range members {
+			if member == nil || member.Value == nil {
+				continue
+			}
+			for name, property := range documentedProperties(member.Value) {
+				if _, ok := properties[name]; !ok {
+					properties[name] = property
+				}
+			}
+		}
+	}
+	return properties
 }
 
 // validateHeaders validates response headers against OpenAPI spec
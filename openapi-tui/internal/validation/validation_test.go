@@ -5,9 +5,13 @@ import (
 	"io"
 	"net/http"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/Traves-Theberge/OpenAPI-Toolkit/openapi-tui/internal/errors"
+	"github.com/Traves-Theberge/OpenAPI-Toolkit/openapi-tui/internal/models"
+
 	"github.com/getkin/kin-openapi/openapi3"
 )
 
@@ -56,6 +60,159 @@ func TestValidateSpec_InvalidFile(t *testing.T) {
 	}
 }
 
+// TestValidateSpec_MissingExternalRef tests a root spec referencing a file
+// that doesn't exist names the broken ref rather than the root spec
+func TestValidateSpec_NotOpenAPI(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		title   string
+	}{
+		{"random JSON object", `{"name": "package", "dependencies": {"left-pad": "1.3.0"}}`, "Not an OpenAPI Document"},
+		{"top-level list", `[{"id": 1}]`, "Not an OpenAPI Document"},
+		{"Swagger 2.0", "swagger: \"2.0\"\ninfo:\n  title: Old\n  version: \"1.0\"\npaths: {}\n", "Swagger Document"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "spec.json")
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			_, err := ValidateSpec(path)
+			enhanced, ok := err.(*errors.EnhancedError)
+			if !ok {
+				t.Fatalf("Expected an EnhancedError, got %v", err)
+			}
+			if enhanced.Title != tt.title {
+				t.Errorf("Expected %q, got %q: %s", tt.title, enhanced.Title, enhanced.Description)
+			}
+			if len(enhanced.Suggestions) == 0 {
+				t.Error("Expected suggestions")
+			}
+		})
+	}
+
+	// The friendly error also stops endpoint extraction
+	path := filepath.Join(t.TempDir(), "data.json")
+	os.WriteFile(path, []byte(`{"users": []}`), 0644)
+	if _, err := ExtractEndpoints(path); err == nil || !strings.Contains(err.Error(), "doesn't look like an OpenAPI document") {
+		t.Errorf("Expected ExtractEndpoints to report a non-OpenAPI file, got %v", err)
+	}
+}
+
+func TestValidateSpec_MissingExternalRef(t *testing.T) {
+	dir := t.TempDir()
+	root := filepath.Join(dir, "openapi.yaml")
+	spec := `openapi: 3.0.0
+info:
+  title: Multi-file
+  version: "1.0"
+paths:
+  /users:
+    get:
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: "./schemas/user.yaml#/User"
+`
+	if err := os.WriteFile(root, []byte(spec), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := ValidateSpec(root)
+	enhanced, ok := err.(*errors.EnhancedError)
+	if !ok {
+		t.Fatalf("Expected an EnhancedError, got %v", err)
+	}
+	if enhanced.Title != "Unresolved Reference" {
+		t.Errorf("Expected an unresolved reference error, got %q", enhanced.Title)
+	}
+	if !strings.Contains(enhanced.Description, `"./schemas/user.yaml#/User"`) || !strings.Contains(enhanced.Description, filepath.Join(dir, "schemas", "user.yaml")) {
+		t.Errorf("Expected the description to name the ref and the missing file, got %q", enhanced.Description)
+	}
+}
+
+func TestValidateSpec_UndefinedComponentRef(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "openapi.yaml")
+	spec := `openapi: 3.0.0
+info:
+  title: Dangling
+  version: "1.0"
+paths:
+  /users:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: "#/components/schemas/NewUser"
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: "#/components/schemas/User"
+components:
+  schemas:
+    NewUser:
+      type: object
+`
+	if err := os.WriteFile(path, []byte(spec), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := ValidateSpec(path)
+	enhanced, ok := err.(*errors.EnhancedError)
+	if !ok {
+		t.Fatalf("Expected an EnhancedError, got %v", err)
+	}
+	if enhanced.Title != "Undefined Component Reference" {
+		t.Errorf("Expected a dangling ref error, got %q: %s", enhanced.Title, enhanced.Description)
+	}
+	want := `paths./users.post.responses.200.content.application/json.schema: "User" is not defined in components.schemas ($ref "#/components/schemas/User") (line 19)`
+	if enhanced.Description != want {
+		t.Errorf("Expected %q, got %q", want, enhanced.Description)
+	}
+	if enhanced.Location != path+":19" {
+		t.Errorf("Expected the location of the dangling ref, got %q", enhanced.Location)
+	}
+}
+
+// TestParseOnly tests that parse-only checking catches syntax errors but
+// not semantic ones
+func TestParseOnly(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	// Parses, but info.version is required and the path doesn't start with /
+	semantic := write("semantic.yaml", "openapi: 3.0.0\ninfo:\n  title: T\npaths:\n  users:\n    get:\n      responses:\n        '200':\n          description: OK\n")
+	if err := ParseOnly(semantic); err != nil {
+		t.Errorf("Expected a semantically invalid spec to parse, got %v", err)
+	}
+	if _, err := ValidateSpec(semantic); err == nil {
+		t.Error("Expected full validation to reject the semantically invalid spec")
+	}
+
+	if err := ParseOnly(write("syntax.yaml", "openapi: 3.0.0\ninfo: [unclosed\n")); err == nil {
+		t.Error("Expected a syntax error to fail parse-only")
+	}
+	if err := ParseOnly(write("data.json", `{"users": []}`)); err == nil || !strings.Contains(err.Error(), "doesn't look like an OpenAPI document") {
+		t.Errorf("Expected a non-OpenAPI file to fail parse-only, got %v", err)
+	}
+}
+
 // TestValidateSpec_InvalidYAML tests validation with malformed YAML
 func TestValidateSpec_InvalidYAML(t *testing.T) {
 	invalidYAML := "this is not: [valid yaml"
@@ -98,6 +255,149 @@ paths: {}
 	}
 }
 
+// TestValidateSpec_UndefinedSecurityScheme tests that references to undefined security schemes are reported
+func TestValidateSpec_UndefinedSecurityScheme(t *testing.T) {
+	spec := `
+openapi: 3.0.0
+info:
+  title: Test
+  version: 1.0.0
+components:
+  securitySchemes:
+    bearerAuth:
+      type: http
+      scheme: bearer
+security:
+  - bearerAuth: []
+paths:
+  /users:
+    get:
+      security:
+        - oauth2: []
+      responses:
+        '200':
+          description: OK
+`
+	tmpFile, err := os.CreateTemp("", "security-spec-*.yaml")
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	defer os.Remove(tmpFile.Name())
+
+	tmpFile.Write([]byte(spec))
+	tmpFile.Close()
+
+	_, err = ValidateSpec(tmpFile.Name())
+	if err == nil {
+		t.Fatal("Expected error for undefined security scheme, got nil")
+	}
+	if !strings.Contains(err.Error(), `GET /users: unknown security scheme "oauth2"`) {
+		t.Errorf("Expected unknown scheme to be reported, got: %v", err)
+	}
+	if strings.Contains(err.Error(), "bearerAuth") {
+		t.Errorf("Did not expect defined scheme to be reported, got: %v", err)
+	}
+}
+
+// TestValidateSpecWithWarnings_MissingSuccessResponse tests that operations
+// documenting only error responses are flagged without failing validation
+func TestValidateSpecWithWarnings_MissingSuccessResponse(t *testing.T) {
+	spec := `
+openapi: 3.0.0
+info:
+  title: Test
+  version: 1.0.0
+paths:
+  /users:
+    post:
+      responses:
+        '400':
+          description: Bad Request
+    get:
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json: {}
+  /health:
+    get:
+      responses:
+        default:
+          description: Anything
+`
+	tmpFile, err := os.CreateTemp("", "responses-spec-*.yaml")
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	defer os.Remove(tmpFile.Name())
+
+	tmpFile.Write([]byte(spec))
+	tmpFile.Close()
+
+	result, warnings, err := ValidateSpecWithWarnings(tmpFile.Name())
+	if err != nil {
+		t.Fatalf("Expected the spec to stay valid, got: %v", err)
+	}
+	if !strings.Contains(result, "valid") {
+		t.Errorf("Expected a success message, got %q", result)
+	}
+	if len(warnings) != 1 || warnings[0] != "POST /users: no 2xx or default response documented (line 9)" {
+		t.Errorf("Expected one warning for POST /users, got %v", warnings)
+	}
+}
+
+// TestValidateSpecWithWarnings_ResponseWithoutContent tests that GET success
+// responses declaring no content are flagged, while 204s and other methods are not
+func TestValidateSpecWithWarnings_ResponseWithoutContent(t *testing.T) {
+	spec := `
+openapi: 3.0.0
+info:
+  title: Test
+  version: 1.0.0
+paths:
+  /users:
+    get:
+      responses:
+        '200':
+          description: OK
+    post:
+      responses:
+        '200':
+          description: Created
+  /users/{id}:
+    get:
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json: {}
+        '204':
+          description: No Content
+`
+	tmpFile, err := os.CreateTemp("", "content-spec-*.yaml")
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	defer os.Remove(tmpFile.Name())
+
+	tmpFile.Write([]byte(spec))
+	tmpFile.Close()
+
+	_, warnings, err := ValidateSpecWithWarnings(tmpFile.Name())
+	if err != nil {
+		t.Fatalf("Expected the spec to stay valid, got: %v", err)
+	}
+	if len(warnings) != 1 || warnings[0] != "GET /users: 200 response declares no content (line 10)" {
+		t.Errorf("Expected one warning for GET /users, got %v", warnings)
+	}
+}
+
 // TestValidateResponse tests response validation against OpenAPI spec
 func TestValidateResponse(t *testing.T) {
 	// Create a test operation
@@ -289,6 +589,45 @@ func TestValidateResponse_ContentTypeWithCharset(t *testing.T) {
 	}
 }
 
+// TestValidateResponseAs tests validating a mislabelled response as an assumed content type
+func TestValidateResponseAs(t *testing.T) {
+	responses := openapi3.NewResponses()
+	desc200 := "OK"
+	responses.Set("200", &openapi3.ResponseRef{
+		Value: &openapi3.Response{
+			Description: &desc200,
+			Content: openapi3.Content{
+				"application/json": &openapi3.MediaType{},
+			},
+		},
+	})
+	operation := &openapi3.Operation{Responses: responses}
+	respond := func(body string) *http.Response {
+		return &http.Response{
+			StatusCode: 200,
+			Header:     http.Header{"Content-Type": []string{"text/plain"}},
+			Body:       io.NopCloser(bytes.NewReader([]byte(body))),
+		}
+	}
+
+	if result := ValidateResponseAs(respond(`{"id": 1}`), operation, 200, ""); result.Valid {
+		t.Error("Expected the text/plain label to fail without an assumed content type")
+	}
+
+	resp := respond(`{"id": 1}`)
+	if result := ValidateResponseAs(resp, operation, 200, "application/json"); !result.Valid {
+		t.Errorf("Expected a JSON body to validate as application/json, got %v", result.SchemaErrors)
+	}
+	if body, _ := io.ReadAll(resp.Body); string(body) != `{"id": 1}` {
+		t.Errorf("Expected the body to stay readable, got %q", body)
+	}
+
+	result := ValidateResponseAs(respond("not json"), operation, 200, "application/json")
+	if result.Valid || len(result.SchemaErrors) == 0 || !strings.Contains(result.SchemaErrors[0], "not valid JSON") {
+		t.Errorf("Expected a non-JSON body to fail as application/json, got %v", result.SchemaErrors)
+	}
+}
+
 // TestValidateResponse_NoOperation tests validation with nil operation
 func TestValidateResponse_NoOperation(t *testing.T) {
 	resp := &http.Response{
@@ -306,18 +645,82 @@ func TestValidateResponse_NoOperation(t *testing.T) {
 
 // TestValidateResponseBody tests response body validation placeholder
 func TestValidateResponseBody(t *testing.T) {
-	// This is a placeholder test for future implementation
 	body := []byte(`{"test": "data"}`)
 	schema := &openapi3.Schema{}
 
 	errors := ValidateResponseBody(body, schema)
 	
-	// Currently returns empty array (not implemented)
+	// An empty schema allows anything
 	if len(errors) != 0 {
 		t.Errorf("Expected empty errors array, got %d errors", len(errors))
 	}
 }
 
+// TestValidateResponseBodyWithMode tests that undocumented fields only fail
+// in strict mode, while documented ones are checked in both
+func TestValidateResponseBodyWithMode(t *testing.T) {
+	closed := false
+	schema := openapi3.NewObjectSchema().
+		WithProperty("id", openapi3.NewIntegerSchema()).
+		WithProperty("tags", openapi3.NewArraySchema().WithItems(openapi3.NewObjectSchema().
+			WithProperty("name", openapi3.NewStringSchema())))
+	schema.Required = []string{"id"}
+	closedSchema := openapi3.NewObjectSchema().WithProperty("id", openapi3.NewIntegerSchema())
+	closedSchema.AdditionalProperties = openapi3.AdditionalProperties{Has: &closed}
+
+	extra := []byte(`{"id": 1, "debug": true, "tags": [{"name": "a", "color": "red"}]}`)
+	if problems := ValidateResponseBodyWithMode(extra, schema, models.ResponseValidationLenient); len(problems) != 0 {
+		t.Errorf("Expected undocumented fields to pass leniently, got %v", problems)
+	}
+	if problems := ValidateResponseBodyWithMode([]byte(`{"id": 1, "debug": true}`), closedSchema, models.ResponseValidationLenient); len(problems) != 0 {
+		t.Errorf("Expected lenient mode to ignore additionalProperties: false, got %v", problems)
+	}
+
+	problems := ValidateResponseBodyWithMode(extra, schema, models.ResponseValidationStrict)
+	if len(problems) != 2 || !strings.HasPrefix(problems[0], "body.debug:") || !strings.HasPrefix(problems[1], "body.tags.0.color:") {
+		t.Errorf("Expected both undocumented fields to fail strictly, got %v", problems)
+	}
+	if problems := ValidateResponseBodyWithMode([]byte(`{"id": 1, "debug": true}`), closedSchema, models.ResponseValidationStrict); len(problems) != 1 {
+		t.Errorf("Expected one problem for an undocumented field under additionalProperties: false, got %v", problems)
+	}
+
+	// Documented fields are checked whatever the mode
+	for _, mode := range []string{models.ResponseValidationLenient, models.ResponseValidationStrict} {
+		problems := ValidateResponseBodyWithMode([]byte(`{"id": "one"}`), schema, mode)
+		if len(problems) != 1 || !strings.HasPrefix(problems[0], "body.id:") {
+			t.Errorf("Expected a wrongly typed id to fail in %s mode, got %v", mode, problems)
+		}
+	}
+
+	// Through response validation, with the body left readable
+	responses := openapi3.NewResponses()
+	desc200 := "OK"
+	responses.Set("200", &openapi3.ResponseRef{
+		Value: &openapi3.Response{
+			Description: &desc200,
+			Content:     openapi3.NewContentWithJSONSchema(schema),
+		},
+	})
+	operation := &openapi3.Operation{Responses: responses}
+	respond := func() *http.Response {
+		return &http.Response{
+			StatusCode: 200,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(bytes.NewReader(extra)),
+		}
+	}
+	resp := respond()
+	if result := ValidateResponseWithOptions(resp, operation, 200, ResponseOptions{}); !result.Valid {
+		t.Errorf("Expected the response to pass by default, got %v", result.SchemaErrors)
+	}
+	if body, _ := io.ReadAll(resp.Body); !bytes.Equal(body, extra) {
+		t.Errorf("Expected the body to stay readable, got %q", body)
+	}
+	if result := ValidateResponseWithOptions(respond(), operation, 200, ResponseOptions{Mode: models.ResponseValidationStrict}); result.Valid {
+		t.Error("Expected the response to fail in strict mode")
+	}
+}
+
 // TestValidateHeaders tests header validation placeholder
 func TestValidateHeaders(t *testing.T) {
 	headers := http.Header{
@@ -0,0 +1,104 @@
+package validation
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// ResponseValidator is a project-specific response check run by
+// ValidateResponse alongside the built-in status and content-type checks
+// Validate returns one message per problem; none means the response passes.
+// The response body can be read freely; each validator gets its own copy.
+type ResponseValidator interface {
+	Validate(resp *http.Response, operation *openapi3.Operation) []string
+}
+
+var (
+	validatorsMu sync.RWMutex
+	validators   = make(map[string]ResponseValidator)
+)
+
+// RegisterResponseValidator adds a validator under name, replacing any
+// validator already registered with that name
+func RegisterResponseValidator(name string, v ResponseValidator) {
+	validatorsMu.Lock()
+	defer validatorsMu.Unlock()
+	validators[name] = v
+}
+
+// UnregisterResponseValidator removes the validator registered under name
+func UnregisterResponseValidator(name string) {
+	validatorsMu.Lock()
+	defer validatorsMu.Unlock()
+	delete(validators, name)
+}
+
+// runResponseValidators runs the registered validators in name order and
+// returns their messages, each prefixed with the validator's name
+// The body is buffered once and restored on resp for later readers.
+func runResponseValidators(resp *http.Response, operation *openapi3.Operation) []string {
+	validatorsMu.RLock()
+	defer validatorsMu.RUnlock()
+	if len(validators) == 0 {
+		return nil
+	}
+
+	var body []byte
+	if resp.Body != nil {
+		body, _ = io.ReadAll(resp.Body)
+		resp.Body = struct {
+			io.Reader
+			io.Closer
+		}{bytes.NewReader(body), resp.Body}
+	}
+
+	names := make([]string, 0, len(validators))
+	for name := range validators {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var messages []string
+	for _, name := range names {
+		copied := *resp
+		copied.Body = io.NopCloser(bytes.NewReader(body))
+		for _, message := range validators[name].Validate(&copied, operation) {
+			messages = append(messages, fmt.Sprintf("%s: %s", name, message))
+		}
+	}
+	return messages
+}
+
+// RequiredFieldsValidator checks that every JSON object response carries the
+// given top-level fields, e.g. a "requestId" for tracing
+// Non-JSON responses and JSON that is not an object are skipped.
+type RequiredFieldsValidator struct {
+	Fields []string
+}
+
+// Validate implements ResponseValidator
+func (v RequiredFieldsValidator) Validate(resp *http.Response, operation *openapi3.Operation) []string {
+	if !IsJSONContentType(resp.Header.Get("Content-Type")) {
+		return nil
+	}
+
+	var object map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&object); err != nil {
+		return nil
+	}
+
+	var missing []string
+	for _, field := range v.Fields {
+		if _, ok := object[field]; !ok {
+			missing = append(missing, fmt.Sprintf("response is missing required field %q", field))
+		}
+	}
+	return missing
+}
@@ -0,0 +1,73 @@
+package validation
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// headerValidator requires a response header, for testing the registry
+type headerValidator string
+
+func (h headerValidator) Validate(resp *http.Response, operation *openapi3.Operation) []string {
+	if resp.Header.Get(string(h)) == "" {
+		return []string{"missing header " + string(h)}
+	}
+	return nil
+}
+
+func TestRegisterResponseValidator(t *testing.T) {
+	desc := "OK"
+	responses := openapi3.NewResponses()
+	responses.Set("200", &openapi3.ResponseRef{Value: &openapi3.Response{
+		Description: &desc,
+		Content:     openapi3.Content{"application/json": &openapi3.MediaType{}},
+	}})
+	operation := &openapi3.Operation{Responses: responses}
+
+	newResponse := func(body string) *http.Response {
+		return &http.Response{
+			StatusCode: 200,
+			Header:     http.Header{"Content-Type": {"application/json"}},
+			Body:       io.NopCloser(bytes.NewReader([]byte(body))),
+		}
+	}
+
+	RegisterResponseValidator("request-id", RequiredFieldsValidator{Fields: []string{"requestId"}})
+	RegisterResponseValidator("trace-header", headerValidator("X-Trace-Id"))
+	defer UnregisterResponseValidator("request-id")
+	defer UnregisterResponseValidator("trace-header")
+
+	resp := newResponse(`{"id": 1}`)
+	result := ValidateResponse(resp, operation, 200)
+	if result.Valid {
+		t.Error("Expected custom validator errors to fail validation")
+	}
+	want := []string{
+		`request-id: response is missing required field "requestId"`,
+		"trace-header: missing header X-Trace-Id",
+	}
+	if !reflect.DeepEqual(result.SchemaErrors, want) {
+		t.Errorf("SchemaErrors = %q, want %q", result.SchemaErrors, want)
+	}
+	// Validators must not consume the body for later readers
+	if body, _ := io.ReadAll(resp.Body); string(body) != `{"id": 1}` {
+		t.Errorf("Expected the body to stay readable, got %q", body)
+	}
+
+	resp = newResponse(`{"id": 1, "requestId": "abc"}`)
+	resp.Header.Set("X-Trace-Id", "t-1")
+	if result := ValidateResponse(resp, operation, 200); !result.Valid {
+		t.Errorf("Expected a passing response, got %v", result.SchemaErrors)
+	}
+
+	UnregisterResponseValidator("request-id")
+	UnregisterResponseValidator("trace-header")
+	if result := ValidateResponse(newResponse(`{"id": 1}`), operation, 200); !result.Valid {
+		t.Errorf("Expected no custom checks after unregistering, got %v", result.SchemaErrors)
+	}
+}
@@ -0,0 +1,125 @@
+package validation
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Webhook is an operation under an OpenAPI 3.1 spec's top-level webhooks
+// section: a request the API sends to its subscribers, rather than one it
+// answers, so it is validated but never tested
+type Webhook struct {
+	Name      string // Key under webhooks, e.g. "newPet"
+	Method    string // Upper-case HTTP method
+	Operation *openapi3.Operation
+}
+
+// String returns "METHOD name", with the operation summary when it has one
+func (w Webhook) String() string {
+	s := w.Method + " " + w.Name
+	if w.Operation != nil && w.Operation.Summary != "" {
+		s += " — " + w.Operation.Summary
+	}
+	return s
+}
+
+// ExtractWebhooks returns the operations of the spec's webhooks, sorted by
+// name and then method, with $refs to the spec's components resolved
+// The loader keeps the webhooks section as raw data among the document's
+// extensions, so it is loaded again as if its entries were paths
+// Returns nil for a spec without webhooks
+func ExtractWebhooks(doc *openapi3.T) ([]Webhook, error) {
+	hooks, _, err := loadWebhooks(doc)
+	if err != nil || hooks == nil {
+		return nil, err
+	}
+
+	var webhooks []Webhook
+	paths := hooks.Paths.Map()
+	names := make([]string, 0, len(paths))
+	for name := range paths {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		operations := paths[name].Operations()
+		methods := make([]string, 0, len(operations))
+		for method := range operations {
+			methods = append(methods, method)
+		}
+		sort.Strings(methods)
+		for _, method := range methods {
+			webhooks = append(webhooks, Webhook{
+				Name:      strings.TrimPrefix(name, "/"),
+				Method:    strings.ToUpper(method),
+				Operation: operations[method],
+			})
+		}
+	}
+	return webhooks, nil
+}
+
+// ListWebhooks loads the spec at specPath and returns its webhook operations
+// as Webhook.String() lines, for reporting after validation
+func ListWebhooks(specPath string) ([]string, error) {
+	loader := &openapi3.Loader{IsExternalRefsAllowed: true}
+	doc, err := loader.LoadFromFile(specPath)
+	if err != nil {
+		return nil, err
+	}
+	webhooks, err := ExtractWebhooks(doc)
+	if err != nil {
+		return nil, err
+	}
+	var lines []string
+	for _, w := range webhooks {
+		lines = append(lines, w.String())
+	}
+	return lines, nil
+}
+
+// validateWebhooks validates the operations and schemas of the spec's
+// webhooks the way the rest of the spec is validated
+func validateWebhooks(doc *openapi3.T) error {
+	hooks, loader, err := loadWebhooks(doc)
+	if err != nil || hooks == nil {
+		return err
+	}
+	if err := hooks.Validate(loader.Context); err != nil {
+		return fmt.Errorf("%s", strings.ReplaceAll(err.Error(), "invalid paths: invalid path /", "invalid webhook "))
+	}
+	return nil
+}
+
+// loadWebhooks loads a document holding doc's components, with doc's
+// webhooks as its paths, each under "/" + its name, so the loader resolves
+// their references. Returns a nil document when doc has no webhooks
+func loadWebhooks(doc *openapi3.T) (*openapi3.T, *openapi3.Loader, error) {
+	webhooks, ok := doc.Extensions["webhooks"].(map[string]interface{})
+	if !ok || len(webhooks) == 0 {
+		return nil, nil, nil
+	}
+
+	paths := make(map[string]interface{}, len(webhooks))
+	for name, item := range webhooks {
+		paths["/"+name] = item
+	}
+	spec := map[string]interface{}{"openapi": doc.OpenAPI, "info": doc.Info, "paths": paths}
+	if doc.Components != nil {
+		spec["components"] = doc.Components
+	}
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read webhooks: %w", err)
+	}
+	loader := &openapi3.Loader{}
+	hooks, err := loader.LoadFromData(data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load webhooks: %w", err)
+	}
+	return hooks, loader, nil
+}
@@ -0,0 +1,95 @@
+package validation
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+const webhookSpec = `openapi: 3.1.0
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      responses:
+        200:
+          description: OK
+webhooks:
+  newPet:
+    post:
+      summary: A pet was added
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Pet'
+      responses:
+        200:
+          description: Received
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [name]
+      properties:
+        name:
+          type: string
+`
+
+func TestExtractWebhooks(t *testing.T) {
+	specPath := filepath.Join(t.TempDir(), "spec.yaml")
+	if err := os.WriteFile(specPath, []byte(webhookSpec), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	doc, err := openapi3.NewLoader().LoadFromFile(specPath)
+	if err != nil {
+		t.Fatalf("Failed to load the spec: %v", err)
+	}
+	webhooks, err := ExtractWebhooks(doc)
+	if err != nil {
+		t.Fatalf("ExtractWebhooks() error = %v", err)
+	}
+	if len(webhooks) != 1 {
+		t.Fatalf("Expected one webhook operation, got %+v", webhooks)
+	}
+	hook := webhooks[0]
+	if hook.String() != "POST newPet — A pet was added" {
+		t.Errorf("Expected POST newPet with its summary, got %q", hook.String())
+	}
+	schema := hook.Operation.RequestBody.Value.Content.Get("application/json").Schema
+	if schema.Value == nil || schema.Value.Properties["name"] == nil {
+		t.Errorf("Expected the body schema $ref to be resolved, got %+v", schema)
+	}
+
+	if _, _, err := ValidateSpecWithWarnings(specPath); err != nil {
+		t.Errorf("Expected a spec with a valid webhook to validate, got %v", err)
+	}
+	if lines, err := ListWebhooks(specPath); err != nil || len(lines) != 1 || lines[0] != hook.String() {
+		t.Errorf("Expected the webhook to be listed, got %v, %v", lines, err)
+	}
+
+	// A broken webhook makes the spec invalid
+	brokenPath := filepath.Join(t.TempDir(), "broken.yaml")
+	broken := strings.Replace(webhookSpec, "'#/components/schemas/Pet'", "'#/components/schemas/Missing'", 1)
+	if err := os.WriteFile(brokenPath, []byte(broken), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := ValidateSpecWithWarnings(brokenPath); err == nil || !strings.Contains(err.Error(), "Webhook") {
+		t.Errorf("Expected an invalid webhook error, got %v", err)
+	}
+
+	// Specs without webhooks have none
+	plain, err := openapi3.NewLoader().LoadFromData([]byte(strings.Split(webhookSpec, "webhooks:")[0]))
+	if err != nil {
+		t.Fatalf("Failed to load the spec: %v", err)
+	}
+	if webhooks, err := ExtractWebhooks(plain); err != nil || webhooks != nil {
+		t.Errorf("Expected no webhooks, got %v, %v", webhooks, err)
+	}
+}